@@ -0,0 +1,191 @@
+package bdb
+
+import (
+	"regexp"
+	"strings"
+)
+
+// CheckConstraint is a CHECK constraint of a table, with its definition
+// as the database gives it.
+type CheckConstraint struct {
+	Name       string
+	Definition string
+}
+
+// Check is a simple condition of a CHECK constraint that generated models
+// can evaluate: a comparison of a column, or of its length in characters,
+// with a number, like "age >= 0" or "char_length(name) <= 50".
+type Check struct {
+	// Name of the constraint the condition is part of
+	Name   string
+	Column string
+	// Length compares the length of the column instead of its value
+	Length bool
+	// Op is the comparison as a Go operator, like ">=" or "!="
+	Op    string
+	Value string
+}
+
+var rgxCheckCondition = regexp.MustCompile(
+	`^(?i)(?:(?:char_length|character_length|length|len)\s*\(\s*(\(?[^()\s]+?\)?(?:::[a-z ]+)?)\s*\)|(\(?[^()\s]+?\)?(?:::[a-z ]+)?))` +
+		`\s*(<=|>=|<>|!=|=|<|>)\s*\(?\s*(-?[0-9]+(?:\.[0-9]+)?)\s*\)?(?:::[a-z ]+)?$`,
+)
+
+var rgxCheckColumn = regexp.MustCompile(`^(?i)[a-z_][a-z0-9_]*$`)
+
+// checkOps are the Go operators of the SQL comparisons
+var checkOps = map[string]string{
+	"<": "<", "<=": "<=", ">": ">", ">=": ">=", "=": "==", "<>": "!=", "!=": "!=",
+}
+
+// parseChecks returns the simple conditions of a CHECK constraint. A
+// constraint made of conditions ANDed together gives a Check for each of
+// them that is simple, the others are skipped. Nothing is returned for
+// constraints with OR at the top level, since no condition of them holds
+// on its own.
+func parseChecks(con CheckConstraint) []Check {
+	def := strings.TrimSpace(con.Definition)
+	if len(def) >= 5 && strings.EqualFold(def[:5], "CHECK") {
+		def = def[5:]
+	}
+	def = strings.TrimSuffix(strings.TrimSpace(def), " NOT VALID")
+
+	conditions, ok := splitCheckConditions(trimCheckParens(def))
+	if !ok {
+		return nil
+	}
+
+	var checks []Check
+	for _, condition := range conditions {
+		m := rgxCheckCondition.FindStringSubmatch(trimCheckParens(condition))
+		if m == nil {
+			continue
+		}
+
+		check := Check{Name: con.Name, Length: len(m[1]) != 0, Op: checkOps[m[3]], Value: m[4]}
+		column := m[2]
+		if check.Length {
+			column = m[1]
+		}
+		if check.Column = checkColumn(column); len(check.Column) == 0 {
+			continue
+		}
+
+		checks = append(checks, check)
+	}
+
+	return checks
+}
+
+// checkColumn returns the column name of a column in a condition, which
+// may be quoted, wrapped in parentheses and cast like ("name")::text. It
+// returns an empty string if it's not a plain column name.
+func checkColumn(s string) string {
+	if i := strings.Index(s, "::"); i != -1 {
+		s = s[:i]
+	}
+	s = strings.Trim(s, "()`\"[]")
+
+	if !rgxCheckColumn.MatchString(s) {
+		return ""
+	}
+
+	return s
+}
+
+// trimCheckParens removes the parentheses wrapping all of s.
+func trimCheckParens(s string) string {
+	for {
+		s = strings.TrimSpace(s)
+		if len(s) < 2 || s[0] != '(' || closingParen(s) != len(s)-1 {
+			return s
+		}
+
+		s = s[1 : len(s)-1]
+	}
+}
+
+// closingParen returns the index of the parenthesis closing the one that
+// s starts with, or -1 if it isn't closed.
+func closingParen(s string) int {
+	depth := 0
+	quoted := false
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\'':
+			quoted = !quoted
+		case quoted:
+		case s[i] == '(':
+			depth++
+		case s[i] == ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+// splitCheckConditions splits s on the ANDs outside of parentheses and
+// quotes. It returns false if there is an OR outside of them.
+func splitCheckConditions(s string) ([]string, bool) {
+	var conditions []string
+
+	upper := strings.ToUpper(s)
+	depth := 0
+	quoted := false
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\'':
+			quoted = !quoted
+		case quoted:
+		case s[i] == '(':
+			depth++
+		case s[i] == ')':
+			depth--
+		case depth != 0:
+		case strings.HasPrefix(upper[i:], " OR "):
+			return nil, false
+		case strings.HasPrefix(upper[i:], " AND "):
+			conditions = append(conditions, s[start:i])
+			start = i + len(" AND ")
+		}
+	}
+
+	return append(conditions, s[start:]), true
+}
+
+// filterChecks returns the checks on columns whose Go type they can be
+// evaluated on: strings for length checks and numbers for the others,
+// including their null types.
+func filterChecks(checks []Check, columns []Column) []Check {
+	var filtered []Check
+	for _, check := range checks {
+		for _, c := range columns {
+			if c.Name != check.Column {
+				continue
+			}
+
+			goType := strings.TrimPrefix(c.Type, "null.")
+			if (check.Length && strings.ToLower(goType) == "string") || (!check.Length && isNumericType(goType)) {
+				filtered = append(filtered, check)
+			}
+			break
+		}
+	}
+
+	return filtered
+}
+
+func isNumericType(goType string) bool {
+	for _, prefix := range []string{"int", "uint", "float", "Int", "Uint", "Float"} {
+		if strings.HasPrefix(goType, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
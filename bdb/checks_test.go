@@ -0,0 +1,77 @@
+package bdb
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseChecks(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		def  string
+		want []Check
+	}{
+		// Postgres
+		{"CHECK ((age >= 0))", []Check{{Column: "age", Op: ">=", Value: "0"}}},
+		{"CHECK ((price > (0)::numeric))", []Check{{Column: "price", Op: ">", Value: "0"}}},
+		{"CHECK ((char_length((name)::text) <= 50))", []Check{{Column: "name", Length: true, Op: "<=", Value: "50"}}},
+		{"CHECK (((age >= 18) AND (age <= 150)))", []Check{
+			{Column: "age", Op: ">=", Value: "18"},
+			{Column: "age", Op: "<=", Value: "150"},
+		}},
+		{`CHECK (("Rating" <> '-1.5'::numeric))`, nil},
+		{"CHECK ((ratio <> -1.5)) NOT VALID", []Check{{Column: "ratio", Op: "!=", Value: "-1.5"}}},
+		// MySQL
+		{"(`age` = 5)", []Check{{Column: "age", Op: "==", Value: "5"}}},
+		{"(char_length(`code`) between 2 and 3)", nil},
+		// MS SQL
+		{"([age]>=(0))", []Check{{Column: "age", Op: ">=", Value: "0"}}},
+		{"(len([name])>(0))", []Check{{Column: "name", Length: true, Op: ">", Value: "0"}}},
+		// Partly or not simple
+		{"CHECK (((age >= 0) AND (name <> ''::text)))", []Check{{Column: "age", Op: ">=", Value: "0"}}},
+		{"CHECK (((age >= 0) OR (age IS NULL)))", nil},
+		{"CHECK ((age >= min_age))", nil},
+		{"CHECK ((lower(name) = 'x'::text))", nil},
+		{"CHECK ((age + 1 > 0))", nil},
+	}
+
+	for i, test := range tests {
+		for j := range test.want {
+			test.want[j].Name = "check"
+		}
+
+		got := parseChecks(CheckConstraint{Name: "check", Definition: test.def})
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%d) %s\nwant: %#v\ngot:  %#v", i, test.def, test.want, got)
+		}
+	}
+}
+
+func TestFilterChecks(t *testing.T) {
+	t.Parallel()
+
+	cols := []Column{
+		{Name: "age", Type: "int"},
+		{Name: "rating", Type: "null.Float64"},
+		{Name: "name", Type: "string"},
+		{Name: "nick", Type: "null.String"},
+		{Name: "born", Type: "time.Time"},
+	}
+
+	checks := []Check{
+		{Column: "age", Op: ">", Value: "0"},
+		{Column: "rating", Op: "<=", Value: "5"},
+		{Column: "name", Length: true, Op: "<=", Value: "50"},
+		{Column: "nick", Length: true, Op: ">", Value: "0"},
+		{Column: "name", Op: ">", Value: "0"},
+		{Column: "age", Length: true, Op: ">", Value: "0"},
+		{Column: "born", Op: ">", Value: "0"},
+		{Column: "missing", Op: ">", Value: "0"},
+	}
+
+	got := filterChecks(checks, cols)
+	if !reflect.DeepEqual(got, checks[:4]) {
+		t.Errorf("want the checks on supported columns, got: %#v", got)
+	}
+}
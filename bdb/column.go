@@ -1,6 +1,8 @@
 package bdb
 
 import (
+	"bytes"
+	"strconv"
 	"strings"
 
 	"github.com/volatiletech/sqlboiler/strmangle"
@@ -23,6 +25,9 @@ type Column struct {
 	// https://www.postgresql.org/docs/9.1/static/infoschema-element-types.html
 	ArrType *string
 	UDTName string
+	// Composite holds the attributes of a Postgres composite type,
+	// translated to Go types as nullable columns.
+	Composite []Column
 
 	// MySQL only bits
 	// Used to get full type, ex:
@@ -83,6 +88,141 @@ func FilterColumnsByDefault(defaults bool, columns []Column) []Column {
 	return cols
 }
 
+// LiteralDefault returns the column's default value when it is a literal
+// number, boolean or string, as opposed to an expression like now() whose
+// value can't be known in advance. Quotes, the N prefix of MS SQL unicode
+// strings, wrapping parentheses and Postgres type casts are removed.
+// Only columns whose Go type is a number, bool or string qualify.
+func (c Column) LiteralDefault() (string, bool) {
+	if !isLiteralDefaultType(c.Type) {
+		return "", false
+	}
+
+	def := strings.TrimSpace(c.Default)
+	for len(def) >= 2 && def[0] == '(' && def[len(def)-1] == ')' {
+		def = strings.TrimSpace(def[1 : len(def)-1])
+	}
+
+	if strings.HasPrefix(def, "N'") {
+		def = def[1:]
+	}
+
+	if strings.HasPrefix(def, "'") {
+		str, rest, ok := unquoteLiteral(def)
+		if !ok || (len(rest) != 0 && !strings.HasPrefix(rest, "::")) {
+			return "", false
+		}
+		return str, true
+	}
+
+	if i := strings.Index(def, "::"); i >= 0 {
+		def = def[:i]
+	}
+
+	if _, err := strconv.ParseFloat(def, 64); err == nil {
+		return def, true
+	}
+
+	switch lower := strings.ToLower(def); lower {
+	case "true", "false":
+		return lower, true
+	}
+
+	// MySQL gives string defaults without quotes
+	if strings.TrimPrefix(c.Type, "null.") == "String" || c.Type == "string" {
+		if len(def) != 0 && !strings.ContainsAny(def, "()") {
+			return def, true
+		}
+	}
+
+	return "", false
+}
+
+// isLiteralDefaultType reports whether values of the Go type typ can be
+// compared with a literal default.
+func isLiteralDefaultType(typ string) bool {
+	typ = strings.ToLower(strings.TrimPrefix(strings.TrimPrefix(typ, "*"), "null."))
+
+	switch {
+	case typ == "string", typ == "bool":
+		return true
+	case strings.HasPrefix(typ, "int"), strings.HasPrefix(typ, "uint"), strings.HasPrefix(typ, "float"):
+		return true
+	}
+
+	return false
+}
+
+// unquoteLiteral reads the single quoted SQL string at the start of s,
+// returning its contents and whatever follows it.
+func unquoteLiteral(s string) (str, rest string, ok bool) {
+	buf := &bytes.Buffer{}
+
+	for i := 1; i < len(s); i++ {
+		if s[i] != '\'' {
+			buf.WriteByte(s[i])
+			continue
+		}
+
+		if i+1 < len(s) && s[i+1] == '\'' {
+			buf.WriteByte('\'')
+			i++
+			continue
+		}
+
+		return buf.String(), s[i+1:], true
+	}
+
+	return "", "", false
+}
+
+// LiteralDefaults returns a map of column name to literal default value for
+// the columns that have one. See Column.LiteralDefault.
+func LiteralDefaults(columns []Column) map[string]string {
+	defaults := make(map[string]string)
+
+	for _, c := range columns {
+		if def, ok := c.LiteralDefault(); ok {
+			defaults[c.Name] = def
+		}
+	}
+
+	return defaults
+}
+
+// FilterColumnsByMapKey generates the list of columns whose Go type is a
+// string or an integer, so their values can be used as map keys.
+func FilterColumnsByMapKey(columns []Column) []Column {
+	var cols []Column
+
+	for _, c := range columns {
+		if c.Type == "string" || strings.HasPrefix(c.Type, "int") || strings.HasPrefix(c.Type, "uint") {
+			cols = append(cols, c)
+		}
+	}
+
+	return cols
+}
+
+// FilterColumnsByRequired generates the list of NOT NULL columns that must be
+// set before inserting: those without a default whose Go type can be nil,
+// like []byte, types.JSON and arrays, since nil is written as NULL.
+func FilterColumnsByRequired(columns []Column) []Column {
+	var cols []Column
+
+	for _, c := range columns {
+		if c.Nullable || len(c.Default) != 0 || c.AutoGenerated {
+			continue
+		}
+		if strings.HasPrefix(c.Type, "[]") || strings.HasSuffix(c.Type, "Array") ||
+			c.Type == "types.JSON" || c.Type == "types.HStore" {
+			cols = append(cols, c)
+		}
+	}
+
+	return cols
+}
+
 // FilterColumnsByEnum generates the list of columns that are enum values.
 func FilterColumnsByEnum(columns []Column) []Column {
 	var cols []Column
@@ -95,3 +235,17 @@ func FilterColumnsByEnum(columns []Column) []Column {
 
 	return cols
 }
+
+// FilterColumnsByComposite generates the list of columns that are
+// postgres composite types.
+func FilterColumnsByComposite(columns []Column) []Column {
+	var cols []Column
+
+	for _, c := range columns {
+		if strings.HasPrefix(c.DBType, "composite.") {
+			cols = append(cols, c)
+		}
+	}
+
+	return cols
+}
@@ -86,3 +86,118 @@ func TestFilterColumnsByEnum(t *testing.T) {
 		t.Errorf("Invalid result: %#v", res)
 	}
 }
+
+func TestFilterColumnsByComposite(t *testing.T) {
+	t.Parallel()
+
+	cols := []Column{
+		{Name: "col1", DBType: "composite.address(street text)"},
+		{Name: "col2", DBType: "enum('hello')"},
+		{Name: "col3", DBType: "USER-DEFINED"},
+	}
+
+	res := FilterColumnsByComposite(cols)
+	if len(res) != 1 || res[0].Name != `col1` {
+		t.Errorf("Invalid result: %#v", res)
+	}
+}
+
+func TestLiteralDefault(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Type    string
+		Default string
+		Literal string
+		OK      bool
+	}{
+		{"int", "0", "0", true},
+		{"int64", "-12", "-12", true},
+		{"null.Int", "(-1)", "-1", true},
+		{"int", "((5))", "5", true},
+		{"float64", "1.5", "1.5", true},
+		{"float64", "'1.5'::numeric", "1.5", true},
+		{"bool", "true", "true", true},
+		{"null.Bool", "FALSE", "false", true},
+		{"bool", "((1))", "1", true},
+		{"string", "'abc'::character varying", "abc", true},
+		{"string", "'it''s'::text", "it's", true},
+		{"null.String", "('abc')", "abc", true},
+		{"*string", "'abc'::text", "abc", true},
+		{"*int64", "7", "7", true},
+		{"string", "N'abc'", "abc", true},
+		{"string", "abc", "abc", true},
+		{"string", "''::text", "", true},
+
+		{"int", "", "", false},
+		{"int", "nextval('jets_id_seq'::regclass)", "", false},
+		{"string", "uuid_generate_v4()", "", false},
+		{"string", "'a' || 'b'", "", false},
+		{"time.Time", "now()", "", false},
+		{"time.Time", "'2017-01-01'::date", "", false},
+		{"null.Time", "CURRENT_TIMESTAMP", "", false},
+		{"types.StringArray", "'{}'::text[]", "", false},
+	}
+
+	for i, test := range tests {
+		col := Column{Type: test.Type, Default: test.Default}
+		literal, ok := col.LiteralDefault()
+		if ok != test.OK || literal != test.Literal {
+			t.Errorf("%d) want: %q %t, got: %q %t", i, test.Literal, test.OK, literal, ok)
+		}
+	}
+}
+
+func TestLiteralDefaults(t *testing.T) {
+	t.Parallel()
+
+	cols := []Column{
+		{Name: "id", Type: "int", Default: "nextval('jets_id_seq'::regclass)"},
+		{Name: "age", Type: "int", Default: "5"},
+		{Name: "name", Type: "string"},
+	}
+
+	defaults := LiteralDefaults(cols)
+	if len(defaults) != 1 || defaults["age"] != "5" {
+		t.Errorf("wrong defaults: %#v", defaults)
+	}
+}
+
+func TestFilterColumnsByMapKey(t *testing.T) {
+	t.Parallel()
+
+	cols := []Column{
+		{Name: "col1", Type: "string"},
+		{Name: "col2", Type: "int64"},
+		{Name: "col3", Type: "uint8"},
+		{Name: "col4", Type: "null.Int"},
+		{Name: "col5", Type: "float64"},
+		{Name: "col6", Type: "types.Byte"},
+	}
+
+	res := ColumnNames(FilterColumnsByMapKey(cols))
+	if strings.Join(res, " ") != "col1 col2 col3" {
+		t.Errorf("Wrong map key columns: %v", res)
+	}
+}
+
+func TestFilterColumnsByRequired(t *testing.T) {
+	t.Parallel()
+
+	cols := []Column{
+		{Name: "col1", Type: "[]byte"},
+		{Name: "col2", Type: "types.JSON"},
+		{Name: "col3", Type: "types.StringArray"},
+		{Name: "col4", Type: "types.HStore"},
+		{Name: "col5", Type: "null.Bytes", Nullable: true},
+		{Name: "col6", Type: "[]byte", Default: "'\\x00'::bytea"},
+		{Name: "col7", Type: "[]byte", AutoGenerated: true},
+		{Name: "col8", Type: "string"},
+		{Name: "col9", Type: "time.Time"},
+	}
+
+	res := ColumnNames(FilterColumnsByRequired(cols))
+	if strings.Join(res, " ") != "col1 col2 col3 col4" {
+		t.Errorf("Wrong required columns: %v", res)
+	}
+}
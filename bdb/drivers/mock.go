@@ -13,10 +13,19 @@ func (m *MockDriver) TableNames(schema string, whitelist, blacklist []string) ([
 	if len(whitelist) > 0 {
 		return whitelist, nil
 	}
-	tables := []string{"pilots", "jets", "airports", "licenses", "hangars", "languages", "pilot_languages"}
+	tables := []string{"pilots", "jets", "airports", "licenses", "hangars", "languages", "pilot_languages", "jet_logs"}
 	return strmangle.SetComplement(tables, blacklist), nil
 }
 
+// ViewNames returns a list of mock view names
+func (m *MockDriver) ViewNames(schema string, whitelist, blacklist []string) ([]string, error) {
+	if len(whitelist) > 0 {
+		return nil, nil
+	}
+	views := []string{"pilot_jet_counts"}
+	return strmangle.SetComplement(views, blacklist), nil
+}
+
 // Columns returns a list of mock columns
 func (m *MockDriver) Columns(schema, tableName string) ([]bdb.Column, error) {
 	return map[string][]bdb.Column{
@@ -55,6 +64,14 @@ func (m *MockDriver) Columns(schema, tableName string) ([]bdb.Column, error) {
 			{Name: "pilot_id", Type: "int", DBType: "integer"},
 			{Name: "language_id", Type: "int", DBType: "integer"},
 		},
+		"jet_logs": {
+			{Name: "jet_id", Type: "int", DBType: "integer"},
+			{Name: "message", Type: "null.String", DBType: "character", Nullable: true},
+		},
+		"pilot_jet_counts": {
+			{Name: "pilot_id", Type: "int", DBType: "integer", Nullable: true},
+			{Name: "jets", Type: "int64", DBType: "bigint", Nullable: true},
+		},
 	}[tableName], nil
 }
 
@@ -72,6 +89,24 @@ func (m *MockDriver) ForeignKeyInfo(schema, tableName string) ([]bdb.ForeignKey,
 			{Table: "pilot_languages", Name: "pilot_id_fk", Column: "pilot_id", ForeignTable: "pilots", ForeignColumn: "id"},
 			{Table: "pilot_languages", Name: "jet_id_fk", Column: "language_id", ForeignTable: "languages", ForeignColumn: "id"},
 		},
+		"jet_logs": {
+			{Table: "jet_logs", Name: "jet_logs_jet_id_fk", Column: "jet_id", ForeignTable: "jets", ForeignColumn: "id"},
+		},
+	}[tableName], nil
+}
+
+// CheckConstraints returns a list of mock check constraints
+func (m *MockDriver) CheckConstraints(schema, tableName string) ([]bdb.CheckConstraint, error) {
+	return map[string][]bdb.CheckConstraint{
+		"pilots": {
+			{Name: "pilots_name_check", Definition: "CHECK ((char_length((name)::text) <= 50))"},
+		},
+		"airports": {
+			{Name: "airports_size_check", Definition: "CHECK (((size >= 0) AND (size <= 1000)))"},
+		},
+		"jets": {
+			{Name: "jets_name_check", Definition: "CHECK ((name <> ''::text))"},
+		},
 	}[tableName], nil
 }
 
@@ -121,6 +156,57 @@ func (m *MockDriver) UseLastInsertID() bool { return false }
 // UseTopClause returns a database mock SQL TOP clause compatibility flag
 func (m *MockDriver) UseTopClause() bool { return false }
 
+// ShareLock returns a database mock shared row locking clause
+func (m *MockDriver) ShareLock() string { return "FOR SHARE" }
+
+// RandomFunction returns a database mock random ordering function
+func (m *MockDriver) RandomFunction() string { return "RANDOM()" }
+
+// UseTableSample returns a database mock table sampling flag
+func (m *MockDriver) UseTableSample() bool { return true }
+
+// FullTextSearch returns a database mock full text search syntax
+func (m *MockDriver) FullTextSearch() string { return "" }
+
+// UseArrayParams returns a database mock array parameter flag
+func (m *MockDriver) UseArrayParams() bool { return true }
+
+// UseGroupingSets returns a database mock grouping sets flag
+func (m *MockDriver) UseGroupingSets() bool { return true }
+
+// UseFromOnly returns a database mock from only flag
+func (m *MockDriver) UseFromOnly() bool { return true }
+
+// UseNullsOrder returns a database mock nulls order flag
+func (m *MockDriver) UseNullsOrder() bool { return true }
+
+// UseRowValues returns a database mock row values flag
+func (m *MockDriver) UseRowValues() bool { return true }
+
+// UseDistinctOn returns a database mock distinct on flag
+func (m *MockDriver) UseDistinctOn() bool { return true }
+
+// UseIndexHints returns a database mock index hint flag
+func (m *MockDriver) UseIndexHints() bool { return false }
+
+// UsePostGIS returns a database mock PostGIS flag
+func (m *MockDriver) UsePostGIS() bool { return true }
+
+// UseCountEstimate returns a database mock count estimate flag
+func (m *MockDriver) UseCountEstimate() bool { return true }
+
+// UseNullSafeEqual returns a database mock null safe equal flag
+func (m *MockDriver) UseNullSafeEqual() bool { return false }
+
+// JSONContains returns a database mock JSON containment syntax
+func (m *MockDriver) JSONContains() string { return "@>" }
+
+// OrderByField returns a database mock syntax for ordering by a value list
+func (m *MockDriver) OrderByField() string { return "array_position" }
+
+// UpsertSyntax returns a database mock upsert syntax
+func (m *MockDriver) UpsertSyntax() string { return "postgres" }
+
 // Open mimics a database open call and returns nil for no error
 func (m *MockDriver) Open() error { return nil }
 
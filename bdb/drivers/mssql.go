@@ -73,16 +73,119 @@ func (m *MSSQLDriver) UseTopClause() bool {
 	return true
 }
 
+// ShareLock returns empty, MS SQL has no shared row locking clause
+func (m *MSSQLDriver) ShareLock() string {
+	return ""
+}
+
+// RandomFunction returns NEWID(), MS SQL has no random function that
+// is evaluated once per row so rows are ordered by a new uniqueidentifier
+func (m *MSSQLDriver) RandomFunction() string {
+	return "NEWID()"
+}
+
+// UseTableSample returns false, the MS SQL TABLESAMPLE clause takes a
+// different form that is not supported
+func (m *MSSQLDriver) UseTableSample() bool {
+	return false
+}
+
+// FullTextSearch returns "freetext", MS SQL searches with FREETEXT
+func (m *MSSQLDriver) FullTextSearch() string {
+	return "freetext"
+}
+
+// UseArrayParams returns false, MS SQL does not have array parameters
+func (m *MSSQLDriver) UseArrayParams() bool {
+	return false
+}
+
+// UseGroupingSets returns true, MS SQL supports GROUPING SETS, CUBE and ROLLUP
+func (m *MSSQLDriver) UseGroupingSets() bool {
+	return true
+}
+
+// UseFromOnly returns false, MS SQL has no table inheritance
+func (m *MSSQLDriver) UseFromOnly() bool {
+	return false
+}
+
+// UseNullsOrder returns false, MS SQL has no NULLS FIRST or NULLS LAST
+func (m *MSSQLDriver) UseNullsOrder() bool {
+	return false
+}
+
+// UseRowValues returns false, MS SQL can't compare row values
+func (m *MSSQLDriver) UseRowValues() bool {
+	return false
+}
+
+// UseDistinctOn returns false, MS SQL has no DISTINCT ON
+func (m *MSSQLDriver) UseDistinctOn() bool {
+	return false
+}
+
+// UseIndexHints returns false, MS SQL table hints take a different form
+// that is not supported
+func (m *MSSQLDriver) UseIndexHints() bool {
+	return false
+}
+
+// UsePostGIS returns false, MS SQL has its own spatial functions
+func (m *MSSQLDriver) UsePostGIS() bool {
+	return false
+}
+
+// UseCountEstimate returns false, count estimates are not supported
+// on MS SQL
+func (m *MSSQLDriver) UseCountEstimate() bool {
+	return false
+}
+
+// UseNullSafeEqual returns false, MS SQL compares null safely with
+// IS DISTINCT FROM since SQL Server 2022
+func (m *MSSQLDriver) UseNullSafeEqual() bool {
+	return false
+}
+
+// JSONContains returns empty, MS SQL has no JSON containment operator
+func (m *MSSQLDriver) JSONContains() string {
+	return ""
+}
+
+// OrderByField returns empty, MS SQL orders by a value list with CASE
+func (m *MSSQLDriver) OrderByField() string {
+	return ""
+}
+
+// UpsertSyntax returns "mssql", MS SQL upserts with MERGE
+func (m *MSSQLDriver) UpsertSyntax() string {
+	return "mssql"
+}
+
 // TableNames connects to the postgres database and
 // retrieves all table names from the information_schema where the
 // table schema is schema. It uses a whitelist and blacklist.
 func (m *MSSQLDriver) TableNames(schema string, whitelist, blacklist []string) ([]string, error) {
+	return m.names(schema, "BASE TABLE", whitelist, blacklist)
+}
+
+// ViewNames connects to the mssql database and
+// retrieves all view names from the information_schema where the
+// table schema is schema. It uses a whitelist and blacklist.
+func (m *MSSQLDriver) ViewNames(schema string, whitelist, blacklist []string) ([]string, error) {
+	return m.names(schema, "VIEW", whitelist, blacklist)
+}
+
+// names retrieves the names in information_schema.tables
+// of the given table type.
+func (m *MSSQLDriver) names(schema, tableType string, whitelist, blacklist []string) ([]string, error) {
 	var names []string
 
-	query := `
+	query := fmt.Sprintf(`
 		SELECT table_name
 		FROM   information_schema.tables
-		WHERE  table_schema = ? AND table_type = 'BASE TABLE'`
+		WHERE  table_schema = ? AND table_type = '%s'`, tableType)
 
 	args := []interface{}{schema}
 	if len(whitelist) > 0 {
@@ -249,6 +352,7 @@ func (m *MSSQLDriver) ForeignKeyInfo(schema, tableName string) ([]bdb.ForeignKey
 	SELECT ccu.constraint_name ,
 		ccu.table_name AS local_table ,
 		ccu.column_name AS local_column ,
+		kcu.table_schema AS foreign_schema ,
 		kcu.table_name AS foreign_table ,
 		kcu.column_name AS foreign_column
 	FROM information_schema.constraint_column_usage ccu
@@ -270,7 +374,7 @@ func (m *MSSQLDriver) ForeignKeyInfo(schema, tableName string) ([]bdb.ForeignKey
 		var sourceTable string
 
 		fkey.Table = tableName
-		err = rows.Scan(&fkey.Name, &sourceTable, &fkey.Column, &fkey.ForeignTable, &fkey.ForeignColumn)
+		err = rows.Scan(&fkey.Name, &sourceTable, &fkey.Column, &fkey.ForeignSchema, &fkey.ForeignTable, &fkey.ForeignColumn)
 		if err != nil {
 			return nil, err
 		}
@@ -285,6 +389,44 @@ func (m *MSSQLDriver) ForeignKeyInfo(schema, tableName string) ([]bdb.ForeignKey
 	return fkeys, nil
 }
 
+// CheckConstraints retrieves the CHECK constraints of a table with their
+// definitions from sys.check_constraints.
+func (m *MSSQLDriver) CheckConstraints(schema, tableName string) ([]bdb.CheckConstraint, error) {
+	var cons []bdb.CheckConstraint
+
+	query := `
+	SELECT cc.name, cc.definition
+	FROM sys.check_constraints cc
+	INNER JOIN sys.tables t ON t.object_id = cc.parent_object_id
+	INNER JOIN sys.schemas s ON s.schema_id = t.schema_id
+	WHERE s.name = ?
+	  AND t.name = ?
+	ORDER BY cc.name
+	`
+
+	var rows *sql.Rows
+	var err error
+	if rows, err = m.dbConn.Query(query, schema, tableName); err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var con bdb.CheckConstraint
+		if err = rows.Scan(&con.Name, &con.Definition); err != nil {
+			return nil, err
+		}
+
+		cons = append(cons, con)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return cons, nil
+}
+
 // TranslateColumnType converts postgres database types to Go types, for example
 // "varchar" to "string" and "bigint" to "int64". It returns this parsed data
 // as a Column object.
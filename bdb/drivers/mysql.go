@@ -86,13 +86,115 @@ func (m *MySQLDriver) UseTopClause() bool {
 	return false
 }
 
+// ShareLock returns LOCK IN SHARE MODE, the MySQL shared row locking
+// clause, which unlike FOR SHARE is supported before 8.0
+func (m *MySQLDriver) ShareLock() string {
+	return "LOCK IN SHARE MODE"
+}
+
+// RandomFunction returns RAND(), the MySQL random number function
+func (m *MySQLDriver) RandomFunction() string {
+	return "RAND()"
+}
+
+// UseTableSample returns false, MySQL has no TABLESAMPLE clause
+func (m *MySQLDriver) UseTableSample() bool {
+	return false
+}
+
+// FullTextSearch returns "match", MySQL searches with MATCH ... AGAINST
+func (m *MySQLDriver) FullTextSearch() string {
+	return "match"
+}
+
+// UseArrayParams returns false, MySQL does not have array parameters
+func (m *MySQLDriver) UseArrayParams() bool {
+	return false
+}
+
+// UseGroupingSets returns false, MySQL only has GROUP BY ... WITH ROLLUP
+func (m *MySQLDriver) UseGroupingSets() bool {
+	return false
+}
+
+// UseFromOnly returns false, MySQL has no table inheritance
+func (m *MySQLDriver) UseFromOnly() bool {
+	return false
+}
+
+// UseNullsOrder returns false, MySQL has no NULLS FIRST or NULLS LAST
+func (m *MySQLDriver) UseNullsOrder() bool {
+	return false
+}
+
+// UseRowValues returns false, MySQL compares row values but
+// doesn't use indexes for them, so comparisons are expanded
+func (m *MySQLDriver) UseRowValues() bool {
+	return false
+}
+
+// UseDistinctOn returns false, MySQL has no DISTINCT ON
+func (m *MySQLDriver) UseDistinctOn() bool {
+	return false
+}
+
+// UseIndexHints returns true, MySQL supports index hints like FORCE INDEX
+func (m *MySQLDriver) UseIndexHints() bool {
+	return true
+}
+
+// UsePostGIS returns false, MySQL has its own spatial functions
+func (m *MySQLDriver) UsePostGIS() bool {
+	return false
+}
+
+// UseCountEstimate returns false, the row estimates of MySQL's
+// information_schema are too rough for InnoDB to be used
+func (m *MySQLDriver) UseCountEstimate() bool {
+	return false
+}
+
+// UseNullSafeEqual returns true, MySQL compares null safely with <=>
+func (m *MySQLDriver) UseNullSafeEqual() bool {
+	return true
+}
+
+// JSONContains returns "json_contains", MySQL matches JSON containment
+// with JSON_CONTAINS
+func (m *MySQLDriver) JSONContains() string {
+	return "json_contains"
+}
+
+// OrderByField returns "field", MySQL orders by a value list with FIELD
+func (m *MySQLDriver) OrderByField() string {
+	return "field"
+}
+
+// UpsertSyntax returns "mysql", MySQL upserts with ON DUPLICATE KEY UPDATE
+func (m *MySQLDriver) UpsertSyntax() string {
+	return "mysql"
+}
+
 // TableNames connects to the postgres database and
 // retrieves all table names from the information_schema where the
 // table schema is public.
 func (m *MySQLDriver) TableNames(schema string, whitelist, blacklist []string) ([]string, error) {
+	return m.names(schema, "BASE TABLE", whitelist, blacklist)
+}
+
+// ViewNames connects to the mysql database and
+// retrieves all view names from the information_schema where the
+// table schema is schema. It uses a whitelist and blacklist.
+func (m *MySQLDriver) ViewNames(schema string, whitelist, blacklist []string) ([]string, error) {
+	return m.names(schema, "VIEW", whitelist, blacklist)
+}
+
+// names retrieves the names in information_schema.tables
+// of the given table type.
+func (m *MySQLDriver) names(schema, tableType string, whitelist, blacklist []string) ([]string, error) {
 	var names []string
 
-	query := fmt.Sprintf(`select table_name from information_schema.tables where table_schema = ? and table_type = 'BASE TABLE'`)
+	query := fmt.Sprintf(`select table_name from information_schema.tables where table_schema = ? and table_type = '%s'`, tableType)
 	args := []interface{}{schema}
 	if len(whitelist) > 0 {
 		query += fmt.Sprintf(" and table_name in (%s);", strings.Repeat(",?", len(whitelist))[1:])
@@ -268,6 +370,57 @@ func (m *MySQLDriver) ForeignKeyInfo(schema, tableName string) ([]bdb.ForeignKey
 	return fkeys, nil
 }
 
+// CheckConstraints retrieves the CHECK constraints of a table. They are
+// only enforced from MySQL 8.0.16, older versions have no check_constraints
+// table and none are returned.
+func (m *MySQLDriver) CheckConstraints(schema, tableName string) ([]bdb.CheckConstraint, error) {
+	var cons []bdb.CheckConstraint
+
+	var supported bool
+	row := m.dbConn.QueryRow(`
+	select exists (
+		select 1 from information_schema.tables
+		where table_schema = 'information_schema' and table_name = 'check_constraints'
+	)`)
+	if err := row.Scan(&supported); err != nil {
+		return nil, err
+	}
+	if !supported {
+		return nil, nil
+	}
+
+	query := `
+	select tc.constraint_name, cc.check_clause
+	from information_schema.table_constraints tc
+		inner join information_schema.check_constraints cc
+			on cc.constraint_schema = tc.constraint_schema and cc.constraint_name = tc.constraint_name
+	where tc.table_schema = ? and tc.table_name = ? and tc.constraint_type = 'CHECK'
+	order by tc.constraint_name
+	`
+
+	var rows *sql.Rows
+	var err error
+	if rows, err = m.dbConn.Query(query, schema, tableName); err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var con bdb.CheckConstraint
+		if err = rows.Scan(&con.Name, &con.Definition); err != nil {
+			return nil, err
+		}
+
+		cons = append(cons, con)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return cons, nil
+}
+
 // TranslateColumnType converts postgres database types to Go types, for example
 // "varchar" to "string" and "bigint" to "int64". It returns this parsed data
 // as a Column object.
@@ -314,8 +467,10 @@ func (m *MySQLDriver) TranslateColumnType(c bdb.Column) bdb.Column {
 			c.Type = "null.Float64"
 		case "boolean", "bool":
 			c.Type = "null.Bool"
-		case "date", "datetime", "timestamp", "time":
+		case "date", "datetime", "timestamp":
 			c.Type = "null.Time"
+		case "time":
+			c.Type = "types.NullInterval"
 		case "binary", "varbinary", "tinyblob", "blob", "mediumblob", "longblob":
 			c.Type = "null.Bytes"
 		case "json":
@@ -364,8 +519,10 @@ func (m *MySQLDriver) TranslateColumnType(c bdb.Column) bdb.Column {
 			c.Type = "float64"
 		case "boolean", "bool":
 			c.Type = "bool"
-		case "date", "datetime", "timestamp", "time":
+		case "date", "datetime", "timestamp":
 			c.Type = "time.Time"
+		case "time":
+			c.Type = "types.Interval"
 		case "binary", "varbinary", "tinyblob", "blob", "mediumblob", "longblob":
 			c.Type = "[]byte"
 		case "json":
@@ -84,13 +84,115 @@ func (m *PostgresDriver) UseTopClause() bool {
 	return false
 }
 
+// ShareLock returns FOR SHARE, the PSQL shared row locking clause
+func (m *PostgresDriver) ShareLock() string {
+	return "FOR SHARE"
+}
+
+// RandomFunction returns RANDOM(), the PSQL random number function
+func (m *PostgresDriver) RandomFunction() string {
+	return "RANDOM()"
+}
+
+// UseTableSample returns true to indicate PSQL supports TABLESAMPLE
+func (m *PostgresDriver) UseTableSample() bool {
+	return true
+}
+
+// FullTextSearch returns empty, PSQL searches with to_tsvector @@ plainto_tsquery
+func (m *PostgresDriver) FullTextSearch() string {
+	return ""
+}
+
+// UseArrayParams returns true, PSQL binds slices as arrays for = ANY($1)
+func (m *PostgresDriver) UseArrayParams() bool {
+	return true
+}
+
+// UseGroupingSets returns true, PSQL supports GROUPING SETS, CUBE and ROLLUP
+func (m *PostgresDriver) UseGroupingSets() bool {
+	return true
+}
+
+// UseFromOnly returns true, PSQL leaves out inheriting tables and partitions with ONLY
+func (m *PostgresDriver) UseFromOnly() bool {
+	return true
+}
+
+// UseNullsOrder returns true, PSQL supports NULLS FIRST and NULLS LAST
+func (m *PostgresDriver) UseNullsOrder() bool {
+	return true
+}
+
+// UseRowValues returns true, PSQL compares row values like (a, b) > ($1, $2)
+func (m *PostgresDriver) UseRowValues() bool {
+	return true
+}
+
+// UseDistinctOn returns true, PSQL supports DISTINCT ON
+func (m *PostgresDriver) UseDistinctOn() bool {
+	return true
+}
+
+// UseIndexHints returns false, PSQL has no index hints
+func (m *PostgresDriver) UseIndexHints() bool {
+	return false
+}
+
+// UsePostGIS returns true, PSQL gets PostGIS functions like ST_DWithin
+// from the postgis extension
+func (m *PostgresDriver) UsePostGIS() bool {
+	return true
+}
+
+// UseCountEstimate returns true, PSQL estimates the rows of each table
+// in the reltuples of pg_class
+func (m *PostgresDriver) UseCountEstimate() bool {
+	return true
+}
+
+// UseNullSafeEqual returns false, PSQL compares null safely with
+// IS DISTINCT FROM
+func (m *PostgresDriver) UseNullSafeEqual() bool {
+	return false
+}
+
+// JSONContains returns "@>", PSQL matches jsonb containment with @>
+func (m *PostgresDriver) JSONContains() string {
+	return "@>"
+}
+
+// OrderByField returns "array_position", PSQL orders by a value list with
+// array_position
+func (m *PostgresDriver) OrderByField() string {
+	return "array_position"
+}
+
+// UpsertSyntax returns "postgres", PSQL upserts with ON CONFLICT
+func (m *PostgresDriver) UpsertSyntax() string {
+	return "postgres"
+}
+
 // TableNames connects to the postgres database and
 // retrieves all table names from the information_schema where the
 // table schema is schema. It uses a whitelist and blacklist.
 func (p *PostgresDriver) TableNames(schema string, whitelist, blacklist []string) ([]string, error) {
+	return p.names(schema, "table_type <> 'VIEW'", whitelist, blacklist)
+}
+
+// ViewNames connects to the postgres database and
+// retrieves all view names from the information_schema where the
+// table schema is schema. It uses a whitelist and blacklist.
+func (p *PostgresDriver) ViewNames(schema string, whitelist, blacklist []string) ([]string, error) {
+	return p.names(schema, "table_type = 'VIEW'", whitelist, blacklist)
+}
+
+// names retrieves the names in information_schema.tables
+// matching the typeFilter condition.
+func (p *PostgresDriver) names(schema, typeFilter string, whitelist, blacklist []string) ([]string, error) {
 	var names []string
 
-	query := fmt.Sprintf(`select table_name from information_schema.tables where table_schema = $1`)
+	query := fmt.Sprintf(`select table_name from information_schema.tables where table_schema = $1 and %s`, typeFilter)
 	args := []interface{}{schema}
 	if len(whitelist) > 0 {
 		query += fmt.Sprintf(" and table_name in (%s);", strmangle.Placeholders(true, len(whitelist), 2, 1))
@@ -150,6 +252,13 @@ func (p *PostgresDriver) Columns(schema, tableName string) ([]bdb.Column, error)
 					order by pg_enum.enumsortorder
 				) as labels
 			)
+			when pgt.typtype = 'c'
+			then
+			(
+				select 'composite.' || c.udt_name || '(' || string_agg(a.attribute_name || ' ' || a.data_type, ',' order by a.ordinal_position) || ')'
+				from information_schema.attributes a
+				where a.udt_schema = c.udt_schema and a.udt_name = c.udt_name
+			)
 			else c.data_type
 			end
 		) as column_type,
@@ -275,12 +384,14 @@ func (p *PostgresDriver) ForeignKeyInfo(schema, tableName string) ([]bdb.Foreign
 		pgcon.conname,
 		pgc.relname as source_table,
 		pgasrc.attname as source_column,
+		dstns.nspname as dest_schema,
 		dstlookupname.relname as dest_table,
 		pgadst.attname as dest_column
 	from pg_namespace pgn
 		inner join pg_class pgc on pgn.oid = pgc.relnamespace and pgc.relkind = 'r'
 		inner join pg_constraint pgcon on pgn.oid = pgcon.connamespace and pgc.oid = pgcon.conrelid
 		inner join pg_class dstlookupname on pgcon.confrelid = dstlookupname.oid
+		inner join pg_namespace dstns on dstlookupname.relnamespace = dstns.oid
 		inner join pg_attribute pgasrc on pgc.oid = pgasrc.attrelid and pgasrc.attnum = ANY(pgcon.conkey)
 		inner join pg_attribute pgadst on pgcon.confrelid = pgadst.attrelid and pgadst.attnum = ANY(pgcon.confkey)
 	where pgn.nspname = $2 and pgc.relname = $1 and pgcon.contype = 'f'
@@ -297,7 +408,7 @@ func (p *PostgresDriver) ForeignKeyInfo(schema, tableName string) ([]bdb.Foreign
 		var sourceTable string
 
 		fkey.Table = tableName
-		err = rows.Scan(&fkey.Name, &sourceTable, &fkey.Column, &fkey.ForeignTable, &fkey.ForeignColumn)
+		err = rows.Scan(&fkey.Name, &sourceTable, &fkey.Column, &fkey.ForeignSchema, &fkey.ForeignTable, &fkey.ForeignColumn)
 		if err != nil {
 			return nil, err
 		}
@@ -312,10 +423,51 @@ func (p *PostgresDriver) ForeignKeyInfo(schema, tableName string) ([]bdb.Foreign
 	return fkeys, nil
 }
 
+// CheckConstraints retrieves the CHECK constraints of a table with their
+// definitions as given by pg_get_constraintdef.
+func (p *PostgresDriver) CheckConstraints(schema, tableName string) ([]bdb.CheckConstraint, error) {
+	var cons []bdb.CheckConstraint
+
+	query := `
+	select pgcon.conname, pg_get_constraintdef(pgcon.oid)
+	from pg_namespace pgn
+		inner join pg_class pgc on pgn.oid = pgc.relnamespace
+		inner join pg_constraint pgcon on pgc.oid = pgcon.conrelid
+	where pgn.nspname = $2 and pgc.relname = $1 and pgcon.contype = 'c'
+	order by pgcon.conname
+	`
+
+	var rows *sql.Rows
+	var err error
+	if rows, err = p.dbConn.Query(query, tableName, schema); err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var con bdb.CheckConstraint
+		if err = rows.Scan(&con.Name, &con.Definition); err != nil {
+			return nil, err
+		}
+
+		cons = append(cons, con)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return cons, nil
+}
+
 // TranslateColumnType converts postgres database types to Go types, for example
 // "varchar" to "string" and "bigint" to "int64". It returns this parsed data
 // as a Column object.
 func (p *PostgresDriver) TranslateColumnType(c bdb.Column) bdb.Column {
+	if strings.HasPrefix(c.DBType, "composite.") {
+		return p.translateCompositeType(c)
+	}
+
 	if c.Nullable {
 		switch c.DBType {
 		case "bigint", "bigserial":
@@ -328,8 +480,12 @@ func (p *PostgresDriver) TranslateColumnType(c bdb.Column) bdb.Column {
 			c.Type = "null.Float64"
 		case "real":
 			c.Type = "null.Float32"
-		case "bit", "interval", "bit varying", "character", "money", "character varying", "cidr", "inet", "macaddr", "text", "uuid", "xml":
+		case "bit", "bit varying", "character", "money", "character varying", "cidr", "inet", "macaddr", "text", "uuid", "xml":
 			c.Type = "null.String"
+		case "interval":
+			c.Type = "types.NullInterval"
+		case "int4range", "int8range", "numrange", "tsrange", "tstzrange", "daterange":
+			c.Type = "types.NullRange"
 		case `"char"`:
 			c.Type = "null.Byte"
 		case "bytea":
@@ -370,8 +526,12 @@ func (p *PostgresDriver) TranslateColumnType(c bdb.Column) bdb.Column {
 			c.Type = "float64"
 		case "real":
 			c.Type = "float32"
-		case "bit", "interval", "uuint", "bit varying", "character", "money", "character varying", "cidr", "inet", "macaddr", "text", "uuid", "xml":
+		case "bit", "uuint", "bit varying", "character", "money", "character varying", "cidr", "inet", "macaddr", "text", "uuid", "xml":
 			c.Type = "string"
+		case "interval":
+			c.Type = "types.Interval"
+		case "int4range", "int8range", "numrange", "tsrange", "tstzrange", "daterange":
+			c.Type = "types.Range"
 		case `"char"`:
 			c.Type = "types.Byte"
 		case "json", "jsonb":
@@ -402,6 +562,42 @@ func (p *PostgresDriver) TranslateColumnType(c bdb.Column) bdb.Column {
 	return c
 }
 
+// translateCompositeType sets the type of a composite column to the struct
+// generated for its composite type, and translates its attributes. Postgres
+// attributes are always nullable, and the ones that can't be read from the
+// text of a row literal are kept as their raw text in a null.String.
+func (p *PostgresDriver) translateCompositeType(c bdb.Column) bdb.Column {
+	name := strmangle.TitleCase(strmangle.ParseCompositeName(c.DBType)) + "Composite"
+	if c.Nullable {
+		c.Type = "Null" + name
+	} else {
+		c.Type = name
+	}
+
+	names, dbTypes := strmangle.ParseCompositeAttrs(c.DBType)
+	c.Composite = make([]bdb.Column, len(names))
+	for i, attrName := range names {
+		attr := bdb.Column{Name: attrName, DBType: dbTypes[i], Nullable: true}
+
+		switch attr.DBType {
+		case "ARRAY", "USER-DEFINED":
+			attr.Type = "null.String"
+		default:
+			attr = p.TranslateColumnType(attr)
+		}
+
+		switch attr.Type {
+		case "null.String", "null.Int", "null.Int16", "null.Int64", "null.Float32", "null.Float64", "null.Bool":
+		default:
+			attr.Type = "null.String"
+		}
+
+		c.Composite[i] = attr
+	}
+
+	return c
+}
+
 // getArrayType returns the correct boil.Array type for each database type
 func getArrayType(c bdb.Column) string {
 	switch *c.ArrType {
@@ -12,9 +12,15 @@ import (
 // database type (eg, MySQL, Postgres etc.)
 type Interface interface {
 	TableNames(schema string, whitelist, blacklist []string) ([]string, error)
+	// ViewNames returns the names of the views, which have no keys
+	// and are generated as read-only models
+	ViewNames(schema string, whitelist, blacklist []string) ([]string, error)
 	Columns(schema, tableName string) ([]Column, error)
 	PrimaryKeyInfo(schema, tableName string) (*PrimaryKey, error)
 	ForeignKeyInfo(schema, tableName string) ([]ForeignKey, error)
+	// CheckConstraints returns the CHECK constraints of the table,
+	// ordered by name
+	CheckConstraints(schema, tableName string) ([]CheckConstraint, error)
 
 	// TranslateColumnType takes a Database column type and returns a go column type.
 	TranslateColumnType(Column) Column
@@ -27,6 +33,74 @@ type Interface interface {
 	// the SQL TOP clause
 	UseTopClause() bool
 
+	// ShareLock returns the clause the Database takes shared row locks with,
+	// FOR SHARE or LOCK IN SHARE MODE, or empty if it has none
+	ShareLock() string
+
+	// RandomFunction returns the SQL function used to order rows randomly
+	RandomFunction() string
+
+	// UseTableSample should return true if the Database supports
+	// sampling rows with the TABLESAMPLE clause
+	UseTableSample() bool
+
+	// FullTextSearch returns the full text search syntax of the Database,
+	// "match" or "freetext", or empty for to_tsvector/plainto_tsquery
+	FullTextSearch() string
+
+	// UseArrayParams should return true if the Database can bind
+	// a slice as a single array parameter
+	UseArrayParams() bool
+
+	// UseGroupingSets should return true if the Database supports
+	// GROUPING SETS, CUBE and ROLLUP in GROUP BY
+	UseGroupingSets() bool
+
+	// UseFromOnly should return true if the Database supports ONLY
+	// in FROM to leave out inheriting tables
+	UseFromOnly() bool
+
+	// UseNullsOrder should return true if the Database supports
+	// NULLS FIRST and NULLS LAST in ORDER BY
+	UseNullsOrder() bool
+
+	// UseRowValues should return true if the Database can compare
+	// row values, like (a, b) > (?, ?)
+	UseRowValues() bool
+
+	// UseDistinctOn should return true if the Database supports
+	// DISTINCT ON
+	UseDistinctOn() bool
+
+	// UseIndexHints should return true if the Database supports
+	// index hints like FORCE INDEX after a table in FROM
+	UseIndexHints() bool
+
+	// UsePostGIS should return true if the Database supports
+	// PostGIS functions like ST_DWithin
+	UsePostGIS() bool
+
+	// UseCountEstimate should return true if the Database keeps an
+	// estimate of the rows of each table, like reltuples in pg_class
+	UseCountEstimate() bool
+
+	// UseNullSafeEqual should return true if the Database compares null
+	// safely with <=> instead of IS DISTINCT FROM
+	UseNullSafeEqual() bool
+
+	// JSONContains returns the JSON containment syntax of the Database,
+	// "@>" or "json_contains", or empty if it has none
+	JSONContains() string
+
+	// OrderByField returns the syntax of the Database for ordering by the
+	// position of a value in a list, "field" or "array_position", or empty
+	// for a CASE expression
+	OrderByField() string
+
+	// UpsertSyntax returns the name of the upsert builder of the Database,
+	// see queries.RegisterUpsertBuilder
+	UpsertSyntax() string
+
 	// Open the database connection
 	Open() error
 	// Close the database connection
@@ -40,7 +114,7 @@ type Interface interface {
 	IndexPlaceholders() bool
 }
 
-// Tables returns the metadata for all tables, minus the tables
+// Tables returns the metadata for all tables and views, minus the tables
 // specified in the blacklist.
 func Tables(db Interface, schema string, whitelist, blacklist []string) ([]Table, error) {
 	var err error
@@ -50,12 +124,19 @@ func Tables(db Interface, schema string, whitelist, blacklist []string) ([]Table
 		return nil, errors.Wrap(err, "unable to get table names")
 	}
 
+	viewNames, err := db.ViewNames(schema, whitelist, blacklist)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get view names")
+	}
+
+	names = append(names, viewNames...)
 	sort.Strings(names)
 
 	var tables []Table
 	for _, name := range names {
 		t := Table{
-			Name: name,
+			Name:   name,
+			IsView: strmangle.SetInclude(name, viewNames),
 		}
 
 		if t.Columns, err = db.Columns(schema, name); err != nil {
@@ -66,6 +147,13 @@ func Tables(db Interface, schema string, whitelist, blacklist []string) ([]Table
 			t.Columns[i] = db.TranslateColumnType(c)
 		}
 
+		// Views have no keys, a primary key can be set for them
+		// in the configuration
+		if t.IsView {
+			tables = append(tables, t)
+			continue
+		}
+
 		if t.PKey, err = db.PrimaryKeyInfo(schema, name); err != nil {
 			return nil, errors.Wrapf(err, "unable to fetch table pkey info (%s)", name)
 		}
@@ -74,8 +162,18 @@ func Tables(db Interface, schema string, whitelist, blacklist []string) ([]Table
 			return nil, errors.Wrapf(err, "unable to fetch table fkey info (%s)", name)
 		}
 
+		splitSchemaForeignKeys(&t, schema)
 		filterForeignKeys(&t, whitelist, blacklist)
 
+		cons, err := db.CheckConstraints(schema, name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to fetch table check constraints (%s)", name)
+		}
+		for _, con := range cons {
+			t.Checks = append(t.Checks, parseChecks(con)...)
+		}
+		t.Checks = filterChecks(t.Checks, t.Columns)
+
 		setIsJoinTable(&t)
 
 		tables = append(tables, t)
@@ -94,6 +192,21 @@ func Tables(db Interface, schema string, whitelist, blacklist []string) ([]Table
 	return tables, nil
 }
 
+// splitSchemaForeignKeys moves the foreign keys of t to a table of another
+// schema than schema to its SchemaFKeys, since their tables aren't among
+// the tables of schema.
+func splitSchemaForeignKeys(t *Table, schema string) {
+	var fkeys []ForeignKey
+	for _, fkey := range t.FKeys {
+		if len(fkey.ForeignSchema) != 0 && fkey.ForeignSchema != schema {
+			t.SchemaFKeys = append(t.SchemaFKeys, fkey)
+			continue
+		}
+		fkeys = append(fkeys, fkey)
+	}
+	t.FKeys = fkeys
+}
+
 // filterForeignKeys filter FK whose ForeignTable is not in whitelist or in blacklist
 func filterForeignKeys(t *Table, whitelist, blacklist []string) {
 	var fkeys []ForeignKey
@@ -141,6 +254,14 @@ func setForeignKeyConstraints(t *Table, tables []Table) {
 		t.FKeys[i].ForeignColumnNullable = foreignColumn.Nullable
 		t.FKeys[i].ForeignColumnUnique = foreignColumn.Unique
 	}
+
+	// The foreign columns of the other schemas aren't known here
+	for i, fkey := range t.SchemaFKeys {
+		localColumn := t.GetColumn(fkey.Column)
+
+		t.SchemaFKeys[i].Nullable = localColumn.Nullable
+		t.SchemaFKeys[i].Unique = localColumn.Unique
+	}
 }
 
 func setRelationships(t *Table, tables []Table) {
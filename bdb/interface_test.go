@@ -11,6 +11,23 @@ type testMockDriver struct{}
 func (m testMockDriver) TranslateColumnType(c Column) Column { return c }
 func (m testMockDriver) UseLastInsertID() bool               { return false }
 func (m testMockDriver) UseTopClause() bool                  { return false }
+func (m testMockDriver) ShareLock() string                   { return "FOR SHARE" }
+func (m testMockDriver) RandomFunction() string              { return "RANDOM()" }
+func (m testMockDriver) UseTableSample() bool                { return true }
+func (m testMockDriver) FullTextSearch() string              { return "" }
+func (m testMockDriver) UseArrayParams() bool                { return true }
+func (m testMockDriver) UseGroupingSets() bool               { return true }
+func (m testMockDriver) UseFromOnly() bool                   { return true }
+func (m testMockDriver) UseNullsOrder() bool                 { return true }
+func (m testMockDriver) UseRowValues() bool                  { return true }
+func (m testMockDriver) UseDistinctOn() bool                 { return true }
+func (m testMockDriver) UseIndexHints() bool                 { return false }
+func (m testMockDriver) UsePostGIS() bool                    { return true }
+func (m testMockDriver) UseCountEstimate() bool              { return true }
+func (m testMockDriver) UseNullSafeEqual() bool              { return false }
+func (m testMockDriver) JSONContains() string                { return "@>" }
+func (m testMockDriver) OrderByField() string                { return "array_position" }
+func (m testMockDriver) UpsertSyntax() string                { return "postgres" }
 func (m testMockDriver) Open() error                         { return nil }
 func (m testMockDriver) Close()                              {}
 
@@ -22,6 +39,13 @@ func (m testMockDriver) TableNames(schema string, whitelist, blacklist []string)
 	return strmangle.SetComplement(tables, blacklist), nil
 }
 
+func (m testMockDriver) ViewNames(schema string, whitelist, blacklist []string) ([]string, error) {
+	if len(whitelist) > 0 {
+		return nil, nil
+	}
+	return strmangle.SetComplement([]string{"pilot_jet_counts"}, blacklist), nil
+}
+
 // Columns returns a list of mock columns
 func (m testMockDriver) Columns(schema, tableName string) ([]Column, error) {
 	return map[string][]Column{
@@ -61,6 +85,10 @@ func (m testMockDriver) Columns(schema, tableName string) ([]Column, error) {
 			{Name: "pilot_id", Type: "int", DBType: "integer"},
 			{Name: "language_id", Type: "int", DBType: "integer"},
 		},
+		"pilot_jet_counts": {
+			{Name: "pilot_id", Type: "int", DBType: "integer", Nullable: true},
+			{Name: "jets", Type: "int64", DBType: "bigint", Nullable: true},
+		},
 	}[tableName], nil
 }
 
@@ -68,8 +96,9 @@ func (m testMockDriver) Columns(schema, tableName string) ([]Column, error) {
 func (m testMockDriver) ForeignKeyInfo(schema, tableName string) ([]ForeignKey, error) {
 	return map[string][]ForeignKey{
 		"jets": {
-			{Table: "jets", Name: "jets_pilot_id_fk", Column: "pilot_id", ForeignTable: "pilots", ForeignColumn: "id", ForeignColumnUnique: true},
+			{Table: "jets", Name: "jets_pilot_id_fk", Column: "pilot_id", ForeignSchema: "public", ForeignTable: "pilots", ForeignColumn: "id", ForeignColumnUnique: true},
 			{Table: "jets", Name: "jets_airport_id_fk", Column: "airport_id", ForeignTable: "airports", ForeignColumn: "id"},
+			{Table: "jets", Name: "jets_geo_airport_id_fk", Column: "airport_id", ForeignSchema: "geo", ForeignTable: "airports", ForeignColumn: "code"},
 		},
 		"licenses": {
 			{Table: "licenses", Name: "licenses_pilot_id_fk", Column: "pilot_id", ForeignTable: "pilots", ForeignColumn: "id"},
@@ -84,6 +113,18 @@ func (m testMockDriver) ForeignKeyInfo(schema, tableName string) ([]ForeignKey,
 	}[tableName], nil
 }
 
+func (m testMockDriver) CheckConstraints(schema, tableName string) ([]CheckConstraint, error) {
+	return map[string][]CheckConstraint{
+		"pilots": {
+			{Name: "pilots_name_check", Definition: "CHECK ((char_length((name)::text) <= 50))"},
+		},
+		"airports": {
+			{Name: "airports_size_check", Definition: "CHECK (((size >= 0) AND (size <= 1000)))"},
+			{Name: "airports_name_check", Definition: "CHECK ((char_length(missing) > 0))"},
+		},
+	}[tableName], nil
+}
+
 // PrimaryKeyInfo returns mock primary key info for the passed in table name
 func (m testMockDriver) PrimaryKeyInfo(schema, tableName string) (*PrimaryKey, error) {
 	return map[string]*PrimaryKey{
@@ -120,8 +161,8 @@ func TestTables(t *testing.T) {
 		t.Error(err)
 	}
 
-	if len(tables) != 7 {
-		t.Errorf("Expected len 7, got: %d\n", len(tables))
+	if len(tables) != 8 {
+		t.Errorf("Expected len 8, got: %d\n", len(tables))
 	}
 
 	prev := ""
@@ -146,10 +187,31 @@ func TestTables(t *testing.T) {
 		t.Error("want a to many to languages")
 	}
 
+	if len(pilots.Checks) != 1 || !pilots.Checks[0].Length || pilots.Checks[0].Column != "name" {
+		t.Errorf("want a length check of the name, got: %#v", pilots.Checks)
+	}
+	if checks := GetTable(tables, "airports").Checks; len(checks) != 2 {
+		t.Errorf("want the checks of existing columns, got: %#v", checks)
+	}
+
 	jets := GetTable(tables, "jets")
 	if len(jets.ToManyRelationships) != 0 {
 		t.Error("want no to many relationships")
 	}
+	if len(jets.FKeys) != 2 {
+		t.Errorf("want the foreign keys of the schema, got: %#v", jets.FKeys)
+	}
+	if len(jets.SchemaFKeys) != 1 || jets.SchemaFKeys[0].ForeignSchema != "geo" || jets.SchemaFKeys[0].Nullable {
+		t.Errorf("want the foreign key to the geo schema apart, got: %#v", jets.SchemaFKeys)
+	}
+
+	view := GetTable(tables, "pilot_jet_counts")
+	if !view.IsView || view.PKey != nil || len(view.Columns) != 2 {
+		t.Errorf("want a view without keys, got: %#v", view)
+	}
+	if GetTable(tables, "pilots").IsView {
+		t.Error("want pilots not to be a view")
+	}
 
 	languages := GetTable(tables, "pilot_languages")
 	if !languages.IsJoinTable {
@@ -16,6 +16,9 @@ type ForeignKey struct {
 	Nullable bool
 	Unique   bool
 
+	// ForeignSchema is the schema of ForeignTable, it's left empty by
+	// drivers whose foreign keys can't reference another schema
+	ForeignSchema         string
 	ForeignTable          string
 	ForeignColumn         string
 	ForeignColumnNullable bool
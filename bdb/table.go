@@ -12,8 +12,15 @@ type Table struct {
 
 	PKey  *PrimaryKey
 	FKeys []ForeignKey
+	// SchemaFKeys are the foreign keys to tables of other schemas, they
+	// are left out of FKeys and the relationships
+	SchemaFKeys []ForeignKey
+	// Checks are the simple conditions of the CHECK constraints
+	Checks []Check
 
 	IsJoinTable bool
+	// IsView is set for views, which get read-only models
+	IsView bool
 
 	ToOneRelationships  []ToOneRelationship
 	ToManyRelationships []ToManyRelationship
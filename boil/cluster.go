@@ -0,0 +1,150 @@
+package boil
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"strings"
+	"sync/atomic"
+)
+
+// Cluster is an Executor that sends writes to a primary database and spreads
+// reads over a set of replicas in round robin order. It can be used anywhere
+// an Executor is accepted, including SetDB.
+//
+// Exec always runs against the primary. Query and QueryRow run against a
+// replica only when the statement is a plain SELECT, so statements like
+// INSERT ... RETURNING and locking reads (SELECT ... FOR UPDATE) still reach
+// the primary. Transactions are always begun on the primary, and since the
+// returned transaction is a plain executor everything run inside it stays
+// there.
+//
+// Routing only looks at how the statement starts, so a SELECT with side
+// effects, like SELECT nextval('seq') or SELECT pg_advisory_lock(1), is sent
+// to a replica, where it fails or has no effect on the primary. Run those
+// on the executor returned by Primary.
+type Cluster struct {
+	primary  Executor
+	replicas []Executor
+
+	next uint32
+}
+
+// NewCluster creates a Cluster that writes to primary and reads from
+// replicas. With no replicas all queries go to the primary.
+func NewCluster(primary Executor, replicas ...Executor) *Cluster {
+	return &Cluster{
+		primary:  primary,
+		replicas: replicas,
+	}
+}
+
+// Primary returns the executor that writes are sent to.
+func (c *Cluster) Primary() Executor {
+	return c.primary
+}
+
+// Replica returns the executor the next read will be sent to.
+func (c *Cluster) Replica() Executor {
+	if len(c.replicas) == 0 {
+		return c.primary
+	}
+
+	n := atomic.AddUint32(&c.next, 1)
+	return c.replicas[(n-1)%uint32(len(c.replicas))]
+}
+
+// Exec runs query against the primary.
+func (c *Cluster) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return c.primary.Exec(query, args...)
+}
+
+// Query runs query against a replica if it is a plain read, otherwise
+// against the primary.
+func (c *Cluster) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return c.executorFor(query).Query(query, args...)
+}
+
+// QueryRow runs query against a replica if it is a plain read, otherwise
+// against the primary.
+func (c *Cluster) QueryRow(query string, args ...interface{}) *sql.Row {
+	return c.executorFor(query).QueryRow(query, args...)
+}
+
+// ExecContext runs query against the primary with ctx.
+func (c *Cluster) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return WithContext(ctx, c.primary).Exec(query, args...)
+}
+
+// QueryContext runs query with ctx, on a replica if it is a plain read,
+// otherwise on the primary.
+func (c *Cluster) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return WithContext(ctx, c.executorFor(query)).Query(query, args...)
+}
+
+// QueryRowContext runs query with ctx, on a replica if it is a plain read,
+// otherwise on the primary.
+func (c *Cluster) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return WithContext(ctx, c.executorFor(query)).QueryRow(query, args...)
+}
+
+// Begin starts a transaction on the primary.
+func (c *Cluster) Begin() (*sql.Tx, error) {
+	creator, ok := c.primary.(Beginner)
+	if !ok {
+		panic("database does not support transactions")
+	}
+
+	return creator.Begin()
+}
+
+func (c *Cluster) executorFor(query string) Executor {
+	if isReplicaSafe(query) {
+		return c.Replica()
+	}
+
+	return c.primary
+}
+
+// rgxLockingRead matches the row locking clauses of Postgres, MySQL and
+// the ones built by qm.For and qm.ForShare.
+var rgxLockingRead = regexp.MustCompile(`(?i)\bFOR\s+(NO\s+KEY\s+UPDATE|UPDATE|KEY\s+SHARE|SHARE)\b|\bLOCK\s+IN\s+SHARE\s+MODE\b`)
+
+// isReplicaSafe reports whether query is a SELECT that takes no row locks.
+func isReplicaSafe(query string) bool {
+	query = strings.TrimSpace(query)
+	if len(query) < 6 || !strings.EqualFold(query[:6], "SELECT") {
+		return false
+	}
+
+	return !rgxLockingRead.MatchString(query)
+}
+
+// Primary returns the primary of exec if it is a Cluster, otherwise exec
+// itself. Generated code uses it for writes that read their results back,
+// so that they never observe a replica that has not caught up yet. Use it
+// for reads that must run on the primary, like a SELECT with side effects.
+//
+// A Cluster wrapped by the executors of this package is found through them,
+// and the primary is wrapped the same way: the primary of
+// WithTimeout(cluster, d) is WithTimeout(cluster.Primary(), d). WithPrepared
+// of an executor that can't prepare statements, like a Cluster, doesn't
+// prepare them, so it's left out.
+func Primary(exec Executor) Executor {
+	switch e := exec.(type) {
+	case *Cluster:
+		return e.primary
+	case contextExecutor:
+		return contextExecutor{ctx: e.ctx, exec: Primary(e.exec)}
+	case timeoutExecutor:
+		return timeoutExecutor{exec: Primary(e.exec), timeout: e.timeout}
+	case identityExecutor:
+		return identityExecutor{exec: Primary(e.exec), identities: e.identities}
+	case *PreparedExecutor:
+		if _, ok := e.exec.(Preparer); !ok {
+			return Primary(e.exec)
+		}
+	}
+
+	return exec
+}
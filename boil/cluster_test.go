@@ -0,0 +1,156 @@
+package boil
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+)
+
+type recordingExecutor struct {
+	queries []string
+}
+
+func (r *recordingExecutor) Exec(query string, args ...interface{}) (sql.Result, error) {
+	r.queries = append(r.queries, query)
+	return nil, nil
+}
+
+func (r *recordingExecutor) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	r.queries = append(r.queries, query)
+	return nil, nil
+}
+
+func (r *recordingExecutor) QueryRow(query string, args ...interface{}) *sql.Row {
+	r.queries = append(r.queries, query)
+	return nil
+}
+
+func TestClusterRouting(t *testing.T) {
+	t.Parallel()
+
+	primary := &recordingExecutor{}
+	replica1 := &recordingExecutor{}
+	replica2 := &recordingExecutor{}
+	c := NewCluster(primary, replica1, replica2)
+
+	c.Query(`SELECT * FROM "a";`)
+	c.QueryRow(`  select count(*) from "a";`)
+	c.Query(`SELECT * FROM "a";`)
+	c.Exec(`DELETE FROM "a";`)
+	c.QueryRow(`INSERT INTO "a" ("b") VALUES ($1) RETURNING "id"`)
+	c.Query(`SELECT * FROM "a" FOR UPDATE;`)
+	c.Query(`SELECT * FROM "a" FOR NO KEY UPDATE NOWAIT;`)
+	c.Query("SELECT * FROM `a` LOCK IN SHARE MODE;")
+	c.QueryRow(`WITH x AS (DELETE FROM "a" RETURNING *) SELECT * FROM x;`)
+
+	if len(replica1.queries) != 2 {
+		t.Errorf("Expected 2 queries on replica1, got: %#v", replica1.queries)
+	}
+	if len(replica2.queries) != 1 {
+		t.Errorf("Expected 1 query on replica2, got: %#v", replica2.queries)
+	}
+	if len(primary.queries) != 6 {
+		t.Errorf("Expected 6 queries on primary, got: %#v", primary.queries)
+	}
+}
+
+func TestClusterNoReplicas(t *testing.T) {
+	t.Parallel()
+
+	primary := &recordingExecutor{}
+	c := NewCluster(primary)
+
+	c.Query(`SELECT 1;`)
+	if len(primary.queries) != 1 {
+		t.Errorf("Expected the read to go to the primary, got: %#v", primary.queries)
+	}
+	if c.Replica() != primary {
+		t.Error("Expected the replica to be the primary")
+	}
+}
+
+func TestPrimary(t *testing.T) {
+	t.Parallel()
+
+	primary := &recordingExecutor{}
+	replica := &recordingExecutor{}
+
+	if Primary(NewCluster(primary, replica)) != primary {
+		t.Error("Expected the primary of a cluster")
+	}
+	if Primary(replica) != replica {
+		t.Error("Expected a non-cluster executor to be returned as is")
+	}
+}
+
+func TestPrimaryWrapped(t *testing.T) {
+	t.Parallel()
+
+	primary := &recordingExecutor{}
+	replica := &recordingExecutor{}
+	c := NewCluster(primary, replica)
+	m := NewIdentityMap()
+
+	execs := []Executor{
+		WithTimeout(c, time.Minute),
+		WithContext(context.Background(), c),
+		WithIdentityMap(c, m),
+		WithPrepared(c),
+		WithContext(context.Background(), WithTimeout(WithIdentityMap(c, m), time.Minute)),
+	}
+	for i, exec := range execs {
+		Primary(exec).QueryRow(`SELECT nextval('pilots_id_seq');`)
+		if len(primary.queries) != i+1 || len(replica.queries) != 0 {
+			t.Errorf("%d) Expected the SELECT to reach the primary, got primary: %#v replica: %#v", i, primary.queries, replica.queries)
+		}
+	}
+
+	if _, ok := Primary(WithTimeout(c, time.Minute)).(timeoutExecutor); !ok {
+		t.Error("Expected the primary to keep the timeout")
+	}
+	if IdentityMapOf(Primary(WithIdentityMap(c, m))) != m {
+		t.Error("Expected the primary to keep the identity map")
+	}
+}
+
+func TestClusterSideEffectRead(t *testing.T) {
+	t.Parallel()
+
+	primary := &recordingExecutor{}
+	replica := &recordingExecutor{}
+	c := NewCluster(primary, replica)
+
+	// A SELECT is routed to a replica even when it has side effects
+	c.QueryRow(`SELECT nextval('pilots_id_seq');`)
+	if len(replica.queries) != 1 || len(primary.queries) != 0 {
+		t.Errorf("Expected the SELECT on the replica, got primary: %#v replica: %#v", primary.queries, replica.queries)
+	}
+
+	Primary(c).QueryRow(`SELECT nextval('pilots_id_seq');`)
+	if len(primary.queries) != 1 || len(replica.queries) != 1 {
+		t.Errorf("Expected the SELECT run on Primary to reach the primary, got primary: %#v replica: %#v", primary.queries, replica.queries)
+	}
+}
+
+func TestClusterContext(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	primary := &ctxRecorder{}
+	replica := &ctxRecorder{}
+	exec := WithContext(ctx, NewCluster(primary, replica))
+
+	exec.Query(`SELECT * FROM "a";`)
+	exec.Exec(`DELETE FROM "a";`)
+	exec.QueryRow(`SELECT * FROM "a" FOR UPDATE;`)
+
+	if len(replica.ctxs) != 1 || replica.ctxs[0] != ctx {
+		t.Errorf("Expected the read on the replica with the context, got: %#v", replica.ctxs)
+	}
+	if len(primary.ctxs) != 2 || primary.ctxs[0] != ctx || primary.ctxs[1] != ctx {
+		t.Errorf("Expected the writes on the primary with the context, got: %#v", primary.ctxs)
+	}
+}
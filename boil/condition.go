@@ -0,0 +1,46 @@
+package boil
+
+// Condition is a node of a tree of where conditions built with Cond, And
+// and Or. It is a structured alternative to writing a clause string, for
+// building a query out of the filters of a request for example:
+//
+//	And(Or(Cond("a", "=", 1), Cond("b", ">", 2)), Cond("c", "<", 3))
+//
+// is compiled by qm.WhereCond to (("a" = $1 OR "b" > $2) AND "c" < $3).
+type Condition interface {
+	condition()
+}
+
+// Comparison is a Condition comparing Column with Value by Operator.
+type Comparison struct {
+	Column   string
+	Operator string
+	Value    interface{}
+}
+
+// Conditions is a Condition joining Conds with AND, or with OR when Or
+// is set. An empty AND is always true and an empty OR never is.
+type Conditions struct {
+	Or    bool
+	Conds []Condition
+}
+
+func (Comparison) condition() {}
+func (Conditions) condition() {}
+
+// Cond compares column with value by operator, like "=", ">=" or "LIKE".
+// A nil value compared with "=" or "<>" is written as IS NULL or IS NOT
+// NULL instead of being bound.
+func Cond(column, operator string, value interface{}) Condition {
+	return Comparison{Column: column, Operator: operator, Value: value}
+}
+
+// And joins conds with AND.
+func And(conds ...Condition) Condition {
+	return Conditions{Conds: conds}
+}
+
+// Or joins conds with OR.
+func Or(conds ...Condition) Condition {
+	return Conditions{Or: true, Conds: conds}
+}
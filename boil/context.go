@@ -0,0 +1,130 @@
+package boil
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// ContextExecutor can perform SQL queries with a context, like sql.DB and
+// sql.Tx can.
+type ContextExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// WithContext returns an Executor that performs the queries of exec with ctx,
+// so they're cancelled along with it. It can be given to anything taking an
+// Executor, and the context is kept for every query made with it, like the
+// queries of nested eager loads.
+//
+// The returned Executor is a ContextExecutor too, whose methods use the
+// context they're given instead of ctx, as do the other executors of this
+// package, so wrapping them in one another passes the context down. When
+// exec isn't a ContextExecutor, like an Executor of your own, its queries
+// are performed without ctx, but Exec and Query fail once ctx is done.
+func WithContext(ctx context.Context, exec Executor) Executor {
+	return contextExecutor{ctx: ctx, exec: exec}
+}
+
+type contextExecutor struct {
+	ctx  context.Context
+	exec Executor
+}
+
+func (c contextExecutor) Exec(query string, args ...interface{}) (sql.Result, error) {
+	if exec, ok := c.exec.(ContextExecutor); ok {
+		return exec.ExecContext(c.ctx, query, args...)
+	}
+	if err := c.ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return c.exec.Exec(query, args...)
+}
+
+func (c contextExecutor) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	if exec, ok := c.exec.(ContextExecutor); ok {
+		return exec.QueryContext(c.ctx, query, args...)
+	}
+	if err := c.ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return c.exec.Query(query, args...)
+}
+
+func (c contextExecutor) QueryRow(query string, args ...interface{}) *sql.Row {
+	if exec, ok := c.exec.(ContextExecutor); ok {
+		return exec.QueryRowContext(c.ctx, query, args...)
+	}
+
+	return c.exec.QueryRow(query, args...)
+}
+
+func (c contextExecutor) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return contextExecutor{ctx: ctx, exec: c.exec}.Exec(query, args...)
+}
+
+func (c contextExecutor) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return contextExecutor{ctx: ctx, exec: c.exec}.Query(query, args...)
+}
+
+func (c contextExecutor) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return contextExecutor{ctx: ctx, exec: c.exec}.QueryRow(query, args...)
+}
+
+// WithTimeout returns an Executor that gives every query of exec its own
+// deadline, d after the query starts, and cancels it once that passes. It
+// needs exec to be a ContextExecutor, otherwise the queries run without one.
+// The deadline of Query and QueryRow also covers reading their rows. The
+// Context methods of the returned Executor derive the deadline from the
+// context they're given, so WithContext(ctx, WithTimeout(exec, d)) stops a
+// query at whichever comes first.
+func WithTimeout(exec Executor, d time.Duration) Executor {
+	return timeoutExecutor{exec: exec, timeout: d}
+}
+
+type timeoutExecutor struct {
+	exec    Executor
+	timeout time.Duration
+}
+
+func (t timeoutExecutor) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return t.ExecContext(context.Background(), query, args...)
+}
+
+func (t timeoutExecutor) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return t.QueryContext(context.Background(), query, args...)
+}
+
+func (t timeoutExecutor) QueryRow(query string, args ...interface{}) *sql.Row {
+	return t.QueryRowContext(context.Background(), query, args...)
+}
+
+func (t timeoutExecutor) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+
+	return WithContext(ctx, t.exec).Exec(query, args...)
+}
+
+func (t timeoutExecutor) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return WithContext(t.rowsContext(ctx), t.exec).Query(query, args...)
+}
+
+func (t timeoutExecutor) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return WithContext(t.rowsContext(ctx), t.exec).QueryRow(query, args...)
+}
+
+// rowsContext returns the context of a query returning rows, derived from
+// parent. Cancelling it would close the rows, which are read after the
+// query returns, so it isn't cancelled: the timer of its deadline releases
+// it once that passes, which takes no goroutine.
+func (t timeoutExecutor) rowsContext(parent context.Context) context.Context {
+	ctx, cancel := context.WithTimeout(parent, t.timeout)
+	_ = cancel
+
+	return ctx
+}
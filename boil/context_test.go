@@ -0,0 +1,230 @@
+package boil
+
+import (
+	"context"
+	"database/sql"
+	"runtime"
+	"testing"
+	"time"
+)
+
+type ctxRecorder struct {
+	Executor
+	ctxs []context.Context
+}
+
+func (c *ctxRecorder) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	c.ctxs = append(c.ctxs, ctx)
+	return nil, ctx.Err()
+}
+
+func (c *ctxRecorder) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	c.ctxs = append(c.ctxs, ctx)
+	return nil, ctx.Err()
+}
+
+func (c *ctxRecorder) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	c.ctxs = append(c.ctxs, ctx)
+	return nil
+}
+
+// slowExecutor takes a second for every query unless its context is done
+// first.
+type slowExecutor struct {
+	Executor
+}
+
+func (slowExecutor) wait(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(time.Second):
+		return nil
+	}
+}
+
+func (s slowExecutor) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return nil, s.wait(ctx)
+}
+
+func (s slowExecutor) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return nil, s.wait(ctx)
+}
+
+func (s slowExecutor) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	s.wait(ctx)
+	return nil
+}
+
+type plainExecutor struct {
+	queries int
+}
+
+func (p *plainExecutor) Exec(query string, args ...interface{}) (sql.Result, error) {
+	p.queries++
+	return nil, nil
+}
+
+func (p *plainExecutor) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	p.queries++
+	return nil, nil
+}
+
+func (p *plainExecutor) QueryRow(query string, args ...interface{}) *sql.Row {
+	p.queries++
+	return nil
+}
+
+func TestWithContext(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	rec := &ctxRecorder{}
+	exec := WithContext(ctx, rec)
+
+	if _, err := exec.Exec("update"); err != nil {
+		t.Error(err)
+	}
+	exec.QueryRow("select")
+
+	cancel()
+	if _, err := exec.Query("select"); err != context.Canceled {
+		t.Errorf("want a canceled error, got: %v", err)
+	}
+
+	if len(rec.ctxs) != 3 {
+		t.Fatalf("want 3 queries with a context, got %d", len(rec.ctxs))
+	}
+	for i, c := range rec.ctxs {
+		if c != ctx {
+			t.Errorf("%d) query was not given the context", i)
+		}
+	}
+}
+
+func TestWithContextNested(t *testing.T) {
+	t.Parallel()
+
+	outer, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rec := &ctxRecorder{}
+	exec := WithContext(outer, WithContext(context.Background(), rec))
+	if _, ok := exec.(ContextExecutor); !ok {
+		t.Fatal("want a ContextExecutor")
+	}
+
+	exec.Exec("update")
+	exec.Query("select")
+	exec.QueryRow("select")
+
+	if len(rec.ctxs) != 3 {
+		t.Fatalf("want 3 queries with a context, got %d", len(rec.ctxs))
+	}
+	for i, c := range rec.ctxs {
+		if c != outer {
+			t.Errorf("%d) query was not given the outer context", i)
+		}
+	}
+}
+
+func TestWithContextFallback(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	plain := &plainExecutor{}
+	exec := WithContext(ctx, plain)
+
+	if _, err := exec.Exec("update"); err != nil {
+		t.Error(err)
+	}
+
+	cancel()
+	if _, err := exec.Exec("update"); err != context.Canceled {
+		t.Errorf("want a canceled error, got: %v", err)
+	}
+	if _, err := exec.Query("select"); err != context.Canceled {
+		t.Errorf("want a canceled error, got: %v", err)
+	}
+
+	if plain.queries != 1 {
+		t.Errorf("want only the query made before cancel to run, got %d", plain.queries)
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	t.Parallel()
+
+	exec := WithTimeout(slowExecutor{}, 10*time.Millisecond)
+
+	start := time.Now()
+	if _, err := exec.Exec("select pg_sleep(1)"); err != context.DeadlineExceeded {
+		t.Errorf("want a deadline exceeded error, got: %v", err)
+	}
+	if _, err := exec.Query("select pg_sleep(1)"); err != context.DeadlineExceeded {
+		t.Errorf("want a deadline exceeded error, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed >= time.Second {
+		t.Errorf("want the queries cancelled, they took %s", elapsed)
+	}
+}
+
+func TestWithTimeoutPerQuery(t *testing.T) {
+	t.Parallel()
+
+	rec := &ctxRecorder{}
+	exec := WithTimeout(rec, time.Minute)
+
+	if _, err := exec.Exec("update"); err != nil {
+		t.Error(err)
+	}
+	if _, err := exec.Query("select"); err != nil {
+		t.Error(err)
+	}
+
+	if len(rec.ctxs) != 2 {
+		t.Fatalf("want 2 queries with a context, got %d", len(rec.ctxs))
+	}
+	if rec.ctxs[0] == rec.ctxs[1] {
+		t.Error("want every query to get its own context")
+	}
+	for i, c := range rec.ctxs {
+		if _, ok := c.Deadline(); !ok {
+			t.Errorf("%d) query context has no deadline", i)
+		}
+	}
+}
+
+func TestWithTimeoutContext(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	exec := WithContext(ctx, WithTimeout(slowExecutor{}, time.Minute))
+
+	cancel()
+	start := time.Now()
+	if _, err := exec.Exec("select pg_sleep(1)"); err != context.Canceled {
+		t.Errorf("want a canceled error, got: %v", err)
+	}
+	if _, err := exec.Query("select pg_sleep(1)"); err != context.Canceled {
+		t.Errorf("want a canceled error, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed >= time.Second {
+		t.Errorf("want the queries cancelled with the outer context, they took %s", elapsed)
+	}
+}
+
+// TestWithTimeoutRowsNoGoroutine is not parallel because it counts the
+// running goroutines.
+func TestWithTimeoutRowsNoGoroutine(t *testing.T) {
+	exec := WithTimeout(&ctxRecorder{}, time.Hour)
+
+	before := runtime.NumGoroutine()
+	for i := 0; i < 100; i++ {
+		exec.Query("select")
+		exec.QueryRow("select")
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("want no goroutine left per query, had %d and now %d", before, after)
+	}
+}
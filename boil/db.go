@@ -1,6 +1,11 @@
 package boil
 
-import "database/sql"
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
 
 // Executor can perform SQL queries.
 type Executor interface {
@@ -24,6 +29,10 @@ type Beginner interface {
 
 // Begin a transaction
 func Begin() (Transactor, error) {
+	if currentDB == nil {
+		return nil, ErrNoDB
+	}
+
 	creator, ok := currentDB.(Beginner)
 	if !ok {
 		panic("database does not support transactions")
@@ -31,3 +40,67 @@ func Begin() (Transactor, error) {
 
 	return creator.Begin()
 }
+
+// TxOption runs against a transaction after it has been begun and before
+// the function passed to Transaction is called. Returning an error rolls
+// the transaction back.
+type TxOption func(tx Transactor) error
+
+// TxExec returns a TxOption that executes query at the start of the
+// transaction, for statements like SET CONSTRAINTS or SET LOCAL.
+func TxExec(query string, args ...interface{}) TxOption {
+	return func(tx Transactor) error {
+		if DebugMode {
+			fmt.Fprintln(DebugWriter, query)
+			fmt.Fprintln(DebugWriter, args...)
+		}
+
+		_, err := tx.Exec(query, args...)
+		return err
+	}
+}
+
+// TransactionG runs fn inside a transaction on the global database.
+func TransactionG(fn func(tx Transactor) error, opts ...TxOption) error {
+	if currentDB == nil {
+		return ErrNoDB
+	}
+
+	creator, ok := currentDB.(Beginner)
+	if !ok {
+		panic("database does not support transactions")
+	}
+
+	return Transaction(creator, fn, opts...)
+}
+
+// Transaction begins a transaction on db, applies opts in order and then
+// calls fn. The transaction is committed if fn returns nil, and rolled back
+// if an option or fn returns an error or panics.
+func Transaction(db Beginner, fn func(tx Transactor) error, opts ...TxOption) (err error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return errors.Wrap(err, "boil: unable to begin transaction")
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	for _, opt := range opts {
+		if err = opt(tx); err != nil {
+			_ = tx.Rollback()
+			return errors.Wrap(err, "boil: transaction option failed")
+		}
+	}
+
+	if err = fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return errors.Wrap(tx.Commit(), "boil: unable to commit transaction")
+}
@@ -3,6 +3,9 @@ package boil
 import (
 	"database/sql"
 	"testing"
+
+	"github.com/pkg/errors"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
 )
 
 func TestGetSetDB(t *testing.T) {
@@ -14,3 +17,100 @@ func TestGetSetDB(t *testing.T) {
 		t.Errorf("Expected GetDB to return a database handle, got nil")
 	}
 }
+
+// TestGetDBUnset is not parallel, the global handle is unset while it runs.
+func TestGetDBUnset(t *testing.T) {
+	db := currentDB
+	defer SetDB(db)
+	SetDB(nil)
+
+	exec := GetDB()
+	if _, err := exec.Exec("DELETE FROM pilots"); err != ErrNoDB {
+		t.Errorf("want ErrNoDB from Exec, got: %v", err)
+	}
+	if _, err := exec.Query("SELECT * FROM pilots"); err != ErrNoDB {
+		t.Errorf("want ErrNoDB from Query, got: %v", err)
+	}
+	func() {
+		defer func() {
+			if p := recover(); p != ErrNoDB {
+				t.Errorf("want a panic with ErrNoDB from QueryRow, got: %v", p)
+			}
+		}()
+		exec.QueryRow("SELECT * FROM pilots")
+	}()
+
+	if _, err := Begin(); err != ErrNoDB {
+		t.Errorf("want ErrNoDB from Begin, got: %v", err)
+	}
+	if err := TransactionG(func(Transactor) error { return nil }); err != ErrNoDB {
+		t.Errorf("want ErrNoDB from TransactionG, got: %v", err)
+	}
+}
+
+func TestTransaction(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`SET CONSTRAINTS ALL DEFERRED`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`DELETE FROM pilots`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = Transaction(db, func(tx Transactor) error {
+		_, err := tx.Exec("DELETE FROM pilots")
+		return err
+	}, TxExec("SET CONSTRAINTS ALL DEFERRED"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestTransactionRollback(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	want := errors.New("fail")
+	err = Transaction(db, func(tx Transactor) error {
+		return want
+	})
+	if err != want {
+		t.Errorf("want: %v, got: %v", want, err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	called := false
+	err = Transaction(db, func(tx Transactor) error {
+		called = true
+		return nil
+	}, func(tx Transactor) error {
+		return want
+	})
+	if errors.Cause(err) != want {
+		t.Errorf("want: %v, got: %v", want, err)
+	}
+	if called {
+		t.Error("fn should not be called when an option fails")
+	}
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
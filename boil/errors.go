@@ -1,5 +1,53 @@
 package boil
 
+import "database/sql"
+
+// ErrNoRows is returned by the generated One and Find finishers when no
+// record matches. It wraps sql.ErrNoRows, so errors.Is(err, sql.ErrNoRows)
+// and errors.Cause(err) == sql.ErrNoRows from github.com/pkg/errors still
+// hold for it.
+var ErrNoRows error = noRowsErr{}
+
+type noRowsErr struct{}
+
+// Error returns the error string of sql.ErrNoRows
+func (noRowsErr) Error() string {
+	return sql.ErrNoRows.Error()
+}
+
+// Unwrap returns sql.ErrNoRows
+func (noRowsErr) Unwrap() error {
+	return sql.ErrNoRows
+}
+
+// Cause returns sql.ErrNoRows
+func (noRowsErr) Cause() error {
+	return sql.ErrNoRows
+}
+
+// IsNoRows checks if err is ErrNoRows or sql.ErrNoRows, or wraps either of
+// them with errors.Wrap or fmt.Errorf's %w.
+func IsNoRows(err error) bool {
+	for err != nil {
+		if err == ErrNoRows || err == sql.ErrNoRows {
+			return true
+		}
+
+		switch e := err.(type) {
+		case interface{ Cause() error }:
+			err = e.Cause()
+		case interface{ Unwrap() error }:
+			err = e.Unwrap()
+		case boilErr:
+			err = e.error
+		default:
+			return false
+		}
+	}
+
+	return false
+}
+
 type boilErr struct {
 	error
 }
@@ -1,8 +1,12 @@
 package boil
 
 import (
+	"database/sql"
 	"errors"
+	"fmt"
 	"testing"
+
+	pkgerrors "github.com/pkg/errors"
 )
 
 func TestErrors(t *testing.T) {
@@ -22,3 +26,55 @@ func TestErrors(t *testing.T) {
 		t.Errorf("Expected true")
 	}
 }
+
+func TestErrNoRows(t *testing.T) {
+	t.Parallel()
+
+	if !errors.Is(ErrNoRows, sql.ErrNoRows) {
+		t.Error("want ErrNoRows to be sql.ErrNoRows")
+	}
+	if errors.Is(sql.ErrNoRows, ErrNoRows) {
+		t.Error("want sql.ErrNoRows not to be ErrNoRows")
+	}
+	if ErrNoRows.Error() != sql.ErrNoRows.Error() {
+		t.Errorf("want the message of sql.ErrNoRows, got: %s", ErrNoRows)
+	}
+
+	if !errors.Is(fmt.Errorf("finding pilot: %w", ErrNoRows), sql.ErrNoRows) {
+		t.Error("want a wrapped ErrNoRows to be sql.ErrNoRows")
+	}
+	if pkgerrors.Cause(ErrNoRows) != sql.ErrNoRows {
+		t.Error("want the cause of ErrNoRows to be sql.ErrNoRows")
+	}
+	if pkgerrors.Cause(pkgerrors.Wrap(ErrNoRows, "models: failed")) != sql.ErrNoRows {
+		t.Error("want the cause of a wrapped ErrNoRows to be sql.ErrNoRows")
+	}
+}
+
+func TestIsNoRows(t *testing.T) {
+	t.Parallel()
+
+	noRows := []error{
+		ErrNoRows,
+		sql.ErrNoRows,
+		pkgerrors.Wrap(ErrNoRows, "models: failed"),
+		fmt.Errorf("finding pilot: %w", sql.ErrNoRows),
+		WrapErr(ErrNoRows),
+	}
+	for i, err := range noRows {
+		if !IsNoRows(err) {
+			t.Errorf("%d) want %v to be no rows", i, err)
+		}
+	}
+
+	others := []error{
+		nil,
+		errors.New("sql: no rows in result set"),
+		pkgerrors.Wrap(errors.New("connection refused"), "models: failed"),
+	}
+	for i, err := range others {
+		if IsNoRows(err) {
+			t.Errorf("%d) want %v not to be no rows", i, err)
+		}
+	}
+}
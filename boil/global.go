@@ -1,9 +1,12 @@
 package boil
 
 import (
+	"database/sql"
 	"io"
 	"os"
 	"time"
+
+	"github.com/pkg/errors"
 )
 
 var (
@@ -12,6 +15,12 @@ var (
 	// timestampLocation is the timezone used for the
 	// automated setting of created_at/updated_at columns
 	timestampLocation = time.UTC
+	// defaultLimit and maxLimit bound the number of rows
+	// select queries built from query mods return
+	defaultLimit, maxLimit int
+	// nullsOrder is where null values sort in the order
+	// by clauses of queries built from query mods
+	nullsOrder string
 )
 
 // DebugMode is a flag controlling whether generated sql statements and
@@ -28,11 +37,37 @@ func SetDB(db Executor) {
 	currentDB = db
 }
 
-// GetDB retrieves the global state database handle
+// ErrNoDB is returned by the G variants of the generated functions, and
+// by Begin and TransactionG, while no global database handle is set.
+var ErrNoDB = errors.New("boil: no global database handle is set, call boil.SetDB or pass an executor")
+
+// GetDB retrieves the global state database handle. While none is set it
+// returns an executor failing with ErrNoDB, so the G variants report the
+// missing handle instead of calling a nil executor.
 func GetDB() Executor {
+	if currentDB == nil {
+		return noDB{}
+	}
+
 	return currentDB
 }
 
+// noDB is the executor of GetDB while no global database handle is set.
+// QueryRow has no error to return, so it panics with ErrNoDB.
+type noDB struct{}
+
+func (noDB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return nil, ErrNoDB
+}
+
+func (noDB) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return nil, ErrNoDB
+}
+
+func (noDB) QueryRow(query string, args ...interface{}) *sql.Row {
+	panic(ErrNoDB)
+}
+
 // SetLocation sets the global timestamp Location.
 // This is the timezone used by the generated package for the
 // automated setting of created_at and updated_at columns.
@@ -49,3 +84,52 @@ func SetLocation(loc *time.Location) {
 func GetLocation() *time.Location {
 	return timestampLocation
 }
+
+// SetQueryLimits sets the limit of select queries built from query mods
+// that don't set one, and the largest limit they can have, larger limits
+// are lowered to it. Either is disabled when it is zero, the default.
+// Raw queries, counts, the queries used for eager loading and the selects
+// nested in another statement, like subqueries, are never limited. A
+// negative limit is taken as zero.
+func SetQueryLimits(defaultLim, maxLim int) {
+	if defaultLim < 0 {
+		defaultLim = 0
+	}
+	if maxLim < 0 {
+		maxLim = 0
+	}
+	defaultLimit, maxLimit = defaultLim, maxLim
+}
+
+// GetQueryLimits retrieves the default and max limit of select queries,
+// see SetQueryLimits.
+func GetQueryLimits() (defaultLim, maxLim int) {
+	return defaultLimit, maxLimit
+}
+
+// The places null values can sort in, see SetNullsOrder.
+const (
+	// NullsDefault leaves null values where the database sorts them
+	NullsDefault = ""
+	// NullsFirst sorts null values before the others
+	NullsFirst = "first"
+	// NullsLast sorts null values after the others
+	NullsLast = "last"
+)
+
+// SetNullsOrder sets where null values sort in queries built from query
+// mods, NullsFirst or NullsLast adds NULLS FIRST or NULLS LAST to each
+// order by expression that doesn't have either. It is NullsDefault by
+// default, and has no effect on databases without NULLS FIRST and LAST.
+// Queries can override it with qm.NullsOrder. It panics on any other order.
+func SetNullsOrder(order string) {
+	if order != NullsDefault && order != NullsFirst && order != NullsLast {
+		panic("boil: nulls order must be NullsDefault, NullsFirst or NullsLast")
+	}
+	nullsOrder = order
+}
+
+// GetNullsOrder retrieves where null values sort, see SetNullsOrder.
+func GetNullsOrder() string {
+	return nullsOrder
+}
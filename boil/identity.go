@@ -0,0 +1,130 @@
+package boil
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+)
+
+// IdentityMap holds the records found by primary key within a scope, like a
+// single request, so that finding the same row again returns the same
+// pointer instead of loading it once more. Generated Find functions use the
+// IdentityMap of their executor, see WithIdentityMap, and generated writes
+// evict the rows they change from it.
+//
+// Records are only evicted by writes made with an executor carrying the
+// map, and by Evict, EvictTable and Clear. Scope the map to a unit of work
+// rather than keeping it around.
+type IdentityMap struct {
+	mut     sync.RWMutex
+	records map[string]map[string]interface{}
+}
+
+// NewIdentityMap creates an empty IdentityMap.
+func NewIdentityMap() *IdentityMap {
+	return &IdentityMap{records: make(map[string]map[string]interface{})}
+}
+
+// identityKey returns the key of the primary key values pk.
+func identityKey(pk []interface{}) string {
+	return fmt.Sprintf("%v", pk)
+}
+
+// Get returns the record of table with primary key pk, if it's in the map.
+func (m *IdentityMap) Get(table string, pk ...interface{}) (interface{}, bool) {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	record, ok := m.records[table][identityKey(pk)]
+	return record, ok
+}
+
+// Set puts record in the map as the record of table with primary key pk.
+func (m *IdentityMap) Set(table string, record interface{}, pk ...interface{}) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	if m.records[table] == nil {
+		m.records[table] = make(map[string]interface{})
+	}
+	m.records[table][identityKey(pk)] = record
+}
+
+// Evict removes the record of table with primary key pk from the map.
+func (m *IdentityMap) Evict(table string, pk ...interface{}) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	delete(m.records[table], identityKey(pk))
+}
+
+// EvictTable removes all the records of table from the map.
+func (m *IdentityMap) EvictTable(table string) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	delete(m.records, table)
+}
+
+// Clear removes all records from the map.
+func (m *IdentityMap) Clear() {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	m.records = make(map[string]map[string]interface{})
+}
+
+// WithIdentityMap returns an Executor that performs the queries of exec and
+// carries m, so that generated code finding and writing records with it
+// uses m. Wrap it with WithContext, WithTimeout or WithPrepared as needed,
+// m is found through them.
+func WithIdentityMap(exec Executor, m *IdentityMap) Executor {
+	return identityExecutor{exec: exec, identities: m}
+}
+
+// IdentityMapOf returns the IdentityMap carried by exec, or nil if it has
+// none.
+func IdentityMapOf(exec Executor) *IdentityMap {
+	switch e := exec.(type) {
+	case identityExecutor:
+		return e.identities
+	case contextExecutor:
+		return IdentityMapOf(e.exec)
+	case timeoutExecutor:
+		return IdentityMapOf(e.exec)
+	case *PreparedExecutor:
+		return IdentityMapOf(e.exec)
+	}
+
+	return nil
+}
+
+type identityExecutor struct {
+	exec       Executor
+	identities *IdentityMap
+}
+
+func (i identityExecutor) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return i.exec.Exec(query, args...)
+}
+
+func (i identityExecutor) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return i.exec.Query(query, args...)
+}
+
+func (i identityExecutor) QueryRow(query string, args ...interface{}) *sql.Row {
+	return i.exec.QueryRow(query, args...)
+}
+
+func (i identityExecutor) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return WithContext(ctx, i.exec).Exec(query, args...)
+}
+
+func (i identityExecutor) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return WithContext(ctx, i.exec).Query(query, args...)
+}
+
+func (i identityExecutor) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return WithContext(ctx, i.exec).QueryRow(query, args...)
+}
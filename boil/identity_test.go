@@ -0,0 +1,98 @@
+package boil
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestIdentityMap(t *testing.T) {
+	t.Parallel()
+
+	m := NewIdentityMap()
+	pilot, jet := &struct{ ID int }{1}, &struct{ ID int }{1}
+
+	if _, ok := m.Get("pilots", 1); ok {
+		t.Error("expected an empty map")
+	}
+
+	m.Set("pilots", pilot, 1)
+	m.Set("jets", jet, 1)
+	m.Set("licenses", "a", 1, "b")
+
+	if got, ok := m.Get("pilots", 1); !ok || got != pilot {
+		t.Errorf("want pilot %p, got %v", pilot, got)
+	}
+	if got, ok := m.Get("jets", 1); !ok || got != jet {
+		t.Errorf("want jet %p, got %v", jet, got)
+	}
+	if got, ok := m.Get("licenses", 1, "b"); !ok || got != "a" {
+		t.Errorf("want license a, got %v", got)
+	}
+	if _, ok := m.Get("licenses", 1); ok {
+		t.Error("expected no license with a partial key")
+	}
+
+	m.Evict("pilots", 1)
+	if _, ok := m.Get("pilots", 1); ok {
+		t.Error("expected the pilot to be evicted")
+	}
+	if _, ok := m.Get("jets", 1); !ok {
+		t.Error("expected the jet to stay")
+	}
+
+	m.EvictTable("jets")
+	if _, ok := m.Get("jets", 1); ok {
+		t.Error("expected the jets to be evicted")
+	}
+
+	m.Clear()
+	if _, ok := m.Get("licenses", 1, "b"); ok {
+		t.Error("expected the map to be cleared")
+	}
+}
+
+func TestIdentityMapOf(t *testing.T) {
+	t.Parallel()
+
+	m := NewIdentityMap()
+	exec := WithIdentityMap(&ctxRecorder{}, m)
+
+	if got := IdentityMapOf(exec); got != m {
+		t.Errorf("want %p, got %p", m, got)
+	}
+	if got := IdentityMapOf(WithContext(context.Background(), exec)); got != m {
+		t.Errorf("want %p through a context, got %p", m, got)
+	}
+	if got := IdentityMapOf(WithTimeout(exec, time.Second)); got != m {
+		t.Errorf("want %p through a timeout, got %p", m, got)
+	}
+	if got := IdentityMapOf(WithPrepared(exec)); got != m {
+		t.Errorf("want %p through a prepared executor, got %p", m, got)
+	}
+	if got := IdentityMapOf(&ctxRecorder{}); got != nil {
+		t.Errorf("want no identity map, got %p", got)
+	}
+}
+
+func TestIdentityMapContext(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rec := &ctxRecorder{}
+	exec := WithContext(ctx, WithIdentityMap(rec, NewIdentityMap()))
+	exec.Exec("update")
+	exec.Query("select")
+	exec.QueryRow("select")
+
+	if len(rec.ctxs) != 3 {
+		t.Fatalf("want 3 queries with a context, got %d", len(rec.ctxs))
+	}
+	for i, c := range rec.ctxs {
+		if c != ctx {
+			t.Errorf("%d) query was not given the context", i)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package boil
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+)
+
+// Preparer can prepare statements, like sql.DB and sql.Tx can.
+type Preparer interface {
+	Prepare(query string) (*sql.Stmt, error)
+}
+
+// PreparedExecutor is an Executor that prepares every distinct query it is
+// given once, and runs the prepared statement for it from then on. It is
+// meant for running the same queries many times in a row, like inserting
+// the rows of a slice one at a time. Close it once done to close the
+// statements.
+type PreparedExecutor struct {
+	exec Executor
+
+	mut   sync.Mutex
+	stmts map[string]*sql.Stmt
+}
+
+// WithPrepared returns a PreparedExecutor preparing the queries of exec on
+// it. When exec isn't a Preparer its queries are run without preparing
+// them.
+func WithPrepared(exec Executor) *PreparedExecutor {
+	return &PreparedExecutor{exec: exec, stmts: make(map[string]*sql.Stmt)}
+}
+
+// stmt returns the prepared statement of query, preparing it the first
+// time. It returns nil if exec can't prepare statements.
+func (p *PreparedExecutor) stmt(query string) (*sql.Stmt, error) {
+	preparer, ok := p.exec.(Preparer)
+	if !ok {
+		return nil, nil
+	}
+
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	if stmt, ok := p.stmts[query]; ok {
+		return stmt, nil
+	}
+
+	stmt, err := preparer.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	p.stmts[query] = stmt
+
+	return stmt, nil
+}
+
+// Exec runs the prepared statement of query.
+func (p *PreparedExecutor) Exec(query string, args ...interface{}) (sql.Result, error) {
+	stmt, err := p.stmt(query)
+	if err != nil {
+		return nil, err
+	}
+	if stmt == nil {
+		return p.exec.Exec(query, args...)
+	}
+
+	return stmt.Exec(args...)
+}
+
+// Query runs the prepared statement of query.
+func (p *PreparedExecutor) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	stmt, err := p.stmt(query)
+	if err != nil {
+		return nil, err
+	}
+	if stmt == nil {
+		return p.exec.Query(query, args...)
+	}
+
+	return stmt.Query(args...)
+}
+
+// QueryRow runs the prepared statement of query. A sql.Row can't hold an
+// error of its own, so when query fails to prepare it is run unprepared,
+// and the error surfaces when the row is scanned.
+func (p *PreparedExecutor) QueryRow(query string, args ...interface{}) *sql.Row {
+	stmt, err := p.stmt(query)
+	if err != nil || stmt == nil {
+		return p.exec.QueryRow(query, args...)
+	}
+
+	return stmt.QueryRow(args...)
+}
+
+// ExecContext runs the prepared statement of query with ctx.
+func (p *PreparedExecutor) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	stmt, err := p.stmt(query)
+	if err != nil {
+		return nil, err
+	}
+	if stmt == nil {
+		return WithContext(ctx, p.exec).Exec(query, args...)
+	}
+
+	return stmt.ExecContext(ctx, args...)
+}
+
+// QueryContext runs the prepared statement of query with ctx.
+func (p *PreparedExecutor) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	stmt, err := p.stmt(query)
+	if err != nil {
+		return nil, err
+	}
+	if stmt == nil {
+		return WithContext(ctx, p.exec).Query(query, args...)
+	}
+
+	return stmt.QueryContext(ctx, args...)
+}
+
+// QueryRowContext runs the prepared statement of query with ctx, like
+// QueryRow.
+func (p *PreparedExecutor) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	stmt, err := p.stmt(query)
+	if err != nil || stmt == nil {
+		return WithContext(ctx, p.exec).QueryRow(query, args...)
+	}
+
+	return stmt.QueryRowContext(ctx, args...)
+}
+
+// Close the prepared statements. The first error closing them is returned.
+func (p *PreparedExecutor) Close() error {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	var err error
+	for query, stmt := range p.stmts {
+		if closeErr := stmt.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+		delete(p.stmts, query)
+	}
+
+	return err
+}
@@ -0,0 +1,145 @@
+package boil
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"testing"
+)
+
+// prepareCounter is a database/sql driver whose connections count the
+// statements they prepare and close.
+type prepareCounter struct {
+	prepared map[string]int
+	closed   int
+}
+
+func (p *prepareCounter) Open(name string) (driver.Conn, error) {
+	return prepareCounterConn{p}, nil
+}
+
+type prepareCounterConn struct {
+	counter *prepareCounter
+}
+
+func (c prepareCounterConn) Prepare(query string) (driver.Stmt, error) {
+	c.counter.prepared[query]++
+	return prepareCounterStmt{c.counter}, nil
+}
+
+func (c prepareCounterConn) Close() error              { return nil }
+func (c prepareCounterConn) Begin() (driver.Tx, error) { return nil, driver.ErrSkip }
+
+type prepareCounterStmt struct {
+	counter *prepareCounter
+}
+
+func (s prepareCounterStmt) Close() error {
+	s.counter.closed++
+	return nil
+}
+
+func (s prepareCounterStmt) NumInput() int { return -1 }
+
+func (s prepareCounterStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return driver.RowsAffected(1), nil
+}
+
+func (s prepareCounterStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return prepareCounterRows{}, nil
+}
+
+type prepareCounterRows struct{}
+
+func (prepareCounterRows) Columns() []string              { return []string{"id"} }
+func (prepareCounterRows) Close() error                   { return nil }
+func (prepareCounterRows) Next(dest []driver.Value) error { return io.EOF }
+
+var counter = &prepareCounter{prepared: make(map[string]int)}
+
+func init() {
+	sql.Register("boil_prepare_counter", counter)
+}
+
+func TestPreparedExecutor(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("boil_prepare_counter", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	p := WithPrepared(db)
+	for i := 0; i < 3; i++ {
+		if _, err := p.Exec(`INSERT INTO "a" ("b") VALUES ($1)`, i); err != nil {
+			t.Fatal(err)
+		}
+
+		rows, err := p.Query(`SELECT "id" FROM "a"`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rows.Close()
+
+		if err := p.QueryRow(`INSERT INTO "a" ("b") VALUES ($1) RETURNING "id"`, i).Scan(new(int)); err != sql.ErrNoRows {
+			t.Fatalf("Expected no rows, got: %v", err)
+		}
+	}
+
+	for query, count := range counter.prepared {
+		if count != 1 {
+			t.Errorf("Expected %s to be prepared once, got: %d", query, count)
+		}
+	}
+	if len(counter.prepared) != 3 {
+		t.Errorf("Expected 3 prepared statements, got: %#v", counter.prepared)
+	}
+
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if counter.closed != 3 {
+		t.Errorf("Expected the 3 statements to be closed, got: %d", counter.closed)
+	}
+}
+
+func TestPreparedExecutorNoPreparer(t *testing.T) {
+	t.Parallel()
+
+	exec := &recordingExecutor{}
+	p := WithPrepared(exec)
+	p.Exec(`DELETE FROM "a"`)
+	p.Exec(`DELETE FROM "a"`)
+
+	if len(exec.queries) != 2 {
+		t.Errorf("Expected the queries to be run unprepared, got: %#v", exec.queries)
+	}
+	if err := p.Close(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPreparedExecutorContext(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rec := &ctxRecorder{}
+	exec := WithContext(ctx, WithPrepared(rec))
+	exec.Exec(`DELETE FROM "a"`)
+	exec.Query(`SELECT "id" FROM "a"`)
+	exec.QueryRow(`SELECT "id" FROM "a"`)
+
+	if len(rec.ctxs) != 3 {
+		t.Fatalf("Expected 3 queries with a context, got %d", len(rec.ctxs))
+	}
+	for i, c := range rec.ctxs {
+		if c != ctx {
+			t.Errorf("%d) query was not given the context", i)
+		}
+	}
+}
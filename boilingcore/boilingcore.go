@@ -6,7 +6,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"go/build"
+	"go/token"
+	"io/ioutil"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -37,6 +40,14 @@ type State struct {
 	Tables  []bdb.Table
 	Dialect queries.Dialect
 
+	// BaseColumns are the fields of the generated ModelBase struct, see
+	// Config.BaseColumns
+	BaseColumns []bdb.Column
+
+	// SchemaToOnes are the relationships of the tables, by name, to the
+	// tables of the other schema packages, see NewSchemaPackages
+	SchemaToOnes map[string][]TxtSchemaToOne
+
 	Templates              *templateList
 	TestTemplates          *templateList
 	SingletonTemplates     *templateList
@@ -68,6 +79,11 @@ func New(config *Config) (*State, error) {
 		return nil, errors.Wrap(err, "unable to initialize tables")
 	}
 
+	if config.NullableAsPointers {
+		setNullableAsPointers(s.Tables)
+	}
+	s.BaseColumns = baseColumns(s.Tables, config.BaseColumns)
+
 	if s.Config.Debug {
 		b, err := json.Marshal(s.Tables)
 		if err != nil {
@@ -92,10 +108,205 @@ func New(config *Config) (*State, error) {
 	}
 
 	s.Importer = newImporter()
+	s.Importer.addCompositeImports(s.Tables)
+	s.Importer.addEnumImports(s.Tables)
+	s.Importer.addBaseImports(s.BaseColumns)
+	if config.AddContext {
+		s.Importer.Standard.standard = append(s.Importer.Standard.standard, `"context"`)
+	}
 
 	return s, nil
 }
 
+// NewSchemaPackages creates a state for each of config.SchemaPackages, see
+// schemaPackageConfigs. A foreign key to a table of another of the schemas
+// gets a to-one relationship returning the model of that schema's package,
+// see linkSchemaPackages. The reverse relationships aren't generated, the
+// packages would import each other.
+func NewSchemaPackages(config *Config) ([]*State, error) {
+	configs, err := schemaPackageConfigs(config)
+	if err != nil {
+		return nil, err
+	}
+
+	states := make([]*State, 0, len(configs))
+	for _, c := range configs {
+		s, err := New(c)
+		if err != nil {
+			for _, s := range states {
+				s.Cleanup()
+			}
+			return nil, errors.Wrapf(err, "schema %s", c.Schema)
+		}
+		states = append(states, s)
+	}
+
+	if err := linkSchemaPackages(states); err != nil {
+		for _, s := range states {
+			s.Cleanup()
+		}
+		return nil, err
+	}
+
+	return states, nil
+}
+
+// linkSchemaPackages sets the SchemaToOnes of states from the foreign keys
+// of their tables to the tables of the other states. Foreign keys to a
+// schema or table that isn't generated are left out. Since the package of
+// a foreign key imports the package of its table, schemas whose foreign
+// keys reference each other would be an import cycle, which is an error.
+func linkSchemaPackages(states []*State) error {
+	bySchema := make(map[string]*State, len(states))
+	for _, s := range states {
+		bySchema[s.Config.Schema] = s
+	}
+
+	names := make(map[string]tableNames, len(states))
+	for _, s := range states {
+		n, err := newTableNames(s.Tables, s.Config.TablePrefix, s.Config.TableAliases)
+		if err != nil {
+			return errors.Wrapf(err, "schema %s", s.Config.Schema)
+		}
+		names[s.Config.Schema] = n
+	}
+
+	imports := make(map[string][]string, len(states))
+	for _, s := range states {
+		s.SchemaToOnes = make(map[string][]TxtSchemaToOne)
+
+		for _, t := range s.Tables {
+			for _, fkey := range t.SchemaFKeys {
+				other, ok := bySchema[fkey.ForeignSchema]
+				if !ok || !hasTable(other.Tables, fkey.ForeignTable) {
+					continue
+				}
+
+				importPath, err := packageImportPath(other.Config.OutFolder)
+				if err != nil {
+					return errors.Wrapf(err, "schema %s", other.Config.Schema)
+				}
+
+				txt := names[s.Config.Schema].txtsFromSchemaFKey(t, fkey, names[other.Config.Schema], other.Config.PkgName, importPath)
+				s.SchemaToOnes[t.Name] = append(s.SchemaToOnes[t.Name], txt)
+
+				if !strmangle.SetInclude(other.Config.Schema, imports[s.Config.Schema]) {
+					imports[s.Config.Schema] = append(imports[s.Config.Schema], other.Config.Schema)
+				}
+			}
+		}
+	}
+
+	for _, s := range states {
+		if cycle := importCycle(imports, s.Config.Schema, nil); cycle != nil {
+			return errors.Errorf("the foreign keys of schemas %s reference each other, their packages would import each other", strings.Join(cycle, ", "))
+		}
+	}
+
+	return nil
+}
+
+// importCycle returns the schemas of an import cycle through schema, found
+// by following imports from the schemas in chain, or nil if there's none.
+func importCycle(imports map[string][]string, schema string, chain []string) []string {
+	if len(chain) != 0 && chain[0] == schema {
+		return chain
+	}
+	if strmangle.SetInclude(schema, chain) {
+		return nil
+	}
+
+	chain = append(chain, schema)
+	for _, imp := range imports[schema] {
+		if cycle := importCycle(imports, imp, chain); cycle != nil {
+			return cycle
+		}
+	}
+
+	return nil
+}
+
+// hasTable reports whether tables has a table named name.
+func hasTable(tables []bdb.Table, name string) bool {
+	for _, t := range tables {
+		if t.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+var rgxModulePath = regexp.MustCompile(`(?m)^module\s+"?([^"\s]+)"?`)
+
+// packageImportPath returns the import path of the package in dir, from the
+// go.mod of the module it's in, or else from its folder in the GOPATH.
+func packageImportPath(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	for root := dir; ; root = filepath.Dir(root) {
+		if mod, err := ioutil.ReadFile(filepath.Join(root, "go.mod")); err == nil {
+			m := rgxModulePath.FindSubmatch(mod)
+			if m == nil {
+				return "", errors.Errorf("no module path in %s", filepath.Join(root, "go.mod"))
+			}
+			rel, err := filepath.Rel(root, dir)
+			if err != nil {
+				return "", err
+			}
+			return path.Join(string(m[1]), filepath.ToSlash(rel)), nil
+		}
+		if filepath.Dir(root) == root {
+			break
+		}
+	}
+
+	for _, gopath := range filepath.SplitList(build.Default.GOPATH) {
+		src := filepath.Join(gopath, "src") + string(filepath.Separator)
+		if strings.HasPrefix(dir, src) {
+			return filepath.ToSlash(strings.TrimPrefix(dir, src)), nil
+		}
+	}
+
+	return "", errors.Errorf("unable to find the import path of %s, it's neither in a module nor in the GOPATH", dir)
+}
+
+var rgxPackageName = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// schemaPackageConfigs returns a copy of config for each of its
+// SchemaPackages, outputting the schema to a subfolder of the output folder
+// as a package both named after the lowercased schema.
+func schemaPackageConfigs(config *Config) ([]*Config, error) {
+	if config.DriverName == "mysql" {
+		return nil, errors.New("schema packages are not supported by mysql, its schema is the database")
+	}
+
+	configs := make([]*Config, len(config.SchemaPackages))
+	seen := make(map[string]string, len(config.SchemaPackages))
+	for i, schema := range config.SchemaPackages {
+		pkgName := strings.ToLower(schema)
+		if !rgxPackageName.MatchString(pkgName) || token.Lookup(pkgName).IsKeyword() {
+			return nil, errors.Errorf("schema %s is not a valid package name", schema)
+		}
+		if other, ok := seen[pkgName]; ok {
+			return nil, errors.Errorf("schemas %s and %s would both be generated into package %s", other, schema, pkgName)
+		}
+		seen[pkgName] = schema
+
+		c := *config
+		c.Schema = schema
+		c.PkgName = pkgName
+		c.OutFolder = filepath.Join(config.OutFolder, pkgName)
+		c.SchemaPackages = nil
+		configs[i] = &c
+	}
+
+	return configs, nil
+}
+
 // Run executes the sqlboiler templates and outputs them to files based on the
 // state given.
 func (s *State) Run(includeTests bool) error {
@@ -107,7 +318,11 @@ func (s *State) Run(includeTests bool) error {
 		PkgName:          s.Config.PkgName,
 		NoHooks:          s.Config.NoHooks,
 		NoAutoTimestamps: s.Config.NoAutoTimestamps,
+		AddContext:       s.Config.AddContext,
+		ExplicitScan:     s.Config.ExplicitScan,
 		StructTagCasing:  s.Config.StructTagCasing,
+		Tags:             s.Config.Tags,
+		BaseColumns:      s.BaseColumns,
 		Dialect:          s.Dialect,
 		LQ:               strmangle.QuoteCharacter(s.Dialect.LQ),
 		RQ:               strmangle.QuoteCharacter(s.Dialect.RQ),
@@ -143,8 +358,12 @@ func (s *State) Run(includeTests bool) error {
 			PkgName:          s.Config.PkgName,
 			NoHooks:          s.Config.NoHooks,
 			NoAutoTimestamps: s.Config.NoAutoTimestamps,
+			AddContext:       s.Config.AddContext,
+			ExplicitScan:     s.Config.ExplicitScan,
 			StructTagCasing:  s.Config.StructTagCasing,
 			Tags:             s.Config.Tags,
+			BaseColumns:      s.BaseColumns,
+			SchemaToOnes:     s.SchemaToOnes[table.Name],
 			Dialect:          s.Dialect,
 			LQ:               strmangle.QuoteCharacter(s.Dialect.LQ),
 			RQ:               strmangle.QuoteCharacter(s.Dialect.RQ),
@@ -157,8 +376,10 @@ func (s *State) Run(includeTests bool) error {
 			return errors.Wrap(err, "unable to generate output")
 		}
 
-		// Generate the test templates
-		if !s.Config.NoTests && includeTests {
+		// Generate the test templates, they rely on inserting rows and
+		// finding them again so there are none for views or for tables
+		// without a primary key
+		if !s.Config.NoTests && includeTests && !table.IsView && table.PKey != nil {
 			if err := generateTestOutput(s, data); err != nil {
 				return errors.Wrap(err, "unable to generate test output")
 			}
@@ -183,28 +404,34 @@ func (s *State) initTemplates() error {
 		return err
 	}
 
-	s.Templates, err = loadTemplates(filepath.Join(basePath, templatesDirectory))
+	names, err := newTableNames(s.Tables, s.Config.TablePrefix, s.Config.TableAliases)
+	if err != nil {
+		return err
+	}
+	funcs := tableNameFunctions(names)
+
+	s.Templates, err = loadTemplates(filepath.Join(basePath, templatesDirectory), funcs)
 	if err != nil {
 		return err
 	}
 
-	s.SingletonTemplates, err = loadTemplates(filepath.Join(basePath, templatesSingletonDirectory))
+	s.SingletonTemplates, err = loadTemplates(filepath.Join(basePath, templatesSingletonDirectory), funcs)
 	if err != nil {
 		return err
 	}
 
 	if !s.Config.NoTests {
-		s.TestTemplates, err = loadTemplates(filepath.Join(basePath, templatesTestDirectory))
+		s.TestTemplates, err = loadTemplates(filepath.Join(basePath, templatesTestDirectory), funcs)
 		if err != nil {
 			return err
 		}
 
-		s.SingletonTestTemplates, err = loadTemplates(filepath.Join(basePath, templatesSingletonTestDirectory))
+		s.SingletonTestTemplates, err = loadTemplates(filepath.Join(basePath, templatesSingletonTestDirectory), funcs)
 		if err != nil {
 			return err
 		}
 
-		s.TestMainTemplate, err = loadTemplate(filepath.Join(basePath, templatesTestMainDirectory), s.Config.DriverName+"_main.tpl")
+		s.TestMainTemplate, err = loadTemplate(filepath.Join(basePath, templatesTestMainDirectory), s.Config.DriverName+"_main.tpl", funcs)
 		if err != nil {
 			return err
 		}
@@ -328,6 +555,23 @@ func (s *State) initDriver(driverName string) error {
 	s.Dialect.RQ = s.Driver.RightQuote()
 	s.Dialect.IndexPlaceholders = s.Driver.IndexPlaceholders()
 	s.Dialect.UseTopClause = s.Driver.UseTopClause()
+	s.Dialect.ShareLock = s.Driver.ShareLock()
+	s.Dialect.RandomFunction = s.Driver.RandomFunction()
+	s.Dialect.UseTableSample = s.Driver.UseTableSample()
+	s.Dialect.FullTextSearch = s.Driver.FullTextSearch()
+	s.Dialect.UseArrayParams = s.Driver.UseArrayParams()
+	s.Dialect.UseGroupingSets = s.Driver.UseGroupingSets()
+	s.Dialect.UseFromOnly = s.Driver.UseFromOnly()
+	s.Dialect.UseNullsOrder = s.Driver.UseNullsOrder()
+	s.Dialect.JSONContains = s.Driver.JSONContains()
+	s.Dialect.OrderByField = s.Driver.OrderByField()
+	s.Dialect.UpsertSyntax = s.Driver.UpsertSyntax()
+	s.Dialect.UseRowValues = s.Driver.UseRowValues()
+	s.Dialect.UseDistinctOn = s.Driver.UseDistinctOn()
+	s.Dialect.UseIndexHints = s.Driver.UseIndexHints()
+	s.Dialect.UsePostGIS = s.Driver.UsePostGIS()
+	s.Dialect.UseCountEstimate = s.Driver.UseCountEstimate()
+	s.Dialect.UseNullSafeEqual = s.Driver.UseNullSafeEqual()
 
 	return nil
 }
@@ -344,7 +588,11 @@ func (s *State) initTables(schema string, whitelist, blacklist []string) error {
 		return errors.New("no tables found in database")
 	}
 
-	if err := checkPKeys(s.Tables); err != nil {
+	if err := setViewPrimaryKeys(s.Tables, s.Config.ViewPrimaryKeys); err != nil {
+		return err
+	}
+
+	if err := setTableKeys(s.Tables, s.Config.TableKeys); err != nil {
 		return err
 	}
 
@@ -378,17 +626,184 @@ func (s *State) initOutFolder() error {
 	return os.MkdirAll(s.Config.OutFolder, os.ModePerm)
 }
 
-// checkPKeys ensures every table has a primary key column
-func checkPKeys(tables []bdb.Table) error {
-	var missingPkey []string
+// nullablePointerTypes are the pointer types used in place of the nullable
+// types set by TranslateColumnType when NullableAsPointers is set.
+var nullablePointerTypes = map[string]string{
+	"null.Bool":          "*bool",
+	"null.Byte":          "*types.Byte",
+	"null.Bytes":         "*[]byte",
+	"null.Float32":       "*float32",
+	"null.Float64":       "*float64",
+	"null.Int":           "*int",
+	"null.Int8":          "*int8",
+	"null.Int16":         "*int16",
+	"null.Int32":         "*int32",
+	"null.Int64":         "*int64",
+	"null.JSON":          "*types.JSON",
+	"null.String":        "*string",
+	"null.Time":          "*time.Time",
+	"null.Uint":          "*uint",
+	"null.Uint8":         "*uint8",
+	"null.Uint16":        "*uint16",
+	"null.Uint32":        "*uint32",
+	"null.Uint64":        "*uint64",
+	"types.NullInterval": "*types.Interval",
+}
+
+// setNullableAsPointers changes the nullable columns of tables to pointer
+// types. A nil pointer is bound as NULL and scanned from it by database/sql.
+// Columns used by foreign keys keep their nullable types since the
+// relationship code sets and compares them through their Valid fields.
+func setNullableAsPointers(tables []bdb.Table) {
+	for i, t := range tables {
+		for j, c := range t.Columns {
+			if !c.Nullable || isForeignKeyColumn(tables, t.Name, c.Name) {
+				continue
+			}
+
+			if typ, ok := nullablePointerTypes[c.Type]; ok {
+				tables[i].Columns[j].Type = typ
+			}
+		}
+	}
+}
+
+// isForeignKeyColumn reports whether column of table is on either side of a
+// foreign key.
+func isForeignKeyColumn(tables []bdb.Table, table, column string) bool {
+	for _, t := range tables {
+		for _, fkey := range t.FKeys {
+			if t.Name == table && fkey.Column == column {
+				return true
+			}
+			if fkey.ForeignTable == table && fkey.ForeignColumn == column {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// baseColumns returns the columns called names, as they are in the first of
+// tables that has all of them, or nil if no table does.
+func baseColumns(tables []bdb.Table, names []string) []bdb.Column {
+	if len(names) == 0 {
+		return nil
+	}
+
+TableLoop:
 	for _, t := range tables {
-		if t.PKey == nil {
-			missingPkey = append(missingPkey, t.Name)
+		if t.IsJoinTable {
+			continue
 		}
+
+		columns := make([]bdb.Column, len(names))
+		for i, name := range names {
+			c, ok := findColumn(t.Columns, name)
+			if !ok {
+				continue TableLoop
+			}
+			columns[i] = c
+		}
+		return columns
 	}
 
-	if len(missingPkey) != 0 {
-		return errors.Errorf("primary key missing in tables (%s)", strings.Join(missingPkey, ", "))
+	return nil
+}
+
+// embedsBase reports whether table has every one of the base columns with
+// the same type, so its model embeds ModelBase instead of declaring them.
+func embedsBase(table bdb.Table, base []bdb.Column) bool {
+	if len(base) == 0 || table.IsJoinTable {
+		return false
+	}
+
+	for _, b := range base {
+		c, ok := findColumn(table.Columns, b.Name)
+		if !ok || c.Type != b.Type || c.Nullable != b.Nullable {
+			return false
+		}
+	}
+
+	return true
+}
+
+// findColumn returns the column of columns called name.
+func findColumn(columns []bdb.Column, name string) (bdb.Column, bool) {
+	for _, c := range columns {
+		if c.Name == name {
+			return c, true
+		}
+	}
+
+	return bdb.Column{}, false
+}
+
+// setViewPrimaryKeys sets the primary key of views from the configured
+// columns, which enables the finders that need one. The columns should be
+// unique in the view since nothing in the database enforces it.
+func setViewPrimaryKeys(tables []bdb.Table, pkeys map[string][]string) error {
+	for view, columns := range pkeys {
+		found := false
+		for i, t := range tables {
+			if t.Name != view {
+				continue
+			}
+			if !t.IsView {
+				return errors.Errorf("unable to set the primary key of %s, it is not a view", view)
+			}
+
+			for _, c := range columns {
+				if !strmangle.SetInclude(c, bdb.ColumnNames(t.Columns)) {
+					return errors.Errorf("unable to set the primary key of view %s, it has no column %s", view, c)
+				}
+			}
+
+			tables[i].PKey = &bdb.PrimaryKey{Name: view + "_pkey", Columns: columns}
+			found = true
+		}
+
+		if !found {
+			return errors.Errorf("unable to set the primary key of view %s, it was not found", view)
+		}
+	}
+
+	return nil
+}
+
+// setTableKeys sets the primary key of tables that have none from the
+// configured columns. The columns should be unique in the table, like the
+// columns of a unique index. Tables left without a primary key match their
+// rows on all of their columns instead.
+func setTableKeys(tables []bdb.Table, keys map[string][]string) error {
+	for table, columns := range keys {
+		found := false
+		for i, t := range tables {
+			if t.Name != table {
+				continue
+			}
+			if t.IsView {
+				return errors.Errorf("unable to set the key of table %s, it is a view, use view-pkey", table)
+			}
+			if t.PKey != nil {
+				return errors.Errorf("unable to set the key of table %s, it has a primary key", table)
+			}
+
+			for _, c := range columns {
+				if !strmangle.SetInclude(c, bdb.ColumnNames(t.Columns)) {
+					return errors.Errorf("unable to set the key of table %s, it has no column %s", table, c)
+				}
+			}
+
+			// The key is not a constraint of the table, so it has no name
+			tables[i].PKey = &bdb.PrimaryKey{Columns: columns}
+			found = true
+		}
+
+		if !found {
+			return errors.Errorf("unable to set the key of table %s, it was not found", table)
+		}
 	}
 
 	return nil
@@ -8,9 +8,12 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
 	"regexp"
 	"strconv"
 	"testing"
+
+	"github.com/volatiletech/sqlboiler/bdb"
 )
 
 var state *State
@@ -65,6 +68,321 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestSetViewPrimaryKeys(t *testing.T) {
+	t.Parallel()
+
+	newTables := func() []bdb.Table {
+		return []bdb.Table{
+			{Name: "pilots", Columns: []bdb.Column{{Name: "id"}}, PKey: &bdb.PrimaryKey{Columns: []string{"id"}}},
+			{Name: "pilot_jet_counts", Columns: []bdb.Column{{Name: "pilot_id"}, {Name: "jets"}}, IsView: true},
+		}
+	}
+
+	tables := newTables()
+	if err := setViewPrimaryKeys(tables, map[string][]string{"pilot_jet_counts": {"pilot_id"}}); err != nil {
+		t.Fatal(err)
+	}
+	if pkey := tables[1].PKey; pkey == nil || !reflect.DeepEqual(pkey.Columns, []string{"pilot_id"}) {
+		t.Errorf("Expected the view primary key to be set, got: %#v", pkey)
+	}
+
+	bad := []map[string][]string{
+		{"pilots": {"id"}},
+		{"pilot_jet_counts": {"pilot"}},
+		{"jet_counts": {"pilot_id"}},
+	}
+	for i, pkeys := range bad {
+		if err := setViewPrimaryKeys(newTables(), pkeys); err == nil {
+			t.Errorf("%d) Expected an error setting %v", i, pkeys)
+		}
+	}
+}
+
+func TestSetTableKeys(t *testing.T) {
+	t.Parallel()
+
+	newTables := func() []bdb.Table {
+		return []bdb.Table{
+			{Name: "pilots", Columns: []bdb.Column{{Name: "id"}}, PKey: &bdb.PrimaryKey{Columns: []string{"id"}}},
+			{Name: "jet_logs", Columns: []bdb.Column{{Name: "jet_id"}, {Name: "logged_at"}, {Name: "message"}}},
+			{Name: "pilot_jet_counts", Columns: []bdb.Column{{Name: "pilot_id"}, {Name: "jets"}}, IsView: true},
+		}
+	}
+
+	tables := newTables()
+	if err := setTableKeys(tables, map[string][]string{"jet_logs": {"jet_id", "logged_at"}}); err != nil {
+		t.Fatal(err)
+	}
+	if pkey := tables[1].PKey; pkey == nil || !reflect.DeepEqual(pkey.Columns, []string{"jet_id", "logged_at"}) {
+		t.Errorf("Expected the table key to be set, got: %#v", pkey)
+	}
+
+	bad := []map[string][]string{
+		{"pilots": {"id"}},
+		{"pilot_jet_counts": {"pilot_id"}},
+		{"jet_logs": {"jet"}},
+		{"jets": {"id"}},
+	}
+	for i, keys := range bad {
+		if err := setTableKeys(newTables(), keys); err == nil {
+			t.Errorf("%d) Expected an error setting %v", i, keys)
+		}
+	}
+}
+
+func TestSchemaPackageConfigs(t *testing.T) {
+	t.Parallel()
+
+	config := &Config{
+		DriverName:     "postgres",
+		Schema:         "public",
+		PkgName:        "models",
+		OutFolder:      "models",
+		SchemaPackages: []string{"Billing", "auth"},
+	}
+
+	configs, err := schemaPackageConfigs(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(configs) != 2 {
+		t.Fatalf("want 2 configs, got %d", len(configs))
+	}
+
+	want := []Config{
+		{DriverName: "postgres", Schema: "Billing", PkgName: "billing", OutFolder: filepath.Join("models", "billing")},
+		{DriverName: "postgres", Schema: "auth", PkgName: "auth", OutFolder: filepath.Join("models", "auth")},
+	}
+	for i, c := range configs {
+		if !reflect.DeepEqual(*c, want[i]) {
+			t.Errorf("%d) want: %#v\ngot: %#v", i, want[i], *c)
+		}
+	}
+	if config.Schema != "public" || config.PkgName != "models" {
+		t.Error("the original config should be untouched")
+	}
+
+	bad := []*Config{
+		{DriverName: "mysql", SchemaPackages: []string{"app"}},
+		{DriverName: "postgres", SchemaPackages: []string{"my-schema"}},
+		{DriverName: "postgres", SchemaPackages: []string{"type"}},
+		{DriverName: "postgres", SchemaPackages: []string{"app", "APP"}},
+	}
+	for i, c := range bad {
+		if _, err := schemaPackageConfigs(c); err == nil {
+			t.Errorf("%d) want an error for schemas %v", i, c.SchemaPackages)
+		}
+	}
+}
+
+func TestLinkSchemaPackages(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "boil_schema_packages")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err = ioutil.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/app\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	newStates := func(authFKeys []bdb.ForeignKey) []*State {
+		return []*State{
+			{
+				Config: &Config{Schema: "billing", PkgName: "billing", OutFolder: filepath.Join(dir, "models", "billing")},
+				Tables: []bdb.Table{{
+					Name: "invoices",
+					SchemaFKeys: []bdb.ForeignKey{
+						{Table: "invoices", Column: "user_id", ForeignSchema: "auth", ForeignTable: "users", ForeignColumn: "id"},
+						{Table: "invoices", Column: "shop_id", ForeignSchema: "shops", ForeignTable: "shops", ForeignColumn: "id"},
+						{Table: "invoices", Column: "group_id", ForeignSchema: "auth", ForeignTable: "groups", ForeignColumn: "id"},
+					},
+				}},
+			},
+			{
+				Config: &Config{Schema: "auth", PkgName: "auth", OutFolder: filepath.Join(dir, "models", "auth")},
+				Tables: []bdb.Table{{Name: "users", SchemaFKeys: authFKeys}},
+			},
+		}
+	}
+
+	states := newStates(nil)
+	if err := linkSchemaPackages(states); err != nil {
+		t.Fatal(err)
+	}
+
+	rels := states[0].SchemaToOnes["invoices"]
+	if len(rels) != 1 {
+		t.Fatalf("want only the relationship to a generated table, got: %#v", rels)
+	}
+	rel := rels[0]
+	if rel.Package != "auth" || rel.ImportPath != "example.com/app/models/auth" {
+		t.Errorf("want the auth package, got %s at %s", rel.Package, rel.ImportPath)
+	}
+	if rel.Function.Name != "User" || rel.LocalTable.NameGo != "Invoice" || rel.LocalTable.ColumnNameGo != "UserID" {
+		t.Errorf("want Invoice.User from UserID, got: %#v", rel)
+	}
+	if rel.ForeignTable.NameGo != "User" || rel.ForeignTable.NamePluralGo != "Users" || rel.ForeignTable.ColumnName != "id" {
+		t.Errorf("want the auth.Users finder by id, got: %#v", rel.ForeignTable)
+	}
+	if len(states[1].SchemaToOnes) != 0 {
+		t.Errorf("want no relationships from auth, got: %#v", states[1].SchemaToOnes)
+	}
+
+	states = newStates([]bdb.ForeignKey{
+		{Table: "users", Column: "invoice_id", ForeignSchema: "billing", ForeignTable: "invoices", ForeignColumn: "id"},
+	})
+	if err := linkSchemaPackages(states); err == nil {
+		t.Error("want an error for schemas whose packages would import each other")
+	}
+}
+
+func TestPackageImportPath(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "boil_import_path")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err = ioutil.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/app // the app\n\ngo 1.12\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]string{
+		dir:                                  "example.com/app",
+		filepath.Join(dir, "models", "auth"): "example.com/app/models/auth",
+	}
+	for folder, want := range tests {
+		got, err := packageImportPath(folder)
+		if err != nil {
+			t.Error(err)
+		}
+		if got != want {
+			t.Errorf("want %s, got %s", want, got)
+		}
+	}
+}
+
+func TestSetNullableAsPointers(t *testing.T) {
+	t.Parallel()
+
+	tables := []bdb.Table{
+		{
+			Name: "pilots",
+			Columns: []bdb.Column{
+				{Name: "id", Type: "int"},
+				{Name: "nick", Type: "null.String", Nullable: true},
+				{Name: "code", Type: "null.Int", Nullable: true},
+				{Name: "tags", Type: "types.StringArray", Nullable: true},
+			},
+		},
+		{
+			Name: "jets",
+			Columns: []bdb.Column{
+				{Name: "id", Type: "int"},
+				{Name: "pilot_code", Type: "null.Int", Nullable: true},
+				{Name: "flown_at", Type: "null.Time", Nullable: true},
+			},
+			FKeys: []bdb.ForeignKey{
+				{Table: "jets", Column: "pilot_code", ForeignTable: "pilots", ForeignColumn: "code"},
+			},
+		},
+	}
+
+	setNullableAsPointers(tables)
+
+	expect := map[string]string{
+		"pilots.id":       "int",
+		"pilots.nick":     "*string",
+		"pilots.code":     "null.Int",
+		"pilots.tags":     "types.StringArray",
+		"jets.id":         "int",
+		"jets.pilot_code": "null.Int",
+		"jets.flown_at":   "*time.Time",
+	}
+	for _, table := range tables {
+		for _, c := range table.Columns {
+			if want := expect[table.Name+"."+c.Name]; c.Type != want {
+				t.Errorf("%s.%s: want %s, got %s", table.Name, c.Name, want, c.Type)
+			}
+		}
+	}
+}
+
+func TestBaseColumns(t *testing.T) {
+	t.Parallel()
+
+	tables := []bdb.Table{
+		{
+			Name: "airports",
+			Columns: []bdb.Column{
+				{Name: "id", Type: "int"},
+				{Name: "name", Type: "string"},
+			},
+		},
+		{
+			Name: "pilots",
+			Columns: []bdb.Column{
+				{Name: "name", Type: "string"},
+				{Name: "updated_at", Type: "time.Time"},
+				{Name: "id", Type: "int"},
+			},
+			PKey: &bdb.PrimaryKey{Columns: []string{"id"}},
+		},
+		{
+			Name: "jets",
+			Columns: []bdb.Column{
+				{Name: "id", Type: "int64"},
+				{Name: "updated_at", Type: "time.Time"},
+			},
+		},
+		{
+			Name: "licenses",
+			Columns: []bdb.Column{
+				{Name: "id", Type: "int"},
+				{Name: "updated_at", Type: "null.Time", Nullable: true},
+			},
+		},
+	}
+
+	if base := baseColumns(tables, nil); base != nil {
+		t.Errorf("want no base columns, got %#v", base)
+	}
+	if base := baseColumns(tables, []string{"id", "created_at"}); base != nil {
+		t.Errorf("want no base columns, got %#v", base)
+	}
+
+	base := baseColumns(tables, []string{"id", "updated_at"})
+	want := []bdb.Column{{Name: "id", Type: "int"}, {Name: "updated_at", Type: "time.Time"}}
+	if !reflect.DeepEqual(base, want) {
+		t.Errorf("want %#v, got %#v", want, base)
+	}
+
+	embeds := map[string]bool{"airports": false, "pilots": true, "jets": false, "licenses": false}
+	for _, table := range tables {
+		if got := embedsBase(table, base); got != embeds[table.Name] {
+			t.Errorf("%s: want embeds %t, got %t", table.Name, embeds[table.Name], got)
+		}
+	}
+
+	data := templateData{Table: tables[1], BaseColumns: base}
+	if cols := data.StructColumns(); len(cols) != 1 || cols[0].Name != "name" {
+		t.Errorf("want only the name field, got %#v", cols)
+	}
+	if cols := data.importColumns(); len(cols) != 2 || cols[0].Name != "name" || cols[1].Name != "id" {
+		t.Errorf("want the name and primary key columns imported, got %#v", cols)
+	}
+
+	data = templateData{Table: tables[2], BaseColumns: base}
+	if cols := data.StructColumns(); len(cols) != 2 {
+		t.Errorf("want every field, got %#v", cols)
+	}
+}
+
 func outputCompileErrors(buf *bytes.Buffer, outFolder string) {
 	type errObj struct {
 		errMsg     string
@@ -2,21 +2,36 @@ package boilingcore
 
 // Config for the running of the commands
 type Config struct {
-	DriverName       string
-	Schema           string
-	PkgName          string
-	OutFolder        string
-	BaseDir          string
-	WhitelistTables  []string
-	BlacklistTables  []string
-	Tags             []string
-	Replacements     []string
-	Debug            bool
-	NoTests          bool
-	NoHooks          bool
-	NoAutoTimestamps bool
-	Wipe             bool
-	StructTagCasing  string
+	DriverName         string
+	Schema             string
+	PkgName            string
+	OutFolder          string
+	BaseDir            string
+	WhitelistTables    []string
+	BlacklistTables    []string
+	Tags               []string
+	Replacements       []string
+	Debug              bool
+	NoTests            bool
+	NoHooks            bool
+	NoAutoTimestamps   bool
+	AddContext         bool
+	ExplicitScan       bool
+	Wipe               bool
+	NullableAsPointers bool
+	StructTagCasing    string
+	TablePrefix        string
+	TableAliases       map[string]string
+	ViewPrimaryKeys    map[string][]string
+	// TableKeys are unique columns used as the primary key of tables
+	// that have none
+	TableKeys map[string][]string
+	// SchemaPackages are generated each into its own package named after
+	// the schema, in a subfolder of OutFolder, instead of Schema
+	SchemaPackages []string
+	// BaseColumns are generated into a ModelBase struct, which is embedded
+	// by the models of the tables that have all of them with the same types
+	BaseColumns []string
 
 	Postgres PostgresConfig
 	MySQL    MySQLConfig
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/volatiletech/sqlboiler/bdb"
+	"github.com/volatiletech/sqlboiler/strmangle"
 )
 
 // imports defines the optional standard imports and
@@ -181,6 +182,7 @@ func newImporter() importer {
 	imp.Singleton = mapImports{
 		"boil_queries": {
 			thirdParty: importList{
+				`"github.com/pkg/errors"`,
 				`"github.com/volatiletech/sqlboiler/boil"`,
 				`"github.com/volatiletech/sqlboiler/queries"`,
 				`"github.com/volatiletech/sqlboiler/queries/qm"`,
@@ -383,11 +385,94 @@ func newImporter() importer {
 		"types.Hstore": {
 			thirdParty: importList{`"github.com/volatiletech/sqlboiler/types"`},
 		},
+		"types.Interval": {
+			thirdParty: importList{`"github.com/volatiletech/sqlboiler/types"`},
+		},
+		"types.NullInterval": {
+			thirdParty: importList{`"github.com/volatiletech/sqlboiler/types"`},
+		},
+		"types.Range": {
+			thirdParty: importList{`"github.com/volatiletech/sqlboiler/types"`},
+		},
+		"types.NullRange": {
+			thirdParty: importList{`"github.com/volatiletech/sqlboiler/types"`},
+		},
+		"*time.Time": {
+			standard: importList{`"time"`},
+		},
+		"*types.Byte": {
+			thirdParty: importList{`"github.com/volatiletech/sqlboiler/types"`},
+		},
+		"*types.JSON": {
+			thirdParty: importList{`"github.com/volatiletech/sqlboiler/types"`},
+		},
+		"*types.Interval": {
+			thirdParty: importList{`"github.com/volatiletech/sqlboiler/types"`},
+		},
 	}
 
 	return imp
 }
 
+// addCompositeImports adds the imports used by the generated composite types
+// to the types singleton when any of the tables has a composite column.
+func (i importer) addCompositeImports(tables []bdb.Table) {
+	for _, t := range tables {
+		if len(bdb.FilterColumnsByComposite(t.Columns)) == 0 {
+			continue
+		}
+
+		i.Singleton.Add("boil_types", `"database/sql/driver"`, false)
+		i.Singleton.Add("boil_types", `"github.com/volatiletech/sqlboiler/types"`, true)
+		i.Singleton.Add("boil_types", `"gopkg.in/volatiletech/null.v6"`, true)
+		return
+	}
+}
+
+// addEnumImports adds the imports used by the Scan and Value methods of the
+// generated enum types to the types singleton when any of the tables has an
+// enum column with values, leaving out the ones already added for composite
+// types.
+func (i importer) addEnumImports(tables []bdb.Table) {
+	for _, t := range tables {
+		for _, c := range bdb.FilterColumnsByEnum(t.Columns) {
+			if len(strmangle.ParseEnumVals(c.DBType)) == 0 {
+				continue
+			}
+
+			i.Singleton.Add("boil_types", `"database/sql/driver"`, false)
+			i.Singleton.Add("boil_types", `"github.com/volatiletech/sqlboiler/types"`, true)
+
+			imps := i.Singleton["boil_types"]
+			imps.standard = removeDuplicates(imps.standard)
+			imps.thirdParty = removeDuplicates(imps.thirdParty)
+			i.Singleton["boil_types"] = imps
+			return
+		}
+	}
+}
+
+// addBaseImports adds the imports of the types of the base columns to the
+// types singleton, which declares the ModelBase struct holding them.
+func (i importer) addBaseImports(columns []bdb.Column) {
+	if len(columns) == 0 {
+		return
+	}
+
+	i.Singleton["boil_types"] = combineTypeImports(i.Singleton["boil_types"], i.BasedOnType, columns)
+}
+
+// schemaToOneImports returns the imports of the packages of the tables that
+// the relationships point to.
+func schemaToOneImports(rels []TxtSchemaToOne) imports {
+	var imps imports
+	for _, rel := range rels {
+		imps.thirdParty = append(imps.thirdParty, fmt.Sprintf(`"%s"`, rel.ImportPath))
+	}
+
+	return imps
+}
+
 // Remove an import matching the match string under the specified key.
 // Remove will search both standard and thirdParty import lists for a match.
 func (m mapImports) Remove(key string, match string) {
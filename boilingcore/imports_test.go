@@ -292,6 +292,64 @@ func TestCombineTypeImports(t *testing.T) {
 	}
 }
 
+func TestAddCompositeImports(t *testing.T) {
+	t.Parallel()
+
+	imps := newImporter()
+	imps.addCompositeImports([]bdb.Table{{Columns: []bdb.Column{{DBType: "integer"}}}})
+	if len(imps.Singleton["boil_types"].standard) != 0 {
+		t.Errorf("Expected no composite imports, got: %#v", imps.Singleton["boil_types"])
+	}
+
+	imps.addCompositeImports([]bdb.Table{
+		{Columns: []bdb.Column{{DBType: "integer"}}},
+		{Columns: []bdb.Column{{DBType: "composite.address(street text)"}}},
+	})
+
+	expected := imports{
+		standard: importList{`"database/sql/driver"`},
+		thirdParty: importList{
+			`"github.com/pkg/errors"`,
+			`"github.com/volatiletech/sqlboiler/strmangle"`,
+			`"github.com/volatiletech/sqlboiler/types"`,
+			`"gopkg.in/volatiletech/null.v6"`,
+		},
+	}
+	if got := imps.Singleton["boil_types"]; !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected composite imports, got:\n\n%#v\n", got)
+	}
+}
+
+func TestAddEnumImports(t *testing.T) {
+	t.Parallel()
+
+	imps := newImporter()
+	imps.addEnumImports([]bdb.Table{{Columns: []bdb.Column{{DBType: "integer"}, {DBType: "enum.mood()"}}}})
+	if len(imps.Singleton["boil_types"].standard) != 0 {
+		t.Errorf("Expected no enum imports, got: %#v", imps.Singleton["boil_types"])
+	}
+
+	tables := []bdb.Table{
+		{Columns: []bdb.Column{{DBType: "composite.address(street text)"}}},
+		{Columns: []bdb.Column{{DBType: "enum.mood('happy','sad')"}, {DBType: "enum('a','b')"}}},
+	}
+	imps.addCompositeImports(tables)
+	imps.addEnumImports(tables)
+
+	expected := imports{
+		standard: importList{`"database/sql/driver"`},
+		thirdParty: importList{
+			`"github.com/pkg/errors"`,
+			`"github.com/volatiletech/sqlboiler/strmangle"`,
+			`"github.com/volatiletech/sqlboiler/types"`,
+			`"gopkg.in/volatiletech/null.v6"`,
+		},
+	}
+	if got := imps.Singleton["boil_types"]; !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected enum imports once, got:\n\n%#v\n", got)
+	}
+}
+
 func TestCombineImports(t *testing.T) {
 	t.Parallel()
 
@@ -105,7 +105,8 @@ func executeTemplates(e executeTemplateData) error {
 	imps.standard = e.importSet.standard
 	imps.thirdParty = e.importSet.thirdParty
 	if e.combineImportsOnType {
-		imps = combineTypeImports(imps, e.state.Importer.BasedOnType, e.data.Table.Columns)
+		imps = combineTypeImports(imps, e.state.Importer.BasedOnType, e.data.importColumns())
+		imps = combineImports(imps, schemaToOneImports(e.data.SchemaToOnes))
 	}
 
 	writeFileDisclaimer(out)
@@ -31,12 +31,27 @@ type templateData struct {
 	NoHooks          bool
 	NoAutoTimestamps bool
 
+	// Generate context variants of relationship loaders and setters
+	AddContext bool
+
+	// Scan the columns of models into their fields explicitly instead of
+	// binding them by reflection when a query selects them all
+	ExplicitScan bool
+
 	// Tags control which
 	Tags []string
 
 	// Generate struct tags as camelCase or snake_case
 	StructTagCasing string
 
+	// BaseColumns are the fields of ModelBase, embedded by the models of
+	// the tables that have all of them
+	BaseColumns []bdb.Column
+
+	// SchemaToOnes are the relationships of Table to the tables of other
+	// schema packages
+	SchemaToOnes []TxtSchemaToOne
+
 	// StringFuncs are usable in templates with stringMap
 	StringFuncs map[string]func(string) string
 
@@ -54,6 +69,44 @@ func (t templateData) SchemaTable(table string) string {
 	return strmangle.SchemaTable(t.LQ, t.RQ, t.DriverName, t.Schema, table)
 }
 
+// EmbedsBase reports whether the model of table embeds ModelBase.
+func (t templateData) EmbedsBase(table bdb.Table) bool {
+	return embedsBase(table, t.BaseColumns)
+}
+
+// StructColumns returns the columns of Table that are fields of its model
+// struct, leaving out the ones it gets from an embedded ModelBase.
+func (t templateData) StructColumns() []bdb.Column {
+	if !t.EmbedsBase(t.Table) {
+		return t.Table.Columns
+	}
+
+	columns := make([]bdb.Column, 0, len(t.Table.Columns))
+	for _, c := range t.Table.Columns {
+		if _, ok := findColumn(t.BaseColumns, c.Name); !ok {
+			columns = append(columns, c)
+		}
+	}
+	return columns
+}
+
+// importColumns returns the columns of Table whose types are imported into
+// its model file. Those of an embedded ModelBase are imported by the types
+// file instead, except for primary key columns used in function arguments.
+func (t templateData) importColumns() []bdb.Column {
+	columns := t.StructColumns()
+	if len(columns) == len(t.Table.Columns) || t.Table.PKey == nil {
+		return columns
+	}
+
+	for _, c := range t.BaseColumns {
+		if strmangle.SetInclude(c.Name, t.Table.PKey.Columns) {
+			columns = append(columns, c)
+		}
+	}
+	return columns
+}
+
 type templateList struct {
 	*template.Template
 }
@@ -103,9 +156,9 @@ func (t templateList) Templates() []string {
 }
 
 // loadTemplates loads all of the template files in the specified directory.
-func loadTemplates(dir string) (*templateList, error) {
+func loadTemplates(dir string, funcs template.FuncMap) (*templateList, error) {
 	pattern := filepath.Join(dir, "*.tpl")
-	tpl, err := template.New("").Funcs(templateFunctions).ParseGlob(pattern)
+	tpl, err := template.New("").Funcs(funcs).ParseGlob(pattern)
 
 	if err != nil {
 		return nil, err
@@ -115,9 +168,9 @@ func loadTemplates(dir string) (*templateList, error) {
 }
 
 // loadTemplate loads a single template file
-func loadTemplate(dir string, filename string) (*template.Template, error) {
+func loadTemplate(dir string, filename string, funcs template.FuncMap) (*template.Template, error) {
 	pattern := filepath.Join(dir, filename)
-	tpl, err := template.New("").Funcs(templateFunctions).ParseFiles(pattern)
+	tpl, err := template.New("").Funcs(funcs).ParseFiles(pattern)
 
 	if err != nil {
 		return nil, err
@@ -127,7 +180,8 @@ func loadTemplate(dir string, filename string) (*template.Template, error) {
 }
 
 // replaceTemplate finds the template matching with name and replaces its
-// contents with the contents of the template located at filename
+// contents with the contents of the template located at filename. The
+// replacement shares the functions tpl was loaded with.
 func replaceTemplate(tpl *template.Template, name, filename string) error {
 	if tpl == nil {
 		return fmt.Errorf("template for %s is nil", name)
@@ -138,7 +192,7 @@ func replaceTemplate(tpl *template.Template, name, filename string) error {
 		return errors.Wrapf(err, "failed reading template file: %s", filename)
 	}
 
-	if tpl, err = tpl.New(name).Funcs(templateFunctions).Parse(string(b)); err != nil {
+	if tpl, err = tpl.New(name).Parse(string(b)); err != nil {
 		return errors.Wrapf(err, "failed to parse template file: %s", filename)
 	}
 
@@ -184,8 +238,9 @@ var templateStringMappers = map[string]func(string) string{
 // add a function pointer here.
 var templateFunctions = template.FuncMap{
 	// String ops
-	"quoteWrap": func(s string) string { return fmt.Sprintf(`"%s"`, s) },
-	"id":        strmangle.Identifier,
+	"quoteWrap":  func(s string) string { return fmt.Sprintf(`"%s"`, s) },
+	"id":         strmangle.Identifier,
+	"trimPrefix": func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
 
 	// Pluralization
 	"singular": strmangle.Singular,
@@ -213,6 +268,9 @@ var templateFunctions = template.FuncMap{
 	"oncePut":             once.Put,
 	"onceHas":             once.Has,
 
+	// Composite type ops
+	"parseCompositeName": strmangle.ParseCompositeName,
+
 	// String Map ops
 	"makeStringMap": strmangle.MakeStringMap,
 
@@ -223,16 +281,37 @@ var templateFunctions = template.FuncMap{
 	"whereClause": strmangle.WhereClause,
 
 	// Relationship text helpers
-	"txtsFromFKey":     txtsFromFKey,
-	"txtsFromOneToOne": txtsFromOneToOne,
-	"txtsFromToMany":   txtsFromToMany,
+	"txtsFromFKey":     tableNames(nil).txtsFromFKey,
+	"txtsFromOneToOne": tableNames(nil).txtsFromOneToOne,
+	"txtsFromToMany":   tableNames(nil).txtsFromToMany,
 
 	// dbdrivers ops
-	"filterColumnsByAuto":    bdb.FilterColumnsByAuto,
-	"filterColumnsByDefault": bdb.FilterColumnsByDefault,
-	"filterColumnsByEnum":    bdb.FilterColumnsByEnum,
-	"sqlColDefinitions":      bdb.SQLColDefinitions,
-	"columnNames":            bdb.ColumnNames,
-	"columnDBTypes":          bdb.ColumnDBTypes,
-	"getTable":               bdb.GetTable,
+	"filterColumnsByAuto":      bdb.FilterColumnsByAuto,
+	"filterColumnsByDefault":   bdb.FilterColumnsByDefault,
+	"filterColumnsByEnum":      bdb.FilterColumnsByEnum,
+	"filterColumnsByComposite": bdb.FilterColumnsByComposite,
+	"filterColumnsByMapKey":    bdb.FilterColumnsByMapKey,
+	"filterColumnsByRequired":  bdb.FilterColumnsByRequired,
+	"sqlColDefinitions":        bdb.SQLColDefinitions,
+	"columnNames":              bdb.ColumnNames,
+	"columnDBTypes":            bdb.ColumnDBTypes,
+	"literalDefaults":          bdb.LiteralDefaults,
+	"getTable":                 bdb.GetTable,
+}
+
+// tableNameFunctions returns a copy of templateFunctions where the functions
+// that derive Go names from table names use names.
+func tableNameFunctions(names tableNames) template.FuncMap {
+	funcs := make(template.FuncMap, len(templateFunctions))
+	for name, fn := range templateFunctions {
+		funcs[name] = fn
+	}
+
+	funcs["singular"] = names.singular
+	funcs["plural"] = names.plural
+	funcs["txtsFromFKey"] = names.txtsFromFKey
+	funcs["txtsFromOneToOne"] = names.txtsFromOneToOne
+	funcs["txtsFromToMany"] = names.txtsFromToMany
+
+	return funcs
 }
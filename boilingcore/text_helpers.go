@@ -2,12 +2,72 @@ package boilingcore
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/volatiletech/sqlboiler/bdb"
 	"github.com/volatiletech/sqlboiler/strmangle"
 )
 
+// tableNames maps database table names to the names that Go identifiers are
+// generated from. Table names that are not in the map are used as is.
+type tableNames map[string]string
+
+// rgxTableAlias matches the lower_snake names aliases must be given as, like
+// table names. Anything else, like "Plane", could camel case to the same
+// identifier it title cases to and collide in the generated code.
+var rgxTableAlias = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// newTableNames strips prefix from every table name that has it, and then
+// applies the aliases, which take precedence over the prefix.
+func newTableNames(tables []bdb.Table, prefix string, aliases map[string]string) (tableNames, error) {
+	names := make(tableNames)
+	seen := make(map[string]string)
+
+	for _, t := range tables {
+		name := t.Name
+		if alias, ok := aliases[t.Name]; ok {
+			if !rgxTableAlias.MatchString(alias) {
+				return nil, fmt.Errorf("alias %s for table %s must be lower_snake_case, like a table name", alias, t.Name)
+			}
+			name = alias
+		} else if len(prefix) != 0 && strings.HasPrefix(t.Name, prefix) && t.Name != prefix {
+			name = strings.TrimPrefix(t.Name, prefix)
+		}
+
+		goName := strmangle.TitleCase(strmangle.Singular(name))
+		if other, ok := seen[goName]; ok {
+			return nil, fmt.Errorf("tables %s and %s would both generate the model %s", other, t.Name, goName)
+		}
+		seen[goName] = t.Name
+
+		if name != t.Name {
+			names[t.Name] = name
+		}
+	}
+
+	return names, nil
+}
+
+// name returns the name Go identifiers for table should be generated from.
+func (n tableNames) name(table string) string {
+	if name, ok := n[table]; ok {
+		return name
+	}
+
+	return table
+}
+
+// singular is strmangle.Singular applied to the mapped table name.
+func (n tableNames) singular(table string) string {
+	return strmangle.Singular(n.name(table))
+}
+
+// plural is strmangle.Plural applied to the mapped table name.
+func (n tableNames) plural(table string) string {
+	return strmangle.Plural(n.name(table))
+}
+
 // TxtToOne contains text that will be used by templates for a one-to-many or
 // a one-to-one relationship.
 type TxtToOne struct {
@@ -36,20 +96,20 @@ type TxtToOne struct {
 	}
 }
 
-func txtsFromFKey(tables []bdb.Table, table bdb.Table, fkey bdb.ForeignKey) TxtToOne {
+func (n tableNames) txtsFromFKey(tables []bdb.Table, table bdb.Table, fkey bdb.ForeignKey) TxtToOne {
 	r := TxtToOne{}
 
 	r.ForeignKey = fkey
 
-	r.LocalTable.NameGo = strmangle.TitleCase(strmangle.Singular(table.Name))
+	r.LocalTable.NameGo = strmangle.TitleCase(n.singular(table.Name))
 	r.LocalTable.ColumnNameGo = strmangle.TitleCase(strmangle.Singular(fkey.Column))
 
-	r.ForeignTable.NameGo = strmangle.TitleCase(strmangle.Singular(fkey.ForeignTable))
-	r.ForeignTable.NamePluralGo = strmangle.TitleCase(strmangle.Plural(fkey.ForeignTable))
+	r.ForeignTable.NameGo = strmangle.TitleCase(n.singular(fkey.ForeignTable))
+	r.ForeignTable.NamePluralGo = strmangle.TitleCase(n.plural(fkey.ForeignTable))
 	r.ForeignTable.ColumnName = fkey.ForeignColumn
 	r.ForeignTable.ColumnNameGo = strmangle.TitleCase(strmangle.Singular(fkey.ForeignColumn))
 
-	r.Function.Name, r.Function.ForeignName = txtNameToOne(fkey)
+	r.Function.Name, r.Function.ForeignName = n.txtNameToOne(fkey)
 
 	if fkey.Nullable {
 		col := table.GetColumn(fkey.Column)
@@ -72,7 +132,7 @@ func txtsFromFKey(tables []bdb.Table, table bdb.Table, fkey bdb.ForeignKey) TxtT
 	return r
 }
 
-func txtsFromOneToOne(tables []bdb.Table, table bdb.Table, oneToOne bdb.ToOneRelationship) TxtToOne {
+func (n tableNames) txtsFromOneToOne(tables []bdb.Table, table bdb.Table, oneToOne bdb.ToOneRelationship) TxtToOne {
 	fkey := bdb.ForeignKey{
 		Table:    oneToOne.Table,
 		Name:     "none",
@@ -86,7 +146,7 @@ func txtsFromOneToOne(tables []bdb.Table, table bdb.Table, oneToOne bdb.ToOneRel
 		ForeignColumnUnique:   oneToOne.ForeignColumnUnique,
 	}
 
-	rel := txtsFromFKey(tables, table, fkey)
+	rel := n.txtsFromFKey(tables, table, fkey)
 	col := table.GetColumn(oneToOne.Column)
 
 	// Reverse foreign key
@@ -95,7 +155,7 @@ func txtsFromOneToOne(tables []bdb.Table, table bdb.Table, oneToOne bdb.ToOneRel
 	rel.ForeignKey.Nullable, rel.ForeignKey.ForeignColumnNullable = rel.ForeignKey.ForeignColumnNullable, rel.ForeignKey.Nullable
 	rel.ForeignKey.Unique, rel.ForeignKey.ForeignColumnUnique = rel.ForeignKey.ForeignColumnUnique, rel.ForeignKey.Unique
 	rel.Function.UsesBytes = col.Type == "[]byte"
-	rel.Function.ForeignName, rel.Function.Name = txtNameToOne(bdb.ForeignKey{
+	rel.Function.ForeignName, rel.Function.Name = n.txtNameToOne(bdb.ForeignKey{
 		Table:         oneToOne.ForeignTable,
 		Column:        oneToOne.ForeignColumn,
 		Unique:        true,
@@ -105,6 +165,54 @@ func txtsFromOneToOne(tables []bdb.Table, table bdb.Table, oneToOne bdb.ToOneRel
 	return rel
 }
 
+// TxtSchemaToOne contains text that will be used by templates for a to-one
+// relationship to a table of another schema, whose model is generated into
+// another package by NewSchemaPackages.
+type TxtSchemaToOne struct {
+	ForeignKey bdb.ForeignKey
+
+	// Package is the name of the package of the foreign table, and
+	// ImportPath its import path
+	Package    string
+	ImportPath string
+
+	LocalTable struct {
+		NameGo       string
+		ColumnNameGo string
+	}
+
+	ForeignTable struct {
+		NameGo       string
+		NamePluralGo string
+		ColumnName   string
+	}
+
+	Function struct {
+		Name string
+	}
+}
+
+// txtsFromSchemaFKey creates the text of the relationship of fkey from table,
+// named with n, to a table of pkg, named with foreign.
+func (n tableNames) txtsFromSchemaFKey(table bdb.Table, fkey bdb.ForeignKey, foreign tableNames, pkg, importPath string) TxtSchemaToOne {
+	r := TxtSchemaToOne{}
+
+	r.ForeignKey = fkey
+	r.Package = pkg
+	r.ImportPath = importPath
+
+	r.LocalTable.NameGo = strmangle.TitleCase(n.singular(table.Name))
+	r.LocalTable.ColumnNameGo = strmangle.TitleCase(strmangle.Singular(fkey.Column))
+
+	r.ForeignTable.NameGo = strmangle.TitleCase(foreign.singular(fkey.ForeignTable))
+	r.ForeignTable.NamePluralGo = strmangle.TitleCase(foreign.plural(fkey.ForeignTable))
+	r.ForeignTable.ColumnName = fkey.ForeignColumn
+
+	r.Function.Name = strmangle.TitleCase(strmangle.Singular(trimSuffixes(fkey.Column)))
+
+	return r
+}
+
 // TxtToMany contains text that will be used by many-to-one relationships.
 type TxtToMany struct {
 	LocalTable struct {
@@ -133,19 +241,19 @@ type TxtToMany struct {
 
 // txtsFromToMany creates a struct that does a lot of the text
 // transformation in advance for a given relationship.
-func txtsFromToMany(tables []bdb.Table, table bdb.Table, rel bdb.ToManyRelationship) TxtToMany {
+func (n tableNames) txtsFromToMany(tables []bdb.Table, table bdb.Table, rel bdb.ToManyRelationship) TxtToMany {
 	r := TxtToMany{}
-	r.LocalTable.NameGo = strmangle.TitleCase(strmangle.Singular(table.Name))
+	r.LocalTable.NameGo = strmangle.TitleCase(n.singular(table.Name))
 	r.LocalTable.ColumnNameGo = strmangle.TitleCase(rel.Column)
 
-	foreignNameSingular := strmangle.Singular(rel.ForeignTable)
-	r.ForeignTable.NamePluralGo = strmangle.TitleCase(strmangle.Plural(rel.ForeignTable))
+	foreignNameSingular := n.singular(rel.ForeignTable)
+	r.ForeignTable.NamePluralGo = strmangle.TitleCase(n.plural(rel.ForeignTable))
 	r.ForeignTable.NameGo = strmangle.TitleCase(foreignNameSingular)
 	r.ForeignTable.ColumnNameGo = strmangle.TitleCase(rel.ForeignColumn)
 	r.ForeignTable.Slice = fmt.Sprintf("%sSlice", strmangle.TitleCase(foreignNameSingular))
 	r.ForeignTable.NameHumanReadable = strings.Replace(rel.ForeignTable, "_", " ", -1)
 
-	r.Function.Name, r.Function.ForeignName = txtNameToMany(rel)
+	r.Function.Name, r.Function.ForeignName = n.txtNameToMany(rel)
 
 	col := table.GetColumn(rel.Column)
 	if rel.Nullable {
@@ -195,18 +303,18 @@ func txtsFromToMany(tables []bdb.Table, table bdb.Table, rel bdb.ToManyRelations
 //
 // fk == table = industry.Industry | industry.Industry
 // fk != table = industry.ParentIndustry | industry.Industry
-func txtNameToOne(fk bdb.ForeignKey) (localFn, foreignFn string) {
+func (n tableNames) txtNameToOne(fk bdb.ForeignKey) (localFn, foreignFn string) {
 	localFn = strmangle.Singular(trimSuffixes(fk.Column))
-	fkeyIsTableName := localFn != strmangle.Singular(fk.ForeignTable)
+	fkeyIsTableName := localFn != n.singular(fk.ForeignTable)
 	localFn = strmangle.TitleCase(localFn)
 
 	if fkeyIsTableName {
 		foreignFn = localFn
 	}
 
-	plurality := strmangle.Plural
+	plurality := n.plural
 	if fk.Unique {
-		plurality = strmangle.Singular
+		plurality = n.singular
 	}
 	foreignFn += strmangle.TitleCase(plurality(fk.Table))
 
@@ -233,29 +341,29 @@ func txtNameToOne(fk bdb.ForeignKey) (localFn, foreignFn string) {
 //
 // fk == table = industry.Industries
 // fk != table = industry.MappedIndustryIndustry
-func txtNameToMany(toMany bdb.ToManyRelationship) (localFn, foreignFn string) {
+func (n tableNames) txtNameToMany(toMany bdb.ToManyRelationship) (localFn, foreignFn string) {
 	if toMany.ToJoinTable {
 		localFkey := strmangle.Singular(trimSuffixes(toMany.JoinLocalColumn))
 		foreignFkey := strmangle.Singular(trimSuffixes(toMany.JoinForeignColumn))
 
-		if localFkey != strmangle.Singular(toMany.Table) {
+		if localFkey != n.singular(toMany.Table) {
 			foreignFn = strmangle.TitleCase(localFkey)
 		}
-		foreignFn += strmangle.TitleCase(strmangle.Plural(toMany.Table))
+		foreignFn += strmangle.TitleCase(n.plural(toMany.Table))
 
-		if foreignFkey != strmangle.Singular(toMany.ForeignTable) {
+		if foreignFkey != n.singular(toMany.ForeignTable) {
 			localFn = strmangle.TitleCase(foreignFkey)
 		}
-		localFn += strmangle.TitleCase(strmangle.Plural(toMany.ForeignTable))
+		localFn += strmangle.TitleCase(n.plural(toMany.ForeignTable))
 
 		return localFn, foreignFn
 	}
 
 	fkeyName := strmangle.Singular(trimSuffixes(toMany.ForeignColumn))
-	if fkeyName != strmangle.Singular(toMany.Table) {
+	if fkeyName != n.singular(toMany.Table) {
 		localFn = strmangle.TitleCase(fkeyName)
 	}
-	localFn += strmangle.TitleCase(strmangle.Plural(toMany.ForeignTable))
+	localFn += strmangle.TitleCase(n.plural(toMany.ForeignTable))
 	foreignFn = strmangle.TitleCase(strmangle.Singular(fkeyName))
 	return localFn, foreignFn
 }
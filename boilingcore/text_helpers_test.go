@@ -18,7 +18,7 @@ func TestTxtsFromOne(t *testing.T) {
 	}
 
 	jets := bdb.GetTable(tables, "jets")
-	texts := txtsFromFKey(tables, jets, jets.FKeys[0])
+	texts := tableNames(nil).txtsFromFKey(tables, jets, jets.FKeys[0])
 	expect := TxtToOne{}
 
 	expect.ForeignKey = jets.FKeys[0]
@@ -41,7 +41,7 @@ func TestTxtsFromOne(t *testing.T) {
 		t.Errorf("Want:\n%s\nGot:\n%s\n", spew.Sdump(expect), spew.Sdump(texts))
 	}
 
-	texts = txtsFromFKey(tables, jets, jets.FKeys[1])
+	texts = tableNames(nil).txtsFromFKey(tables, jets, jets.FKeys[1])
 	expect = TxtToOne{}
 	expect.ForeignKey = jets.FKeys[1]
 
@@ -77,7 +77,7 @@ func TestTxtsFromOneToOne(t *testing.T) {
 	}
 
 	pilots := bdb.GetTable(tables, "pilots")
-	texts := txtsFromOneToOne(tables, pilots, pilots.ToOneRelationships[0])
+	texts := tableNames(nil).txtsFromOneToOne(tables, pilots, pilots.ToOneRelationships[0])
 	expect := TxtToOne{}
 
 	expect.ForeignKey = bdb.ForeignKey{
@@ -122,7 +122,7 @@ func TestTxtsFromMany(t *testing.T) {
 	}
 
 	pilots := bdb.GetTable(tables, "pilots")
-	texts := txtsFromToMany(tables, pilots, pilots.ToManyRelationships[0])
+	texts := tableNames(nil).txtsFromToMany(tables, pilots, pilots.ToManyRelationships[0])
 	expect := TxtToMany{}
 	expect.LocalTable.NameGo = "Pilot"
 	expect.LocalTable.ColumnNameGo = "ID"
@@ -142,7 +142,7 @@ func TestTxtsFromMany(t *testing.T) {
 		t.Errorf("Want:\n%s\nGot:\n%s\n", spew.Sdump(expect), spew.Sdump(texts))
 	}
 
-	texts = txtsFromToMany(tables, pilots, pilots.ToManyRelationships[1])
+	texts = tableNames(nil).txtsFromToMany(tables, pilots, pilots.ToManyRelationships[1])
 	expect = TxtToMany{}
 	expect.LocalTable.NameGo = "Pilot"
 	expect.LocalTable.ColumnNameGo = "ID"
@@ -200,7 +200,7 @@ func TestTxtNameToOne(t *testing.T) {
 			ForeignTable: test.ForeignTable, ForeignColumn: test.ForeignColumn, ForeignColumnUnique: test.ForeignColumnUnique,
 		}
 
-		local, foreign := txtNameToOne(fk)
+		local, foreign := tableNames(nil).txtNameToOne(fk)
 		if local != test.LocalFn {
 			t.Error(i, "local wrong:", local, "want:", test.LocalFn)
 		}
@@ -251,7 +251,7 @@ func TestTxtNameToMany(t *testing.T) {
 			JoinLocalColumn: test.JoinLocalColumn, JoinForeignColumn: test.JoinForeignColumn,
 		}
 
-		local, foreign := txtNameToMany(fk)
+		local, foreign := tableNames(nil).txtNameToMany(fk)
 		if local != test.LocalFn {
 			t.Error(i, "local wrong:", local, "want:", test.LocalFn)
 		}
@@ -272,3 +272,64 @@ func TestTrimSuffixes(t *testing.T) {
 		}
 	}
 }
+
+func TestTableNames(t *testing.T) {
+	t.Parallel()
+
+	tables := []bdb.Table{
+		{Name: "app_users"},
+		{Name: "app_videos"},
+		{Name: "app_legacy_people"},
+		{Name: "settings"},
+	}
+
+	names, err := newTableNames(tables, "app_", map[string]string{"app_legacy_people": "members"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		In       string
+		Singular string
+		Plural   string
+	}{
+		{"app_users", "user", "users"},
+		{"app_legacy_people", "member", "members"},
+		{"settings", "setting", "settings"},
+		{"user_id", "user_id", "user_ids"},
+	}
+
+	for i, test := range tests {
+		if got := names.singular(test.In); got != test.Singular {
+			t.Errorf("%d) singular wrong: %s want: %s", i, got, test.Singular)
+		}
+		if got := names.plural(test.In); got != test.Plural {
+			t.Errorf("%d) plural wrong: %s want: %s", i, got, test.Plural)
+		}
+	}
+
+	local, foreign := names.txtNameToOne(bdb.ForeignKey{
+		Table: "app_videos", Column: "user_id", ForeignTable: "app_users", ForeignColumn: "id",
+	})
+	if local != "User" || foreign != "Videos" {
+		t.Errorf("to one names wrong: %s %s", local, foreign)
+	}
+
+	local, foreign = names.txtNameToMany(bdb.ToManyRelationship{
+		Table: "app_users", Column: "id", ForeignTable: "app_videos", ForeignColumn: "user_id",
+	})
+	if local != "Videos" || foreign != "User" {
+		t.Errorf("to many names wrong: %s %s", local, foreign)
+	}
+
+	_, err = newTableNames(tables, "", map[string]string{"app_users": "settings"})
+	if err == nil {
+		t.Error("expected an error when two tables generate the same model")
+	}
+
+	for _, alias := range []string{"Plane", "planeModel", "1planes", "_planes", "plane-models"} {
+		if _, err = newTableNames(tables, "", map[string]string{"app_users": alias}); err == nil {
+			t.Errorf("expected an error for the alias %q", alias)
+		}
+	}
+}
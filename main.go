@@ -17,7 +17,7 @@ import (
 const sqlBoilerVersion = "2.6.0"
 
 var (
-	cmdState  *boilingcore.State
+	cmdStates []*boilingcore.State
 	cmdConfig *boilingcore.Config
 )
 
@@ -79,13 +79,22 @@ func main() {
 	rootCmd.PersistentFlags().StringSliceP("blacklist", "b", nil, "Do not include these tables in your generated package")
 	rootCmd.PersistentFlags().StringSliceP("whitelist", "w", nil, "Only include these tables in your generated package")
 	rootCmd.PersistentFlags().StringSliceP("tag", "t", nil, "Struct tags to be included on your models in addition to json, yaml, toml")
+	rootCmd.PersistentFlags().StringP("table-prefix", "", "", "Prefix to strip from table names when generating Go names, eg: app_")
+	rootCmd.PersistentFlags().StringSliceP("table-alias", "", nil, "Go names for specific tables, overrides the table prefix: table_name:go_name")
+	rootCmd.PersistentFlags().StringSliceP("view-pkey", "", nil, "Primary key columns for views, repeat it for composite keys: view_name:column")
+	rootCmd.PersistentFlags().StringSliceP("table-key", "", nil, "Unique columns that identify the rows of tables without a primary key, repeat it for composite keys: table_name:column")
+	rootCmd.PersistentFlags().StringSliceP("schema-packages", "", nil, "Generate each of these schemas into a package named after it in the output folder, instead of --schema")
+	rootCmd.PersistentFlags().StringSliceP("base-columns", "", nil, "Columns shared by many tables, like id,created_at,updated_at, generated into a ModelBase struct embedded by the tables that have them all")
 	rootCmd.PersistentFlags().StringSliceP("replace", "", nil, "Replace templates by directory: relpath/to_file.tpl:relpath/to_replacement.tpl")
 	rootCmd.PersistentFlags().BoolP("debug", "d", false, "Debug mode prints stack traces on error")
 	rootCmd.PersistentFlags().BoolP("no-tests", "", false, "Disable generated go test files")
 	rootCmd.PersistentFlags().BoolP("no-hooks", "", false, "Disable hooks feature for your models")
 	rootCmd.PersistentFlags().BoolP("no-auto-timestamps", "", false, "Disable automatic timestamps for created_at/updated_at")
+	rootCmd.PersistentFlags().BoolP("add-context", "", false, "Generate context variants of the relationship loaders and setters")
+	rootCmd.PersistentFlags().BoolP("explicit-scan", "", false, "Scan the columns of queries selecting whole models explicitly instead of binding them by reflection")
 	rootCmd.PersistentFlags().BoolP("version", "", false, "Print the version")
 	rootCmd.PersistentFlags().BoolP("tinyint-as-bool", "", false, "Map MySQL tinyint(1) in Go to bool instead of int8")
+	rootCmd.PersistentFlags().BoolP("nullable-as-pointers", "", false, "Use pointer types like *string for nullable columns instead of the null package types")
 	rootCmd.PersistentFlags().BoolP("wipe", "", false, "Delete the output folder (rm -rf) before generation to ensure sanity")
 	rootCmd.PersistentFlags().StringP("struct-tag-casing", "", "snake", "Decides the casing for go structure tag names. camel or snake (default snake)")
 
@@ -132,17 +141,21 @@ func preRun(cmd *cobra.Command, args []string) error {
 	driverName := args[0]
 
 	cmdConfig = &boilingcore.Config{
-		DriverName:       driverName,
-		OutFolder:        viper.GetString("output"),
-		Schema:           viper.GetString("schema"),
-		PkgName:          viper.GetString("pkgname"),
-		BaseDir:          viper.GetString("basedir"),
-		Debug:            viper.GetBool("debug"),
-		NoTests:          viper.GetBool("no-tests"),
-		NoHooks:          viper.GetBool("no-hooks"),
-		NoAutoTimestamps: viper.GetBool("no-auto-timestamps"),
-		Wipe:             viper.GetBool("wipe"),
-		StructTagCasing:  strings.ToLower(viper.GetString("struct-tag-casing")), // camel | snake
+		DriverName:         driverName,
+		OutFolder:          viper.GetString("output"),
+		Schema:             viper.GetString("schema"),
+		PkgName:            viper.GetString("pkgname"),
+		BaseDir:            viper.GetString("basedir"),
+		Debug:              viper.GetBool("debug"),
+		NoTests:            viper.GetBool("no-tests"),
+		NoHooks:            viper.GetBool("no-hooks"),
+		NoAutoTimestamps:   viper.GetBool("no-auto-timestamps"),
+		AddContext:         viper.GetBool("add-context"),
+		ExplicitScan:       viper.GetBool("explicit-scan"),
+		Wipe:               viper.GetBool("wipe"),
+		NullableAsPointers: viper.GetBool("nullable-as-pointers"),
+		StructTagCasing:    strings.ToLower(viper.GetString("struct-tag-casing")), // camel | snake
+		TablePrefix:        viper.GetString("table-prefix"),
 	}
 
 	// BUG: https://github.com/spf13/viper/issues/200
@@ -181,6 +194,79 @@ func preRun(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	aliases := viper.GetStringSlice("table-alias")
+	if len(aliases) == 1 && strings.ContainsRune(aliases[0], ',') {
+		aliases, err = cmd.PersistentFlags().GetStringSlice("table-alias")
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(aliases) != 0 {
+		cmdConfig.TableAliases = make(map[string]string, len(aliases))
+		for _, alias := range aliases {
+			splits := strings.Split(alias, ":")
+			if len(splits) != 2 || len(splits[0]) == 0 || len(splits[1]) == 0 {
+				return commandFailure(fmt.Sprintf("table-alias parameters must be in the form table_name:go_name, given: %s", alias))
+			}
+			cmdConfig.TableAliases[splits[0]] = splits[1]
+		}
+	}
+
+	viewPKeys := viper.GetStringSlice("view-pkey")
+	if len(viewPKeys) == 1 && strings.ContainsRune(viewPKeys[0], ',') {
+		viewPKeys, err = cmd.PersistentFlags().GetStringSlice("view-pkey")
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(viewPKeys) != 0 {
+		cmdConfig.ViewPrimaryKeys = make(map[string][]string, len(viewPKeys))
+		for _, pkey := range viewPKeys {
+			splits := strings.Split(pkey, ":")
+			if len(splits) != 2 || len(splits[0]) == 0 || len(splits[1]) == 0 {
+				return commandFailure(fmt.Sprintf("view-pkey parameters must be in the form view_name:column, given: %s", pkey))
+			}
+			cmdConfig.ViewPrimaryKeys[splits[0]] = append(cmdConfig.ViewPrimaryKeys[splits[0]], splits[1])
+		}
+	}
+
+	tableKeys := viper.GetStringSlice("table-key")
+	if len(tableKeys) == 1 && strings.ContainsRune(tableKeys[0], ',') {
+		tableKeys, err = cmd.PersistentFlags().GetStringSlice("table-key")
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(tableKeys) != 0 {
+		cmdConfig.TableKeys = make(map[string][]string, len(tableKeys))
+		for _, key := range tableKeys {
+			splits := strings.Split(key, ":")
+			if len(splits) != 2 || len(splits[0]) == 0 || len(splits[1]) == 0 {
+				return commandFailure(fmt.Sprintf("table-key parameters must be in the form table_name:column, given: %s", key))
+			}
+			cmdConfig.TableKeys[splits[0]] = append(cmdConfig.TableKeys[splits[0]], splits[1])
+		}
+	}
+
+	cmdConfig.SchemaPackages = viper.GetStringSlice("schema-packages")
+	if len(cmdConfig.SchemaPackages) == 1 && strings.ContainsRune(cmdConfig.SchemaPackages[0], ',') {
+		cmdConfig.SchemaPackages, err = cmd.PersistentFlags().GetStringSlice("schema-packages")
+		if err != nil {
+			return err
+		}
+	}
+
+	cmdConfig.BaseColumns = viper.GetStringSlice("base-columns")
+	if len(cmdConfig.BaseColumns) == 1 && strings.ContainsRune(cmdConfig.BaseColumns[0], ',') {
+		cmdConfig.BaseColumns, err = cmd.PersistentFlags().GetStringSlice("base-columns")
+		if err != nil {
+			return err
+		}
+	}
+
 	if driverName == "postgres" {
 		cmdConfig.Postgres = boilingcore.PostgresConfig{
 			User:    viper.GetString("postgres.user"),
@@ -303,14 +389,35 @@ func preRun(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	cmdState, err = boilingcore.New(cmdConfig)
-	return err
+	if len(cmdConfig.SchemaPackages) != 0 {
+		cmdStates, err = boilingcore.NewSchemaPackages(cmdConfig)
+		return err
+	}
+
+	state, err := boilingcore.New(cmdConfig)
+	if err != nil {
+		return err
+	}
+	cmdStates = []*boilingcore.State{state}
+	return nil
 }
 
 func run(cmd *cobra.Command, args []string) error {
-	return cmdState.Run(true)
+	for _, state := range cmdStates {
+		if err := state.Run(true); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func postRun(cmd *cobra.Command, args []string) error {
-	return cmdState.Cleanup()
+	for _, state := range cmdStates {
+		if err := state.Cleanup(); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
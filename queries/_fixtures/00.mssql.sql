@@ -0,0 +1 @@
+SELECT * FROM [t];
\ No newline at end of file
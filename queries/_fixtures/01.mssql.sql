@@ -0,0 +1 @@
+SELECT * FROM [q] ORDER BY (SELECT NULL) OFFSET 6 FETCH NEXT 5 ROWS ONLY;
\ No newline at end of file
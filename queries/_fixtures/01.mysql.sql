@@ -0,0 +1 @@
+SELECT * FROM `q` LIMIT 5 OFFSET 6;
\ No newline at end of file
@@ -0,0 +1 @@
+SELECT * FROM [q] ORDER BY a ASC, b DESC;
\ No newline at end of file
@@ -0,0 +1 @@
+SELECT count(*) as ab, thing as bd, `"stuff"` FROM `a`, `b`;
\ No newline at end of file
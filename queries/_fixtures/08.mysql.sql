@@ -0,0 +1 @@
+SELECT * FROM `a` WHERE (a=? or b=?) AND (c=?) GROUP BY id, name HAVING id <> ? AND length(name, ?) > ?;
\ No newline at end of file
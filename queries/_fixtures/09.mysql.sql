@@ -0,0 +1 @@
+DELETE FROM thing happy, upset as "sad", `fun`, thing as stuff, "angry" as mad WHERE (a=?) AND (b=?) AND (c=?);
\ No newline at end of file
@@ -0,0 +1 @@
+DELETE FROM thing happy, upset as "sad", [fun], thing as stuff, "angry" as mad WHERE ((id=$1 and thing=$2) or stuff=$3);
\ No newline at end of file
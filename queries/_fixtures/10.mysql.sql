@@ -0,0 +1 @@
+DELETE FROM thing happy, upset as "sad", `fun`, thing as stuff, "angry" as mad WHERE ((id=? and thing=?) or stuff=?) LIMIT 5;
\ No newline at end of file
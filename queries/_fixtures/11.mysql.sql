@@ -0,0 +1 @@
+UPDATE thing happy, `"fun"`, `stuff` SET `"col2"` = ?, `"fun"`.`col3` = ?, `col1` = ? WHERE (aa=? or bb=? or cc=?) AND (dd=? or ee=? or ff=? and gg=?) LIMIT 5;
\ No newline at end of file
@@ -0,0 +1 @@
+SELECT `c`.* FROM cats c INNER JOIN dogs d on d.cat_id = cats.id;
\ No newline at end of file
@@ -0,0 +1 @@
+SELECT * FROM `pilots` WHERE (id=?) ORDER BY name LIMIT 5 LOCK IN SHARE MODE;
\ No newline at end of file
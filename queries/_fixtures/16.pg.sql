@@ -0,0 +1 @@
+SELECT * FROM "pilots" WHERE (id=$1) ORDER BY name LIMIT 5 FOR SHARE;
\ No newline at end of file
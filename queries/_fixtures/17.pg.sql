@@ -0,0 +1 @@
+SELECT * FROM "pilots" ORDER BY name LIMIT 5 OFFSET 2 FOR SHARE NOWAIT;
\ No newline at end of file
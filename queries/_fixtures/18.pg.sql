@@ -0,0 +1 @@
+SELECT * FROM "pilots" LIMIT 1 FOR UPDATE NOWAIT;
\ No newline at end of file
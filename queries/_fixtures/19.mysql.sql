@@ -0,0 +1 @@
+SELECT * FROM `t` WHERE (a=?) OR (b=?) AND (c=?);
\ No newline at end of file
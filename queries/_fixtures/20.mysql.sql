@@ -0,0 +1 @@
+SELECT * FROM `t` WHERE (a=? or b=?) OR (c=?) OR `d` IN (?,?);
\ No newline at end of file
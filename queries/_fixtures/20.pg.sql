@@ -0,0 +1 @@
+SELECT * FROM "t" WHERE (a=$1 or b=$2) OR (c=$3) OR "d" IN ($4,$5);
\ No newline at end of file
@@ -0,0 +1 @@
+SELECT  TOP (10) * FROM [pilots] ORDER BY name, NEWID();
\ No newline at end of file
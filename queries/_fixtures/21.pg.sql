@@ -0,0 +1 @@
+SELECT * FROM "pilots" ORDER BY name, RANDOM() LIMIT 10;
\ No newline at end of file
@@ -0,0 +1 @@
+SELECT `j`.* FROM jets j INNER JOIN pilots p ON (j.pilot_id = p.id) AND (j.tenant = p.tenant) AND (p.active = ?) WHERE (j.name = ?);
\ No newline at end of file
@@ -0,0 +1 @@
+SELECT [j].* FROM jets j INNER JOIN pilots p on j.pilot_id = p.id and p.name = $1 AND (p.active = $2) WHERE (j.name = $3);
\ No newline at end of file
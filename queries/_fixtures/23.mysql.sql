@@ -0,0 +1 @@
+SELECT `j`.* FROM jets j INNER JOIN pilots p on j.pilot_id = p.id and p.name = ? AND (p.active = ?) WHERE (j.name = ?);
\ No newline at end of file
@@ -0,0 +1 @@
+WITH RECURSIVE tree(id, parent_id, depth) AS (SELECT id, parent_id, 0 FROM nodes WHERE id = $1 UNION ALL SELECT n.id, n.parent_id, t.depth + 1 FROM nodes n INNER JOIN tree t ON n.parent_id = t.id WHERE t.depth < $2), up(id, parent_id) AS (SELECT id, parent_id FROM nodes WHERE id = $3 UNION ALL SELECT n.id, n.parent_id FROM nodes n INNER JOIN up u ON n.id = u.parent_id) SELECT * FROM "tree" WHERE (depth > $4);
\ No newline at end of file
@@ -0,0 +1 @@
+WITH RECURSIVE tree AS (SELECT id FROM nodes WHERE id = ? UNION ALL SELECT n.id FROM nodes n INNER JOIN tree t ON n.parent_id = t.id) SELECT * FROM `tree` WHERE (id <> ?);
\ No newline at end of file
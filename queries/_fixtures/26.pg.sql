@@ -0,0 +1 @@
+SELECT "p".* FROM pilots p TABLESAMPLE BERNOULLI (10) INNER JOIN jets j on j.pilot_id = p.id WHERE (p.age > $1);
\ No newline at end of file
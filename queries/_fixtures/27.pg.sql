@@ -0,0 +1 @@
+SELECT COUNT(*) FROM "pilots" TABLESAMPLE SYSTEM (2.5);
\ No newline at end of file
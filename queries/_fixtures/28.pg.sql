@@ -0,0 +1 @@
+SELECT DISTINCT ON ("pilot_id", "f"."jet_id") * FROM "flights" ORDER BY f.jet_id, "pilot_id" ASC, departed_at desc;
\ No newline at end of file
@@ -0,0 +1 @@
+SELECT DISTINCT ON ("pilot_id") "pilot_id", "departed_at" FROM "flights" ORDER BY "pilot_id";
\ No newline at end of file
@@ -0,0 +1 @@
+SELECT "id", data->'meta'->>'rank' as rank FROM "pilots" WHERE ((data->>'age')::int > $1) AND (data ? 'callsign' and data#>>'{meta,base}' = $2) ORDER BY data->'meta'->>'rank' DESC, (data->>'age')::int;
\ No newline at end of file
@@ -0,0 +1 @@
+SELECT * FROM `pilots` WHERE (CAST(data->>'$.age' AS UNSIGNED) > ?) ORDER BY data->>'$.meta.rank';
\ No newline at end of file
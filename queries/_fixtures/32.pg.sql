@@ -0,0 +1 @@
+SELECT "airport_id", "pilot_id", count(*) FROM "flights" WHERE (departed_at > $1) GROUP BY year, CUBE(airport_id, pilot_id) HAVING count(*) > $2;
\ No newline at end of file
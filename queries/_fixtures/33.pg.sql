@@ -0,0 +1 @@
+SELECT * FROM "flights" GROUP BY GROUPING SETS ((airport_id), (pilot_id, jet_id), ()) HAVING count(*) > $1;
\ No newline at end of file
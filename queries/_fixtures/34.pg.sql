@@ -0,0 +1 @@
+SELECT * FROM "flights" GROUP BY ROLLUP(airport_id, pilot_id);
\ No newline at end of file
@@ -0,0 +1 @@
+SELECT * FROM `flights` GROUP BY airport_id, pilot_id WITH ROLLUP HAVING count(*) > ?;
\ No newline at end of file
@@ -0,0 +1 @@
+SELECT "f".*, "pilots".* FROM ONLY flights f, "pilots" INNER JOIN jets j on j.id = f.jet_id WHERE (f.pilot_id = pilots.id);
\ No newline at end of file
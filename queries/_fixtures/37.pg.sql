@@ -0,0 +1 @@
+DELETE FROM ONLY "flights" WHERE (departed_at < $1);
\ No newline at end of file
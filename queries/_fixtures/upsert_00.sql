@@ -0,0 +1 @@
+INSERT INTO "pilots" ("id", "name", "age") VALUES ($1,$2,$3) ON CONFLICT ("id") DO UPDATE SET "name" = EXCLUDED."name","age" = EXCLUDED."age" RETURNING "created_at"
\ No newline at end of file
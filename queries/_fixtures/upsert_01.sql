@@ -0,0 +1 @@
+INSERT INTO "pilots" ("id", "name") VALUES ($1,$2) ON CONFLICT DO NOTHING
\ No newline at end of file
@@ -0,0 +1 @@
+INSERT INTO "pilots" ("code", "name") VALUES ($1,$2) ON CONFLICT ON CONSTRAINT "pilots_code_key" DO UPDATE SET "name" = EXCLUDED."name" RETURNING "id", (xmax = 0) AS inserted
\ No newline at end of file
@@ -0,0 +1 @@
+INSERT INTO "pilots" ("id", "name", "updated_at") VALUES ($1,$2,$3) ON CONFLICT ("id") DO UPDATE SET "name" = EXCLUDED."name","updated_at" = EXCLUDED."updated_at" WHERE "pilots"."name" IS DISTINCT FROM EXCLUDED."name"
\ No newline at end of file
@@ -0,0 +1 @@
+INSERT INTO "pilots" ("id", "name", "age") VALUES ($1,$2,$3),($4,$5,DEFAULT) ON CONFLICT ("id") DO UPDATE SET "name" = EXCLUDED."name"
\ No newline at end of file
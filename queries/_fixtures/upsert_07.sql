@@ -0,0 +1 @@
+INSERT INTO pilots (`id`, `name`, `age`) VALUES (?,?,?) ON DUPLICATE KEY UPDATE `name` = VALUES(`name`),`age` = VALUES(`age`)
\ No newline at end of file
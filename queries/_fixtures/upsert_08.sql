@@ -0,0 +1 @@
+INSERT IGNORE INTO pilots (`id`, `name`) VALUES (?,?)
\ No newline at end of file
@@ -0,0 +1 @@
+INSERT INTO pilots (`id`, `name`, `age`) VALUES (?,?,DEFAULT),(?,?,?) ON DUPLICATE KEY UPDATE `name` = VALUES(`name`)
\ No newline at end of file
@@ -0,0 +1,6 @@
+MERGE INTO pilots as [t]
+USING (SELECT $1) as [s] ([id])
+ON ([s].[id] = [t].[id])
+WHEN MATCHED THEN UPDATE SET [name]=$2
+WHEN NOT MATCHED THEN INSERT ([name]) VALUES ($3)
+OUTPUT $action;
\ No newline at end of file
@@ -0,0 +1,188 @@
+package queries
+
+import (
+	"database/sql"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// coerceScan scans the current row of rows again after a failed scan,
+// converting the values of numeric fields that the database/sql package
+// doesn't: numeric text with a fraction of zeros like "12.00", integral
+// floats and integers of another width or sign. Conversions that would lose
+// anything fail with the column in the error.
+func coerceScan(rows *sql.Rows, cols []string, pointers []interface{}) error {
+	scanners := make([]interface{}, len(pointers))
+	for i, ptr := range pointers {
+		scanners[i] = ptr
+
+		if _, ok := ptr.(sql.Scanner); ok {
+			continue
+		}
+		dest := reflect.ValueOf(ptr)
+		if dest.Kind() != reflect.Ptr || !isNumericKind(dest.Type().Elem().Kind()) {
+			continue
+		}
+		scanners[i] = numericScanner{column: cols[i], dest: dest.Elem()}
+	}
+
+	return rows.Scan(scanners...)
+}
+
+// numericScanner scans a column into a numeric field.
+type numericScanner struct {
+	column string
+	dest   reflect.Value
+}
+
+// Scan converts src to the type of the field and sets it.
+func (n numericScanner) Scan(src interface{}) error {
+	dest := n.dest
+	if src == nil {
+		return errors.Errorf("cannot bind NULL of column %s to %s", n.column, dest.Type())
+	}
+
+	if err := setNumeric(dest, src); err != nil {
+		return errors.Wrapf(err, "cannot bind %v of column %s to %s", printable(src), n.column, dest.Type())
+	}
+
+	return nil
+}
+
+// setNumeric sets dest, a numeric value, to src when that loses nothing.
+func setNumeric(dest reflect.Value, src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		return setNumericString(dest, string(s))
+	case string:
+		return setNumericString(dest, s)
+	case int64:
+		return setNumericInt(dest, s)
+	case float64:
+		return setNumericFloat(dest, s)
+	case bool:
+		if s {
+			return setNumericInt(dest, 1)
+		}
+		return setNumericInt(dest, 0)
+	}
+
+	return errors.Errorf("unsupported type %T", src)
+}
+
+func setNumericInt(dest reflect.Value, i int64) error {
+	switch {
+	case isIntKind(dest.Kind()):
+		if dest.OverflowInt(i) {
+			return errors.New("value out of range")
+		}
+		dest.SetInt(i)
+	case isUintKind(dest.Kind()):
+		if i < 0 || dest.OverflowUint(uint64(i)) {
+			return errors.New("value out of range")
+		}
+		dest.SetUint(uint64(i))
+	default:
+		f := float64(i)
+		if int64(f) != i || dest.OverflowFloat(f) {
+			return errors.New("value loses precision")
+		}
+		dest.SetFloat(f)
+	}
+
+	return nil
+}
+
+func setNumericFloat(dest reflect.Value, f float64) error {
+	if isFloatKind(dest.Kind()) {
+		if dest.OverflowFloat(f) {
+			return errors.New("value out of range")
+		}
+		dest.SetFloat(f)
+		return nil
+	}
+
+	if f != math.Trunc(f) {
+		return errors.New("value has a fraction")
+	}
+	if f < math.MinInt64 || f >= math.MaxInt64 {
+		return errors.New("value out of range")
+	}
+
+	return setNumericInt(dest, int64(f))
+}
+
+func setNumericString(dest reflect.Value, s string) error {
+	s = strings.TrimSpace(s)
+
+	if isFloatKind(dest.Kind()) {
+		f, err := strconv.ParseFloat(s, dest.Type().Bits())
+		if err != nil {
+			return err
+		}
+		dest.SetFloat(f)
+		return nil
+	}
+
+	// Numeric columns come back as text like "12.00", which is only an
+	// integer when its fraction is all zeros
+	if dot := strings.IndexByte(s, '.'); dot >= 0 {
+		if strings.Trim(s[dot+1:], "0") != "" {
+			return errors.New("value has a fraction")
+		}
+		s = s[:dot]
+	}
+
+	if isUintKind(dest.Kind()) {
+		u, err := strconv.ParseUint(s, 10, dest.Type().Bits())
+		if err != nil {
+			return err
+		}
+		dest.SetUint(u)
+		return nil
+	}
+
+	i, err := strconv.ParseInt(s, 10, dest.Type().Bits())
+	if err != nil {
+		return err
+	}
+	dest.SetInt(i)
+
+	return nil
+}
+
+// printable returns src with []byte as a string, for error messages.
+func printable(src interface{}) interface{} {
+	if b, ok := src.([]byte); ok {
+		return string(b)
+	}
+	return src
+}
+
+func isNumericKind(kind reflect.Kind) bool {
+	return isIntKind(kind) || isUintKind(kind) || isFloatKind(kind)
+}
+
+func isIntKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+	return false
+}
+
+func isUintKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+func isFloatKind(kind reflect.Kind) bool {
+	return kind == reflect.Float32 || kind == reflect.Float64
+}
@@ -0,0 +1,98 @@
+package queries
+
+import (
+	"database/sql/driver"
+	"strings"
+	"testing"
+
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+type coerced struct {
+	ID     int
+	Amount int
+	Small  int8
+	Count  uint
+	Ratio  float32
+}
+
+// bindCoerced binds a row of values for the columns of coerced.
+func bindCoerced(t *testing.T, values ...driver.Value) (coerced, error) {
+	query := &Query{
+		from:    []string{"fun"},
+		dialect: &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true},
+	}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ret := sqlmock.NewRows([]string{"id", "amount", "small", "count", "ratio"})
+	ret.AddRow(values...)
+	mock.ExpectQuery(`SELECT \* FROM "fun";`).WillReturnRows(ret)
+
+	SetExecutor(query, db)
+	var obj coerced
+	err = query.Bind(&obj)
+	return obj, err
+}
+
+func TestBindCoerce(t *testing.T) {
+	t.Parallel()
+
+	obj, err := bindCoerced(t, []byte("123"), []byte("45.00"), int64(-8), int64(7), []byte("0.5"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if obj.ID != 123 {
+		t.Error("wrong id:", obj.ID)
+	}
+	if obj.Amount != 45 {
+		t.Error("wrong amount:", obj.Amount)
+	}
+	if obj.Small != -8 {
+		t.Error("wrong small:", obj.Small)
+	}
+	if obj.Count != 7 {
+		t.Error("wrong count:", obj.Count)
+	}
+	if obj.Ratio != 0.5 {
+		t.Error("wrong ratio:", obj.Ratio)
+	}
+
+	obj, err = bindCoerced(t, float64(9), "10", int64(1), int64(2), int64(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if obj.ID != 9 || obj.Amount != 10 || obj.Ratio != 3 {
+		t.Errorf("wrong values: %#v", obj)
+	}
+}
+
+func TestBindCoerceLossy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		values []driver.Value
+		column string
+	}{
+		{[]driver.Value{int64(1), int64(2), int64(300), int64(0), float64(0)}, "small"},
+		{[]driver.Value{int64(1), []byte("45.50"), int64(3), int64(0), float64(0)}, "amount"},
+		{[]driver.Value{int64(1), int64(2), int64(3), int64(-1), float64(0)}, "count"},
+		{[]driver.Value{float64(1.5), int64(2), int64(3), int64(0), float64(0)}, "id"},
+		{[]driver.Value{int64(1), nil, int64(3), int64(0), float64(0)}, "amount"},
+	}
+
+	for i, test := range tests {
+		_, err := bindCoerced(t, test.values...)
+		if err == nil {
+			t.Errorf("%d) want an error binding %v", i, test.values)
+			continue
+		}
+		if !strings.Contains(err.Error(), "column "+test.column) {
+			t.Errorf("%d) want the error to name column %s, got: %v", i, test.column, err)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package queries
 
 import (
 	"database/sql"
+	"database/sql/driver"
 	"reflect"
 	"strings"
 
@@ -49,7 +50,7 @@ func (l loadRelationshipState) buildKey(depth int) string {
 // bkind should reflect what kind of thing it is above
 func eagerLoad(exec boil.Executor, toLoad []string, obj interface{}, bkind bindKind) error {
 	state := loadRelationshipState{
-		exec:   exec,
+		exec:   eagerLoadExecutor{Executor: exec, cache: &EagerLoadCache{}},
 		loaded: map[string]struct{}{},
 	}
 	for _, toLoad := range toLoad {
@@ -291,3 +292,105 @@ func findRelationshipStruct(obj reflect.Value) (reflect.Value, error) {
 
 	return relationshipStruct, nil
 }
+
+// eagerLoadExecutor is the executor passed to the eager load functions, it
+// carries the cache shared by all the relationships of one eager load.
+type eagerLoadExecutor struct {
+	boil.Executor
+	cache *EagerLoadCache
+}
+
+// EagerLoadCache remembers the rows loaded by the relationships of an eager
+// load by the foreign column they were loaded by, so that relationships to
+// the same rows, like a jet's pilot and copilot, don't query them twice.
+// Only columns whose value identifies a single row can be cached.
+//
+// A nil *EagerLoadCache caches nothing.
+type EagerLoadCache struct {
+	rows map[string]map[interface{}]interface{}
+}
+
+// FromEagerLoad returns the executor and cache of the eager load that exec
+// was passed in by, or exec and a nil cache when called outside of one.
+func FromEagerLoad(exec boil.Executor) (boil.Executor, *EagerLoadCache) {
+	if e, ok := exec.(eagerLoadExecutor); ok {
+		return e.Executor, e.cache
+	}
+
+	return exec, nil
+}
+
+// Split keys of column, a "table.column" name, into the rows already loaded
+// for them and the keys left to query. Keys are deduplicated and NULL keys
+// are dropped since they can't match any row.
+func (c *EagerLoadCache) Split(column string, keys []interface{}) (loaded []interface{}, missing []interface{}) {
+	seen := make(map[interface{}]struct{}, len(keys))
+	for _, key := range keys {
+		k, ok := cacheKey(key)
+		if !ok {
+			missing = append(missing, key)
+			continue
+		}
+		if k == nil {
+			continue
+		}
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+
+		if row, ok := c.lookup(column, k); ok {
+			loaded = append(loaded, row)
+		} else {
+			missing = append(missing, key)
+		}
+	}
+
+	return loaded, missing
+}
+
+// Put the row loaded for key of column, a "table.column" name.
+func (c *EagerLoadCache) Put(column string, key interface{}, row interface{}) {
+	if c == nil {
+		return
+	}
+	k, ok := cacheKey(key)
+	if !ok || k == nil {
+		return
+	}
+
+	if c.rows == nil {
+		c.rows = make(map[string]map[interface{}]interface{})
+	}
+	rows, ok := c.rows[column]
+	if !ok {
+		rows = make(map[interface{}]interface{})
+		c.rows[column] = rows
+	}
+	rows[k] = row
+}
+
+func (c *EagerLoadCache) lookup(column string, key interface{}) (interface{}, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	row, ok := c.rows[column][key]
+	return row, ok
+}
+
+// cacheKey converts key to its driver value so that the local and foreign
+// columns of a relationship, like a null.Int and an int, have the same key.
+// It returns false for keys that can't be converted.
+func cacheKey(key interface{}) (interface{}, bool) {
+	value, err := driver.DefaultParameterConverter.ConvertValue(key)
+	if err != nil {
+		return nil, false
+	}
+
+	if b, ok := value.([]byte); ok {
+		return string(b), true
+	}
+
+	return value, true
+}
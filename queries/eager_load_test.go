@@ -1,10 +1,15 @@
 package queries
 
 import (
+	"context"
 	"fmt"
+	"reflect"
 	"testing"
 
+	"github.com/pkg/errors"
 	"github.com/volatiletech/sqlboiler/boil"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+	"gopkg.in/volatiletech/null.v6"
 )
 
 var testEagerCounters struct {
@@ -25,6 +30,8 @@ type testEagerR struct {
 	ChildMany []*testEagerChild
 	ZeroOne   *testEagerZero
 	ZeroMany  []*testEagerZero
+	Pilot     *testEagerChild
+	Copilot   *testEagerChild
 }
 type testEagerL struct {
 }
@@ -191,6 +198,137 @@ func (testEagerZeroL) LoadNestedMany(_ boil.Executor, singular bool, obj interfa
 	return nil
 }
 
+// testEagerQueried has the keys queried by LoadPilot and LoadCopilot
+var testEagerQueried [][]interface{}
+
+// LoadPilot loads the child with the object's id
+func (testEagerL) LoadPilot(e boil.Executor, singular bool, obj interface{}) error {
+	return testEagerLoadCached(e, obj, 0, func(o *testEager, c *testEagerChild) { o.R.Pilot = c })
+}
+
+// LoadCopilot loads the child with the object's id plus one
+func (testEagerL) LoadCopilot(e boil.Executor, singular bool, obj interface{}) error {
+	return testEagerLoadCached(e, obj, 1, func(o *testEager, c *testEagerChild) { o.R.Copilot = c })
+}
+
+func testEagerLoadCached(e boil.Executor, obj interface{}, offset int, set func(*testEager, *testEagerChild)) error {
+	exec, cache := FromEagerLoad(e)
+	if exec != nil || cache == nil {
+		return fmt.Errorf("want the nil executor and a cache, got %#v %#v", exec, cache)
+	}
+
+	objs := *obj.(*[]*testEager)
+	keys := make([]interface{}, len(objs))
+	for i, o := range objs {
+		keys[i] = o.ID + offset
+	}
+
+	var children []*testEagerChild
+	loaded, missing := cache.Split("children.id", keys)
+	for _, l := range loaded {
+		children = append(children, l.(*testEagerChild))
+	}
+	testEagerQueried = append(testEagerQueried, missing)
+	for _, key := range missing {
+		child := &testEagerChild{ID: key.(int)}
+		cache.Put("children.id", child.ID, child)
+		children = append(children, child)
+	}
+
+	for _, o := range objs {
+		if o.R == nil {
+			o.R = &testEagerR{}
+		}
+		for _, c := range children {
+			if c.ID == o.ID+offset {
+				set(o, c)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (testEagerChildL) LoadQueried(e boil.Executor, singular bool, obj interface{}) error {
+	exec, _ := FromEagerLoad(e)
+	rows, err := exec.Query("select * from queried")
+	if err != nil {
+		return err
+	}
+
+	return rows.Close()
+}
+
+func TestEagerLoadContext(t *testing.T) {
+	t.Parallel()
+
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	obj := &testEager{}
+	err = eagerLoad(boil.WithContext(ctx, db), []string{"ChildOne.Queried"}, obj, kindStruct)
+	if errors.Cause(err) != context.Canceled {
+		t.Errorf("want the nested load to be canceled, got: %v", err)
+	}
+}
+
+func TestEagerLoadCached(t *testing.T) {
+	testEagerQueried = nil
+
+	objs := []*testEager{{ID: 1}, {ID: 2}, {ID: 2}}
+	if err := eagerLoad(nil, []string{"Pilot", "Copilot"}, &objs, kindPtrSliceStruct); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := [][]interface{}{{1, 2}, {3}}; !reflect.DeepEqual(testEagerQueried, want) {
+		t.Errorf("want queried keys %v, got %v", want, testEagerQueried)
+	}
+
+	for _, o := range objs {
+		if o.R.Pilot.ID != o.ID || o.R.Copilot.ID != o.ID+1 {
+			t.Errorf("object %d got pilot %d and copilot %d", o.ID, o.R.Pilot.ID, o.R.Copilot.ID)
+		}
+	}
+	if objs[0].R.Copilot != objs[1].R.Pilot {
+		t.Error("want the row loaded by both relationships to be shared")
+	}
+}
+
+func TestEagerLoadCache(t *testing.T) {
+	t.Parallel()
+
+	if exec, cache := FromEagerLoad(nil); exec != nil || cache != nil {
+		t.Error("want no cache outside of an eager load")
+	}
+
+	var cache *EagerLoadCache
+	loaded, missing := cache.Split("pilots.id", []interface{}{1, 1, nil, null.Int{}, 2})
+	if len(loaded) != 0 || !reflect.DeepEqual(missing, []interface{}{1, 2}) {
+		t.Errorf("want deduplicated keys without a cache, got %v %v", loaded, missing)
+	}
+	cache.Put("pilots.id", 1, "ignored")
+
+	cache = &EagerLoadCache{}
+	cache.Put("pilots.id", 1, "one")
+	cache.Put("pilots.id", []byte("b"), "bytes")
+	cache.Put("pilots.id", null.Int{}, "null")
+
+	loaded, missing = cache.Split("pilots.id", []interface{}{null.IntFrom(1), int64(1), []byte("b"), null.Int{}, 3})
+	if !reflect.DeepEqual(loaded, []interface{}{"one", "bytes"}) || !reflect.DeepEqual(missing, []interface{}{3}) {
+		t.Errorf("want normalized keys to be found, got %v %v", loaded, missing)
+	}
+
+	loaded, missing = cache.Split("pilots.code", []interface{}{1})
+	if len(loaded) != 0 || !reflect.DeepEqual(missing, []interface{}{1}) {
+		t.Errorf("want columns cached apart, got %v %v", loaded, missing)
+	}
+}
+
 func TestEagerLoadFromOne(t *testing.T) {
 	testEagerCounters.ChildOne = 0
 	testEagerCounters.ChildMany = 0
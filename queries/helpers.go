@@ -1,8 +1,12 @@
 package queries
 
 import (
+	"database/sql/driver"
 	"fmt"
 	"reflect"
+	"sort"
+	"strconv"
+	"time"
 
 	"github.com/volatiletech/sqlboiler/strmangle"
 )
@@ -29,3 +33,185 @@ func NonZeroDefaultSet(defaults []string, obj interface{}) []string {
 
 	return c
 }
+
+// WhereClauseRows returns a condition matching any of rows, which hold the
+// values of columns, and the args to bind for it. Tables without a primary
+// key match their rows on all of their columns this way. NULL values are
+// matched with IS NULL since they're never equal to anything. Placeholders
+// are numbered from start, or are question marks when start is 0.
+func WhereClauseRows(lq, rq byte, start int, columns []string, rows ...[]interface{}) (string, []interface{}) {
+	buf := strmangle.GetBuffer()
+	defer strmangle.PutBuffer(buf)
+
+	var args []interface{}
+	for i, row := range rows {
+		if i != 0 {
+			buf.WriteString(" OR ")
+		}
+
+		buf.WriteByte('(')
+		for j, column := range columns {
+			if j != 0 {
+				buf.WriteString(" AND ")
+			}
+			buf.WriteString(strmangle.IdentQuote(lq, rq, column))
+
+			if isNullValue(row[j]) {
+				buf.WriteString(" IS NULL")
+				continue
+			}
+
+			if start == 0 {
+				buf.WriteString(" = ?")
+			} else {
+				fmt.Fprintf(buf, " = $%d", start+len(args))
+			}
+			args = append(args, row[j])
+		}
+		buf.WriteByte(')')
+	}
+
+	return buf.String(), args
+}
+
+// LiteralDefaultSet returns the columns in defaults, a map of column name to
+// literal default value, whose field in obj holds that same value. The
+// columns are returned in sorted order.
+func LiteralDefaultSet(defaults map[string]string, obj interface{}) []string {
+	c := make([]string, 0, len(defaults))
+
+	val := reflect.Indirect(reflect.ValueOf(obj))
+
+	for d, literal := range defaults {
+		fieldName := strmangle.TitleCase(d)
+		field := val.FieldByName(fieldName)
+		if !field.IsValid() {
+			panic(fmt.Sprintf("Could not find field name %s in type %T", fieldName, obj))
+		}
+
+		if equalsLiteral(field.Interface(), literal) {
+			c = append(c, d)
+		}
+	}
+
+	sort.Strings(c)
+	return c
+}
+
+// equalsLiteral reports whether v, or the value a driver.Valuer gives for
+// it, is the number, bool or string written as literal.
+func equalsLiteral(v interface{}, literal string) bool {
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return false
+		}
+		v = rv.Elem().Interface()
+	}
+
+	if valuer, ok := v.(driver.Valuer); ok {
+		var err error
+		if v, err = valuer.Value(); err != nil || v == nil {
+			return false
+		}
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Bool:
+		b, err := strconv.ParseBool(literal)
+		return err == nil && b == rv.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(literal, 10, 64)
+		return err == nil && i == rv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(literal, 10, 64)
+		return err == nil && u == rv.Uint()
+	case reflect.Float32, reflect.Float64:
+		// A float32 may arrive as a float64 from a driver.Valuer, so also
+		// compare against the literal rounded to float32
+		f64, err := strconv.ParseFloat(literal, 64)
+		if err != nil {
+			return false
+		}
+		f32, _ := strconv.ParseFloat(literal, 32)
+		return f64 == rv.Float() || f32 == rv.Float()
+	case reflect.String:
+		return rv.String() == literal
+	}
+
+	return false
+}
+
+// ColumnValues returns the values of the fields of obj, a pointer to a
+// struct, found with mapping, see BindMapping, in the order of mapping. They
+// are the values written to the database: a driver.Valuer like a null type
+// gives its value, which is nil when it's invalid, and a nil pointer is nil.
+func ColumnValues(obj interface{}, mapping []uint64) []interface{} {
+	values := ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), mapping)
+	for i, v := range values {
+		v = driverValue(v)
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+			v = nil
+		}
+		values[i] = v
+	}
+
+	return values
+}
+
+// ColumnChange holds the values of a column before and after a change.
+type ColumnChange struct {
+	Old interface{}
+	New interface{}
+}
+
+// Diff returns the changes from before to after, pointers to structs of the
+// same type, keyed by the columns whose fields hold different values. The
+// fields of columns are found with mapping, see BindMapping. Fields are
+// compared by the value written to the database, so null types that are both
+// invalid are unchanged whatever else they hold, and times are compared as
+// instants.
+func Diff(columns []string, mapping []uint64, before, after interface{}) map[string]ColumnChange {
+	oldValues := ValuesFromMapping(reflect.Indirect(reflect.ValueOf(before)), mapping)
+	newValues := ValuesFromMapping(reflect.Indirect(reflect.ValueOf(after)), mapping)
+
+	changes := make(map[string]ColumnChange)
+	for i, c := range columns {
+		if !equalValues(oldValues[i], newValues[i]) {
+			changes[c] = ColumnChange{Old: oldValues[i], New: newValues[i]}
+		}
+	}
+
+	return changes
+}
+
+// equalValues reports whether a and b are written to the database as the
+// same value.
+func equalValues(a, b interface{}) bool {
+	a, b = driverValue(a), driverValue(b)
+
+	if at, ok := a.(time.Time); ok {
+		bt, ok := b.(time.Time)
+		return ok && at.Equal(bt)
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// driverValue returns the value the driver.Valuer v gives, or v itself if
+// it is not one or fails to give a value.
+func driverValue(v interface{}) interface{} {
+	valuer, ok := v.(driver.Valuer)
+	if !ok {
+		return v
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return nil
+	}
+
+	value, err := valuer.Value()
+	if err != nil {
+		return v
+	}
+	return value
+}
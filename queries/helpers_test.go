@@ -65,3 +65,221 @@ func TestNonZeroDefaultSet(t *testing.T) {
 		}
 	}
 }
+
+func TestWhereClauseRows(t *testing.T) {
+	t.Parallel()
+
+	columns := []string{"jet_id", "message", "logged_at"}
+	loggedAt := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		LQ, RQ byte
+		Start  int
+		Rows   [][]interface{}
+		Clause string
+		Args   []interface{}
+	}{
+		{
+			'"', '"', 1,
+			[][]interface{}{{5, "takeoff", loggedAt}},
+			`("jet_id" = $1 AND "message" = $2 AND "logged_at" = $3)`,
+			[]interface{}{5, "takeoff", loggedAt},
+		},
+		{
+			'"', '"', 3,
+			[][]interface{}{{5, null.String{}, loggedAt}},
+			`("jet_id" = $3 AND "message" IS NULL AND "logged_at" = $4)`,
+			[]interface{}{5, loggedAt},
+		},
+		{
+			'"', '"', 1,
+			[][]interface{}{{5, "takeoff", nil}, {6, null.StringFrom("landing"), loggedAt}},
+			`("jet_id" = $1 AND "message" = $2 AND "logged_at" IS NULL) OR ("jet_id" = $3 AND "message" = $4 AND "logged_at" = $5)`,
+			[]interface{}{5, "takeoff", 6, null.StringFrom("landing"), loggedAt},
+		},
+		{
+			'`', '`', 0,
+			[][]interface{}{{5, nil, loggedAt}, {6, "landing", loggedAt}},
+			"(`jet_id` = ? AND `message` IS NULL AND `logged_at` = ?) OR (`jet_id` = ? AND `message` = ? AND `logged_at` = ?)",
+			[]interface{}{5, loggedAt, 6, "landing", loggedAt},
+		},
+	}
+
+	for i, test := range tests {
+		clause, args := WhereClauseRows(test.LQ, test.RQ, test.Start, columns, test.Rows...)
+		if clause != test.Clause {
+			t.Errorf("[%d] mismatch:\nWant: %s\nGot:  %s", i, test.Clause, clause)
+		}
+		if !reflect.DeepEqual(args, test.Args) {
+			t.Errorf("[%d] args mismatch:\nWant: %#v\nGot:  %#v", i, test.Args, args)
+		}
+	}
+}
+
+func TestLiteralDefaultSet(t *testing.T) {
+	t.Parallel()
+
+	type Anything struct {
+		ID      int
+		Name    string
+		Active  bool
+		Ratio   float32
+		Age     null.Int
+		Nick    null.String
+		Deleted null.Bool
+		Level   *int
+		Tag     *string
+	}
+
+	level, tag, other := 3, "x", "y"
+
+	defaults := map[string]string{
+		"name":    "hi",
+		"active":  "1",
+		"ratio":   "0.1",
+		"age":     "5",
+		"nick":    "",
+		"deleted": "false",
+		"level":   "3",
+		"tag":     "x",
+	}
+
+	tests := []struct {
+		Obj interface{}
+		Ret []string
+	}{
+		{
+			Anything{},
+			[]string{},
+		},
+		{
+			Anything{Name: "hi", Active: true, Ratio: 0.1, Age: null.IntFrom(5), Nick: null.StringFrom(""), Deleted: null.BoolFrom(false), Level: &level, Tag: &tag},
+			[]string{"active", "age", "deleted", "level", "name", "nick", "ratio", "tag"},
+		},
+		{
+			&Anything{ID: 1, Name: "ho", Active: true, Ratio: 0.2, Age: null.IntFrom(6), Deleted: null.BoolFrom(true), Tag: &other},
+			[]string{"active"},
+		},
+	}
+
+	for i, test := range tests {
+		z := LiteralDefaultSet(defaults, test.Obj)
+		if !reflect.DeepEqual(test.Ret, z) {
+			t.Errorf("[%d] mismatch:\nWant: %#v\nGot:  %#v", i, test.Ret, z)
+		}
+	}
+}
+
+func TestColumnValues(t *testing.T) {
+	t.Parallel()
+
+	type Anything struct {
+		ID    int         `boil:"id"`
+		Name  string      `boil:"name"`
+		Nick  null.String `boil:"nick"`
+		Age   null.Int    `boil:"age"`
+		Title *string     `boil:"title"`
+	}
+
+	typ := reflect.TypeOf(&Anything{})
+	title := "captain"
+	obj := &Anything{ID: 5, Name: "Ann", Nick: null.String{String: "leftover"}, Age: null.IntFrom(30), Title: &title}
+
+	tests := []struct {
+		columns []string
+		want    []interface{}
+	}{
+		{[]string{"id", "name", "nick", "age", "title"}, []interface{}{5, "Ann", nil, int64(30), "captain"}},
+		{[]string{"title", "age", "id"}, []interface{}{"captain", int64(30), 5}},
+		{[]string{"nick", "name", "nick"}, []interface{}{nil, "Ann", nil}},
+		{nil, []interface{}{}},
+	}
+
+	for i, test := range tests {
+		mapping, err := BindMapping(typ, MakeStructMapping(typ), test.columns)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := ColumnValues(obj, mapping); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%d) want %#v, got %#v", i, test.want, got)
+		}
+	}
+
+	obj.Title = nil
+	mapping, err := BindMapping(typ, MakeStructMapping(typ), []string{"title"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ColumnValues(obj, mapping); len(got) != 1 || got[0] != nil {
+		t.Errorf("want a nil pointer as nil, got %#v", got)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	type Anything struct {
+		ID        int         `boil:"id"`
+		Name      string      `boil:"name"`
+		Nick      null.String `boil:"nick"`
+		Age       null.Int    `boil:"age"`
+		Bio       null.String `boil:"bio"`
+		Avatar    []byte      `boil:"avatar"`
+		UpdatedAt time.Time   `boil:"updated_at"`
+	}
+
+	columns := []string{"id", "name", "nick", "age", "bio", "avatar", "updated_at"}
+	typ := reflect.TypeOf(&Anything{})
+	mapping, err := BindMapping(typ, MakeStructMapping(typ), columns)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	updatedAt := time.Date(2018, 1, 1, 12, 0, 0, 0, time.UTC)
+	old := &Anything{
+		ID:        1,
+		Name:      "Ann",
+		Nick:      null.String{String: "leftover", Valid: false},
+		Age:       null.IntFrom(30),
+		Bio:       null.StringFrom("pilot"),
+		Avatar:    []byte("png"),
+		UpdatedAt: updatedAt,
+	}
+	updated := &Anything{
+		ID:        1,
+		Name:      "Anne",
+		Nick:      null.String{},
+		Age:       null.Int{},
+		Bio:       null.StringFrom("pilot"),
+		Avatar:    []byte("png"),
+		UpdatedAt: updatedAt.In(time.FixedZone("UTC+1", 3600)),
+	}
+
+	changes := Diff(columns, mapping, old, updated)
+	want := map[string]ColumnChange{
+		"name": {Old: "Ann", New: "Anne"},
+		"age":  {Old: null.IntFrom(30), New: null.Int{}},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("mismatch:\nWant: %#v\nGot:  %#v", want, changes)
+	}
+
+	updated.Age = null.IntFrom(30)
+	updated.Nick = null.StringFrom("Annie")
+	updated.Bio = null.String{}
+	updated.Avatar = nil
+	changes = Diff(columns, mapping, old, updated)
+	want = map[string]ColumnChange{
+		"name":   {Old: "Ann", New: "Anne"},
+		"nick":   {Old: null.String{String: "leftover", Valid: false}, New: null.StringFrom("Annie")},
+		"bio":    {Old: null.StringFrom("pilot"), New: null.String{}},
+		"avatar": {Old: []byte("png"), New: []byte(nil)},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("mismatch:\nWant: %#v\nGot:  %#v", want, changes)
+	}
+
+	if changes = Diff(columns, mapping, old, old); len(changes) != 0 {
+		t.Errorf("Expected no changes, got %#v", changes)
+	}
+}
@@ -1,6 +1,12 @@
 package qm
 
-import "github.com/volatiletech/sqlboiler/queries"
+import (
+	"strings"
+	"time"
+
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries"
+)
 
 // QueryMod to modify the query object
 type QueryMod func(q *queries.Query)
@@ -12,6 +18,17 @@ func Apply(q *queries.Query, mods ...QueryMod) {
 	}
 }
 
+// If applies mod only when cond is true, and does nothing otherwise. It saves
+// building up a slice of mods for optional filters, for example:
+// If(name != "", Where("name = ?", name))
+func If(cond bool, mod QueryMod) QueryMod {
+	if !cond {
+		return func(q *queries.Query) {}
+	}
+
+	return mod
+}
+
 // SQL allows you to execute a plain SQL statement
 func SQL(sql string, args ...interface{}) QueryMod {
 	return func(q *queries.Query) {
@@ -19,6 +36,17 @@ func SQL(sql string, args ...interface{}) QueryMod {
 	}
 }
 
+// Dialect builds the query with dialect instead of the one of the
+// generated package, for quoting, placeholders and the other dialect
+// specific parts of the statement. Each query gets its own copy of
+// dialect, so nothing is shared between them.
+func Dialect(dialect queries.Dialect) QueryMod {
+	return func(q *queries.Query) {
+		d := dialect
+		queries.SetDialect(q, &d)
+	}
+}
+
 // Load allows you to specify foreign key relationships to eager load
 // for your query. Passed in relationships need to be in the format
 // MyThing or MyThings.
@@ -37,6 +65,80 @@ func InnerJoin(clause string, args ...interface{}) QueryMod {
 	}
 }
 
+// LeftOuterJoin on another table, keeping the rows that have no match in it
+func LeftOuterJoin(clause string, args ...interface{}) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendLeftOuterJoin(q, clause, args...)
+	}
+}
+
+// InnerJoinOn another table, given as the table with an optional alias and
+// the first condition of the ON clause. Add more conditions with JoinOn:
+// InnerJoinOn("pilots p", "jets.pilot_id = p.id"), JoinOn("p.active = ?", true)
+// generates INNER JOIN pilots p ON (jets.pilot_id = p.id) AND (p.active = $1).
+func InnerJoinOn(target, clause string, args ...interface{}) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendInnerJoinOn(q, target, clause, args...)
+	}
+}
+
+// JoinOn ANDs a condition onto the ON clause of the previous join. Its
+// arguments are bound before those of the where clauses. It panics if no
+// join came before it.
+func JoinOn(clause string, args ...interface{}) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendJoinOn(q, clause, args...)
+	}
+}
+
+// WithRecursive adds a recursive common table expression to the query,
+// rendered as WITH RECURSIVE name AS (anchor UNION ALL recursive). name may
+// include a column list, like "tree(id, parent_id)". args are bound to the
+// ? placeholders of anchor and then recursive, and come before the
+// arguments of the rest of the query. It is only used for select queries,
+// and is not supported on MS SQL which has no RECURSIVE keyword.
+func WithRecursive(name, anchor, recursive string, args ...interface{}) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendWithRecursive(q, name, anchor, recursive, args...)
+	}
+}
+
+// WithModel adds a common table expression called name to the query, made
+// of the rows selected by another model query, so it can be selected from
+// or joined like a table: WithModel("active_pilots", models.Pilots(db,
+// Where("active = ?", true)).Query) gives WITH active_pilots AS (SELECT *
+// FROM "pilots" WHERE (active = $1)) SELECT ... The arguments of the model
+// query are numbered ahead of the rest of the query's. It is only used for
+// select queries.
+func WithModel(name string, query *queries.Query) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendWithQuery(q, name, query)
+	}
+}
+
+// DistinctOn keeps only the first row of each set of rows that have the same
+// values in columns (Postgres only). Postgres requires the query to be
+// ordered by those columns first, so they're used as the ordering when no
+// OrderBy is given, and the finishers return an error if the ordering
+// starts with anything else.
+func DistinctOn(columns ...string) QueryMod {
+	return func(q *queries.Query) {
+		queries.SetDistinctOn(q, columns...)
+	}
+}
+
+// Distinct selects only distinct rows. When the query joins other tables,
+// which repeats the rows of its model table for every row they match, one
+// row is kept for each of them: with DISTINCT ON its primary key on
+// Postgres, which like DistinctOn needs any OrderBy to start with the key,
+// and with SELECT DISTINCT of its columns elsewhere. Count counts the
+// distinct rows of the model table.
+func Distinct() QueryMod {
+	return func(q *queries.Query) {
+		queries.SetDistinct(q)
+	}
+}
+
 // Select specific columns opposed to all columns
 func Select(columns ...string) QueryMod {
 	return func(q *queries.Query) {
@@ -44,6 +146,14 @@ func Select(columns ...string) QueryMod {
 	}
 }
 
+// SelectReset replaces the columns selected so far, including the ones of
+// the query the mods are applied to, with columns.
+func SelectReset(columns ...string) QueryMod {
+	return func(q *queries.Query) {
+		q.SetSelect(columns...)
+	}
+}
+
 // Where allows you to specify a where clause for your statement
 func Where(clause string, args ...interface{}) QueryMod {
 	return func(q *queries.Query) {
@@ -51,6 +161,262 @@ func Where(clause string, args ...interface{}) QueryMod {
 	}
 }
 
+// WhereNot allows you to specify a where clause that is negated with NOT,
+// for example WhereNot("a = ? OR b = ?", 1, 2) gives NOT (a = $1 OR b = $2).
+func WhereNot(clause string, args ...interface{}) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendWhereNot(q, clause, args...)
+	}
+}
+
+// WhereNamed allows you to specify a where clause with :name parameters, which
+// are bound to the values of params, for example:
+// WhereNamed("a = :a AND b = :b", map[string]interface{}{"a": 1, "b": 2})
+func WhereNamed(clause string, params map[string]interface{}) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendWhereNamed(q, clause, params)
+	}
+}
+
+// WhereStruct allows you to specify a where clause built from the fields of
+// a struct, for example a search request. The fields with a boil tag give
+// their column an equality condition, ANDed together in column order.
+// Fields that aren't set are skipped: zero values, nil pointers and null
+// types that aren't Valid, so use a pointer to match a zero value.
+func WhereStruct(obj interface{}, opts queries.WhereStructOptions) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendWhereStruct(q, obj, opts)
+	}
+}
+
+// WhereRow allows you to match a row holding all of the values of a model,
+// like the values it was read with before it was changed, so an UpdateAll
+// only changes the row when nobody else has. Every field with a boil tag
+// gives its column an equality condition, or IS NULL when it holds NULL,
+// ANDed together in field order. Leave the primary key out with Exclude
+// when it's matched on its own.
+func WhereRow(obj interface{}, opts queries.WhereStructOptions) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendWhereRow(q, obj, opts)
+	}
+}
+
+// WhereAny allows you to match column against any element of a slice,
+// bound as a single array parameter: column = ANY($1). Unlike WhereIn the
+// number of placeholders doesn't grow with the slice, an empty slice
+// matches nothing. It is only supported on Postgres.
+func WhereAny(column string, values interface{}) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendWhereAny(q, column, values)
+	}
+}
+
+// WhereArrayLen allows you to compare the number of elements of an array
+// column with length: WhereArrayLen("tags", ">", 3) gives cardinality("tags")
+// > $1. The operator must be one of =, <>, !=, <, <=, > and >=, otherwise
+// it panics. It is only supported on Postgres.
+func WhereArrayLen(column, operator string, length int) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendWhereArrayLen(q, column, operator, length)
+	}
+}
+
+// WhereLikeAny allows you to match column against any of a list of patterns:
+// WhereLikeAny("name", []string{"a%", "b%"}) gives ("name" LIKE $1 OR
+// "name" LIKE $2). An empty list matches nothing.
+func WhereLikeAny(column string, patterns []string) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendWhereLikeAny(q, column, patterns)
+	}
+}
+
+// TupleCompare allows you to compare the row of columns with the row of
+// values, for keyset pagination: TupleCompare([]string{"created_at", "id"},
+// ">", []interface{}{t, 10}) gives ("created_at", "id") > ($1, $2) on
+// Postgres, and ("created_at" > ? OR ("created_at" = ? AND "id" > ?)) where
+// rows can't be compared. The operator must be one of >, <, >= and <=, and
+// there must be a value for every column, otherwise it panics.
+func TupleCompare(columns []string, operator string, values []interface{}) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendWhereTupleCompare(q, columns, operator, values)
+	}
+}
+
+// WhereJSONContains allows you to match a JSON column containing value:
+// column @> ? on Postgres, JSON_CONTAINS(column, ?) on MySQL. A types.JSON
+// or []byte value is bound as is, any other value is marshaled to JSON.
+func WhereJSONContains(column string, value interface{}) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendWhereJSONContains(q, column, value)
+	}
+}
+
+// WhereEq allows you to match columns against the values of a map, like the
+// Eq of squirrel. A condition is ANDed for each column in sorted order:
+// WhereEq(map[string]interface{}{"a": 1, "b": []int{2, 3}, "c": nil}) gives
+// ("a" = $1) AND ("b" IN ($2,$3)) AND ("c" IS NULL). An empty slice matches
+// nothing.
+func WhereEq(eq map[string]interface{}) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendWhereEq(q, eq)
+	}
+}
+
+// WhereInModel allows you to match column against the rows of another model
+// query: WhereInModel("pilot_id", models.Pilots(db, Where("active = ?", true)).Query)
+// gives "pilot_id" IN (SELECT "id" FROM "pilots" WHERE (active = $1)). The
+// subquery selects the primary key of its table unless it selects columns
+// with Select, and its arguments are numbered along with the query's.
+func WhereInModel(column string, subquery *queries.Query) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendWhereInQuery(q, column, subquery)
+	}
+}
+
+// Eq allows you to match column equal to value: column = ?. The column is
+// quoted for the dialect. A nil value, nil pointer or null type that isn't
+// Valid is written as column IS NULL.
+func Eq(column string, value interface{}) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendWhereOp(q, column, "=", value)
+	}
+}
+
+// Neq allows you to match column not equal to value: column <> ?. A null
+// value is written as column IS NOT NULL.
+func Neq(column string, value interface{}) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendWhereOp(q, column, "<>", value)
+	}
+}
+
+// WhereDistinctFrom allows you to match rows where column is null safely
+// unequal to value, unlike <> a null column and a value, or two nulls, are
+// compared as distinct and equal. It's column IS DISTINCT FROM ?, or
+// NOT (column <=> ?) on mysql.
+func WhereDistinctFrom(column string, value interface{}) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendWhereDistinctFrom(q, column, value)
+	}
+}
+
+// WhereTimeRange allows you to match a time column within the half open
+// interval [from, to): column >= ? AND column < ?. A zero from or to leaves
+// that side open, and the condition is left out when both are zero.
+func WhereTimeRange(column string, from, to time.Time) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendWhereTimeRange(q, column, from, to)
+	}
+}
+
+// WhereIdent allows you to use a dynamic column, like one picked from an
+// allowlist by a request, in a where clause: WhereIdent(column, "= ?", value).
+// The column is quoted for the dialect and must only hold letters, digits
+// and underscores, with a dot after its table, building the query panics
+// otherwise. The args are bound to the question marks of clause.
+func WhereIdent(column, clause string, args ...interface{}) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendWhereIdent(q, column, clause, args...)
+	}
+}
+
+// WhereCond allows you to add a condition tree built with boil.Cond,
+// boil.And and boil.Or instead of a clause string:
+// WhereCond(boil.Or(boil.Cond("age", ">", 30), boil.Cond("name", "=", "Ann"))).
+func WhereCond(c boil.Condition) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendWhereCondition(q, c)
+	}
+}
+
+// Gt allows you to match column greater than value: column > ?.
+func Gt(column string, value interface{}) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendWhereOp(q, column, ">", value)
+	}
+}
+
+// Gte allows you to match column greater than or equal to value: column >= ?.
+func Gte(column string, value interface{}) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendWhereOp(q, column, ">=", value)
+	}
+}
+
+// Lt allows you to match column less than value: column < ?.
+func Lt(column string, value interface{}) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendWhereOp(q, column, "<", value)
+	}
+}
+
+// Lte allows you to match column less than or equal to value: column <= ?.
+func Lte(column string, value interface{}) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendWhereOp(q, column, "<=", value)
+	}
+}
+
+// Like allows you to match column against a pattern: column LIKE ?.
+func Like(column string, pattern interface{}) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendWhereOp(q, column, "LIKE", pattern)
+	}
+}
+
+// RangeContains allows you to match a range or array column containing
+// value, which is an element or another range: column @> ?. It is only
+// supported on Postgres.
+func RangeContains(column string, value interface{}) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendWhereOp(q, column, "@>", value)
+	}
+}
+
+// RangeContainedBy allows you to match a range or array column contained
+// by value: column <@ ?. It is only supported on Postgres.
+func RangeContainedBy(column string, value interface{}) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendWhereOp(q, column, "<@", value)
+	}
+}
+
+// RangeOverlaps allows you to match a range or array column with any
+// values in common with value: column && ?. It is only supported on
+// Postgres.
+func RangeOverlaps(column string, value interface{}) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendWhereOp(q, column, "&&", value)
+	}
+}
+
+// WhereWithinDistance allows you to match a PostGIS geography column within
+// meters of the point at lng and lat:
+// ST_DWithin(column, ST_MakePoint(?, ?)::geography, ?). It is only
+// supported on Postgres with PostGIS.
+func WhereWithinDistance(column string, lng, lat, meters float64) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendWhereWithinDistance(q, column, lng, lat, meters)
+	}
+}
+
+// FullText allows you to specify a full text search of columns for terms,
+// several columns are separated by commas: FullText("title, body", terms).
+// It is written as to_tsvector(columns) @@ plainto_tsquery(terms) on
+// Postgres, MATCH(columns) AGAINST (terms IN NATURAL LANGUAGE MODE) on
+// MySQL and FREETEXT((columns), terms) on MSSQL, which need a full text
+// index on the columns.
+func FullText(columns, terms string) QueryMod {
+	cols := strings.Split(columns, ",")
+	for i, c := range cols {
+		cols[i] = strings.TrimSpace(c)
+	}
+
+	return func(q *queries.Query) {
+		queries.AppendFullText(q, terms, cols...)
+	}
+}
+
 // And allows you to specify a where clause separated by an AND for your statement
 // And is a duplicate of the Where function, but allows for more natural looking
 // query mod chains, for example: (Where("a=?"), And("b=?"), Or("c=?")))
@@ -68,6 +434,24 @@ func Or(clause string, args ...interface{}) QueryMod {
 	}
 }
 
+// AndWhere allows you to specify a where clause separated by an AND for your
+// statement. It is a duplicate of And, named to pair with OrWhere.
+func AndWhere(clause string, args ...interface{}) QueryMod {
+	return And(clause, args...)
+}
+
+// OrWhere allows you to specify a where clause separated by an OR for your
+// statement. It is a duplicate of Or, named to pair with AndWhere.
+//
+// Each clause is wrapped in parentheses but the separators between them are
+// not, so normal SQL precedence applies and AND binds tighter than OR:
+// Where("a"), OrWhere("b"), Where("c") renders (a) OR (b) AND (c), which
+// is evaluated as (a) OR ((b) AND (c)). Put the OR inside a single clause,
+// as in Where("a OR b"), to group it differently.
+func OrWhere(clause string, args ...interface{}) QueryMod {
+	return Or(clause, args...)
+}
+
 // WhereIn allows you to specify a "x IN (set)" clause for your where statement
 // Example clauses: "column in ?", "(column1,column2) in ?"
 func WhereIn(clause string, args ...interface{}) QueryMod {
@@ -102,6 +486,36 @@ func GroupBy(clause string) QueryMod {
 	}
 }
 
+// Rollup allows you to group by ROLLUP(columns), adding subtotal rows for
+// each prefix of columns and a grand total. On MySQL it's written as
+// GROUP BY columns WITH ROLLUP, so it can't be combined with other group bys.
+func Rollup(columns ...string) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendRollup(q, columns...)
+	}
+}
+
+// Cube allows you to group by CUBE(columns), adding subtotal rows for every
+// combination of columns. It is not supported on MySQL.
+func Cube(columns ...string) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendCube(q, columns...)
+	}
+}
+
+// GroupingSets allows you to group by GROUPING SETS, one grouping for each
+// of sets, an empty set is the grand total:
+//
+//	GroupingSets([]string{"a"}, []string{"b"}, nil)
+//	// GROUP BY GROUPING SETS ((a), (b), ())
+//
+// It is not supported on MySQL.
+func GroupingSets(sets ...[]string) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendGroupingSets(q, sets...)
+	}
+}
+
 // OrderBy allows you to specify a order by clause for your statement
 func OrderBy(clause string) QueryMod {
 	return func(q *queries.Query) {
@@ -109,6 +523,39 @@ func OrderBy(clause string) QueryMod {
 	}
 }
 
+// OrderByRandom orders the rows randomly, using RANDOM() or the dialect's
+// equivalent (RAND() on MySQL, NEWID() on MS SQL). It is usually combined
+// with Limit to take a random sample. Every row has to be read and sorted,
+// so it is slow on large tables.
+func OrderByRandom() QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendOrderByRandom(q)
+	}
+}
+
+// OrderByField orders the rows by the position of the value of column in
+// values, for example to keep the order of the ids of an IN query:
+// qm.OrderByField("id", 3, 1, 2). It is written as FIELD(id, ...) on MySQL,
+// array_position(ARRAY[...], id) on Postgres and a CASE expression on
+// MS SQL. Rows whose value isn't in values come first on MySQL and last
+// on the others.
+func OrderByField(column string, values ...interface{}) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendOrderByField(q, column, values...)
+	}
+}
+
+// NullsOrder sorts null values where order says for this query, instead of
+// where boil.SetNullsOrder does: boil.NullsFirst, boil.NullsLast, or
+// boil.NullsDefault to leave them where the database sorts them. Order by
+// expressions with an explicit NULLS FIRST or LAST keep it. It has no
+// effect on MySQL and MS SQL.
+func NullsOrder(order string) QueryMod {
+	return func(q *queries.Query) {
+		queries.SetNullsOrder(q, order)
+	}
+}
+
 // Having allows you to specify a having clause for your statement
 func Having(clause string, args ...interface{}) QueryMod {
 	return func(q *queries.Query) {
@@ -116,6 +563,38 @@ func Having(clause string, args ...interface{}) QueryMod {
 	}
 }
 
+// GroupByAggregate sets up a grouped aggregate query in a single mod. The
+// group columns are selected and grouped by, the aggregate expressions are
+// selected after them, and the having mods (normally made with Having) are
+// applied to filter the groups, for example:
+//
+//	GroupByAggregate(
+//	  []string{"pilot_id"},
+//	  []string{"count(*) as jets"},
+//	  Having("count(*) > ?", 2),
+//	)
+//
+// Where mods are not affected, so they still filter rows before grouping.
+func GroupByAggregate(groupBy []string, aggregates []string, having ...QueryMod) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendSelect(q, groupBy...)
+		queries.AppendSelect(q, aggregates...)
+		if len(groupBy) != 0 {
+			queries.AppendGroupBy(q, strings.Join(groupBy, ", "))
+		}
+		Apply(q, having...)
+	}
+}
+
+// Schema selects the table of the model the query is for from schema,
+// instead of the schema it was generated for. The schema is quoted, so it
+// may come from user input, like a tenant's schema.
+func Schema(schema string) QueryMod {
+	return func(q *queries.Query) {
+		queries.SetSchema(q, schema)
+	}
+}
+
 // From allows to specify the table for your statement
 func From(from string) QueryMod {
 	return func(q *queries.Query) {
@@ -123,6 +602,15 @@ func From(from string) QueryMod {
 	}
 }
 
+// FromOnly allows to specify the table for your statement with ONLY, so
+// that the tables inheriting from it, like its partitions, are left out.
+// It is only supported on Postgres.
+func FromOnly(table string) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendFromOnly(q, table)
+	}
+}
+
 // Limit the number of returned rows
 func Limit(limit int) QueryMod {
 	return func(q *queries.Query) {
@@ -137,9 +625,52 @@ func Offset(offset int) QueryMod {
 	}
 }
 
+// TableSample reads only a sample of the rows of the table being selected
+// from, using the sampling method (BERNOULLI or SYSTEM) and the given
+// percentage of the table. It is only supported on Postgres, building
+// the query returns an error on other databases.
+func TableSample(method string, percent float64) QueryMod {
+	return func(q *queries.Query) {
+		queries.SetTableSample(q, method, percent)
+	}
+}
+
+// IndexHint writes a MySQL index hint, like FORCE INDEX (idx_name), after
+// the table being selected from. It is only supported on MySQL, building
+// the query returns an error on other databases.
+func IndexHint(hint string) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendIndexHint(q, "", hint)
+	}
+}
+
+// IndexHintOn is like IndexHint, but writes the hint after another table in
+// the FROM clause, found by its name or alias.
+func IndexHintOn(table, hint string) QueryMod {
+	return func(q *queries.Query) {
+		queries.AppendIndexHint(q, table, hint)
+	}
+}
+
 // For inserts a concurrency locking clause at the end of your statement
 func For(clause string) QueryMod {
 	return func(q *queries.Query) {
 		queries.SetFor(q, clause)
 	}
 }
+
+// ForShare inserts a shared row locking clause at the end of your statement,
+// FOR SHARE or LOCK IN SHARE MODE depending on the database. MS SQL has none.
+func ForShare() QueryMod {
+	return func(q *queries.Query) {
+		queries.SetForShare(q, false)
+	}
+}
+
+// ForShareNoWait is like ForShare but fails instead of waiting for rows
+// that are already locked (FOR SHARE NOWAIT, Postgres only)
+func ForShareNoWait() QueryMod {
+	return func(q *queries.Query) {
+		queries.SetForShare(q, true)
+	}
+}
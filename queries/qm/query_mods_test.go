@@ -0,0 +1,59 @@
+package qm
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"github.com/volatiletech/sqlboiler/queries"
+)
+
+type recordingExecutor struct {
+	query string
+	args  []interface{}
+}
+
+func (r *recordingExecutor) Exec(query string, args ...interface{}) (sql.Result, error) {
+	r.query, r.args = query, args
+	return nil, nil
+}
+
+func (r *recordingExecutor) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	r.query, r.args = query, args
+	return nil, nil
+}
+
+func (r *recordingExecutor) QueryRow(query string, args ...interface{}) *sql.Row {
+	r.query, r.args = query, args
+	return nil
+}
+
+// build returns the statement and args of a select from pilots with mods.
+func build(mods ...QueryMod) (string, []interface{}) {
+	exec := &recordingExecutor{}
+	q := &queries.Query{}
+	queries.SetExecutor(q, exec)
+	queries.SetDialect(q, &queries.Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true})
+	queries.SetFrom(q, "pilots")
+	Apply(q, mods...)
+
+	q.Query()
+	return exec.query, exec.args
+}
+
+func TestIf(t *testing.T) {
+	t.Parallel()
+
+	want, wantArgs := build(Where("age > ?", 18))
+
+	got, gotArgs := build(Where("age > ?", 18), If(false, Where("name = ?", "John")), If(false, Limit(5)))
+	if got != want || !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Errorf("want a false condition to leave the query as it was:\nwant: %s %v\ngot:  %s %v", want, wantArgs, got, gotArgs)
+	}
+
+	want, wantArgs = build(Where("age > ?", 18), Where("name = ?", "John"))
+	got, gotArgs = build(Where("age > ?", 18), If(true, Where("name = ?", "John")))
+	if got != want || !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Errorf("want a true condition to apply the mod:\nwant: %s %v\ngot:  %s %v", want, wantArgs, got, gotArgs)
+	}
+}
@@ -2,9 +2,18 @@ package queries
 
 import (
 	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
 	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/pkg/errors"
 	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/types"
 )
 
 // joinKind is the type of join
@@ -27,17 +36,50 @@ type Query struct {
 	delete     bool
 	update     map[string]interface{}
 	selectCols []string
+	distinctOn []string
+	distinct   bool
 	count      bool
+	with       []with
 	from       []string
 	joins      []join
 	where      []where
 	in         []in
 	groupBy    []string
+	grouping   []grouping
 	orderBy    []string
 	having     []having
 	limit      int
 	offset     int
 	forlock    string
+	forShare   bool
+	forNoWait  bool
+
+	sampleMethod  string
+	samplePercent float64
+	indexHints    []indexHint
+
+	// insertSource makes this an INSERT of insertCols into the FROM
+	// table, with the rows selected by insertSource
+	insertCols   []string
+	insertSource *Query
+
+	// orderByFields are the value list orderings of orderBy, see
+	// AppendOrderByField
+	orderByFields []orderByField
+	// nullsOrder overrides boil.GetNullsOrder when nullsOrderSet, see
+	// SetNullsOrder
+	nullsOrder    string
+	nullsOrderSet bool
+
+	// keyColumns are the primary key columns of the FROM table, selected
+	// when the query is the subquery of a where in and selects nothing else
+	keyColumns []string
+
+	// table is the model table the from clause tableFrom names, which is
+	// selected from schema instead when it is set
+	table     string
+	tableFrom string
+	schema    string
 }
 
 // Dialect holds values that direct the query builder
@@ -55,12 +97,101 @@ type Dialect struct {
 	// Bool flag indicating whether "TOP" or "LIMIT" clause
 	// must be used for rows limitation
 	UseTopClause bool
+	// The shared row locking clause, "FOR SHARE" or
+	// "LOCK IN SHARE MODE", unsupported if empty. NOWAIT
+	// is only supported with FOR SHARE
+	ShareLock string
+	// The function used to order rows randomly, RANDOM() if empty
+	RandomFunction string
+	// Bool flag indicating whether the TABLESAMPLE clause
+	// is supported
+	UseTableSample bool
+	// The full text search syntax, "match" for MATCH ... AGAINST,
+	// "freetext" for FREETEXT, to_tsvector @@ plainto_tsquery if empty
+	FullTextSearch string
+	// Bool flag indicating whether a slice can be bound
+	// as a single array parameter
+	UseArrayParams bool
+	// Bool flag indicating whether GROUPING SETS, CUBE
+	// and ROLLUP are supported in GROUP BY
+	UseGroupingSets bool
+	// Bool flag indicating whether ONLY is supported
+	// in FROM to leave out inheriting tables
+	UseFromOnly bool
+	// Bool flag indicating whether NULLS FIRST and NULLS LAST
+	// are supported in ORDER BY
+	UseNullsOrder bool
+	// The JSON containment syntax, "@>" or "json_contains"
+	// for JSON_CONTAINS, unsupported if empty
+	JSONContains string
+	// The syntax for ordering by the position of a value in a list,
+	// "field" for FIELD, "array_position" for array_position,
+	// a CASE expression if empty
+	OrderByField string
+	// The name of the upsert builder, see RegisterUpsertBuilder
+	UpsertSyntax string
+	// Bool flag indicating whether row values can be compared,
+	// like (a, b) > (?, ?)
+	UseRowValues bool
+	// Bool flag indicating whether DISTINCT ON is supported
+	UseDistinctOn bool
+	// Bool flag indicating whether index hints like
+	// FORCE INDEX are supported in FROM
+	UseIndexHints bool
+	// Bool flag indicating whether PostGIS functions
+	// like ST_DWithin are supported
+	UsePostGIS bool
+	// Bool flag indicating whether the rows of a table can be
+	// estimated from reltuples in pg_class
+	UseCountEstimate bool
+	// Bool flag indicating whether null safe comparisons are
+	// written with <=> instead of IS DISTINCT FROM
+	UseNullSafeEqual bool
 }
 
 type where struct {
 	clause      string
 	orSeparator bool
 	args        []interface{}
+	// fullText columns make this a full text search, its clause is
+	// written for the dialect when the query is built
+	fullText []string
+	// anyColumn makes this an = ANY condition binding an array
+	anyColumn string
+	// opColumn makes this a comparison of the column, quoted when the
+	// query is built, followed by operator, like "> ?" or "IS NULL"
+	opColumn string
+	operator string
+	// lenColumn makes this a comparison of the number of elements of an
+	// array column followed by operator, like opColumn
+	lenColumn string
+	// jsonColumn makes this a JSON containment condition, written
+	// for the dialect when the query is built
+	jsonColumn string
+	// likeColumn makes this a LIKE condition of the column for every
+	// pattern in args, ORed together
+	likeColumn string
+	// tupleColumns make this a comparison of the row of the columns with
+	// the row of args by operator, written for the dialect when the query
+	// is built
+	tupleColumns []string
+	// inQuery makes this an IN condition of inColumn with the rows
+	// selected by inQuery, built along with the query
+	inColumn string
+	inQuery  *Query
+	// distanceColumn makes this an ST_DWithin condition of the column and
+	// the point and distance in args
+	distanceColumn string
+	// condition makes this the condition tree, compiled along with its
+	// args when the query is built
+	condition boil.Condition
+	// rangeColumn makes this the comparisons of the column with each of
+	// rangeOps and the arg at the same index, ANDed together
+	rangeColumn string
+	rangeOps    []string
+	// distinctColumn makes this a null safe inequality of the column and
+	// the arg, written for the dialect when the query is built
+	distinctColumn string
 }
 
 type in struct {
@@ -69,6 +200,20 @@ type in struct {
 	args        []interface{}
 }
 
+// grouping is a ROLLUP, CUBE or GROUPING SETS element of the group by
+// clause, rollups and cubes have a single set of columns.
+type grouping struct {
+	kind string
+	sets [][]string
+}
+
+// orderByField orders rows by the position of the value of column
+// in values.
+type orderByField struct {
+	column string
+	values []interface{}
+}
+
 type having struct {
 	clause string
 	args   []interface{}
@@ -79,10 +224,38 @@ type rawSQL struct {
 	args []interface{}
 }
 
+type with struct {
+	name      string
+	anchor    string
+	recursive string
+	args      []interface{}
+	// query makes this a common table expression of the rows selected
+	// by query, built along with the query
+	query *Query
+}
+
 type join struct {
 	kind   joinKind
 	clause string
 	args   []interface{}
+
+	// on holds conditions ANDed onto the join's ON clause. When targetOnly
+	// is set the clause is just the joined table, and the first condition
+	// starts the ON clause.
+	on         []joinCondition
+	targetOnly bool
+}
+
+type joinCondition struct {
+	clause string
+	args   []interface{}
+}
+
+// indexHint is written after the FROM clause of table, or after the first
+// FROM clause when table is empty.
+type indexHint struct {
+	table string
+	hint  string
 }
 
 // Raw makes a raw query, usually for use with bind
@@ -103,7 +276,10 @@ func RawG(query string, args ...interface{}) *Query {
 
 // Exec executes a query that does not need a row returned
 func (q *Query) Exec() (sql.Result, error) {
-	qs, args := buildQuery(q)
+	qs, args, err := buildQuery(q)
+	if err != nil {
+		return nil, err
+	}
 	if boil.DebugMode {
 		fmt.Fprintln(boil.DebugWriter, qs)
 		fmt.Fprintln(boil.DebugWriter, args)
@@ -112,8 +288,12 @@ func (q *Query) Exec() (sql.Result, error) {
 }
 
 // QueryRow executes the query for the One finisher and returns a row
+// It will panic if the query can't be built, use ScanRow to get the error
 func (q *Query) QueryRow() *sql.Row {
-	qs, args := buildQuery(q)
+	qs, args, err := buildQuery(q)
+	if err != nil {
+		panic(boil.WrapErr(err))
+	}
 	if boil.DebugMode {
 		fmt.Fprintln(boil.DebugWriter, qs)
 		fmt.Fprintln(boil.DebugWriter, args)
@@ -121,9 +301,26 @@ func (q *Query) QueryRow() *sql.Row {
 	return q.executor.QueryRow(qs, args...)
 }
 
+// ScanRow executes the query and scans its first row into dest like
+// sql.Row.Scan, returning the error when the query can't be built
+func (q *Query) ScanRow(dest ...interface{}) error {
+	qs, args, err := buildQuery(q)
+	if err != nil {
+		return err
+	}
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, qs)
+		fmt.Fprintln(boil.DebugWriter, args)
+	}
+	return q.executor.QueryRow(qs, args...).Scan(dest...)
+}
+
 // Query executes the query for the All finisher and returns multiple rows
 func (q *Query) Query() (*sql.Rows, error) {
-	qs, args := buildQuery(q)
+	qs, args, err := buildQuery(q)
+	if err != nil {
+		return nil, err
+	}
 	if boil.DebugMode {
 		fmt.Fprintln(boil.DebugWriter, qs)
 		fmt.Fprintln(boil.DebugWriter, args)
@@ -153,6 +350,186 @@ func (q *Query) QueryP() *sql.Rows {
 	return rows
 }
 
+// CreateTableAs executes CREATE TABLE tableName AS with the query, creating
+// the table from the rows it selects with their args bound. If temporary is
+// true the table is created with CREATE TEMPORARY TABLE and dropped at the
+// end of the session. It's supported by Postgres and MySQL.
+func (q *Query) CreateTableAs(tableName string, temporary bool) (sql.Result, error) {
+	qs, args, err := buildCreateTableAsQuery(q, tableName, temporary)
+	if err != nil {
+		return nil, err
+	}
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, qs)
+		fmt.Fprintln(boil.DebugWriter, args)
+	}
+	return q.executor.Exec(qs, args...)
+}
+
+// CountGroups executes the query selecting its group by columns and
+// COUNT(*), and returns the count of each group keyed by GroupKey of
+// the group's values. The query must have group by clauses and no
+// grouping sets, and its select columns are replaced.
+func (q *Query) CountGroups() (map[string]int64, error) {
+	if len(q.groupBy) == 0 {
+		return nil, errors.New("count groups requires group by clauses")
+	}
+	if len(q.grouping) != 0 {
+		return nil, errors.New("count groups does not support grouping sets")
+	}
+
+	q.selectCols = append(append([]string(nil), q.groupBy...), "COUNT(*)")
+	q.count = false
+
+	rows, err := q.Query()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]interface{}, len(cols)-1)
+	pointers := make([]interface{}, len(cols))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+
+	counts := make(map[string]int64)
+	for rows.Next() {
+		var count int64
+		pointers[len(values)] = &count
+		if err = rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+
+		counts[GroupKey(values...)] += count
+	}
+
+	return counts, rows.Err()
+}
+
+// CountEstimate returns the number of rows counted by the query. When it
+// counts a whole table, selecting from just the model table of SetTable
+// without conditions, joins, grouping or limits, and the dialect supports
+// it, the count is estimated from the table's statistics instead of
+// scanning it. Otherwise, or when the table has no statistics yet, it
+// falls back to an exact COUNT(*). Its select columns are replaced.
+func (q *Query) CountEstimate() (int64, error) {
+	if countEstimable(q) {
+		qs := "SELECT reltuples::bigint FROM pg_class WHERE oid = to_regclass($1)"
+		table := schemaFrom(q)[0]
+		if boil.DebugMode {
+			fmt.Fprintln(boil.DebugWriter, qs)
+			fmt.Fprintln(boil.DebugWriter, table)
+		}
+
+		var count int64
+		err := q.executor.QueryRow(qs, table).Scan(&count)
+		if err != nil && err != sql.ErrNoRows {
+			return 0, err
+		}
+		if err == nil && count >= 0 {
+			return count, nil
+		}
+	}
+
+	var count int64
+	q.selectCols = nil
+	q.count = true
+	err := q.ScanRow(&count)
+	return count, err
+}
+
+// countEstimable reports whether q counts every row of its model table, so
+// that the count can be estimated.
+func countEstimable(q *Query) bool {
+	return q.dialect.UseCountEstimate && len(q.rawSQL.sql) == 0 &&
+		len(q.tableFrom) != 0 && len(q.from) == 1 && q.from[0] == q.tableFrom &&
+		len(q.where) == 0 && len(q.in) == 0 && len(q.joins) == 0 && len(q.with) == 0 &&
+		len(q.groupBy) == 0 && len(q.grouping) == 0 && len(q.having) == 0 &&
+		!q.distinct && len(q.distinctOn) == 0 && q.limit == 0 && q.offset == 0 &&
+		len(q.sampleMethod) == 0
+}
+
+// GroupKey returns the key of the values of a group in the map returned
+// by CountGroups. One value is formatted as is, for example GroupKey("a")
+// is "a" and GroupKey(5) is "5". Several values are each quoted and joined
+// by commas, like "\"a\",\"5\"". NULL is formatted as NULL and times
+// as RFC 3339.
+func GroupKey(values ...interface{}) string {
+	if len(values) == 1 {
+		return groupKeyValue(values[0])
+	}
+
+	keys := make([]string, len(values))
+	for i, v := range values {
+		keys[i] = strconv.Quote(groupKeyValue(v))
+	}
+
+	return strings.Join(keys, ",")
+}
+
+func groupKeyValue(value interface{}) string {
+	if isNullValue(value) {
+		return "NULL"
+	}
+
+	if valuer, ok := value.(driver.Valuer); ok {
+		if v, err := valuer.Value(); err == nil {
+			value = v
+		}
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		return string(v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// OrderBy returns a copy of the order by clauses of the query, in the
+// order they were added.
+func (q *Query) OrderBy() []string {
+	if len(q.orderBy) == 0 {
+		return nil
+	}
+
+	clauses := make([]string, len(q.orderBy))
+	for i, clause := range q.orderBy {
+		clauses[i], _ = orderByClause(q, clause)
+	}
+
+	return clauses
+}
+
+// SetOrderBy replaces the order by clauses of the query with clauses,
+// unlike qm.OrderBy which adds to them.
+func (q *Query) SetOrderBy(clauses ...string) {
+	q.orderBy = append([]string(nil), clauses...)
+	q.orderByFields = nil
+}
+
+// SetSelect replaces the selected columns of the query with columns,
+// unlike qm.Select which adds to them. With no columns the query selects
+// everything again.
+func (q *Query) SetSelect(columns ...string) {
+	q.selectCols = append([]string(nil), columns...)
+}
+
+// ClearOrderBy removes all order by clauses from the query, for example
+// before counting the rows of a cloned query.
+func (q *Query) ClearOrderBy() {
+	q.orderBy = nil
+	q.orderByFields = nil
+}
+
 // SetExecutor on the query.
 func SetExecutor(q *Query, exec boil.Executor) {
 	q.executor = exec
@@ -193,6 +570,19 @@ func GetSelect(q *Query) []string {
 	return q.selectCols
 }
 
+// SetDistinctOn on the query. Only one row is kept for each set of
+// values of the columns, the first one in the order of the query.
+func SetDistinctOn(q *Query, columns ...string) {
+	q.distinctOn = append([]string(nil), columns...)
+}
+
+// SetDistinct on the query. Only distinct rows are selected, and when the
+// query joins other tables one row is kept for each row of its model table,
+// see qm.Distinct.
+func SetDistinct(q *Query) {
+	q.distinct = true
+}
+
 // SetCount on the query.
 func SetCount(q *Query) {
 	q.count = true
@@ -216,6 +606,63 @@ func SetOffset(q *Query, offset int) {
 // SetFor on the query.
 func SetFor(q *Query, clause string) {
 	q.forlock = clause
+	q.forShare = false
+	q.forNoWait = false
+}
+
+// SetTableSample on the query. The first table in the FROM clause is
+// sampled with method, reading roughly percent of its rows.
+func SetTableSample(q *Query, method string, percent float64) {
+	q.sampleMethod = method
+	q.samplePercent = percent
+}
+
+// AppendIndexHint on the query. The hint, like FORCE INDEX (idx_name), is
+// written after the FROM clause of table, which is matched against the
+// table names and aliases of the FROM clauses. An empty table is the first
+// FROM clause, the table of the model being queried.
+func AppendIndexHint(q *Query, table, hint string) {
+	q.indexHints = append(q.indexHints, indexHint{table: table, hint: hint})
+}
+
+// SetForShare on the query, replacing any previous locking clause.
+// If noWait is true the query fails instead of waiting for locked rows.
+func SetForShare(q *Query, noWait bool) {
+	q.forlock = ""
+	q.forShare = true
+	q.forNoWait = noWait
+}
+
+// SetKeyColumns on the query. They are the primary key columns of its FROM
+// table, which it selects by default as the subquery of AppendWhereInQuery.
+func SetKeyColumns(q *Query, columns ...string) {
+	q.keyColumns = columns
+}
+
+// SetTable on the query. It records that from, one of its from clauses, is
+// the model table table, so that SetSchema can select it from another schema.
+func SetTable(q *Query, from, table string) {
+	q.tableFrom = from
+	q.table = table
+}
+
+// SetSchema on the query. Its model table, see SetTable, is qualified with
+// schema instead of the schema it was generated for.
+func SetSchema(q *Query, schema string) {
+	q.schema = schema
+}
+
+// SchemaTable returns table qualified with schema, both quoted for dialect.
+// Quote characters in them are escaped, so they may come from user input.
+func SchemaTable(dialect *Dialect, schema, table string) string {
+	return quoteIdentifier(dialect, schema) + "." + quoteIdentifier(dialect, table)
+}
+
+// quoteIdentifier quotes s as a single identifier, doubling any right quote
+// characters in it.
+func quoteIdentifier(dialect *Dialect, s string) string {
+	rq := string(dialect.RQ)
+	return string(dialect.LQ) + strings.Replace(s, rq, rq+rq, -1) + rq
 }
 
 // SetUpdate on the query.
@@ -223,6 +670,15 @@ func SetUpdate(q *Query, cols map[string]interface{}) {
 	q.update = cols
 }
 
+// SetInsertSelect on the query, turning it into an INSERT into its FROM table
+// of the rows selected by source, which take the given columns in order:
+// INSERT INTO t ("a", "b") SELECT ... The args of source become the args of
+// the query. Without columns the rows fill all columns of the table.
+func SetInsertSelect(q *Query, columns []string, source *Query) {
+	q.insertCols = columns
+	q.insertSource = source
+}
+
 // AppendSelect on the query.
 func AppendSelect(q *Query, columns ...string) {
 	q.selectCols = append(q.selectCols, columns...)
@@ -233,6 +689,15 @@ func AppendFrom(q *Query, from ...string) {
 	q.from = append(q.from, from...)
 }
 
+// fromOnlyPrefix starts the from clauses of AppendFromOnly.
+const fromOnlyPrefix = "ONLY "
+
+// AppendFromOnly on the query, selecting from table with ONLY so the tables
+// inheriting from it, like its partitions, are left out.
+func AppendFromOnly(q *Query, table string) {
+	q.from = append(q.from, fromOnlyPrefix+table)
+}
+
 // SetFrom replaces the current from statements.
 func SetFrom(q *Query, from ...string) {
 	q.from = append([]string(nil), from...)
@@ -243,6 +708,68 @@ func AppendInnerJoin(q *Query, clause string, args ...interface{}) {
 	q.joins = append(q.joins, join{clause: clause, kind: JoinInner, args: args})
 }
 
+// AppendLeftOuterJoin on the query.
+func AppendLeftOuterJoin(q *Query, clause string, args ...interface{}) {
+	q.joins = append(q.joins, join{clause: clause, kind: JoinOuterLeft, args: args})
+}
+
+// AppendToOneJoin on the query. It left joins a to-one relationship of the
+// model selected from table, with clause giving the joined table aliased as
+// name and its ON condition. The relationship's columns are selected as
+// "name.column", so that Bind sets them into the name field of the model's
+// R struct, see Bind. The model's own columns are selected first when
+// nothing else was.
+func AppendToOneJoin(q *Query, table, name, clause string, columns []string) {
+	if len(q.selectCols) == 0 {
+		q.selectCols = append(q.selectCols, table+".*")
+	}
+	for _, c := range columns {
+		q.selectCols = append(q.selectCols, name+"."+c)
+	}
+
+	AppendLeftOuterJoin(q, clause)
+}
+
+// AppendInnerJoinOn on the query. target is the joined table with an
+// optional alias, and clause is the first condition of its ON clause.
+// More conditions can be ANDed on with AppendJoinOn.
+func AppendInnerJoinOn(q *Query, target, clause string, args ...interface{}) {
+	q.joins = append(q.joins, join{
+		kind:       JoinInner,
+		clause:     target,
+		on:         []joinCondition{{clause: clause, args: args}},
+		targetOnly: true,
+	})
+}
+
+// AppendJoinOn ANDs a condition onto the ON clause of the last join
+// of the query. It panics if the query has no joins, rather than
+// silently dropping the condition.
+func AppendJoinOn(q *Query, clause string, args ...interface{}) {
+	if len(q.joins) == 0 {
+		panic("join condition added to a query without joins")
+	}
+
+	j := &q.joins[len(q.joins)-1]
+	j.on = append(j.on, joinCondition{clause: clause, args: args})
+}
+
+// AppendWithRecursive on the query. It adds a recursive common table
+// expression called name, made of the anchor and recursive queries joined
+// by UNION ALL. args are bound to the placeholders of anchor and then
+// recursive, ahead of the rest of the query's arguments.
+func AppendWithRecursive(q *Query, name, anchor, recursive string, args ...interface{}) {
+	q.with = append(q.with, with{name: name, anchor: anchor, recursive: recursive, args: args})
+}
+
+// AppendWithQuery on the query. It adds a common table expression called
+// name, made of the rows selected by query, which is built with the dialect
+// of the query. Its args are bound in place, ahead of the rest of the
+// query's arguments.
+func AppendWithQuery(q *Query, name string, query *Query) {
+	q.with = append(q.with, with{name: name, query: query})
+}
+
 // AppendHaving on the query.
 func AppendHaving(q *Query, clause string, args ...interface{}) {
 	q.having = append(q.having, having{clause: clause, args: args})
@@ -253,6 +780,302 @@ func AppendWhere(q *Query, clause string, args ...interface{}) {
 	q.where = append(q.where, where{clause: clause, args: args})
 }
 
+// AppendWhereNot on the query, negating clause with NOT.
+func AppendWhereNot(q *Query, clause string, args ...interface{}) {
+	q.where = append(q.where, where{clause: fmt.Sprintf("NOT (%s)", clause), args: args})
+}
+
+// AppendWhereNamed on the query. The :name parameters of clause are bound to
+// the values of params, once for every time they're used.
+func AppendWhereNamed(q *Query, clause string, params map[string]interface{}) {
+	clause, args := convertNamedParams(clause, params)
+	q.where = append(q.where, where{clause: clause, args: args})
+}
+
+// AppendWhereStruct on the query. It ANDs an equality condition for every
+// field of obj with a boil tag that is set, see qm.WhereStruct.
+func AppendWhereStruct(q *Query, obj interface{}, opts WhereStructOptions) {
+	clause, args := whereStructClause(obj, opts)
+	if len(clause) == 0 {
+		return
+	}
+
+	q.where = append(q.where, where{clause: clause, args: args})
+}
+
+// AppendWhereRow on the query. It ANDs a condition for every field of obj
+// with a boil tag matching the value it holds, see qm.WhereRow.
+func AppendWhereRow(q *Query, obj interface{}, opts WhereStructOptions) {
+	clause, args := whereRowClause(obj, opts)
+	if len(clause) == 0 {
+		return
+	}
+
+	q.where = append(q.where, where{clause: clause, args: args})
+}
+
+// AppendFullText on the query. It searches columns for terms with the full
+// text search of the dialect.
+func AppendFullText(q *Query, terms string, columns ...string) {
+	q.where = append(q.where, where{fullText: columns, args: []interface{}{terms}})
+}
+
+// AppendWhereAny on the query. It ANDs a condition matching column against
+// any element of values, which is bound as a single array parameter. It is
+// only supported on postgres.
+func AppendWhereAny(q *Query, column string, values interface{}) {
+	q.where = append(q.where, where{anyColumn: column, args: []interface{}{types.Array(values)}})
+}
+
+// AppendWhereWithinDistance on the query. It ANDs a condition matching the
+// PostGIS geography column within meters of the point at lng and lat, with
+// ST_DWithin. It is only supported on postgres.
+func AppendWhereWithinDistance(q *Query, column string, lng, lat, meters float64) {
+	q.where = append(q.where, where{distanceColumn: column, args: []interface{}{lng, lat, meters}})
+}
+
+// AppendWhereOp on the query. It ANDs a condition comparing column to value
+// with operator, like "=" or ">=". A null value compared with "=" or "<>"
+// is written as IS NULL or IS NOT NULL instead of being bound.
+func AppendWhereOp(q *Query, column, operator string, value interface{}) {
+	if isNullValue(value) {
+		switch operator {
+		case "=":
+			q.where = append(q.where, where{opColumn: column, operator: "IS NULL"})
+			return
+		case "<>":
+			q.where = append(q.where, where{opColumn: column, operator: "IS NOT NULL"})
+			return
+		}
+	}
+
+	q.where = append(q.where, where{opColumn: column, operator: operator + " ?", args: []interface{}{value}})
+}
+
+// AppendWhereDistinctFrom on the query. It ANDs a null safe inequality of
+// column and value, which is true when exactly one of them is null or
+// neither is and they differ. It is IS DISTINCT FROM, or NOT (column <=> ?)
+// on mysql.
+func AppendWhereDistinctFrom(q *Query, column string, value interface{}) {
+	q.where = append(q.where, where{distinctColumn: column, args: []interface{}{value}})
+}
+
+// AppendWhereTimeRange on the query. It ANDs a condition matching column
+// within the half open interval from from up to, but not including, to. A
+// zero from or to leaves that side of the interval open, and when both are
+// zero nothing is added.
+func AppendWhereTimeRange(q *Query, column string, from, to time.Time) {
+	w := where{rangeColumn: column}
+	if !from.IsZero() {
+		w.rangeOps = append(w.rangeOps, ">=")
+		w.args = append(w.args, from)
+	}
+	if !to.IsZero() {
+		w.rangeOps = append(w.rangeOps, "<")
+		w.args = append(w.args, to)
+	}
+
+	if len(w.args) != 0 {
+		q.where = append(q.where, w)
+	}
+}
+
+// AppendWhereIdent on the query. It ANDs a condition of the identifier
+// ident, quoted for the dialect, followed by clause, like "= ?" or
+// "BETWEEN ? AND ?", with args bound to its question marks. The identifier
+// is a column, optionally qualified with its table, made only of letters,
+// digits and underscores, it panics on anything else so that a dynamic
+// column name can't inject SQL.
+func AppendWhereIdent(q *Query, ident, clause string, args ...interface{}) {
+	if !rgxSafeIdent.MatchString(ident) {
+		panic(fmt.Sprintf("invalid identifier %q, only letters, digits, underscores and a dot after the table are allowed", ident))
+	}
+
+	q.where = append(q.where, where{opColumn: ident, operator: clause, args: args})
+}
+
+// arrayLenOps are the operators AppendWhereArrayLen accepts.
+var arrayLenOps = map[string]bool{
+	"=": true, "<>": true, "!=": true, "<": true, "<=": true, ">": true, ">=": true,
+}
+
+// AppendWhereArrayLen on the query. It ANDs a condition comparing the number
+// of elements of the array column to length with operator, which must be a
+// comparison like ">" or "<=", it panics otherwise. It is only supported on
+// postgres.
+func AppendWhereArrayLen(q *Query, column, operator string, length int) {
+	if !arrayLenOps[operator] {
+		panic(fmt.Sprintf("invalid operator %q for an array length", operator))
+	}
+
+	q.where = append(q.where, where{lenColumn: column, operator: operator + " ?", args: []interface{}{length}})
+}
+
+// conditionOps are the operators AppendWhereCondition accepts.
+var conditionOps = map[string]bool{
+	"=": true, "<>": true, "!=": true, "<": true, "<=": true, ">": true, ">=": true,
+	"LIKE": true, "NOT LIKE": true, "ILIKE": true, "NOT ILIKE": true,
+}
+
+// AppendWhereCondition on the query. It ANDs the condition tree c, see
+// boil.Condition, which is compiled into a clause with each group of
+// conditions parenthesized and its values bound in the order they appear.
+// It panics on an operator that isn't a comparison or LIKE, and on a column
+// that isn't an identifier as accepted by AppendWhereIdent, so that the tree
+// can be built from the filters of a request.
+func AppendWhereCondition(q *Query, c boil.Condition) {
+	validateCondition(c)
+	q.where = append(q.where, where{condition: c})
+}
+
+// validateCondition panics on the first comparison of c with an invalid
+// column or operator.
+func validateCondition(c boil.Condition) {
+	switch c := c.(type) {
+	case boil.Comparison:
+		if !rgxSafeIdent.MatchString(c.Column) {
+			panic(fmt.Sprintf("invalid identifier %q in a condition", c.Column))
+		}
+		if !conditionOps[c.Operator] {
+			panic(fmt.Sprintf("invalid operator %q in a condition", c.Operator))
+		}
+	case boil.Conditions:
+		for _, child := range c.Conds {
+			validateCondition(child)
+		}
+	default:
+		panic(fmt.Sprintf("unknown condition %T", c))
+	}
+}
+
+// AppendWhereLikeAny on the query. It ANDs a condition matching column
+// against any of patterns with LIKE, ORed together as a single condition.
+// An empty patterns matches nothing.
+func AppendWhereLikeAny(q *Query, column string, patterns []string) {
+	if len(patterns) == 0 {
+		q.where = append(q.where, where{clause: "1=0"})
+		return
+	}
+
+	args := make([]interface{}, len(patterns))
+	for i, pattern := range patterns {
+		args[i] = pattern
+	}
+
+	q.where = append(q.where, where{likeColumn: column, args: args})
+}
+
+// tupleCompareOps are the operators AppendWhereTupleCompare accepts.
+var tupleCompareOps = map[string]bool{
+	">": true, "<": true, ">=": true, "<=": true,
+}
+
+// AppendWhereTupleCompare on the query. It ANDs a condition comparing the
+// row of columns to the row of values with operator, which must be one of
+// >, <, >= and <=, like keyset pagination does. It panics on any other
+// operator or when there isn't a value for every column.
+func AppendWhereTupleCompare(q *Query, columns []string, operator string, values []interface{}) {
+	if !tupleCompareOps[operator] {
+		panic(fmt.Sprintf("invalid operator %q for a tuple comparison", operator))
+	}
+	if len(columns) == 0 || len(columns) != len(values) {
+		panic(fmt.Sprintf("a tuple comparison needs a value for each of its %d columns, got %d", len(columns), len(values)))
+	}
+
+	q.where = append(q.where, where{tupleColumns: columns, operator: operator, args: values})
+}
+
+// AppendWhereEq on the query. It ANDs a condition for every column of eq in
+// sorted order: column = ? for a value, column IN (?, ...) for a slice, which
+// matches nothing when empty, and column IS NULL for a null value. Byte
+// slices and driver.Valuers like types.JSON are bound as single values.
+func AppendWhereEq(q *Query, eq map[string]interface{}) {
+	columns := make([]string, 0, len(eq))
+	for column := range eq {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	for _, column := range columns {
+		value := eq[column]
+		if _, ok := value.(driver.Valuer); ok {
+			AppendWhereOp(q, column, "=", value)
+			continue
+		}
+
+		val := reflect.ValueOf(value)
+		if (val.Kind() != reflect.Slice && val.Kind() != reflect.Array) || val.Type().Elem().Kind() == reflect.Uint8 {
+			AppendWhereOp(q, column, "=", value)
+			continue
+		}
+
+		if val.Len() == 0 {
+			q.where = append(q.where, where{clause: "1=0"})
+			continue
+		}
+
+		args := make([]interface{}, val.Len())
+		for i := range args {
+			args[i] = val.Index(i).Interface()
+		}
+		operator := fmt.Sprintf("IN (%s)", strings.TrimSuffix(strings.Repeat("?,", len(args)), ","))
+		q.where = append(q.where, where{opColumn: column, operator: operator, args: args})
+	}
+}
+
+// AppendWhereJSONContains on the query. It ANDs a condition matching a JSON
+// column containing value, written with the JSON containment of the
+// dialect. A types.JSON or []byte value is bound as is, anything else is
+// marshaled to JSON first. It panics if value can't be marshaled.
+func AppendWhereJSONContains(q *Query, column string, value interface{}) {
+	var doc []byte
+	switch v := value.(type) {
+	case types.JSON:
+		doc = v
+	case []byte:
+		doc = v
+	default:
+		var err error
+		if doc, err = json.Marshal(value); err != nil {
+			panic(errors.Wrap(err, "unable to marshal JSON containment value"))
+		}
+	}
+
+	// Bound as a string, MySQL won't create a JSON value from binary
+	q.where = append(q.where, where{jsonColumn: column, args: []interface{}{string(doc)}})
+}
+
+// AppendWhereInQuery on the query. It ANDs a condition matching column
+// against the rows selected by subquery: column IN (SELECT ...). The
+// subquery is built with the dialect of the query and its args are bound
+// in place. When it has no select columns it selects its key columns.
+func AppendWhereInQuery(q *Query, column string, subquery *Query) {
+	q.where = append(q.where, where{inColumn: column, inQuery: subquery})
+}
+
+// isNullValue reports whether value is nil, a nil pointer or a
+// driver.Valuer holding NULL, like an invalid null.String.
+func isNullValue(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+
+	val := reflect.ValueOf(value)
+	switch val.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface:
+		if val.IsNil() {
+			return true
+		}
+	}
+
+	if valuer, ok := value.(driver.Valuer); ok {
+		v, err := valuer.Value()
+		return err == nil && v == nil
+	}
+
+	return false
+}
+
 // AppendIn on the query.
 func AppendIn(q *Query, clause string, args ...interface{}) {
 	q.in = append(q.in, in{clause: clause, args: args})
@@ -281,7 +1104,61 @@ func AppendGroupBy(q *Query, clause string) {
 	q.groupBy = append(q.groupBy, clause)
 }
 
+// AppendRollup on the query, grouping by ROLLUP(columns).
+func AppendRollup(q *Query, columns ...string) {
+	q.grouping = append(q.grouping, grouping{kind: "ROLLUP", sets: [][]string{columns}})
+}
+
+// AppendCube on the query, grouping by CUBE(columns).
+func AppendCube(q *Query, columns ...string) {
+	q.grouping = append(q.grouping, grouping{kind: "CUBE", sets: [][]string{columns}})
+}
+
+// AppendGroupingSets on the query, grouping by GROUPING SETS of sets.
+// An empty set groups all rows together.
+func AppendGroupingSets(q *Query, sets ...[]string) {
+	q.grouping = append(q.grouping, grouping{kind: "GROUPING SETS", sets: sets})
+}
+
 // AppendOrderBy on the query.
 func AppendOrderBy(q *Query, clause string) {
 	q.orderBy = append(q.orderBy, clause)
 }
+
+// SetNullsOrder on the query. It sorts null values where order says instead
+// of where boil.SetNullsOrder does, boil.NullsDefault leaves them where the
+// database sorts them. It panics on any other order.
+func SetNullsOrder(q *Query, order string) {
+	if order != boil.NullsDefault && order != boil.NullsFirst && order != boil.NullsLast {
+		panic(fmt.Sprintf("invalid nulls order %q", order))
+	}
+	q.nullsOrder = order
+	q.nullsOrderSet = true
+}
+
+// orderByRandom stands in for the dialect's random function in orderBy
+// until the query is built.
+const orderByRandom = "\x00random"
+
+// AppendOrderByRandom on the query. The random function is chosen by the
+// dialect when the query is built.
+func AppendOrderByRandom(q *Query) {
+	q.orderBy = append(q.orderBy, orderByRandom)
+}
+
+// orderByFieldPrefix followed by an index into orderByFields stands in for
+// a value list ordering in orderBy until the query is built.
+const orderByFieldPrefix = "\x00field"
+
+// AppendOrderByField on the query, ordering rows by the position of the
+// value of column in values. The values are bound, and the ordering is
+// written for the dialect when the query is built. It panics without
+// values.
+func AppendOrderByField(q *Query, column string, values ...interface{}) {
+	if len(values) == 0 {
+		panic(fmt.Sprintf("ordering by the position of %s needs at least one value", column))
+	}
+
+	q.orderBy = append(q.orderBy, fmt.Sprintf("%s%d", orderByFieldPrefix, len(q.orderByFields)))
+	q.orderByFields = append(q.orderByFields, orderByField{column: column, values: values})
+}
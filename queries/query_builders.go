@@ -5,17 +5,58 @@ import (
 	"fmt"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 
+	"github.com/pkg/errors"
+	"github.com/volatiletech/sqlboiler/boil"
 	"github.com/volatiletech/sqlboiler/strmangle"
 )
 
 var (
 	rgxIdentifier = regexp.MustCompile(`^(?i)"?[a-z_][_a-z0-9]*"?(?:\."?[_a-z][_a-z0-9]*"?)*$`)
 	rgxInClause   = regexp.MustCompile(`^(?i)(.*[\s|\)|\?])IN([\s|\(|\?].*)$`)
+	rgxNullsOrder = regexp.MustCompile(`(?i)\sNULLS\s+(?:FIRST|LAST)$`)
+	rgxAlias      = regexp.MustCompile(`(?i)\sas\s+("[^"]+"|` + "`[^`]+`" + `|\[[^\]]+\]|[a-z_][a-z0-9_]*)$`)
+	rgxExcluded   = regexp.MustCompile(`(?i)\bEXCLUDED\.("[^"]+"|` + "`[^`]+`" + `|[a-z_][a-z0-9_]*)`)
+	rgxSafeIdent  = regexp.MustCompile(`^(?i)[a-z_][a-z0-9_]*(?:\.[a-z_][a-z0-9_]*)?$`)
 )
 
-func buildQuery(q *Query) (string, []interface{}) {
+// queryError is panicked by the builders when the clauses of a query don't
+// make a valid statement, and recovered by buildQuery to return its error.
+type queryError struct {
+	err error
+}
+
+// buildQuery builds the statement of q and its args, and returns an error
+// when the clauses of q don't make a valid statement. A select is bounded
+// by the limits of boil.SetQueryLimits.
+func buildQuery(q *Query) (string, []interface{}, error) {
+	return buildLimited(q, true)
+}
+
+// buildLimited is buildQuery with the limits of boil.SetQueryLimits only
+// applied when limited is true, they are meant for the select whose rows
+// are returned, not for the selects nested in another statement.
+func buildLimited(q *Query, limited bool) (qs string, args []interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			qe, ok := r.(queryError)
+			if !ok {
+				panic(r)
+			}
+			qs, args, err = "", nil, qe.err
+		}
+	}()
+
+	qs, args = build(q, limited)
+	return qs, args, nil
+}
+
+// build builds the statement of q and its args like buildLimited, but panics
+// with a queryError instead of returning it, so that the errors of the
+// queries built inside another one reach the buildQuery of the outer one.
+func build(q *Query, limited bool) (string, []interface{}) {
 	var buf *bytes.Buffer
 	var args []interface{}
 
@@ -26,39 +67,112 @@ func buildQuery(q *Query) (string, []interface{}) {
 		buf, args = buildDeleteQuery(q)
 	case len(q.update) > 0:
 		buf, args = buildUpdateQuery(q)
+	case q.insertSource != nil:
+		buf, args = buildInsertSelectQuery(q)
 	default:
-		buf, args = buildSelectQuery(q)
+		buf, args = buildSelectQuery(q, limited)
 	}
 
 	defer strmangle.PutBuffer(buf)
 
 	// Cache the generated query for query object re-use
 	bufStr := buf.String()
+	if n := countPlaceholders(bufStr, q.dialect.IndexPlaceholders); n != len(args) {
+		panic(queryError{errors.Errorf("query has %d placeholders but %d arguments, check the arguments of its clauses: %s", n, len(args), bufStr)})
+	}
 	q.rawSQL.sql = bufStr
 	q.rawSQL.args = args
 
 	return bufStr, args
 }
 
-func buildSelectQuery(q *Query) (*bytes.Buffer, []interface{}) {
+func buildSelectQuery(q *Query, limited bool) (*bytes.Buffer, []interface{}) {
+	if q.limit < 0 || q.offset < 0 {
+		panic(queryError{errors.Errorf("limit and offset must not be negative, got LIMIT %d OFFSET %d", q.limit, q.offset)})
+	}
+	if limit := selectLimit(q); limited && limit != q.limit {
+		bounded := *q
+		bounded.limit = limit
+		q = &bounded
+	}
+	keys := distinctKeys(q)
+	if len(keys) != 0 && !q.count && q.dialect.UseDistinctOn {
+		deduped := *q
+		deduped.distinctOn = keys
+		q = &deduped
+	}
+
 	buf := strmangle.GetBuffer()
 	var args []interface{}
 
+	if len(q.with) != 0 {
+		withBuf := strmangle.GetBuffer()
+		withBuf.WriteString("WITH ")
+		for _, w := range q.with {
+			if w.query == nil {
+				withBuf.WriteString("RECURSIVE ")
+				break
+			}
+		}
+		for i, w := range q.with {
+			if i > 0 {
+				withBuf.WriteString(", ")
+			}
+			if w.query != nil {
+				sel, subArgs := withQueryClause(q.dialect, w.query)
+				fmt.Fprintf(withBuf, "%s AS (%s)", w.name, sel)
+				args = append(args, subArgs...)
+				continue
+			}
+			fmt.Fprintf(withBuf, "%s AS (%s UNION ALL %s)", w.name, w.anchor, w.recursive)
+			args = append(args, w.args...)
+		}
+		withBuf.WriteByte(' ')
+
+		var resp string
+		if q.dialect.IndexPlaceholders {
+			resp, _ = convertQuestionMarks(withBuf.String(), 1)
+		} else {
+			resp = withBuf.String()
+		}
+		buf.WriteString(resp)
+		strmangle.PutBuffer(withBuf)
+	}
+
 	buf.WriteString("SELECT ")
 
+	if q.distinct && len(q.distinctOn) == 0 && !q.count {
+		buf.WriteString("DISTINCT ")
+	}
+
 	if q.dialect.UseTopClause {
 		if q.limit != 0 && q.offset == 0 {
 			fmt.Fprintf(buf, " TOP (%d) ", q.limit)
 		}
 	}
 
+	if len(q.distinctOn) != 0 {
+		fmt.Fprintf(buf, "DISTINCT ON (%s) ", strings.Join(quoteOrderColumns(q, q.distinctOn), ", "))
+	}
+
 	if q.count {
 		buf.WriteString("COUNT(")
 	}
 
 	hasSelectCols := len(q.selectCols) != 0
 	hasJoins := len(q.joins) != 0
-	if hasJoins && hasSelectCols && !q.count {
+	if q.count && q.distinct && (hasSelectCols || len(keys) != 0) {
+		cols := keys
+		if hasSelectCols {
+			cols = q.selectCols
+		}
+		cols = strmangle.IdentQuoteSlice(q.dialect.LQ, q.dialect.RQ, cols)
+		if len(cols) > 1 && q.dialect.UseRowValues {
+			fmt.Fprintf(buf, "DISTINCT (%s)", strings.Join(cols, ", "))
+		} else {
+			fmt.Fprintf(buf, "DISTINCT %s", strings.Join(cols, ", "))
+		}
+	} else if hasJoins && hasSelectCols && !q.count {
 		selectColsWithAs := writeAsStatements(q)
 		// Don't identQuoteSlice - writeAsStatements does this
 		buf.WriteString(strings.Join(selectColsWithAs, ", "))
@@ -76,17 +190,47 @@ func buildSelectQuery(q *Query) (*bytes.Buffer, []interface{}) {
 		buf.WriteByte(')')
 	}
 
-	fmt.Fprintf(buf, " FROM %s", strings.Join(strmangle.IdentQuoteSlice(q.dialect.LQ, q.dialect.RQ, q.from), ", "))
+	from := fromClauses(q)
+	if len(q.sampleMethod) != 0 && len(from) != 0 {
+		if !q.dialect.UseTableSample {
+			panic(queryError{errors.New("TABLESAMPLE is only supported on postgres")})
+		}
+		from[0] = fmt.Sprintf("%s TABLESAMPLE %s (%s)", from[0], q.sampleMethod, strconv.FormatFloat(q.samplePercent, 'g', -1, 64))
+	}
+	if len(q.indexHints) != 0 {
+		if !q.dialect.UseIndexHints {
+			panic(queryError{errors.New("index hints are only supported on mysql")})
+		}
+		for _, h := range q.indexHints {
+			i := indexHintFrom(q, h.table)
+			from[i] = fmt.Sprintf("%s %s", from[i], h.hint)
+		}
+	}
+	fmt.Fprintf(buf, " FROM %s", strings.Join(from, ", "))
 
 	if len(q.joins) > 0 {
 		argsLen := len(args)
 		joinBuf := strmangle.GetBuffer()
 		for _, j := range q.joins {
-			if j.kind != JoinInner {
-				panic("only inner joins are supported")
+			switch j.kind {
+			case JoinInner:
+				fmt.Fprintf(joinBuf, " INNER JOIN %s", j.clause)
+			case JoinOuterLeft:
+				fmt.Fprintf(joinBuf, " LEFT JOIN %s", j.clause)
+			default:
+				panic(queryError{errors.New("only inner and left outer joins are supported")})
 			}
-			fmt.Fprintf(joinBuf, " INNER JOIN %s", j.clause)
 			args = append(args, j.args...)
+
+			for i, on := range j.on {
+				if i == 0 && j.targetOnly {
+					joinBuf.WriteString(" ON ")
+				} else {
+					joinBuf.WriteString(" AND ")
+				}
+				fmt.Fprintf(joinBuf, "(%s)", on.clause)
+				args = append(args, on.args...)
+			}
 		}
 		var resp string
 		if q.dialect.IndexPlaceholders {
@@ -121,7 +265,7 @@ func buildDeleteQuery(q *Query) (*bytes.Buffer, []interface{}) {
 	buf := strmangle.GetBuffer()
 
 	buf.WriteString("DELETE FROM ")
-	buf.WriteString(strings.Join(strmangle.IdentQuoteSlice(q.dialect.LQ, q.dialect.RQ, q.from), ", "))
+	buf.WriteString(strings.Join(fromClauses(q), ", "))
 
 	where, whereArgs := whereClause(q, 1)
 	if len(whereArgs) != 0 {
@@ -146,7 +290,7 @@ func buildUpdateQuery(q *Query) (*bytes.Buffer, []interface{}) {
 	buf := strmangle.GetBuffer()
 
 	buf.WriteString("UPDATE ")
-	buf.WriteString(strings.Join(strmangle.IdentQuoteSlice(q.dialect.LQ, q.dialect.RQ, q.from), ", "))
+	buf.WriteString(strings.Join(fromClauses(q), ", "))
 
 	cols := make(sort.StringSlice, len(q.update))
 	var args []interface{}
@@ -189,8 +333,73 @@ func buildUpdateQuery(q *Query) (*bytes.Buffer, []interface{}) {
 	return buf, args
 }
 
+func buildInsertSelectQuery(q *Query) (*bytes.Buffer, []interface{}) {
+	if q.insertSource.delete || len(q.insertSource.update) != 0 || q.insertSource.insertSource != nil {
+		panic(queryError{errors.New("the source of an insert select must be a select query")})
+	}
+
+	source := *q.insertSource
+	source.rawSQL = rawSQL{}
+	if source.dialect == nil {
+		source.dialect = q.dialect
+	}
+
+	buf := strmangle.GetBuffer()
+
+	buf.WriteString("INSERT INTO ")
+	buf.WriteString(strings.Join(fromClauses(q), ", "))
+	if len(q.insertCols) != 0 {
+		fmt.Fprintf(buf, " (%s)", strings.Join(strmangle.IdentQuoteSlice(q.dialect.LQ, q.dialect.RQ, q.insertCols), ", "))
+	}
+
+	// The insert has no args of its own, so the placeholders of the
+	// source are already numbered right
+	sel, args := build(&source, false)
+	buf.WriteByte(' ')
+	buf.WriteString(strings.TrimSuffix(strings.TrimSpace(sel), ";"))
+	buf.WriteByte(';')
+
+	return buf, args
+}
+
+// buildCreateTableAsQuery builds a CREATE TABLE tableName AS statement
+// creating the table from the rows selected by q, and returns the args of q.
+func buildCreateTableAsQuery(q *Query, tableName string, temporary bool) (string, []interface{}, error) {
+	if q.delete || len(q.update) != 0 || q.insertSource != nil {
+		return "", nil, errors.New("create table as requires a select query")
+	}
+
+	buf := strmangle.GetBuffer()
+	defer strmangle.PutBuffer(buf)
+
+	buf.WriteString("CREATE ")
+	if temporary {
+		buf.WriteString("TEMPORARY ")
+	}
+	fmt.Fprintf(buf, "TABLE %s AS ", strmangle.IdentQuote(q.dialect.LQ, q.dialect.RQ, tableName))
+
+	// The statement has no args of its own, so the placeholders of the
+	// select are already numbered right. All of its rows go in the table,
+	// so it isn't bounded by the query limits.
+	sel := *q
+	sel.rawSQL = rawSQL{}
+	selSQL, args, err := buildLimited(&sel, false)
+	if err != nil {
+		return "", nil, err
+	}
+	buf.WriteString(strings.TrimSuffix(strings.TrimSpace(selSQL), ";"))
+	buf.WriteByte(';')
+
+	return buf.String(), args, nil
+}
+
 // BuildUpsertQueryMySQL builds a SQL statement string using the upsertData provided.
 func BuildUpsertQueryMySQL(dia Dialect, tableName string, update, whitelist []string) string {
+	return buildUpsertQueryMySQL(dia, tableName, update, whitelist, nil, nil)
+}
+
+func buildUpsertQueryMySQL(dia Dialect, tableName string, update, whitelist []string, defaults [][]string, exprs map[string]string) string {
+	values := upsertValues(dia, whitelist, defaults)
 	whitelist = strmangle.IdentQuoteSlice(dia.LQ, dia.RQ, whitelist)
 
 	buf := strmangle.GetBuffer()
@@ -204,20 +413,20 @@ func BuildUpsertQueryMySQL(dia Dialect, tableName string, update, whitelist []st
 	if len(update) == 0 {
 		fmt.Fprintf(
 			buf,
-			"INSERT IGNORE INTO %s (%s) VALUES (%s)",
+			"INSERT IGNORE INTO %s (%s) VALUES %s",
 			tableName,
 			columns,
-			strmangle.Placeholders(dia.IndexPlaceholders, len(whitelist), 1, 1),
+			values,
 		)
 		return buf.String()
 	}
 
 	fmt.Fprintf(
 		buf,
-		"INSERT INTO %s (%s) VALUES (%s) ON DUPLICATE KEY UPDATE ",
+		"INSERT INTO %s (%s) VALUES %s ON DUPLICATE KEY UPDATE ",
 		tableName,
 		columns,
-		strmangle.Placeholders(dia.IndexPlaceholders, len(whitelist), 1, 1),
+		values,
 	)
 
 	for i, v := range update {
@@ -226,7 +435,12 @@ func BuildUpsertQueryMySQL(dia Dialect, tableName string, update, whitelist []st
 		}
 		quoted := strmangle.IdentQuote(dia.LQ, dia.RQ, v)
 		buf.WriteString(quoted)
-		buf.WriteString(" = VALUES(")
+		buf.WriteString(" = ")
+		if expr, ok := exprs[v]; ok {
+			buf.WriteString(mysqlUpsertExpr(dia, expr))
+			continue
+		}
+		buf.WriteString("VALUES(")
 		buf.WriteString(quoted)
 		buf.WriteByte(')')
 	}
@@ -234,8 +448,23 @@ func BuildUpsertQueryMySQL(dia Dialect, tableName string, update, whitelist []st
 	return buf.String()
 }
 
+// mysqlUpsertExpr rewrites the EXCLUDED.column references of a Postgres
+// style update expression of an upsert to VALUES(column), which is how
+// MySQL refers to the row proposed for insertion.
+func mysqlUpsertExpr(dia Dialect, expr string) string {
+	return rgxExcluded.ReplaceAllStringFunc(expr, func(ref string) string {
+		column := strings.Trim(ref[len("EXCLUDED."):], "\"`")
+		return fmt.Sprintf("VALUES(%s)", strmangle.IdentQuote(dia.LQ, dia.RQ, column))
+	})
+}
+
 // BuildUpsertQueryPostgres builds a SQL statement string using the upsertData provided.
 func BuildUpsertQueryPostgres(dia Dialect, tableName string, updateOnConflict bool, ret, update, conflict, whitelist []string) string {
+	return buildUpsertQueryPostgres(dia, tableName, updateOnConflict, ret, update, conflict, whitelist, "", false, nil, nil, nil)
+}
+
+func buildUpsertQueryPostgres(dia Dialect, tableName string, updateOnConflict bool, ret, update, conflict, whitelist []string, constraint string, inserted bool, changed []string, defaults [][]string, exprs map[string]string) string {
+	values := upsertValues(dia, whitelist, defaults)
 	conflict = strmangle.IdentQuoteSlice(dia.LQ, dia.RQ, conflict)
 	whitelist = strmangle.IdentQuoteSlice(dia.LQ, dia.RQ, whitelist)
 	ret = strmangle.IdentQuoteSlice(dia.LQ, dia.RQ, ret)
@@ -245,9 +474,7 @@ func BuildUpsertQueryPostgres(dia Dialect, tableName string, updateOnConflict bo
 
 	columns := "DEFAULT VALUES"
 	if len(whitelist) != 0 {
-		columns = fmt.Sprintf("(%s) VALUES (%s)",
-			strings.Join(whitelist, ", "),
-			strmangle.Placeholders(dia.IndexPlaceholders, len(whitelist), 1, 1))
+		columns = fmt.Sprintf("(%s) VALUES %s", strings.Join(whitelist, ", "), values)
 	}
 
 	fmt.Fprintf(
@@ -257,12 +484,19 @@ func BuildUpsertQueryPostgres(dia Dialect, tableName string, updateOnConflict bo
 		columns,
 	)
 
+	if len(constraint) != 0 {
+		fmt.Fprintf(buf, "ON CONSTRAINT %s ", strmangle.IdentQuote(dia.LQ, dia.RQ, constraint))
+	}
+
 	if !updateOnConflict || len(update) == 0 {
 		buf.WriteString("DO NOTHING")
 	} else {
-		buf.WriteByte('(')
-		buf.WriteString(strings.Join(conflict, ", "))
-		buf.WriteString(") DO UPDATE SET ")
+		if len(constraint) == 0 {
+			buf.WriteByte('(')
+			buf.WriteString(strings.Join(conflict, ", "))
+			buf.WriteString(") ")
+		}
+		buf.WriteString("DO UPDATE SET ")
 
 		for i, v := range update {
 			if i != 0 {
@@ -270,9 +504,31 @@ func BuildUpsertQueryPostgres(dia Dialect, tableName string, updateOnConflict bo
 			}
 			quoted := strmangle.IdentQuote(dia.LQ, dia.RQ, v)
 			buf.WriteString(quoted)
+			if expr, ok := exprs[v]; ok {
+				buf.WriteString(" = ")
+				buf.WriteString(expr)
+				continue
+			}
 			buf.WriteString(" = EXCLUDED.")
 			buf.WriteString(quoted)
 		}
+
+		if len(changed) != 0 {
+			// The existing row is referred to by the table name
+			buf.WriteString(" WHERE ")
+			for i, v := range changed {
+				if i != 0 {
+					buf.WriteString(" OR ")
+				}
+				quoted := strmangle.IdentQuote(dia.LQ, dia.RQ, v)
+				fmt.Fprintf(buf, "%s.%s IS DISTINCT FROM EXCLUDED.%s", tableName, quoted, quoted)
+			}
+		}
+	}
+
+	if inserted {
+		// xmax is only zero for a row version created by an insert
+		ret = append(ret, "(xmax = 0) AS inserted")
 	}
 
 	if len(ret) != 0 {
@@ -283,8 +539,36 @@ func BuildUpsertQueryPostgres(dia Dialect, tableName string, updateOnConflict bo
 	return buf.String()
 }
 
+// upsertValues returns the VALUES rows of an upsert of the whitelist columns.
+// A nil defaults is a single row of placeholders, otherwise there is a row
+// for every element of defaults with its columns written as DEFAULT, and the
+// placeholders are numbered across all of the rows.
+func upsertValues(dia Dialect, whitelist []string, defaults [][]string) string {
+	if defaults == nil {
+		return fmt.Sprintf("(%s)", strmangle.Placeholders(dia.IndexPlaceholders, len(whitelist), 1, 1))
+	}
+
+	buf := strmangle.GetBuffer()
+	defer strmangle.PutBuffer(buf)
+
+	start := 1
+	for i, row := range defaults {
+		if i != 0 {
+			buf.WriteByte(',')
+		}
+		fmt.Fprintf(buf, "(%s)", strmangle.PlaceholdersWithDefaults(dia.IndexPlaceholders, whitelist, row, start))
+		start += len(strmangle.SetComplement(whitelist, row))
+	}
+
+	return buf.String()
+}
+
 // BuildUpsertQueryMSSQL builds a SQL statement string using the upsertData provided.
 func BuildUpsertQueryMSSQL(dia Dialect, tableName string, primary, update, insert []string, output []string) string {
+	return buildUpsertQueryMSSQL(dia, tableName, primary, update, insert, output, false)
+}
+
+func buildUpsertQueryMSSQL(dia Dialect, tableName string, primary, update, insert []string, output []string, action bool) string {
 	insert = strmangle.IdentQuoteSlice(dia.LQ, dia.RQ, insert)
 
 	buf := strmangle.GetBuffer()
@@ -317,9 +601,14 @@ func BuildUpsertQueryMSSQL(dia Dialect, tableName string, primary, update, inser
 		strings.Join(insert, ", "),
 		strmangle.Placeholders(dia.IndexPlaceholders, len(insert), startIndex, 1))
 
-	if len(output) > 0 {
+	switch {
+	case len(output) > 0 && action:
+		fmt.Fprintf(buf, "\nOUTPUT INSERTED.[%s],$action;", strings.Join(output, "],INSERTED.["))
+	case len(output) > 0:
 		fmt.Fprintf(buf, "\nOUTPUT INSERTED.[%s];", strings.Join(output, "],INSERTED.["))
-	} else {
+	case action:
+		fmt.Fprint(buf, "\nOUTPUT $action;")
+	default:
 		fmt.Fprint(buf, ";")
 	}
 
@@ -327,8 +616,8 @@ func BuildUpsertQueryMSSQL(dia Dialect, tableName string, primary, update, inser
 }
 
 func writeModifiers(q *Query, buf *bytes.Buffer, args *[]interface{}) {
-	if len(q.groupBy) != 0 {
-		fmt.Fprintf(buf, " GROUP BY %s", strings.Join(q.groupBy, ", "))
+	if groupBy := groupByClause(q); len(groupBy) != 0 {
+		fmt.Fprintf(buf, " GROUP BY %s", groupBy)
 	}
 
 	if len(q.having) != 0 {
@@ -352,9 +641,22 @@ func writeModifiers(q *Query, buf *bytes.Buffer, args *[]interface{}) {
 		strmangle.PutBuffer(havingBuf)
 	}
 
-	if len(q.orderBy) != 0 {
+	if orderBy := orderByClauses(q); len(orderBy) != 0 {
 		buf.WriteString(" ORDER BY ")
-		buf.WriteString(strings.Join(q.orderBy, ", "))
+		for i, clause := range orderBy {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			if clause != orderByRandom && !strings.HasPrefix(clause, orderByFieldPrefix) {
+				clause = nullsOrderClause(q, clause)
+			}
+			clause, orderArgs := orderByClause(q, clause)
+			if len(orderArgs) != 0 && q.dialect.IndexPlaceholders {
+				clause, _ = convertQuestionMarks(clause, len(*args)+1)
+			}
+			*args = append(*args, orderArgs...)
+			buf.WriteString(clause)
+		}
 	}
 
 	if !q.dialect.UseTopClause {
@@ -389,14 +691,391 @@ func writeModifiers(q *Query, buf *bytes.Buffer, args *[]interface{}) {
 		}
 	}
 
-	if len(q.forlock) != 0 {
+	if q.forShare {
+		if len(q.dialect.ShareLock) == 0 {
+			panic(queryError{errors.New("shared row locks are only supported on postgres and mysql")})
+		}
+		if q.forNoWait && q.dialect.ShareLock != "FOR SHARE" {
+			panic(queryError{errors.New("shared row locks with NOWAIT are only supported on postgres")})
+		}
+		fmt.Fprintf(buf, " %s", q.dialect.ShareLock)
+		if q.forNoWait {
+			buf.WriteString(" NOWAIT")
+		}
+	} else if len(q.forlock) != 0 {
 		fmt.Fprintf(buf, " FOR %s", q.forlock)
 	}
 }
 
+// nullsOrderClause returns the order by clause with NULLS FIRST or NULLS
+// LAST added to each of its expressions that has neither, as set by
+// SetNullsOrder or boil.SetNullsOrder. It is returned as is without nulls
+// order support.
+func nullsOrderClause(q *Query, clause string) string {
+	order := boil.GetNullsOrder()
+	if q.nullsOrderSet {
+		order = q.nullsOrder
+	}
+	if len(order) == 0 || !q.dialect.UseNullsOrder {
+		return clause
+	}
+
+	nulls := " NULLS " + strings.ToUpper(order)
+	exprs := splitOrderBy(clause)
+	for i, expr := range exprs {
+		expr = strings.TrimSpace(expr)
+		if !rgxNullsOrder.MatchString(expr) {
+			expr += nulls
+		}
+		exprs[i] = expr
+	}
+
+	return strings.Join(exprs, ", ")
+}
+
+// splitOrderBy splits an order by clause into its expressions, on the commas
+// outside of parentheses and quotes.
+func splitOrderBy(clause string) []string {
+	var exprs []string
+
+	depth := 0
+	quoted := false
+	start := 0
+	for i := 0; i < len(clause); i++ {
+		switch {
+		case clause[i] == '\'':
+			quoted = !quoted
+		case quoted:
+		case clause[i] == '(':
+			depth++
+		case clause[i] == ')':
+			depth--
+		case clause[i] == ',' && depth == 0:
+			exprs = append(exprs, clause[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(exprs, clause[start:])
+}
+
+// selectLimit returns the limit of the select query q, bounded by the
+// limits of boil.SetQueryLimits. Counts are left alone, a limit would
+// only apply to their single row.
+func selectLimit(q *Query) int {
+	if q.count {
+		return q.limit
+	}
+
+	defaultLimit, maxLimit := boil.GetQueryLimits()
+
+	limit := q.limit
+	if limit == 0 {
+		limit = defaultLimit
+	}
+	if maxLimit != 0 && (limit == 0 || limit > maxLimit) {
+		limit = maxLimit
+	}
+
+	return limit
+}
+
+// groupByClause returns the group by clauses of q followed by its
+// groupings. Without grouping sets support, as on MySQL, a rollup is only
+// possible on its own since WITH ROLLUP rolls up the whole group by.
+func groupByClause(q *Query) string {
+	if !q.dialect.UseGroupingSets && len(q.grouping) != 0 {
+		if len(q.groupBy) != 0 || len(q.grouping) > 1 || q.grouping[0].kind != "ROLLUP" {
+			panic(queryError{errors.New("GROUPING SETS and CUBE are not supported, and ROLLUP must be the only group by")})
+		}
+		return strings.Join(q.grouping[0].sets[0], ", ") + " WITH ROLLUP"
+	}
+
+	clauses := make([]string, len(q.groupBy), len(q.groupBy)+len(q.grouping))
+	copy(clauses, q.groupBy)
+	for _, g := range q.grouping {
+		if g.kind != "GROUPING SETS" {
+			clauses = append(clauses, fmt.Sprintf("%s(%s)", g.kind, strings.Join(g.sets[0], ", ")))
+			continue
+		}
+
+		sets := make([]string, len(g.sets))
+		for i, set := range g.sets {
+			sets[i] = fmt.Sprintf("(%s)", strings.Join(set, ", "))
+		}
+		clauses = append(clauses, fmt.Sprintf("GROUPING SETS (%s)", strings.Join(sets, ", ")))
+	}
+
+	return strings.Join(clauses, ", ")
+}
+
+// orderByClauses returns the order by clauses of q. Postgres requires the
+// leftmost ORDER BY expressions of a DISTINCT ON query to be its DISTINCT ON
+// expressions, so they become the ordering when there is none, and a query
+// ordered by anything else fails to build with an explanation instead of
+// failing with a less helpful error from the database.
+func orderByClauses(q *Query) []string {
+	if len(q.distinctOn) == 0 {
+		return q.orderBy
+	}
+	if len(q.orderBy) == 0 {
+		return quoteOrderColumns(q, q.distinctOn)
+	}
+
+	var exprs []string
+	for _, clause := range q.orderBy {
+		if clause == orderByRandom || strings.HasPrefix(clause, orderByFieldPrefix) {
+			expr, _ := orderByClause(q, clause)
+			exprs = append(exprs, expr)
+			continue
+		}
+		exprs = append(exprs, strings.Split(clause, ",")...)
+	}
+
+	distinct := make(map[string]struct{}, len(q.distinctOn))
+	for _, col := range q.distinctOn {
+		distinct[orderExpression(col)] = struct{}{}
+	}
+
+	ok := len(exprs) >= len(distinct)
+	for i := 0; ok && i < len(distinct); i++ {
+		_, ok = distinct[orderExpression(exprs[i])]
+	}
+
+	if !ok {
+		panic(queryError{errors.Errorf(
+			"DISTINCT ON (%s) must be ordered by those columns first, add them to the start of ORDER BY %s",
+			strings.Join(q.distinctOn, ", "), strings.Join(exprs, ","),
+		)})
+	}
+
+	return q.orderBy
+}
+
+// orderExpression strips the direction, null ordering and identifier quotes
+// from an ORDER BY expression so that it can be compared to a column.
+func orderExpression(expr string) string {
+	toks := strings.Fields(strings.ToLower(expr))
+	for len(toks) > 1 {
+		switch toks[len(toks)-1] {
+		case "asc", "desc", "first", "last", "nulls":
+			toks = toks[:len(toks)-1]
+			continue
+		}
+		break
+	}
+
+	return strings.NewReplacer(`"`, "", "`", "", "[", "", "]", "").Replace(strings.Join(toks, " "))
+}
+
+// randomFunction returns the function dia orders rows randomly with.
+// orderByClause returns the order by clause of q written for its dialect,
+// with question marks for the arguments it returns.
+func orderByClause(q *Query, clause string) (string, []interface{}) {
+	if clause == orderByRandom {
+		return randomFunction(q.dialect), nil
+	}
+	if !strings.HasPrefix(clause, orderByFieldPrefix) {
+		return clause, nil
+	}
+
+	index, _ := strconv.Atoi(strings.TrimPrefix(clause, orderByFieldPrefix))
+	field := q.orderByFields[index]
+	column := field.column
+	if q.dialect != nil {
+		column = quoteOrderColumns(q, []string{column})[0]
+	}
+	return orderByFieldClause(q.dialect, column, len(field.values)), field.values
+}
+
+// orderByFieldClause returns the expression ordering rows by the position
+// of the value of the quoted column in a list of count values bound to
+// question marks, with the syntax of the dialect. The CASE expression puts
+// values missing from the list last, like array_position.
+func orderByFieldClause(dialect *Dialect, quoted string, count int) string {
+	syntax := ""
+	if dialect != nil {
+		syntax = dialect.OrderByField
+	}
+	marks := strings.TrimSuffix(strings.Repeat("?, ", count), ", ")
+
+	switch syntax {
+	case "field":
+		return fmt.Sprintf("FIELD(%s, %s)", quoted, marks)
+	case "array_position":
+		return fmt.Sprintf("array_position(ARRAY[%s], %s)", marks, quoted)
+	}
+
+	buf := strmangle.GetBuffer()
+	defer strmangle.PutBuffer(buf)
+
+	fmt.Fprintf(buf, "CASE %s", quoted)
+	for i := 0; i < count; i++ {
+		fmt.Fprintf(buf, " WHEN ? THEN %d", i)
+	}
+	fmt.Fprintf(buf, " ELSE %d END", count)
+
+	return buf.String()
+}
+
+// quoteOrderColumns quotes the columns of order by or distinct on
+// expressions. A column that is an alias of the select is a single
+// identifier, so it is quoted whole instead of being split on its dots
+// like a table qualified column.
+func quoteOrderColumns(q *Query, columns []string) []string {
+	aliases := selectAliases(q)
+
+	quoted := make([]string, len(columns))
+	for i, column := range columns {
+		if aliases[column] {
+			quoted[i] = quoteIdentifier(q.dialect, column)
+			continue
+		}
+		quoted[i] = strmangle.IdentQuote(q.dialect.LQ, q.dialect.RQ, column)
+	}
+
+	return quoted
+}
+
+// distinctKeys returns the primary key columns of the model table of a
+// distinct query that joins other tables, qualified with the table, so
+// that one row can be kept for each of its rows. It returns nil when the
+// query isn't distinct or the model table and its key aren't known.
+func distinctKeys(q *Query) []string {
+	if !q.distinct || len(q.distinctOn) != 0 || len(q.joins) == 0 || len(q.keyColumns) == 0 || len(q.tableFrom) == 0 {
+		return nil
+	}
+
+	table := q.tableFrom
+	if len(q.schema) != 0 && len(q.table) != 0 {
+		table = SchemaTable(q.dialect, q.schema, q.table)
+	}
+
+	keys := make([]string, len(q.keyColumns))
+	for i, column := range q.keyColumns {
+		keys[i] = table + "." + column
+	}
+
+	return keys
+}
+
+// selectAliases returns the aliases of the selected columns of q, the ones
+// given with AS and the ones writeAsStatements gives the columns of joined
+// tables, like "a.id" for a.id.
+func selectAliases(q *Query) map[string]bool {
+	aliases := make(map[string]bool)
+	for _, col := range q.selectCols {
+		if m := rgxAlias.FindStringSubmatch(col); m != nil {
+			alias := m[1]
+			if alias[0] == '"' || alias[0] == '`' || alias[0] == '[' {
+				alias = alias[1 : len(alias)-1]
+			}
+			aliases[alias] = true
+			continue
+		}
+
+		if len(q.joins) != 0 && !q.count && rgxIdentifier.MatchString(col) && strings.Contains(col, ".") {
+			aliases[strings.Replace(col, `"`, "", -1)] = true
+		}
+	}
+
+	return aliases
+}
+
+func randomFunction(dia *Dialect) string {
+	if dia == nil || len(dia.RandomFunction) == 0 {
+		return "RANDOM()"
+	}
+
+	return dia.RandomFunction
+}
+
+// fromClauses returns the quoted from clauses of q, the table of a from
+// ONLY clause is quoted after the keyword.
+func fromClauses(q *Query) []string {
+	from := make([]string, len(q.from))
+	for i, f := range schemaFrom(q) {
+		table, only := trimFromOnly(f)
+		if !only {
+			from[i] = strmangle.IdentQuote(q.dialect.LQ, q.dialect.RQ, f)
+			continue
+		}
+
+		if !q.dialect.UseFromOnly {
+			panic(queryError{errors.New("FROM ONLY is only supported on postgres")})
+		}
+		from[i] = fromOnlyPrefix + strmangle.IdentQuote(q.dialect.LQ, q.dialect.RQ, table)
+	}
+
+	return from
+}
+
+// indexHintFrom returns the index of the from clause of q that an index hint
+// on table is written after. Tables are matched by name or by alias, and an
+// empty table is the first from clause. It fails the build if there is no
+// such table.
+func indexHintFrom(q *Query, table string) int {
+	if len(q.from) == 0 {
+		panic(queryError{errors.New("index hints need a table to select from")})
+	}
+	if len(table) == 0 {
+		return 0
+	}
+
+	for i, f := range q.from {
+		f, _ = trimFromOnly(f)
+		toks := strings.Fields(f)
+		if len(toks) == 0 {
+			continue
+		}
+
+		name := strings.Trim(toks[0], "`\"[]")
+		alias := strings.Trim(toks[len(toks)-1], "`\"[]")
+		if name == table || (len(toks) > 1 && alias == table) {
+			return i
+		}
+	}
+
+	panic(queryError{errors.Errorf("cannot hint indexes of %s, it is not in the FROM clause", table)})
+}
+
+// schemaFrom returns the from clauses of q, with its model table qualified
+// with the schema of SetSchema if there is one.
+func schemaFrom(q *Query) []string {
+	if len(q.schema) == 0 || len(q.table) == 0 {
+		return q.from
+	}
+
+	from := make([]string, len(q.from))
+	for i, f := range q.from {
+		table, only := trimFromOnly(f)
+		if table != q.tableFrom {
+			from[i] = f
+			continue
+		}
+
+		from[i] = SchemaTable(q.dialect, q.schema, q.table)
+		if only {
+			from[i] = fromOnlyPrefix + from[i]
+		}
+	}
+
+	return from
+}
+
+// trimFromOnly returns from without its ONLY keyword, and whether it had one.
+func trimFromOnly(from string) (string, bool) {
+	if len(from) <= len(fromOnlyPrefix) || !strings.EqualFold(from[:len(fromOnlyPrefix)], fromOnlyPrefix) {
+		return from, false
+	}
+
+	return strings.TrimSpace(from[len(fromOnlyPrefix):]), true
+}
+
 func writeStars(q *Query) []string {
 	cols := make([]string, len(q.from))
-	for i, f := range q.from {
+	for i, f := range schemaFrom(q) {
+		f, _ = trimFromOnly(f)
 		toks := strings.Split(f, " ")
 		if len(toks) == 1 {
 			cols[i] = fmt.Sprintf(`%s.*`, strmangle.IdentQuote(q.dialect.LQ, q.dialect.RQ, toks[0]))
@@ -443,8 +1122,13 @@ func writeAsStatements(q *Query) []string {
 }
 
 // whereClause parses a where slice and converts it into a
-// single WHERE clause like:
-// WHERE (a=$1) AND (b=$2)
+// single WHERE clause, like:
+// WHERE (a=$1) OR (b=$2) AND (c=$3).
+// Each clause is parenthesized on its own and joined with its separator
+// in the order it was added, so SQL precedence applies across them:
+// the example is evaluated as (a=$1) OR ((b=$2) AND (c=$3)).
+// Wrapping every clause means a top-level OR inside one clause, like
+// Where("a=? OR b=?"), can never leak into the clauses it is ANDed with.
 //
 // startAt specifies what number placeholders start at
 func whereClause(q *Query, startAt int) (string, []interface{}) {
@@ -466,8 +1150,69 @@ func whereClause(q *Query, startAt int) (string, []interface{}) {
 			}
 		}
 
-		buf.WriteString(fmt.Sprintf("(%s)", where.clause))
-		args = append(args, where.args...)
+		clause := where.clause
+		if len(where.fullText) != 0 {
+			clause = fullTextClause(q.dialect, where.fullText)
+		}
+		if len(where.anyColumn) != 0 {
+			if !q.dialect.UseArrayParams {
+				panic(queryError{errors.New("= ANY array parameters are only supported on postgres")})
+			}
+			clause = fmt.Sprintf("%s = ANY(?)", strmangle.IdentQuote(q.dialect.LQ, q.dialect.RQ, where.anyColumn))
+		}
+		if len(where.opColumn) != 0 {
+			clause = fmt.Sprintf("%s %s", strmangle.IdentQuote(q.dialect.LQ, q.dialect.RQ, where.opColumn), where.operator)
+		}
+		if len(where.lenColumn) != 0 {
+			if !q.dialect.UseArrayParams {
+				panic(queryError{errors.New("array length conditions are only supported on postgres")})
+			}
+			clause = fmt.Sprintf("cardinality(%s) %s", strmangle.IdentQuote(q.dialect.LQ, q.dialect.RQ, where.lenColumn), where.operator)
+		}
+		if len(where.jsonColumn) != 0 {
+			clause = jsonContainsClause(q.dialect, where.jsonColumn)
+		}
+		if len(where.distanceColumn) != 0 {
+			if !q.dialect.UsePostGIS {
+				panic(queryError{errors.New("distance conditions are only supported on postgres with PostGIS")})
+			}
+			clause = fmt.Sprintf("ST_DWithin(%s, ST_MakePoint(?, ?)::geography, ?)", strmangle.IdentQuote(q.dialect.LQ, q.dialect.RQ, where.distanceColumn))
+		}
+		if len(where.likeColumn) != 0 {
+			like := strmangle.IdentQuote(q.dialect.LQ, q.dialect.RQ, where.likeColumn) + " LIKE ?"
+			clause = strings.TrimSuffix(strings.Repeat(like+" OR ", len(where.args)), " OR ")
+		}
+		whereArgs := where.args
+		if len(where.tupleColumns) != 0 {
+			clause, whereArgs = tupleCompareClause(q.dialect, where.tupleColumns, where.operator, where.args)
+		}
+		if len(where.rangeColumn) != 0 {
+			quoted := strmangle.IdentQuote(q.dialect.LQ, q.dialect.RQ, where.rangeColumn)
+			comparisons := make([]string, len(where.rangeOps))
+			for i, op := range where.rangeOps {
+				comparisons[i] = fmt.Sprintf("%s %s ?", quoted, op)
+			}
+			clause = strings.Join(comparisons, " AND ")
+		}
+		if len(where.distinctColumn) != 0 {
+			quoted := strmangle.IdentQuote(q.dialect.LQ, q.dialect.RQ, where.distinctColumn)
+			if q.dialect.UseNullSafeEqual {
+				clause = fmt.Sprintf("NOT (%s <=> ?)", quoted)
+			} else {
+				clause = fmt.Sprintf("%s IS DISTINCT FROM ?", quoted)
+			}
+		}
+		if where.condition != nil {
+			clause, whereArgs = conditionClause(q.dialect, where.condition)
+		}
+		if where.inQuery != nil {
+			var subArgs []interface{}
+			clause, subArgs = whereInQueryClause(q.dialect, where.inColumn, where.inQuery)
+			args = append(args, subArgs...)
+		}
+
+		buf.WriteString(fmt.Sprintf("(%s)", clause))
+		args = append(args, whereArgs...)
 	}
 
 	var resp string
@@ -480,6 +1225,162 @@ func whereClause(q *Query, startAt int) (string, []interface{}) {
 	return resp, args
 }
 
+// whereInQueryClause returns the condition matching column against the rows
+// selected by subquery, and the args of subquery. The subquery is built with
+// question marks, which are numbered along with the rest of the where clause.
+func whereInQueryClause(dialect *Dialect, column string, subquery *Query) (string, []interface{}) {
+	if subquery.delete || len(subquery.update) != 0 || subquery.insertSource != nil {
+		panic(queryError{errors.New("the subquery of a where in must be a select query")})
+	}
+
+	dia := *dialect
+	dia.IndexPlaceholders = false
+
+	sub := *subquery
+	sub.dialect = &dia
+	sub.rawSQL = rawSQL{}
+	if len(sub.selectCols) == 0 {
+		if len(sub.keyColumns) == 0 {
+			panic(queryError{errors.New("the subquery of a where in must select a column when it has no key columns")})
+		}
+		sub.selectCols = sub.keyColumns
+	}
+
+	sel, args := build(&sub, false)
+	sel = strings.TrimSuffix(strings.TrimSpace(sel), ";")
+
+	return fmt.Sprintf("%s IN (%s)", strmangle.IdentQuote(dialect.LQ, dialect.RQ, column), sel), args
+}
+
+// withQueryClause returns the select of the common table expression query,
+// built with dialect and question marks, and its args.
+func withQueryClause(dialect *Dialect, query *Query) (string, []interface{}) {
+	if query.delete || len(query.update) != 0 || query.insertSource != nil {
+		panic(queryError{errors.New("the query of a common table expression must be a select query")})
+	}
+
+	dia := *dialect
+	dia.IndexPlaceholders = false
+
+	sub := *query
+	sub.dialect = &dia
+	sub.rawSQL = rawSQL{}
+
+	sel, args := build(&sub, false)
+	return strings.TrimSuffix(strings.TrimSpace(sel), ";"), args
+}
+
+// fullTextClause returns the condition searching columns with the full text
+// search of the dialect, with a question mark for the search terms. Postgres
+// searches several columns as one document, skipping NULL columns.
+func fullTextClause(dialect *Dialect, columns []string) string {
+	quoted := strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, columns)
+
+	switch dialect.FullTextSearch {
+	case "match":
+		return fmt.Sprintf("MATCH(%s) AGAINST (? IN NATURAL LANGUAGE MODE)", strings.Join(quoted, ", "))
+	case "freetext":
+		return fmt.Sprintf("FREETEXT((%s), ?)", strings.Join(quoted, ", "))
+	}
+
+	document := quoted[0]
+	if len(quoted) > 1 {
+		document = fmt.Sprintf("concat_ws(' ', %s)", strings.Join(quoted, ", "))
+	}
+
+	return fmt.Sprintf("to_tsvector(%s) @@ plainto_tsquery(?)", document)
+}
+
+// jsonContainsClause returns the condition matching a JSON column containing
+// the document bound to a question mark, with the JSON containment of the
+// dialect.
+func jsonContainsClause(dialect *Dialect, column string) string {
+	quoted := strmangle.IdentQuote(dialect.LQ, dialect.RQ, column)
+
+	switch dialect.JSONContains {
+	case "@>":
+		return fmt.Sprintf("%s @> ?", quoted)
+	case "json_contains":
+		return fmt.Sprintf("JSON_CONTAINS(%s, ?)", quoted)
+	}
+
+	panic(queryError{errors.New("JSON containment is only supported on postgres and mysql")})
+}
+
+// conditionClause compiles the condition tree c into a clause with question
+// mark placeholders and the args bound to them, in order. Groups of several
+// conditions nested in another are parenthesized.
+func conditionClause(dialect *Dialect, c boil.Condition) (string, []interface{}) {
+	switch c := c.(type) {
+	case boil.Comparison:
+		column := strmangle.IdentQuote(dialect.LQ, dialect.RQ, c.Column)
+		if isNullValue(c.Value) {
+			switch c.Operator {
+			case "=":
+				return column + " IS NULL", nil
+			case "<>":
+				return column + " IS NOT NULL", nil
+			}
+		}
+		return fmt.Sprintf("%s %s ?", column, c.Operator), []interface{}{c.Value}
+	case boil.Conditions:
+		if len(c.Conds) == 0 {
+			if c.Or {
+				return "1=0", nil
+			}
+			return "1=1", nil
+		}
+
+		sep := " AND "
+		if c.Or {
+			sep = " OR "
+		}
+
+		clauses := make([]string, len(c.Conds))
+		var args []interface{}
+		for i, child := range c.Conds {
+			clause, childArgs := conditionClause(dialect, child)
+			if group, ok := child.(boil.Conditions); ok && len(group.Conds) > 1 {
+				clause = "(" + clause + ")"
+			}
+			clauses[i] = clause
+			args = append(args, childArgs...)
+		}
+		return strings.Join(clauses, sep), args
+	}
+
+	panic(queryError{errors.Errorf("unknown condition %T", c)})
+}
+
+// tupleCompareClause returns the condition comparing the row of columns to
+// the row of values bound to question marks with operator, and the values
+// in the order they're bound. Without row values in the dialect the
+// comparison is expanded column by column:
+// "a" > ? OR ("a" = ? AND "b" > ?) for (a, b) > (?, ?), which binds every
+// value but the last twice.
+func tupleCompareClause(dialect *Dialect, columns []string, operator string, values []interface{}) (string, []interface{}) {
+	quoted := strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, columns)
+
+	if dialect.UseRowValues {
+		marks := strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ")
+		return fmt.Sprintf("(%s) %s (%s)", strings.Join(quoted, ", "), operator, marks), values
+	}
+
+	strict := operator[:1]
+	last := len(quoted) - 1
+	clause := fmt.Sprintf("%s %s ?", quoted[last], operator)
+	args := []interface{}{values[last]}
+	for i := last - 1; i >= 0; i-- {
+		if i != last-1 {
+			clause = fmt.Sprintf("(%s)", clause)
+		}
+		clause = fmt.Sprintf("%s %s ? OR (%s = ? AND %s)", quoted[i], strict, quoted[i], clause)
+		args = append([]interface{}{values[i], values[i]}, args...)
+	}
+
+	return clause, args
+}
+
 // inClause parses an in slice and converts it into a
 // single IN clause, like:
 // WHERE ("a", "b") IN (($1,$2),($3,$4)).
@@ -633,6 +1534,109 @@ func convertQuestionMarks(clause string, startAt int) (string, int) {
 	return paramBuf.String(), total
 }
 
+// countPlaceholders returns the number of args the built query binds: the
+// highest index of its $1 style placeholders when indexed is true, an index
+// can be used more than once, and the number of its question marks otherwise.
+// Quoted strings and identifiers, and escaped question marks, are skipped.
+// Quotes escaped with a backslash, like MySQL's \', don't end a string
+// when indexed is false.
+func countPlaceholders(query string, indexed bool) int {
+	count := 0
+	var quote byte
+
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+
+		switch {
+		case quote != 0:
+			if c == '\\' && !indexed && quote != '`' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '\\' && i+1 < len(query) && query[i+1] == '?':
+			i++
+		case indexed && c == '$' && i+1 < len(query) && query[i+1] >= '0' && query[i+1] <= '9':
+			start := i + 1
+			for i+1 < len(query) && query[i+1] >= '0' && query[i+1] <= '9' {
+				i++
+			}
+			if n, _ := strconv.Atoi(query[start : i+1]); n > count {
+				count = n
+			}
+		case !indexed && c == '?':
+			count++
+		}
+	}
+
+	return count
+}
+
+// convertNamedParams replaces the :name parameters in clause with question
+// marks and returns the values of params in the order they're used, a
+// parameter used twice is bound twice. Question marks already in the clause
+// are escaped, and :: casts and quoted strings are left alone. It panics when
+// a parameter has no value in params.
+func convertNamedParams(clause string, params map[string]interface{}) (string, []interface{}) {
+	paramBuf := strmangle.GetBuffer()
+	defer strmangle.PutBuffer(paramBuf)
+
+	var args []interface{}
+	inQuotes := false
+
+	for i := 0; i < len(clause); i++ {
+		c := clause[i]
+
+		switch {
+		case c == '\\' && i+1 < len(clause) && clause[i+1] == '?':
+			paramBuf.WriteString(`\?`)
+			i++
+			continue
+		case c == '?':
+			paramBuf.WriteString(`\?`)
+			continue
+		case c == '\'':
+			inQuotes = !inQuotes
+		case inQuotes:
+		case c == ':' && i+1 < len(clause) && clause[i+1] == ':':
+			paramBuf.WriteString("::")
+			i++
+			continue
+		case c == ':':
+			end := i + 1
+			for end < len(clause) && isNamedParamChar(clause[end], end == i+1) {
+				end++
+			}
+			if end == i+1 {
+				break
+			}
+
+			name := clause[i+1 : end]
+			arg, ok := params[name]
+			if !ok {
+				panic(fmt.Sprintf("no value for named parameter :%s in %q", name, clause))
+			}
+
+			args = append(args, arg)
+			paramBuf.WriteByte('?')
+			i = end - 1
+			continue
+		}
+
+		paramBuf.WriteByte(c)
+	}
+
+	return paramBuf.String(), args
+}
+
+// isNamedParamChar reports whether c can be part of a parameter name,
+// names can't start with a digit.
+func isNamedParamChar(c byte, first bool) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (!first && c >= '0' && c <= '9')
+}
+
 // parseFromClause will parse something that looks like
 // a
 // a b
@@ -2,14 +2,21 @@ package queries
 
 import (
 	"bytes"
+	"database/sql/driver"
 	"flag"
 	"fmt"
 	"io/ioutil"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/davecgh/go-spew/spew"
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/strmangle"
+	"github.com/volatiletech/sqlboiler/types"
+	"gopkg.in/volatiletech/null.v6"
 )
 
 var writeGoldenFiles = flag.Bool(
@@ -18,110 +25,2028 @@ var writeGoldenFiles = flag.Bool(
 	"Write golden files.",
 )
 
+// goldenDialects are the dialects TestBuildQuery builds its queries with,
+// by the name used in their golden files: _fixtures/00.pg.sql
+var goldenDialects = []struct {
+	name    string
+	dialect Dialect
+}{
+	{"pg", Dialect{
+		LQ: '"', RQ: '"', IndexPlaceholders: true, UseTableSample: true, UseArrayParams: true,
+		UseGroupingSets: true, UseFromOnly: true, UseNullsOrder: true, JSONContains: "@>",
+		OrderByField: "array_position", UpsertSyntax: "postgres", ShareLock: "FOR SHARE",
+	}},
+	{"mysql", Dialect{
+		LQ: '`', RQ: '`', ShareLock: "LOCK IN SHARE MODE", RandomFunction: "RAND()", FullTextSearch: "match",
+		JSONContains: "json_contains", OrderByField: "field", UpsertSyntax: "mysql",
+	}},
+	{"mssql", Dialect{
+		LQ: '[', RQ: ']', IndexPlaceholders: true, UseTopClause: true, RandomFunction: "NEWID()",
+		FullTextSearch: "freetext", UpsertSyntax: "mssql",
+	}},
+}
+
 func TestBuildQuery(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
-		q    *Query
-		args []interface{}
+		q    *Query
+		args []interface{}
+		// dialects are the names of the golden dialects the query is
+		// built with, all of them if empty
+		dialects []string
+	}{
+		{&Query{from: []string{"t"}}, nil, nil},
+		{&Query{from: []string{"q"}, limit: 5, offset: 6}, nil, nil},
+		{&Query{from: []string{"q"}, orderBy: []string{"a ASC", "b DESC"}}, nil, nil},
+		{&Query{from: []string{"t"}, selectCols: []string{"count(*) as ab, thing as bd", `"stuff"`}}, nil, nil},
+		{&Query{from: []string{"a", "b"}, selectCols: []string{"count(*) as ab, thing as bd", `"stuff"`}}, nil, nil},
+		{&Query{
+			selectCols: []string{"a.happy", "r.fun", "q"},
+			from:       []string{"happiness as a"},
+			joins:      []join{{clause: "rainbows r on a.id = r.happy_id"}},
+		}, nil, nil},
+		{&Query{
+			from:  []string{"happiness as a"},
+			joins: []join{{clause: "rainbows r on a.id = r.happy_id"}},
+		}, nil, nil},
+		{&Query{
+			from: []string{"videos"},
+			joins: []join{{
+				clause: "(select id from users where deleted = ?) u on u.id = videos.user_id",
+				args:   []interface{}{true},
+			}},
+			where: []where{{clause: "videos.deleted = ?", args: []interface{}{false}}},
+		}, []interface{}{true, false}, nil},
+		{&Query{
+			from:    []string{"a"},
+			groupBy: []string{"id", "name"},
+			where: []where{
+				{clause: "a=? or b=?", args: []interface{}{1, 2}},
+				{clause: "c=?", args: []interface{}{3}},
+			},
+			having: []having{
+				{clause: "id <> ?", args: []interface{}{1}},
+				{clause: "length(name, ?) > ?", args: []interface{}{"utf8", 5}},
+			},
+		}, []interface{}{1, 2, 3, 1, "utf8", 5}, nil},
+		{&Query{
+			delete: true,
+			from:   []string{"thing happy", `upset as "sad"`, "fun", "thing as stuff", `"angry" as mad`},
+			where: []where{
+				{clause: "a=?", args: []interface{}{1}},
+				{clause: "b=?", args: []interface{}{2}},
+				{clause: "c=?", args: []interface{}{3}},
+			},
+		}, []interface{}{1, 2, 3}, nil},
+		{&Query{
+			delete: true,
+			from:   []string{"thing happy", `upset as "sad"`, "fun", "thing as stuff", `"angry" as mad`},
+			where: []where{
+				{clause: "(id=? and thing=?) or stuff=?", args: []interface{}{1, 2, 3}},
+			},
+			limit: 5,
+		}, []interface{}{1, 2, 3}, nil},
+		{&Query{
+			from: []string{"thing happy", `"fun"`, `stuff`},
+			update: map[string]interface{}{
+				"col1":       1,
+				`"col2"`:     2,
+				`"fun".col3`: 3,
+			},
+			where: []where{
+				{clause: "aa=? or bb=? or cc=?", orSeparator: true, args: []interface{}{4, 5, 6}},
+				{clause: "dd=? or ee=? or ff=? and gg=?", args: []interface{}{7, 8, 9, 10}},
+			},
+			limit: 5,
+		}, []interface{}{2, 3, 1, 4, 5, 6, 7, 8, 9, 10}, nil},
+		{&Query{from: []string{"cats"}, joins: []join{{kind: JoinInner, clause: "dogs d on d.cat_id = cats.id"}}}, nil, nil},
+		{&Query{from: []string{"cats c"}, joins: []join{{kind: JoinInner, clause: "dogs d on d.cat_id = cats.id"}}}, nil, nil},
+		{&Query{from: []string{"cats as c"}, joins: []join{{kind: JoinInner, clause: "dogs d on d.cat_id = cats.id"}}}, nil, nil},
+		{&Query{from: []string{"cats as c", "dogs as d"}, joins: []join{{kind: JoinInner, clause: "dogs d on d.cat_id = cats.id"}}}, nil, nil},
+		{&Query{
+			from:     []string{"pilots"},
+			where:    []where{{clause: "id=?", args: []interface{}{1}}},
+			orderBy:  []string{"name"},
+			limit:    5,
+			forShare: true,
+		}, []interface{}{1}, []string{"pg", "mysql"}},
+		{&Query{
+			from:      []string{"pilots"},
+			orderBy:   []string{"name"},
+			limit:     5,
+			offset:    2,
+			forShare:  true,
+			forNoWait: true,
+		}, nil, []string{"pg"}},
+		{&Query{from: []string{"pilots"}, limit: 1, forlock: "UPDATE NOWAIT"}, nil, []string{"pg", "mysql"}},
+		{&Query{
+			from: []string{"t"},
+			where: []where{
+				{clause: "a=?", args: []interface{}{1}},
+				{clause: "b=?", orSeparator: true, args: []interface{}{2}},
+				{clause: "c=?", args: []interface{}{3}},
+			},
+		}, []interface{}{1, 2, 3}, nil},
+		{&Query{
+			from: []string{"t"},
+			where: []where{
+				{clause: "a=? or b=?", args: []interface{}{1, 2}},
+				{clause: "c=?", orSeparator: true, args: []interface{}{3}},
+			},
+			in: []in{{clause: "d in ?", orSeparator: true, args: []interface{}{4, 5}}},
+		}, []interface{}{1, 2, 3, 4, 5}, nil},
+		{&Query{from: []string{"pilots"}, orderBy: []string{"name", orderByRandom}, limit: 10}, nil, nil},
+		{&Query{
+			from: []string{"jets j"},
+			joins: []join{{
+				kind:       JoinInner,
+				clause:     "pilots p",
+				on:         []joinCondition{{clause: "j.pilot_id = p.id"}, {clause: "j.tenant = p.tenant"}, {clause: "p.active = ?", args: []interface{}{true}}},
+				targetOnly: true,
+			}},
+			where: []where{{clause: "j.name = ?", args: []interface{}{"a"}}},
+		}, []interface{}{true, "a"}, nil},
+		{&Query{
+			from: []string{"jets j"},
+			joins: []join{{
+				kind:   JoinInner,
+				clause: "pilots p on j.pilot_id = p.id and p.name = ?",
+				args:   []interface{}{"b"},
+				on:     []joinCondition{{clause: "p.active = ?", args: []interface{}{true}}},
+			}},
+			where: []where{{clause: "j.name = ?", args: []interface{}{"a"}}},
+		}, []interface{}{"b", true, "a"}, nil},
+		{&Query{
+			with: []with{
+				{
+					name:      "tree(id, parent_id, depth)",
+					anchor:    "SELECT id, parent_id, 0 FROM nodes WHERE id = ?",
+					recursive: "SELECT n.id, n.parent_id, t.depth + 1 FROM nodes n INNER JOIN tree t ON n.parent_id = t.id WHERE t.depth < ?",
+					args:      []interface{}{1, 2},
+				},
+				{
+					name:      "up(id, parent_id)",
+					anchor:    "SELECT id, parent_id FROM nodes WHERE id = ?",
+					recursive: "SELECT n.id, n.parent_id FROM nodes n INNER JOIN up u ON n.id = u.parent_id",
+					args:      []interface{}{3},
+				},
+			},
+			from:  []string{"tree"},
+			where: []where{{clause: "depth > ?", args: []interface{}{4}}},
+		}, []interface{}{1, 2, 3, 4}, []string{"pg", "mysql"}},
+		{&Query{
+			with: []with{{
+				name:      "tree",
+				anchor:    "SELECT id FROM nodes WHERE id = ?",
+				recursive: "SELECT n.id FROM nodes n INNER JOIN tree t ON n.parent_id = t.id",
+				args:      []interface{}{1},
+			}},
+			from:  []string{"tree"},
+			where: []where{{clause: "id <> ?", args: []interface{}{1}}},
+		}, []interface{}{1, 1}, []string{"mysql"}},
+		{&Query{
+			from:          []string{"pilots p"},
+			joins:         []join{{kind: JoinInner, clause: "jets j on j.pilot_id = p.id"}},
+			where:         []where{{clause: "p.age > ?", args: []interface{}{30}}},
+			sampleMethod:  "BERNOULLI",
+			samplePercent: 10,
+		}, []interface{}{30}, []string{"pg"}},
+		{&Query{
+			from:          []string{"pilots"},
+			count:         true,
+			sampleMethod:  "SYSTEM",
+			samplePercent: 2.5,
+		}, nil, []string{"pg"}},
+		{&Query{
+			from:       []string{"flights"},
+			distinctOn: []string{"pilot_id", "f.jet_id"},
+			orderBy:    []string{`f.jet_id, "pilot_id" ASC`, "departed_at desc"},
+		}, nil, []string{"pg"}},
+		{&Query{
+			from:       []string{"flights"},
+			selectCols: []string{"pilot_id", "departed_at"},
+			distinctOn: []string{"pilot_id"},
+		}, nil, []string{"pg"}},
+		{&Query{
+			from:       []string{"pilots"},
+			selectCols: []string{"id", "data->'meta'->>'rank' as rank"},
+			where: []where{
+				{clause: "(data->>'age')::int > ?", args: []interface{}{30}},
+				{clause: `data \? 'callsign' and data#>>'{meta,base}' = ?`, args: []interface{}{"hangar"}},
+			},
+			orderBy: []string{"data->'meta'->>'rank' DESC", "(data->>'age')::int"},
+		}, []interface{}{30, "hangar"}, []string{"pg"}},
+		{&Query{
+			from:    []string{"pilots"},
+			where:   []where{{clause: "CAST(data->>'$.age' AS UNSIGNED) > ?", args: []interface{}{30}}},
+			orderBy: []string{"data->>'$.meta.rank'"},
+		}, []interface{}{30}, []string{"mysql"}},
+		{&Query{
+			from:       []string{"flights"},
+			selectCols: []string{"airport_id", "pilot_id", "count(*)"},
+			where:      []where{{clause: "departed_at > ?", args: []interface{}{"2018-01-01"}}},
+			groupBy:    []string{"year"},
+			grouping:   []grouping{{kind: "CUBE", sets: [][]string{{"airport_id", "pilot_id"}}}},
+			having:     []having{{clause: "count(*) > ?", args: []interface{}{10}}},
+		}, []interface{}{"2018-01-01", 10}, []string{"pg"}},
+		{&Query{
+			from:     []string{"flights"},
+			grouping: []grouping{{kind: "GROUPING SETS", sets: [][]string{{"airport_id"}, {"pilot_id", "jet_id"}, nil}}},
+			having:   []having{{clause: "count(*) > ?", args: []interface{}{10}}},
+		}, []interface{}{10}, []string{"pg"}},
+		{&Query{
+			from:     []string{"flights"},
+			grouping: []grouping{{kind: "ROLLUP", sets: [][]string{{"airport_id", "pilot_id"}}}},
+		}, nil, []string{"pg"}},
+		{&Query{
+			from:     []string{"flights"},
+			grouping: []grouping{{kind: "ROLLUP", sets: [][]string{{"airport_id", "pilot_id"}}}},
+			having:   []having{{clause: "count(*) > ?", args: []interface{}{10}}},
+		}, []interface{}{10}, []string{"mysql"}},
+		{&Query{
+			from:  []string{fromOnlyPrefix + "flights f", "pilots"},
+			joins: []join{{kind: JoinInner, clause: "jets j on j.id = f.jet_id"}},
+			where: []where{{clause: "f.pilot_id = pilots.id"}},
+		}, nil, []string{"pg"}},
+		{&Query{
+			from:   []string{fromOnlyPrefix + "flights"},
+			delete: true,
+			where:  []where{{clause: "departed_at < ?", args: []interface{}{"2018-01-01"}}},
+		}, []interface{}{"2018-01-01"}, []string{"pg"}},
+	}
+
+	for i, test := range tests {
+		for _, golden := range goldenDialects {
+			if len(test.dialects) != 0 && !strmangle.SetInclude(golden.name, test.dialects) {
+				continue
+			}
+
+			// Each dialect builds its own copy, the built query is cached
+			q := *test.q
+			dialect := golden.dialect
+			q.dialect = &dialect
+
+			filename := filepath.Join("_fixtures", fmt.Sprintf("%02d.%s.sql", i, golden.name))
+			out, args, _ := buildQuery(&q)
+
+			if *writeGoldenFiles {
+				err := ioutil.WriteFile(filename, []byte(out), 0664)
+				if err != nil {
+					t.Fatalf("Failed to write golden file %s: %s\n", filename, err)
+				}
+				t.Logf("wrote golden file: %s\n", filename)
+				continue
+			}
+
+			byt, err := ioutil.ReadFile(filename)
+			if err != nil {
+				t.Fatalf("Failed to read golden file %q: %v", filename, err)
+			}
+
+			if string(bytes.TrimSpace(byt)) != out {
+				t.Errorf("[%02d.%s] Test failed:\nWant:\n%s\nGot:\n%s", i, golden.name, byt, out)
+			}
+
+			if !reflect.DeepEqual(args, test.args) {
+				t.Errorf("[%02d.%s] Test failed:\nWant:\n%s\nGot:\n%s", i, golden.name, spew.Sdump(test.args), spew.Sdump(args))
+			}
+		}
+	}
+}
+
+func TestBuildQueryDialects(t *testing.T) {
+	t.Parallel()
+
+	newQuery := func(dialect *Dialect) *Query {
+		q := &Query{}
+		SetDialect(q, dialect)
+		SetSelect(q, []string{"id", "name"})
+		SetFrom(q, "pilots")
+		AppendWhere(q, "age > ? and name <> ?", 30, "bob")
+		AppendOrderByRandom(q)
+		SetLimit(q, 5)
+		return q
+	}
+
+	tests := []struct {
+		dialect *Dialect
+		expect  string
+	}{
+		{
+			&Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true},
+			`SELECT "id", "name" FROM "pilots" WHERE (age > $1 and name <> $2) ORDER BY RANDOM() LIMIT 5;`,
+		},
+		{
+			&Dialect{LQ: '`', RQ: '`', RandomFunction: "RAND()"},
+			"SELECT `id`, `name` FROM `pilots` WHERE (age > ? and name <> ?) ORDER BY RAND() LIMIT 5;",
+		},
+	}
+
+	for i, test := range tests {
+		out, args, _ := buildQuery(newQuery(test.dialect))
+		if out != test.expect {
+			t.Errorf("%d) mismatch:\nwant: %s\ngot:  %s", i, test.expect, out)
+		}
+		if !reflect.DeepEqual(args, []interface{}{30, "bob"}) {
+			t.Errorf("%d) wrong args: %#v", i, args)
+		}
+	}
+}
+
+func TestBuildQueryFullText(t *testing.T) {
+	t.Parallel()
+
+	newQuery := func(dialect *Dialect, columns ...string) *Query {
+		q := &Query{}
+		SetDialect(q, dialect)
+		SetFrom(q, "posts")
+		AppendWhere(q, "author_id = ?", 5)
+		AppendFullText(q, "boiled eggs", columns...)
+		AppendWhere(q, "draft = ?", false)
+		return q
+	}
+
+	tests := []struct {
+		q      *Query
+		expect string
+	}{
+		{
+			newQuery(&Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true}, "body"),
+			`SELECT * FROM "posts" WHERE (author_id = $1) AND (to_tsvector("body") @@ plainto_tsquery($2)) AND (draft = $3);`,
+		},
+		{
+			newQuery(&Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true}, "title", "body"),
+			`SELECT * FROM "posts" WHERE (author_id = $1) AND (to_tsvector(concat_ws(' ', "title", "body")) @@ plainto_tsquery($2)) AND (draft = $3);`,
+		},
+		{
+			newQuery(&Dialect{LQ: '`', RQ: '`', FullTextSearch: "match"}, "title", "body"),
+			"SELECT * FROM `posts` WHERE (author_id = ?) AND (MATCH(`title`, `body`) AGAINST (? IN NATURAL LANGUAGE MODE)) AND (draft = ?);",
+		},
+		{
+			newQuery(&Dialect{LQ: '[', RQ: ']', IndexPlaceholders: true, FullTextSearch: "freetext"}, "body"),
+			`SELECT * FROM [posts] WHERE (author_id = $1) AND (FREETEXT(([body]), $2)) AND (draft = $3);`,
+		},
+	}
+
+	for i, test := range tests {
+		out, args, _ := buildQuery(test.q)
+		if out != test.expect {
+			t.Errorf("%d) mismatch:\nwant: %s\ngot:  %s", i, test.expect, out)
+		}
+		if !reflect.DeepEqual(args, []interface{}{5, "boiled eggs", false}) {
+			t.Errorf("%d) wrong args: %#v", i, args)
+		}
+	}
+}
+
+func TestBuildQueryWhereAny(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		values interface{}
+		array  driver.Value
+	}{
+		{[]int64{3, 1, 2}, "{3,1,2}"},
+		{[]int{3, 1}, "{3,1}"},
+		{[]string{"a", "b c"}, `{"a","b c"}`},
+		{[]int64{}, "{}"},
+		{[]interface{}{1, nil}, "{1,NULL}"},
+	}
+
+	for i, test := range tests {
+		q := &Query{}
+		SetDialect(q, &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true, UseArrayParams: true})
+		SetFrom(q, "pilots")
+		AppendWhere(q, "age > ?", 30)
+		AppendWhereAny(q, "pilots.id", test.values)
+
+		out, args, _ := buildQuery(q)
+		if expect := `SELECT * FROM "pilots" WHERE (age > $1) AND ("pilots"."id" = ANY($2));`; out != expect {
+			t.Errorf("%d) mismatch:\nwant: %s\ngot:  %s", i, expect, out)
+		}
+		if len(args) != 2 || args[0] != 30 {
+			t.Fatalf("%d) wrong args: %#v", i, args)
+		}
+
+		array, err := args[1].(driver.Valuer).Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if array != test.array {
+			t.Errorf("%d) want array %v, got %v", i, test.array, array)
+		}
+	}
+}
+
+func TestBuildQueryWhereOp(t *testing.T) {
+	t.Parallel()
+
+	var nilPtr *int
+	q := &Query{}
+	SetDialect(q, &Dialect{LQ: '`', RQ: '`'})
+	SetFrom(q, "pilots")
+	AppendWhereOp(q, "pilots.name", "=", "Ann")
+	AppendWhereOp(q, "age", ">=", 30)
+	AppendWhereOp(q, "name", "LIKE", "A%")
+	AppendWhereOp(q, "deleted_at", "=", nil)
+	AppendWhereOp(q, "license_id", "<>", nilPtr)
+	AppendWhereOp(q, "nickname", "=", null.String{})
+	SetLastWhereAsOr(q)
+	AppendWhereOp(q, "rank", "<", nil)
+
+	out, args, _ := buildQuery(q)
+	expect := "SELECT * FROM `pilots` WHERE (`pilots`.`name` = ?) AND (`age` >= ?) AND (`name` LIKE ?)" +
+		" AND (`deleted_at` IS NULL) AND (`license_id` IS NOT NULL) OR (`nickname` IS NULL) AND (`rank` < ?);"
+	if out != expect {
+		t.Errorf("mismatch:\nwant: %s\ngot:  %s", expect, out)
+	}
+
+	if want := []interface{}{"Ann", 30, "A%", nil}; !reflect.DeepEqual(args, want) {
+		t.Errorf("want args %#v, got %#v", want, args)
+	}
+}
+
+func TestBuildQueryWhereTimeRange(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2018, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		from, to time.Time
+		expect   string
+		args     []interface{}
+	}{
+		{from, to, `SELECT * FROM "jets" WHERE (age > $1) AND ("jets"."created_at" >= $2 AND "jets"."created_at" < $3);`, []interface{}{30, from, to}},
+		{from, time.Time{}, `SELECT * FROM "jets" WHERE (age > $1) AND ("jets"."created_at" >= $2);`, []interface{}{30, from}},
+		{time.Time{}, to, `SELECT * FROM "jets" WHERE (age > $1) AND ("jets"."created_at" < $2);`, []interface{}{30, to}},
+		{time.Time{}, time.Time{}, `SELECT * FROM "jets" WHERE (age > $1);`, []interface{}{30}},
+	}
+
+	for i, test := range tests {
+		q := &Query{}
+		SetDialect(q, &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true})
+		SetFrom(q, "jets")
+		AppendWhere(q, "age > ?", 30)
+		AppendWhereTimeRange(q, "jets.created_at", test.from, test.to)
+
+		out, args, _ := buildQuery(q)
+		if out != test.expect {
+			t.Errorf("%d) mismatch:\nwant: %s\ngot:  %s", i, test.expect, out)
+		}
+		if !reflect.DeepEqual(args, test.args) {
+			t.Errorf("%d) want args %#v, got %#v", i, test.args, args)
+		}
+	}
+}
+
+func TestBuildQueryWhereDistinctFrom(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		dialect Dialect
+		expect  string
+	}{
+		{
+			dialect: Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true},
+			expect:  `SELECT * FROM "jets" WHERE (age > $1) AND ("jets"."pilot_id" IS DISTINCT FROM $2);`,
+		},
+		{
+			dialect: Dialect{LQ: '`', RQ: '`', UseNullSafeEqual: true},
+			expect:  "SELECT * FROM `jets` WHERE (age > ?) AND (NOT (`jets`.`pilot_id` <=> ?));",
+		},
+	}
+
+	for i, test := range tests {
+		for _, value := range []interface{}{5, nil} {
+			q := &Query{}
+			SetDialect(q, &test.dialect)
+			SetFrom(q, "jets")
+			AppendWhere(q, "age > ?", 30)
+			AppendWhereDistinctFrom(q, "jets.pilot_id", value)
+
+			out, args, _ := buildQuery(q)
+			if out != test.expect {
+				t.Errorf("%d) mismatch:\nwant: %s\ngot:  %s", i, test.expect, out)
+			}
+			if want := []interface{}{30, value}; !reflect.DeepEqual(args, want) {
+				t.Errorf("%d) want args %#v, got %#v", i, want, args)
+			}
+		}
+	}
+}
+
+func TestBuildQueryWhereIdent(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		dialect Dialect
+		expect  string
+	}{
+		{
+			dialect: Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true},
+			expect:  `SELECT * FROM "pilots" WHERE ("pilots"."name" = $1) AND ("Age" BETWEEN $2 AND $3);`,
+		},
+		{
+			dialect: Dialect{LQ: '`', RQ: '`'},
+			expect:  "SELECT * FROM `pilots` WHERE (`pilots`.`name` = ?) AND (`Age` BETWEEN ? AND ?);",
+		},
+		{
+			dialect: Dialect{LQ: '[', RQ: ']', IndexPlaceholders: true},
+			expect:  `SELECT * FROM [pilots] WHERE ([pilots].[name] = $1) AND ([Age] BETWEEN $2 AND $3);`,
+		},
+	}
+
+	for i, test := range tests {
+		q := &Query{}
+		SetDialect(q, &test.dialect)
+		SetFrom(q, "pilots")
+		AppendWhereIdent(q, "pilots.name", "= ?", "Ann")
+		AppendWhereIdent(q, "Age", "BETWEEN ? AND ?", 20, 30)
+
+		out, args, _ := buildQuery(q)
+		if out != test.expect {
+			t.Errorf("%d) mismatch:\nwant: %s\ngot:  %s", i, test.expect, out)
+		}
+		if want := []interface{}{"Ann", 20, 30}; !reflect.DeepEqual(args, want) {
+			t.Errorf("%d) want args %#v, got %#v", i, want, args)
+		}
+	}
+}
+
+func TestAppendWhereIdentInvalid(t *testing.T) {
+	t.Parallel()
+
+	idents := []string{"", "name; DROP TABLE pilots", `"name"`, "first name", "1name", "a.b.c", "name)", "pilots."}
+	for _, ident := range idents {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected a panic for the identifier %q", ident)
+				}
+			}()
+
+			AppendWhereIdent(&Query{}, ident, "= ?", 1)
+		}()
+	}
+}
+
+func TestBuildQueryWhereWithinDistance(t *testing.T) {
+	t.Parallel()
+
+	q := &Query{}
+	SetDialect(q, &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true, UsePostGIS: true})
+	SetFrom(q, "shops")
+	AppendWhere(q, "open = ?", true)
+	AppendWhereWithinDistance(q, "shops.location", -71.06, 42.36, 500)
+	AppendWhere(q, "ST_Area(ST_Buffer(location::geometry, ?)) > ?", 10, 20)
+
+	out, args, _ := buildQuery(q)
+	expect := `SELECT * FROM "shops" WHERE (open = $1) AND (ST_DWithin("shops"."location", ST_MakePoint($2, $3)::geography, $4))` +
+		` AND (ST_Area(ST_Buffer(location::geometry, $5)) > $6);`
+	if out != expect {
+		t.Errorf("mismatch:\nwant: %s\ngot:  %s", expect, out)
+	}
+
+	if want := []interface{}{true, -71.06, 42.36, float64(500), 10, 20}; !reflect.DeepEqual(args, want) {
+		t.Errorf("want args %#v, got %#v", want, args)
+	}
+
+	q = &Query{}
+	SetDialect(q, &Dialect{LQ: '`', RQ: '`'})
+	SetFrom(q, "shops")
+	AppendWhereWithinDistance(q, "location", 0, 0, 1)
+	if _, _, err := buildQuery(q); err == nil {
+		t.Error("Expected an error building a distance condition without PostGIS")
+	}
+}
+
+func TestBuildQueryWhereCondition(t *testing.T) {
+	t.Parallel()
+
+	q := &Query{}
+	SetDialect(q, &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true})
+	SetFrom(q, "pilots")
+	AppendWhere(q, "active = ?", true)
+	AppendWhereCondition(q, boil.And(
+		boil.Or(boil.Cond("a", "=", 1), boil.Cond("b", ">", 2)),
+		boil.Cond("c", "<", 3),
+	))
+	AppendWhereCondition(q, boil.Or(
+		boil.Cond("pilots.name", "LIKE", "A%"),
+		boil.And(boil.Cond("d", "<>", nil), boil.Or(boil.Cond("e", ">=", 4), boil.Cond("f", "=", nil))),
+		boil.And(boil.Cond("g", "!=", 5)),
+	))
+	AppendWhereCondition(q, boil.Or())
+	SetLastWhereAsOr(q)
+	AppendWhereCondition(q, boil.And())
+	AppendWhere(q, "age > ?", 30)
+
+	out, args, _ := buildQuery(q)
+	expect := `SELECT * FROM "pilots" WHERE (active = $1) AND (("a" = $2 OR "b" > $3) AND "c" < $4)` +
+		` AND ("pilots"."name" LIKE $5 OR ("d" IS NOT NULL AND ("e" >= $6 OR "f" IS NULL)) OR "g" != $7)` +
+		` OR (1=0) AND (1=1) AND (age > $8);`
+	if out != expect {
+		t.Errorf("mismatch:\nwant: %s\ngot:  %s", expect, out)
+	}
+
+	if want := []interface{}{true, 1, 2, 3, "A%", 4, 5, 30}; !reflect.DeepEqual(args, want) {
+		t.Errorf("want args %#v, got %#v", want, args)
+	}
+}
+
+func TestAppendWhereConditionInvalid(t *testing.T) {
+	t.Parallel()
+
+	conds := []boil.Condition{
+		boil.Cond("name; DROP TABLE pilots", "=", 1),
+		boil.Cond("name", "= 1 OR 1 =", 1),
+		boil.And(boil.Cond("a", "=", 1), boil.Or(boil.Cond("b", "IN", 2))),
+		nil,
+	}
+	for i, c := range conds {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%d) Expected a panic for the condition %#v", i, c)
+				}
+			}()
+
+			AppendWhereCondition(&Query{}, c)
+		}()
+	}
+}
+
+func TestBuildQueryWhereArrayLen(t *testing.T) {
+	t.Parallel()
+
+	q := &Query{}
+	SetDialect(q, &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true, UseArrayParams: true})
+	SetFrom(q, "pilots")
+	AppendWhere(q, "age > ?", 30)
+	AppendWhereArrayLen(q, "pilots.tags", ">", 3)
+	AppendWhereArrayLen(q, "licenses", "=", 0)
+	SetLastWhereAsOr(q)
+
+	out, args, _ := buildQuery(q)
+	expect := `SELECT * FROM "pilots" WHERE (age > $1) AND (cardinality("pilots"."tags") > $2) OR (cardinality("licenses") = $3);`
+	if out != expect {
+		t.Errorf("mismatch:\nwant: %s\ngot:  %s", expect, out)
+	}
+
+	if want := []interface{}{30, 3, 0}; !reflect.DeepEqual(args, want) {
+		t.Errorf("want args %#v, got %#v", want, args)
+	}
+}
+
+func TestAppendWhereArrayLenInvalidOperator(t *testing.T) {
+	t.Parallel()
+
+	for _, operator := range []string{"", "LIKE", "> 0 OR 1 =", "=="} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected a panic comparing an array length with %q", operator)
+				}
+			}()
+
+			AppendWhereArrayLen(&Query{}, "tags", operator, 3)
+		}()
+	}
+}
+
+func TestBuildQueryWhereLikeAny(t *testing.T) {
+	t.Parallel()
+
+	q := &Query{}
+	SetDialect(q, &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true})
+	SetFrom(q, "pilots")
+	AppendWhere(q, "active = ?", true)
+	AppendWhereLikeAny(q, "pilots.name", []string{"a%", "b%"})
+	AppendWhereLikeAny(q, "nickname", []string{"%c"})
+	AppendWhereLikeAny(q, "callsign", nil)
+	AppendWhere(q, "age > ?", 30)
+	SetLastWhereAsOr(q)
+
+	out, args, _ := buildQuery(q)
+	expect := `SELECT * FROM "pilots" WHERE (active = $1) AND ("pilots"."name" LIKE $2 OR "pilots"."name" LIKE $3)` +
+		` AND ("nickname" LIKE $4) AND (1=0) OR (age > $5);`
+	if out != expect {
+		t.Errorf("mismatch:\nwant: %s\ngot:  %s", expect, out)
+	}
+
+	if want := []interface{}{true, "a%", "b%", "%c", 30}; !reflect.DeepEqual(args, want) {
+		t.Errorf("want args %#v, got %#v", want, args)
+	}
+}
+
+func TestBuildQueryWhereTupleCompare(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		dialect *Dialect
+		columns []string
+		op      string
+		values  []interface{}
+		expect  string
+		args    []interface{}
+	}{
+		{
+			&Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true, UseRowValues: true},
+			[]string{"created_at", "pilots.id"}, ">", []interface{}{"t", 10},
+			`SELECT * FROM "pilots" WHERE (age > $1) AND (("created_at", "pilots"."id") > ($2, $3));`,
+			[]interface{}{30, "t", 10},
+		},
+		{
+			&Dialect{LQ: '`', RQ: '`'},
+			[]string{"created_at", "pilots.id"}, ">", []interface{}{"t", 10},
+			"SELECT * FROM `pilots` WHERE (age > ?) AND (`created_at` > ? OR (`created_at` = ? AND `pilots`.`id` > ?));",
+			[]interface{}{30, "t", "t", 10},
+		},
+		{
+			&Dialect{LQ: '`', RQ: '`'},
+			[]string{"a", "b", "c"}, "<=", []interface{}{1, 2, 3},
+			"SELECT * FROM `pilots` WHERE (age > ?) AND (`a` < ? OR (`a` = ? AND (`b` < ? OR (`b` = ? AND `c` <= ?))));",
+			[]interface{}{30, 1, 1, 2, 2, 3},
+		},
+		{
+			&Dialect{LQ: '`', RQ: '`'},
+			[]string{"id"}, ">=", []interface{}{5},
+			"SELECT * FROM `pilots` WHERE (age > ?) AND (`id` >= ?);",
+			[]interface{}{30, 5},
+		},
+	}
+
+	for i, test := range tests {
+		q := &Query{}
+		SetDialect(q, test.dialect)
+		SetFrom(q, "pilots")
+		AppendWhere(q, "age > ?", 30)
+		AppendWhereTupleCompare(q, test.columns, test.op, test.values)
+
+		out, args, _ := buildQuery(q)
+		if out != test.expect {
+			t.Errorf("%d) mismatch:\nwant: %s\ngot:  %s", i, test.expect, out)
+		}
+		if !reflect.DeepEqual(args, test.args) {
+			t.Errorf("%d) want args %#v, got %#v", i, test.args, args)
+		}
+	}
+}
+
+func TestAppendWhereTupleCompareInvalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		columns []string
+		op      string
+		values  []interface{}
+	}{
+		{[]string{"a", "b"}, "=", []interface{}{1, 2}},
+		{[]string{"a", "b"}, "<>", []interface{}{1, 2}},
+		{[]string{"a", "b"}, "> 0 OR 1 >", []interface{}{1, 2}},
+		{[]string{"a", "b"}, ">", []interface{}{1}},
+		{nil, ">", nil},
+	}
+
+	for i, test := range tests {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%d) Expected a panic comparing %v %q %v", i, test.columns, test.op, test.values)
+				}
+			}()
+
+			AppendWhereTupleCompare(&Query{}, test.columns, test.op, test.values)
+		}()
+	}
+}
+
+func TestBuildQueryWhereEq(t *testing.T) {
+	t.Parallel()
+
+	eq := map[string]interface{}{
+		"pilots.name": "Ann",
+		"jet_ids":     []int{2, 3},
+		"deleted_at":  nil,
+		"rank":        [2]string{"a", "b"},
+		"license_id":  (*int)(nil),
+		"nickname":    null.String{},
+		"codes":       []string{},
+		"avatar":      []byte("png"),
+		"age":         30,
+	}
+
+	tests := []struct {
+		dialect *Dialect
+		expect  string
+	}{
+		{
+			&Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true},
+			`SELECT * FROM "pilots" WHERE (active = $1) AND ("age" = $2) AND ("avatar" = $3) AND (1=0) AND ("deleted_at" IS NULL)` +
+				` AND ("jet_ids" IN ($4,$5)) AND ("license_id" IS NULL) AND ("nickname" IS NULL) AND ("pilots"."name" = $6)` +
+				` AND ("rank" IN ($7,$8));`,
+		},
+		{
+			&Dialect{LQ: '`', RQ: '`'},
+			"SELECT * FROM `pilots` WHERE (active = ?) AND (`age` = ?) AND (`avatar` = ?) AND (1=0) AND (`deleted_at` IS NULL)" +
+				" AND (`jet_ids` IN (?,?)) AND (`license_id` IS NULL) AND (`nickname` IS NULL) AND (`pilots`.`name` = ?)" +
+				" AND (`rank` IN (?,?));",
+		},
+	}
+
+	for i, test := range tests {
+		// The order of the map must not matter, so build it a few times
+		for j := 0; j < 5; j++ {
+			q := &Query{}
+			SetDialect(q, test.dialect)
+			SetFrom(q, "pilots")
+			AppendWhere(q, "active = ?", true)
+			AppendWhereEq(q, eq)
+
+			out, args, _ := buildQuery(q)
+			if out != test.expect {
+				t.Fatalf("%d) mismatch:\nwant: %s\ngot:  %s", i, test.expect, out)
+			}
+
+			want := []interface{}{true, 30, []byte("png"), 2, 3, "Ann", "a", "b"}
+			if !reflect.DeepEqual(args, want) {
+				t.Fatalf("%d) want args %#v, got %#v", i, want, args)
+			}
+		}
+	}
+}
+
+func TestBuildQueryWhereRangeOp(t *testing.T) {
+	t.Parallel()
+
+	period := types.NewRange("2018-01-01", "2018-02-01")
+	q := &Query{}
+	SetDialect(q, &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true})
+	SetFrom(q, "bookings")
+	AppendWhereOp(q, "bookings.period", "@>", "2018-01-15")
+	AppendWhereOp(q, "period", "&&", period)
+	AppendWhereOp(q, "period", "<@", period)
+
+	out, args, _ := buildQuery(q)
+	expect := `SELECT * FROM "bookings" WHERE ("bookings"."period" @> $1) AND ("period" && $2) AND ("period" <@ $3);`
+	if out != expect {
+		t.Errorf("mismatch:\nwant: %s\ngot:  %s", expect, out)
+	}
+
+	if len(args) != 3 {
+		t.Fatalf("wrong args: %#v", args)
+	}
+	value, err := args[1].(driver.Valuer).Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != "[2018-01-01,2018-02-01)" {
+		t.Errorf("wrong range value: %#v", value)
+	}
+}
+
+func TestBuildQueryWhereJSONContains(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		dialect *Dialect
+		value   interface{}
+		expect  string
+		arg     string
+	}{
+		{
+			&Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true, JSONContains: "@>"},
+			types.JSON(`{"tags":["a"]}`),
+			`SELECT * FROM "posts" WHERE (author_id = $1) AND ("posts"."data" @> $2);`,
+			`{"tags":["a"]}`,
+		},
+		{
+			&Dialect{LQ: '`', RQ: '`', JSONContains: "json_contains"},
+			[]byte(`{"tags":["a"]}`),
+			"SELECT * FROM `posts` WHERE (author_id = ?) AND (JSON_CONTAINS(`posts`.`data`, ?));",
+			`{"tags":["a"]}`,
+		},
+		{
+			&Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true, JSONContains: "@>"},
+			map[string][]string{"tags": {"a"}},
+			`SELECT * FROM "posts" WHERE (author_id = $1) AND ("posts"."data" @> $2);`,
+			`{"tags":["a"]}`,
+		},
+		{
+			&Dialect{LQ: '`', RQ: '`', JSONContains: "json_contains"},
+			struct {
+				Draft bool `json:"draft"`
+			}{true},
+			"SELECT * FROM `posts` WHERE (author_id = ?) AND (JSON_CONTAINS(`posts`.`data`, ?));",
+			`{"draft":true}`,
+		},
+	}
+
+	for i, test := range tests {
+		q := &Query{}
+		SetDialect(q, test.dialect)
+		SetFrom(q, "posts")
+		AppendWhere(q, "author_id = ?", 5)
+		AppendWhereJSONContains(q, "posts.data", test.value)
+
+		out, args, _ := buildQuery(q)
+		if out != test.expect {
+			t.Errorf("%d) mismatch:\nwant: %s\ngot:  %s", i, test.expect, out)
+		}
+		if !reflect.DeepEqual(args, []interface{}{5, test.arg}) {
+			t.Errorf("%d) wrong args: %#v", i, args)
+		}
+	}
+}
+
+func TestBuildQueryWhereInQuery(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		dialect *Dialect
+		selects []string
+		expect  string
+	}{
+		{
+			&Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true},
+			nil,
+			`SELECT * FROM "jets" WHERE (name <> $1) AND ("pilot_id" IN (SELECT "id" FROM "pilots" WHERE (active = $2) AND (age > $3))) AND (age < $4) AND "id" IN ($5,$6);`,
+		},
+		{
+			&Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true},
+			[]string{"pilots.id"},
+			`SELECT * FROM "jets" WHERE (name <> $1) AND ("pilot_id" IN (SELECT "pilots"."id" FROM "pilots" WHERE (active = $2) AND (age > $3))) AND (age < $4) AND "id" IN ($5,$6);`,
+		},
+		{
+			&Dialect{LQ: '`', RQ: '`'},
+			nil,
+			"SELECT * FROM `jets` WHERE (name <> ?) AND (`pilot_id` IN (SELECT `id` FROM `pilots` WHERE (active = ?) AND (age > ?))) AND (age < ?) AND `id` IN (?,?);",
+		},
+	}
+
+	for i, test := range tests {
+		sub := &Query{}
+		SetFrom(sub, "pilots")
+		SetKeyColumns(sub, "id")
+		AppendSelect(sub, test.selects...)
+		AppendWhere(sub, "active = ?", true)
+		AppendWhere(sub, "age > ?", 30)
+
+		q := &Query{}
+		SetDialect(q, test.dialect)
+		SetFrom(q, "jets")
+		AppendWhere(q, "name <> ?", "Concorde")
+		AppendWhereInQuery(q, "pilot_id", sub)
+		AppendWhere(q, "age < ?", 10)
+		AppendIn(q, "id IN ?", 1, 2)
+
+		out, args, _ := buildQuery(q)
+		if out != test.expect {
+			t.Errorf("%d) mismatch:\nwant: %s\ngot:  %s", i, test.expect, out)
+		}
+		if !reflect.DeepEqual(args, []interface{}{"Concorde", true, 30, 10, 1, 2}) {
+			t.Errorf("%d) wrong args: %#v", i, args)
+		}
+		if len(sub.rawSQL.sql) != 0 || sub.dialect != nil {
+			t.Errorf("%d) the subquery was changed by building the query", i)
+		}
+	}
+}
+
+func TestBuildQueryWhereInQueryInvalid(t *testing.T) {
+	t.Parallel()
+
+	sub := &Query{}
+	SetFrom(sub, "pilots")
+
+	q := &Query{}
+	SetDialect(q, &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true})
+	SetFrom(q, "jets")
+	AppendWhereInQuery(q, "pilot_id", sub)
+	if _, _, err := buildQuery(q); err == nil {
+		t.Error("Expected an error building a subquery without select or key columns")
+	}
+
+	sub = &Query{}
+	SetFrom(sub, "pilots")
+	AppendSelect(sub, "id")
+	SetDelete(sub)
+
+	q = &Query{}
+	SetDialect(q, &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true})
+	SetFrom(q, "jets")
+	AppendWhereInQuery(q, "pilot_id", sub)
+	if _, _, err := buildQuery(q); err == nil {
+		t.Error("Expected an error building a subquery that isn't a select")
+	}
+}
+
+func TestBuildQueryWithQuery(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		dialect *Dialect
+		expect  string
+	}{
+		{
+			&Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true},
+			`WITH active_pilots AS (SELECT * FROM "pilots" WHERE (active = $1) AND (age > $2)),` +
+				` busy_jets AS (SELECT "id" FROM "jets" WHERE (flights > $3))` +
+				` SELECT "jets".* FROM "jets" INNER JOIN active_pilots ap on ap.id = jets.pilot_id AND ap.rank = $4` +
+				` WHERE (jets.id IN (SELECT id FROM busy_jets)) AND (jets.name <> $5);`,
+		},
+		{
+			&Dialect{LQ: '`', RQ: '`'},
+			"WITH active_pilots AS (SELECT * FROM `pilots` WHERE (active = ?) AND (age > ?))," +
+				" busy_jets AS (SELECT `id` FROM `jets` WHERE (flights > ?))" +
+				" SELECT `jets`.* FROM `jets` INNER JOIN active_pilots ap on ap.id = jets.pilot_id AND ap.rank = ?" +
+				" WHERE (jets.id IN (SELECT id FROM busy_jets)) AND (jets.name <> ?);",
+		},
+	}
+
+	for i, test := range tests {
+		pilots := &Query{}
+		SetFrom(pilots, "pilots")
+		AppendWhere(pilots, "active = ?", true)
+		AppendWhere(pilots, "age > ?", 30)
+
+		jets := &Query{}
+		SetSelect(jets, []string{"id"})
+		SetFrom(jets, "jets")
+		AppendWhere(jets, "flights > ?", 100)
+
+		q := &Query{}
+		SetDialect(q, test.dialect)
+		SetFrom(q, "jets")
+		AppendWithQuery(q, "active_pilots", pilots)
+		AppendWithQuery(q, "busy_jets", jets)
+		AppendInnerJoin(q, "active_pilots ap on ap.id = jets.pilot_id AND ap.rank = ?", "captain")
+		AppendWhere(q, "jets.id IN (SELECT id FROM busy_jets)")
+		AppendWhere(q, "jets.name <> ?", "Concorde")
+
+		out, args, _ := buildQuery(q)
+		if out != test.expect {
+			t.Errorf("%d) mismatch:\nwant: %s\ngot:  %s", i, test.expect, out)
+		}
+		if want := []interface{}{true, 30, 100, "captain", "Concorde"}; !reflect.DeepEqual(args, want) {
+			t.Errorf("%d) want args %#v, got %#v", i, want, args)
+		}
+		if len(pilots.rawSQL.sql) != 0 || pilots.dialect != nil {
+			t.Errorf("%d) the model query was changed by building the query", i)
+		}
+	}
+}
+
+func TestBuildQueryWithQueryNotSelect(t *testing.T) {
+	t.Parallel()
+
+	pilots := &Query{}
+	SetFrom(pilots, "pilots")
+	SetDelete(pilots)
+
+	q := &Query{}
+	SetDialect(q, &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true})
+	SetFrom(q, "jets")
+	AppendWithQuery(q, "old_pilots", pilots)
+	if _, _, err := buildQuery(q); err == nil {
+		t.Error("Expected an error for a common table expression that isn't a select")
+	}
+}
+
+func TestBuildQueryWithQueryRecursive(t *testing.T) {
+	t.Parallel()
+
+	pilots := &Query{}
+	SetFrom(pilots, "pilots")
+	AppendWhere(pilots, "active = ?", true)
+
+	q := &Query{}
+	SetDialect(q, &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true})
+	SetFrom(q, "chain")
+	AppendWithQuery(q, "active_pilots", pilots)
+	AppendWithRecursive(q, "chain(id, manager_id)",
+		"SELECT id, manager_id FROM active_pilots WHERE id = ?",
+		"SELECT p.id, p.manager_id FROM active_pilots p INNER JOIN chain c ON p.id = c.manager_id", 10)
+
+	out, args, _ := buildQuery(q)
+	expect := `WITH RECURSIVE active_pilots AS (SELECT * FROM "pilots" WHERE (active = $1)),` +
+		` chain(id, manager_id) AS (SELECT id, manager_id FROM active_pilots WHERE id = $2` +
+		` UNION ALL SELECT p.id, p.manager_id FROM active_pilots p INNER JOIN chain c ON p.id = c.manager_id) SELECT * FROM "chain";`
+	if out != expect {
+		t.Errorf("mismatch:\nwant: %s\ngot:  %s", expect, out)
+	}
+	if want := []interface{}{true, 10}; !reflect.DeepEqual(args, want) {
+		t.Errorf("want args %#v, got %#v", want, args)
+	}
+}
+
+func TestBuildQueryWhereNot(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		dialect *Dialect
+		expect  string
+	}{
+		{
+			&Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true},
+			`SELECT * FROM "pilots" WHERE (a = $1) AND (NOT (b = $2 OR c = $3)) OR (d = $4);`,
+		},
+		{
+			&Dialect{LQ: '`', RQ: '`'},
+			"SELECT * FROM `pilots` WHERE (a = ?) AND (NOT (b = ? OR c = ?)) OR (d = ?);",
+		},
+	}
+
+	for i, test := range tests {
+		q := &Query{}
+		SetDialect(q, test.dialect)
+		SetFrom(q, "pilots")
+		AppendWhere(q, "a = ?", 1)
+		AppendWhereNot(q, "b = ? OR c = ?", 2, 3)
+		AppendWhere(q, "d = ?", 4)
+		SetLastWhereAsOr(q)
+
+		out, args, _ := buildQuery(q)
+		if out != test.expect {
+			t.Errorf("%d) mismatch:\nwant: %s\ngot:  %s", i, test.expect, out)
+		}
+		if !reflect.DeepEqual(args, []interface{}{1, 2, 3, 4}) {
+			t.Errorf("%d) wrong args: %#v", i, args)
+		}
+	}
+}
+
+func TestBuildQueryOrderByField(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		dialect *Dialect
+		expect  string
+	}{
+		{
+			&Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true, OrderByField: "array_position"},
+			`SELECT * FROM "pilots" WHERE (age > $1) AND "id" IN ($2,$3,$4) GROUP BY id HAVING count(*) > $5 ORDER BY array_position(ARRAY[$6, $7, $8], "id"), name;`,
+		},
+		{
+			&Dialect{LQ: '`', RQ: '`', OrderByField: "field"},
+			"SELECT * FROM `pilots` WHERE (age > ?) AND `id` IN (?,?,?) GROUP BY id HAVING count(*) > ? ORDER BY FIELD(`id`, ?, ?, ?), name;",
+		},
+		{
+			&Dialect{LQ: '[', RQ: ']', IndexPlaceholders: true},
+			`SELECT * FROM [pilots] WHERE (age > $1) AND [id] IN ($2,$3,$4) GROUP BY id HAVING count(*) > $5 ORDER BY CASE [id] WHEN $6 THEN 0 WHEN $7 THEN 1 WHEN $8 THEN 2 ELSE 3 END, name;`,
+		},
+	}
+
+	for i, test := range tests {
+		q := &Query{}
+		SetDialect(q, test.dialect)
+		SetFrom(q, "pilots")
+		AppendWhere(q, "age > ?", 30)
+		AppendIn(q, "id IN ?", 3, 1, 2)
+		AppendGroupBy(q, "id")
+		AppendHaving(q, "count(*) > ?", 1)
+		AppendOrderByField(q, "id", 3, 1, 2)
+		AppendOrderBy(q, "name")
+
+		out, args, _ := buildQuery(q)
+		if out != test.expect {
+			t.Errorf("%d) mismatch:\nwant: %s\ngot:  %s", i, test.expect, out)
+		}
+		if !reflect.DeepEqual(args, []interface{}{30, 3, 1, 2, 1, 3, 1, 2}) {
+			t.Errorf("%d) wrong args: %#v", i, args)
+		}
+	}
+
+	q := &Query{}
+	SetDialect(q, &Dialect{LQ: '`', RQ: '`', OrderByField: "field"})
+	AppendOrderByField(q, "id", 3, 1)
+	if got := q.OrderBy(); !reflect.DeepEqual(got, []string{"FIELD(`id`, ?, ?)"}) {
+		t.Errorf("wrong order by clauses: %#v", got)
+	}
+
+	q.SetOrderBy("name")
+	SetFrom(q, "pilots")
+	if out, args, _ := buildQuery(q); out != "SELECT * FROM `pilots` ORDER BY name;" || len(args) != 0 {
+		t.Errorf("want the value list ordering replaced, got %s %#v", out, args)
+	}
+}
+
+func TestBuildQueryOrderByAlias(t *testing.T) {
+	t.Parallel()
+
+	pg := &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true, OrderByField: "array_position"}
+
+	tests := []struct {
+		q      *Query
+		expect string
+	}{
+		{
+			&Query{
+				selectCols: []string{"COUNT(*) as cnt", "pilot_id"},
+				from:       []string{"jets"},
+				groupBy:    []string{"pilot_id"},
+				orderBy:    []string{"cnt desc"},
+			},
+			`SELECT COUNT(*) as cnt, "pilot_id" FROM "jets" GROUP BY pilot_id ORDER BY cnt desc;`,
+		},
+		{
+			&Query{
+				selectCols: []string{"a.happy", "r.fun"},
+				from:       []string{"happiness as a"},
+				joins:      []join{{clause: "rainbows r on a.id = r.happy_id"}},
+			},
+			`SELECT "a"."happy" as "a.happy", "r"."fun" as "r.fun" FROM happiness as a INNER JOIN rainbows r on a.id = r.happy_id` +
+				` ORDER BY array_position(ARRAY[$1, $2], "a.happy");`,
+		},
+		{
+			&Query{
+				selectCols: []string{`lower(name) AS "lname.x"`, `age as [years]`},
+				from:       []string{"pilots"},
+				distinctOn: []string{"lname.x"},
+				orderBy:    []string{`"lname.x"`},
+			},
+			`SELECT DISTINCT ON ("lname.x") lower(name) AS "lname.x", age as [years] FROM "pilots"` +
+				` ORDER BY "lname.x", array_position(ARRAY[$1, $2], "years");`,
+		},
+		{
+			&Query{selectCols: []string{"COUNT(*) as cnt"}, from: []string{"jets"}, distinctOn: []string{"cnt"}},
+			`SELECT DISTINCT ON ("cnt") COUNT(*) as cnt FROM "jets" ORDER BY "cnt";`,
+		},
+		{
+			&Query{from: []string{"pilots"}},
+			`SELECT * FROM "pilots" ORDER BY array_position(ARRAY[$1, $2], "pilots"."id");`,
+		},
+	}
+
+	for i, test := range tests {
+		test.q.dialect = pg
+		switch i {
+		case 1:
+			AppendOrderByField(test.q, "a.happy", 3, 1)
+		case 2:
+			AppendOrderByField(test.q, "years", 3, 1)
+		case 4:
+			AppendOrderByField(test.q, "pilots.id", 3, 1)
+		}
+
+		if out, _, _ := buildQuery(test.q); out != test.expect {
+			t.Errorf("%d) mismatch:\nwant: %s\ngot:  %s", i, test.expect, out)
+		}
+	}
+}
+
+func TestBuildQueryOrderByFieldNoValues(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic ordering by the position in an empty value list")
+		}
+	}()
+
+	AppendOrderByField(&Query{}, "id")
+}
+
+func TestBuildQuerySetSelect(t *testing.T) {
+	t.Parallel()
+
+	q := &Query{dialect: &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true}}
+	SetFrom(q, "pilots")
+	AppendSelect(q, "id", "name")
+	AppendWhere(q, "age > ?", 30)
+	AppendOrderBy(q, "name")
+
+	q.SetSelect("count(*)", "age")
+	out, args, _ := buildQuery(q)
+	if expect := `SELECT count(*), "age" FROM "pilots" WHERE (age > $1) ORDER BY name;`; out != expect {
+		t.Errorf("want the select replaced:\n%s\ngot:\n%s", expect, out)
+	}
+	if !reflect.DeepEqual(args, []interface{}{30}) {
+		t.Errorf("wrong args: %#v", args)
+	}
+
+	q = &Query{dialect: q.dialect}
+	SetFrom(q, "pilots")
+	AppendSelect(q, "id")
+	q.SetSelect()
+	if out, _, _ := buildQuery(q); out != `SELECT * FROM "pilots";` {
+		t.Errorf("want everything selected after an empty set, got %s", out)
+	}
+}
+
+func TestBuildQuerySchema(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		q      *Query
+		expect string
+	}{
+		{
+			&Query{dialect: &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true}, from: []string{`"public"."pilots"`}},
+			`SELECT * FROM "tenant_42"."pilots" WHERE (age > $1);`,
+		},
+		{
+			&Query{dialect: &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true}, from: []string{`"public"."pilots"`}, delete: true},
+			`DELETE FROM "tenant_42"."pilots" WHERE (age > $1);`,
+		},
+		{
+			&Query{dialect: &Dialect{LQ: '[', RQ: ']', IndexPlaceholders: true}, from: []string{`[dbo].[pilots]`}, count: true},
+			`SELECT COUNT(*) FROM [tenant_42].[pilots] WHERE (age > $1);`,
+		},
+		{
+			&Query{dialect: &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true}, from: []string{`"public"."pilots"`, "jets"}},
+			`SELECT * FROM "tenant_42"."pilots", "jets" WHERE (age > $1);`,
+		},
+	}
+
+	for i, test := range tests {
+		SetTable(test.q, test.q.from[0], "pilots")
+		SetSchema(test.q, "tenant_42")
+		AppendWhere(test.q, "age > ?", 30)
+
+		if out, _, _ := buildQuery(test.q); out != test.expect {
+			t.Errorf("%d) want:\n%s\ngot:\n%s", i, test.expect, out)
+		}
+	}
+}
+
+func TestSchemaTable(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		dialect Dialect
+		schema  string
+		expect  string
+	}{
+		{Dialect{LQ: '"', RQ: '"'}, "tenant", `"tenant"."pilots"`},
+		{Dialect{LQ: '"', RQ: '"'}, `a"; DROP TABLE pilots; --`, `"a""; DROP TABLE pilots; --"."pilots"`},
+		{Dialect{LQ: '`', RQ: '`'}, "a`b", "`a``b`.`pilots`"},
+		{Dialect{LQ: '[', RQ: ']'}, "a]b", "[a]]b].[pilots]"},
+	}
+
+	for i, test := range tests {
+		if got := SchemaTable(&test.dialect, test.schema, "pilots"); got != test.expect {
+			t.Errorf("%d) want: %s, got: %s", i, test.expect, got)
+		}
+	}
+}
+
+func TestBuildInsertSelectQuery(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		dialect *Dialect
+		expect  string
+	}{
+		{
+			&Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true},
+			`INSERT INTO "jet_archives" ("id", "name") SELECT "id", "name" FROM "jets" WHERE (pilot_id = $1) AND (name <> $2) LIMIT 10;`,
+		},
+		{
+			&Dialect{LQ: '`', RQ: '`'},
+			"INSERT INTO `jet_archives` (`id`, `name`) SELECT `id`, `name` FROM `jets` WHERE (pilot_id = ?) AND (name <> ?) LIMIT 10;",
+		},
+	}
+
+	for i, test := range tests {
+		source := &Query{}
+		SetFrom(source, "jets")
+		AppendSelect(source, "id", "name")
+		AppendWhere(source, "pilot_id = ?", 5)
+		AppendWhere(source, "name <> ?", "Concorde")
+		SetLimit(source, 10)
+
+		q := &Query{}
+		SetDialect(q, test.dialect)
+		SetFrom(q, "jet_archives")
+		SetInsertSelect(q, []string{"id", "name"}, source)
+
+		out, args, _ := buildQuery(q)
+		if out != test.expect {
+			t.Errorf("%d) mismatch:\nwant: %s\ngot:  %s", i, test.expect, out)
+		}
+		if !reflect.DeepEqual(args, []interface{}{5, "Concorde"}) {
+			t.Errorf("%d) want the args of the source query, got: %#v", i, args)
+		}
+	}
+}
+
+func TestBuildInsertSelectQueryNotSelect(t *testing.T) {
+	t.Parallel()
+
+	source := &Query{}
+	SetFrom(source, "jets")
+	SetDelete(source)
+
+	q := &Query{}
+	SetDialect(q, &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true})
+	SetFrom(q, "jet_archives")
+	SetInsertSelect(q, nil, source)
+
+	if _, _, err := buildQuery(q); err == nil {
+		t.Error("Expected an error inserting from a query that isn't a select")
+	}
+}
+
+func TestBuildCreateTableAsQuery(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		dialect   *Dialect
+		temporary bool
+		expect    string
+	}{
+		{
+			&Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true},
+			false,
+			`CREATE TABLE "jet_counts" AS SELECT pilot_id, count(*) FROM "jets" WHERE (name <> $1) GROUP BY pilot_id HAVING count(*) > $2;`,
+		},
+		{
+			&Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true},
+			true,
+			`CREATE TEMPORARY TABLE "jet_counts" AS SELECT pilot_id, count(*) FROM "jets" WHERE (name <> $1) GROUP BY pilot_id HAVING count(*) > $2;`,
+		},
+		{
+			&Dialect{LQ: '`', RQ: '`'},
+			true,
+			"CREATE TEMPORARY TABLE `jet_counts` AS SELECT pilot_id, count(*) FROM `jets` WHERE (name <> ?) GROUP BY pilot_id HAVING count(*) > ?;",
+		},
+	}
+
+	for i, test := range tests {
+		q := &Query{}
+		SetDialect(q, test.dialect)
+		SetFrom(q, "jets")
+		AppendSelect(q, "pilot_id, count(*)")
+		AppendWhere(q, "name <> ?", "Concorde")
+		AppendGroupBy(q, "pilot_id")
+		AppendHaving(q, "count(*) > ?", 2)
+
+		out, args, err := buildCreateTableAsQuery(q, "jet_counts", test.temporary)
+		if err != nil {
+			t.Fatalf("%d) %v", i, err)
+		}
+		if out != test.expect {
+			t.Errorf("%d) mismatch:\nwant: %s\ngot:  %s", i, test.expect, out)
+		}
+		if !reflect.DeepEqual(args, []interface{}{"Concorde", 2}) {
+			t.Errorf("%d) want the args of the query, got: %#v", i, args)
+		}
+	}
+}
+
+func TestBuildCreateTableAsQueryNotSelect(t *testing.T) {
+	t.Parallel()
+
+	q := &Query{}
+	SetDialect(q, &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true})
+	SetFrom(q, "jets")
+	SetUpdate(q, map[string]interface{}{"name": "Concorde"})
+
+	if _, _, err := buildCreateTableAsQuery(q, "jet_counts", false); err == nil {
+		t.Error("Expected an error creating a table from a query that isn't a select")
+	}
+}
+
+func TestBuildQueryPlaceholderMismatch(t *testing.T) {
+	t.Parallel()
+
+	mods := []func(q *Query){
+		func(q *Query) { AppendWhere(q, "a = ? AND b = ?", 1) },
+		func(q *Query) { AppendWhere(q, "a = ?", 1, 2) },
+		func(q *Query) { AppendWhere(q, "a = ?", 1); AppendHaving(q, "count(*) > ?") },
+		func(q *Query) { AppendInnerJoin(q, "b on b.id = a.b_id and b.x = ?") },
+	}
+
+	dialects := []*Dialect{
+		{LQ: '"', RQ: '"', IndexPlaceholders: true},
+		{LQ: '`', RQ: '`'},
+	}
+
+	for _, dialect := range dialects {
+		for i, mod := range mods {
+			q := &Query{}
+			SetDialect(q, dialect)
+			SetFrom(q, "a")
+			mod(q)
+
+			out, args, err := buildQuery(q)
+			if err == nil {
+				t.Errorf("%d) expected an error for a placeholder and argument mismatch", i)
+			}
+			if out != "" || args != nil {
+				t.Errorf("%d) expected no query with the error, got: %s %#v", i, out, args)
+			}
+			if q.rawSQL.sql != "" {
+				t.Errorf("%d) expected the query not to be cached, got: %s", i, q.rawSQL.sql)
+			}
+		}
+	}
+}
+
+func TestBuildQueryPlaceholderMismatchNested(t *testing.T) {
+	t.Parallel()
+
+	sub := &Query{}
+	SetFrom(sub, "b")
+	AppendSelect(sub, "a_id")
+	AppendWhere(sub, "x = ? AND y = ?", 1)
+
+	q := &Query{}
+	SetDialect(q, &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true})
+	SetFrom(q, "a")
+	AppendWhereInQuery(q, "id", sub)
+
+	if _, _, err := buildQuery(q); err == nil {
+		t.Error("expected the mismatch of the subquery to be returned")
+	}
+}
+
+func TestBuildQueryReusedPlaceholder(t *testing.T) {
+	t.Parallel()
+
+	q := &Query{}
+	SetDialect(q, &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true})
+	SetFrom(q, "a")
+	AppendWhere(q, "a = $1 OR b = $1", 1)
+
+	out, args, err := buildQuery(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect := `SELECT * FROM "a" WHERE (a = $1 OR b = $1);`; out != expect {
+		t.Errorf("want: %s\ngot:  %s", expect, out)
+	}
+	if !reflect.DeepEqual(args, []interface{}{1}) {
+		t.Errorf("want the one arg, got: %#v", args)
+	}
+}
+
+func TestCountPlaceholders(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		query   string
+		indexed bool
+		count   int
+	}{
+		{`SELECT * FROM "a" WHERE (a=$1) AND (b=$2 OR c=$3);`, true, 3},
+		{`SELECT * FROM "a" WHERE (a=$10);`, true, 10},
+		{`SELECT * FROM "a" WHERE (a=$1 OR b=$1);`, true, 1},
+		{`SELECT * FROM "a" WHERE (a=$2 OR b=$1);`, true, 2},
+		{`SELECT * FROM "a" WHERE (a='$1' AND "$2"=$1);`, true, 1},
+		{`SELECT * FROM "a" WHERE (a ? 'key') AND (b=$1);`, true, 1},
+		{"SELECT * FROM `a` WHERE (a=?) AND (b=?);", false, 2},
+		{"SELECT * FROM `a` WHERE (a='?' AND `?`=? AND c \\? 'd');", false, 1},
+		{"SELECT * FROM `a` WHERE (a='it''s ?' AND b=?);", false, 1},
+		{"SELECT * FROM `a` WHERE (a='it\\'s ?' AND b=?);", false, 1},
+		{"SELECT * FROM `a` WHERE (a=\"say \\\"?\\\"\" AND b=?);", false, 1},
+	}
+
+	for i, test := range tests {
+		if count := countPlaceholders(test.query, test.indexed); count != test.count {
+			t.Errorf("%d) want: %d, got: %d", i, test.count, count)
+		}
+	}
+}
+
+func TestBuildQueryForShareUnsupported(t *testing.T) {
+	t.Parallel()
+
+	mssql := &Dialect{LQ: '[', RQ: ']', IndexPlaceholders: true, UseTopClause: true}
+	mysql := &Dialect{LQ: '`', RQ: '`', ShareLock: "LOCK IN SHARE MODE"}
+
+	tests := []struct {
+		dialect *Dialect
+		noWait  bool
+	}{
+		{mssql, false},
+		{mssql, true},
+		{mysql, true},
+	}
+
+	for i, test := range tests {
+		q := &Query{}
+		SetDialect(q, test.dialect)
+		SetFrom(q, "pilots")
+		SetForShare(q, test.noWait)
+		if _, _, err := buildQuery(q); err == nil {
+			t.Errorf("%d) Expected an error for an unsupported shared row lock", i)
+		}
+	}
+}
+
+func TestBuildQueryWhereAnyUnsupported(t *testing.T) {
+	t.Parallel()
+
+	q := &Query{}
+	SetDialect(q, &Dialect{LQ: '`', RQ: '`'})
+	SetFrom(q, "pilots")
+	AppendWhereAny(q, "id", []int{1, 2})
+	if _, _, err := buildQuery(q); err == nil {
+		t.Error("Expected an error binding an array parameter without array support")
+	}
+}
+
+func TestBuildQueryWhereArrayLenUnsupported(t *testing.T) {
+	t.Parallel()
+
+	q := &Query{}
+	SetDialect(q, &Dialect{LQ: '`', RQ: '`'})
+	SetFrom(q, "pilots")
+	AppendWhereArrayLen(q, "tags", ">", 3)
+	if _, _, err := buildQuery(q); err == nil {
+		t.Error("Expected an error comparing an array length without array support")
+	}
+}
+
+func TestBuildQueryWhereJSONContainsUnsupported(t *testing.T) {
+	t.Parallel()
+
+	q := &Query{}
+	SetDialect(q, &Dialect{LQ: '[', RQ: ']', IndexPlaceholders: true})
+	SetFrom(q, "posts")
+	AppendWhereJSONContains(q, "data", map[string]int{"a": 1})
+	if _, _, err := buildQuery(q); err == nil {
+		t.Error("Expected an error matching JSON containment without support for it")
+	}
+}
+
+func TestBuildQueryGroupingUnsupported(t *testing.T) {
+	t.Parallel()
+
+	mods := []func(q *Query){
+		func(q *Query) { AppendCube(q, "a", "b") },
+		func(q *Query) { AppendGroupingSets(q, []string{"a"}, nil) },
+		func(q *Query) { AppendGroupBy(q, "c"); AppendRollup(q, "a", "b") },
+		func(q *Query) { AppendRollup(q, "a"); AppendRollup(q, "b") },
+	}
+
+	for i, mod := range mods {
+		q := &Query{}
+		SetDialect(q, &Dialect{LQ: '`', RQ: '`'})
+		SetFrom(q, "flights")
+		mod(q)
+
+		if _, _, err := buildQuery(q); err == nil {
+			t.Errorf("%d) Expected an error grouping without grouping sets support", i)
+		}
+	}
+}
+
+func TestBuildQueryFromOnlyUnsupported(t *testing.T) {
+	t.Parallel()
+
+	q := &Query{}
+	SetDialect(q, &Dialect{LQ: '`', RQ: '`'})
+	AppendFromOnly(q, "flights")
+	if _, _, err := buildQuery(q); err == nil {
+		t.Error("Expected an error selecting from only without FROM ONLY support")
+	}
+}
+
+func TestBuildQueryTableSampleUnsupported(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := buildQuery(&Query{
+		dialect:       &Dialect{LQ: '`', RQ: '`'},
+		from:          []string{"pilots"},
+		sampleMethod:  "BERNOULLI",
+		samplePercent: 10,
+	})
+	if err == nil {
+		t.Error("Expected an error sampling a table without TABLESAMPLE support")
+	}
+}
+
+func TestBuildQueryIndexHint(t *testing.T) {
+	t.Parallel()
+
+	dia := &Dialect{LQ: '`', RQ: '`', UseIndexHints: true}
+
+	tests := []struct {
+		q      func() *Query
+		expect string
+	}{
+		{
+			func() *Query {
+				q := &Query{}
+				SetFrom(q, "`jets`")
+				AppendIndexHint(q, "", "FORCE INDEX (idx_name)")
+				AppendInnerJoin(q, "pilots p on p.id = jets.pilot_id")
+				AppendWhere(q, "p.age > ?", 30)
+				return q
+			},
+			"SELECT `jets`.* FROM `jets` FORCE INDEX (idx_name) INNER JOIN pilots p on p.id = jets.pilot_id WHERE (p.age > ?);",
+		},
+		{
+			func() *Query {
+				q := &Query{}
+				SetFrom(q, "`jets`", "pilots as p", "`hangars`")
+				AppendIndexHint(q, "p", "USE INDEX (idx_age)")
+				AppendIndexHint(q, "hangars", "IGNORE INDEX (idx_size)")
+				AppendIndexHint(q, "", "FORCE INDEX (idx_name)")
+				AppendWhere(q, "p.id = jets.pilot_id")
+				return q
+			},
+			"SELECT * FROM `jets` FORCE INDEX (idx_name), pilots as p USE INDEX (idx_age), `hangars` IGNORE INDEX (idx_size) WHERE (p.id = jets.pilot_id);",
+		},
+	}
+
+	for i, test := range tests {
+		q := test.q()
+		SetDialect(q, dia)
+
+		out, _, _ := buildQuery(q)
+		if out != test.expect {
+			t.Errorf("%d) mismatch:\nwant: %s\ngot:  %s", i, test.expect, out)
+		}
+	}
+}
+
+func TestBuildQueryIndexHintInvalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		dialect *Dialect
+		table   string
+	}{
+		{&Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true}, ""},
+		{&Dialect{LQ: '`', RQ: '`', UseIndexHints: true}, "hangars"},
+	}
+
+	for i, test := range tests {
+		q := &Query{}
+		SetDialect(q, test.dialect)
+		SetFrom(q, "jets")
+		AppendIndexHint(q, test.table, "FORCE INDEX (idx_name)")
+		if _, _, err := buildQuery(q); err == nil {
+			t.Errorf("%d) Expected an error", i)
+		}
+	}
+}
+
+func TestBuildQueryDistinctOnOrderBy(t *testing.T) {
+	t.Parallel()
+
+	tests := [][]string{
+		{"departed_at"},
+		{"departed_at, pilot_id"},
+		{orderByRandom, "pilot_id"},
+	}
+
+	for i, orderBy := range tests {
+		_, _, err := buildQuery(&Query{
+			dialect:    &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true},
+			from:       []string{"flights"},
+			distinctOn: []string{"pilot_id"},
+			orderBy:    orderBy,
+		})
+		if err == nil || !strings.Contains(err.Error(), "DISTINCT ON") {
+			t.Errorf("%d) Expected an error ordering DISTINCT ON by %v, got: %v", i, orderBy, err)
+		}
+	}
+}
+
+func TestBuildQueryDistinct(t *testing.T) {
+	t.Parallel()
+
+	pg := &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true, UseDistinctOn: true, UseRowValues: true}
+	mysql := &Dialect{LQ: '`', RQ: '`'}
+
+	// pilots joined with their jets repeats a pilot for each of their jets
+	joined := func(dialect *Dialect, mods ...func(q *Query)) *Query {
+		q := &Query{}
+		SetDialect(q, dialect)
+		SetFrom(q, "pilots")
+		SetKeyColumns(q, "id")
+		SetTable(q, "pilots", "pilots")
+		AppendInnerJoin(q, "jets j on j.pilot_id = pilots.id")
+		SetDistinct(q)
+		for _, mod := range mods {
+			mod(q)
+		}
+		return q
+	}
+
+	tests := []struct {
+		q      *Query
+		expect string
+	}{
+		{
+			joined(pg),
+			`SELECT DISTINCT ON ("pilots"."id") "pilots".* FROM "pilots" INNER JOIN jets j on j.pilot_id = pilots.id ORDER BY "pilots"."id";`,
+		},
+		{
+			joined(pg, func(q *Query) { AppendOrderBy(q, "pilots.id, j.departed_at desc") }),
+			`SELECT DISTINCT ON ("pilots"."id") "pilots".* FROM "pilots" INNER JOIN jets j on j.pilot_id = pilots.id` +
+				` ORDER BY pilots.id, j.departed_at desc;`,
+		},
+		{
+			joined(pg, func(q *Query) { SetKeyColumns(q, "airline", "number") }),
+			`SELECT DISTINCT ON ("pilots"."airline", "pilots"."number") "pilots".* FROM "pilots" INNER JOIN jets j on j.pilot_id = pilots.id` +
+				` ORDER BY "pilots"."airline", "pilots"."number";`,
+		},
+		{
+			joined(pg, func(q *Query) { SetSchema(q, "tenant") }),
+			`SELECT DISTINCT ON ("tenant"."pilots"."id") "tenant"."pilots".* FROM "tenant"."pilots" INNER JOIN jets j on j.pilot_id = pilots.id` +
+				` ORDER BY "tenant"."pilots"."id";`,
+		},
+		{
+			joined(pg, SetCount),
+			`SELECT COUNT(DISTINCT "pilots"."id") FROM "pilots" INNER JOIN jets j on j.pilot_id = pilots.id;`,
+		},
+		{
+			joined(pg, SetCount, func(q *Query) { SetKeyColumns(q, "airline", "number") }),
+			`SELECT COUNT(DISTINCT ("pilots"."airline", "pilots"."number")) FROM "pilots" INNER JOIN jets j on j.pilot_id = pilots.id;`,
+		},
+		{
+			joined(mysql),
+			"SELECT DISTINCT `pilots`.* FROM `pilots` INNER JOIN jets j on j.pilot_id = pilots.id;",
+		},
+		{
+			joined(mysql, SetCount, func(q *Query) { SetKeyColumns(q, "airline", "number") }),
+			"SELECT COUNT(DISTINCT `pilots`.`airline`, `pilots`.`number`) FROM `pilots` INNER JOIN jets j on j.pilot_id = pilots.id;",
+		},
+		{
+			&Query{dialect: pg, from: []string{"pilots"}, keyColumns: []string{"id"}, tableFrom: "pilots", distinct: true},
+			`SELECT DISTINCT * FROM "pilots";`,
+		},
+		{
+			&Query{dialect: pg, from: []string{"pilots"}, selectCols: []string{"name"}, distinct: true, count: true},
+			`SELECT COUNT(DISTINCT "name") FROM "pilots";`,
+		},
+		{
+			&Query{dialect: pg, from: []string{"pilots"}, distinct: true, count: true},
+			`SELECT COUNT(*) FROM "pilots";`,
+		},
+	}
+
+	for i, test := range tests {
+		if out, _, _ := buildQuery(test.q); out != test.expect {
+			t.Errorf("%d) mismatch:\nwant: %s\ngot:  %s", i, test.expect, out)
+		}
+	}
+}
+
+// TestBuildQueryLimits is not parallel because it changes the global
+// query limits, which are reset before any parallel test resumes.
+func TestBuildQueryLimits(t *testing.T) {
+	defer boil.SetQueryLimits(boil.GetQueryLimits())
+
+	tests := []struct {
+		defaultLimit int
+		maxLimit     int
+		q            *Query
+		expect       string
 	}{
-		{&Query{from: []string{"t"}}, nil},
-		{&Query{from: []string{"q"}, limit: 5, offset: 6}, nil},
-		{&Query{from: []string{"q"}, orderBy: []string{"a ASC", "b DESC"}}, nil},
-		{&Query{from: []string{"t"}, selectCols: []string{"count(*) as ab, thing as bd", `"stuff"`}}, nil},
-		{&Query{from: []string{"a", "b"}, selectCols: []string{"count(*) as ab, thing as bd", `"stuff"`}}, nil},
-		{&Query{
-			selectCols: []string{"a.happy", "r.fun", "q"},
-			from:       []string{"happiness as a"},
-			joins:      []join{{clause: "rainbows r on a.id = r.happy_id"}},
-		}, nil},
-		{&Query{
-			from:  []string{"happiness as a"},
-			joins: []join{{clause: "rainbows r on a.id = r.happy_id"}},
-		}, nil},
-		{&Query{
-			from: []string{"videos"},
-			joins: []join{{
-				clause: "(select id from users where deleted = ?) u on u.id = videos.user_id",
-				args:   []interface{}{true},
-			}},
-			where: []where{{clause: "videos.deleted = ?", args: []interface{}{false}}},
-		}, []interface{}{true, false}},
-		{&Query{
-			from:    []string{"a"},
-			groupBy: []string{"id", "name"},
-			where: []where{
-				{clause: "a=? or b=?", args: []interface{}{1, 2}},
-				{clause: "c=?", args: []interface{}{3}},
-			},
-			having: []having{
-				{clause: "id <> ?", args: []interface{}{1}},
-				{clause: "length(name, ?) > ?", args: []interface{}{"utf8", 5}},
-			},
-		}, []interface{}{1, 2, 3, 1, "utf8", 5}},
-		{&Query{
-			delete: true,
-			from:   []string{"thing happy", `upset as "sad"`, "fun", "thing as stuff", `"angry" as mad`},
-			where: []where{
-				{clause: "a=?", args: []interface{}{1}},
-				{clause: "b=?", args: []interface{}{2}},
-				{clause: "c=?", args: []interface{}{3}},
-			},
-		}, []interface{}{1, 2, 3}},
-		{&Query{
-			delete: true,
-			from:   []string{"thing happy", `upset as "sad"`, "fun", "thing as stuff", `"angry" as mad`},
-			where: []where{
-				{clause: "(id=? and thing=?) or stuff=?", args: []interface{}{1, 2, 3}},
-			},
-			limit: 5,
-		}, []interface{}{1, 2, 3}},
-		{&Query{
-			from: []string{"thing happy", `"fun"`, `stuff`},
-			update: map[string]interface{}{
-				"col1":       1,
-				`"col2"`:     2,
-				`"fun".col3`: 3,
-			},
-			where: []where{
-				{clause: "aa=? or bb=? or cc=?", orSeparator: true, args: []interface{}{4, 5, 6}},
-				{clause: "dd=? or ee=? or ff=? and gg=?", args: []interface{}{7, 8, 9, 10}},
-			},
-			limit: 5,
-		}, []interface{}{2, 3, 1, 4, 5, 6, 7, 8, 9, 10}},
-		{&Query{from: []string{"cats"}, joins: []join{{JoinInner, "dogs d on d.cat_id = cats.id", nil}}}, nil},
-		{&Query{from: []string{"cats c"}, joins: []join{{JoinInner, "dogs d on d.cat_id = cats.id", nil}}}, nil},
-		{&Query{from: []string{"cats as c"}, joins: []join{{JoinInner, "dogs d on d.cat_id = cats.id", nil}}}, nil},
-		{&Query{from: []string{"cats as c", "dogs as d"}, joins: []join{{JoinInner, "dogs d on d.cat_id = cats.id", nil}}}, nil},
+		{0, 0, &Query{from: []string{"pilots"}}, `SELECT * FROM "pilots";`},
+		{10, 0, &Query{from: []string{"pilots"}}, `SELECT * FROM "pilots" LIMIT 10;`},
+		{10, 0, &Query{from: []string{"pilots"}, limit: 50}, `SELECT * FROM "pilots" LIMIT 50;`},
+		{10, 20, &Query{from: []string{"pilots"}, limit: 50, offset: 5}, `SELECT * FROM "pilots" LIMIT 20 OFFSET 5;`},
+		{0, 20, &Query{from: []string{"pilots"}}, `SELECT * FROM "pilots" LIMIT 20;`},
+		{10, 20, &Query{from: []string{"pilots"}, count: true}, `SELECT COUNT(*) FROM "pilots";`},
+		{10, 20, &Query{from: []string{"pilots"}, delete: true}, `DELETE FROM "pilots";`},
+		{10, 20, &Query{rawSQL: rawSQL{sql: `SELECT * FROM "pilots";`}}, `SELECT * FROM "pilots";`},
 	}
 
 	for i, test := range tests {
-		filename := filepath.Join("_fixtures", fmt.Sprintf("%02d.sql", i))
+		boil.SetQueryLimits(test.defaultLimit, test.maxLimit)
 		test.q.dialect = &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true}
-		out, args := buildQuery(test.q)
 
-		if *writeGoldenFiles {
-			err := ioutil.WriteFile(filename, []byte(out), 0664)
-			if err != nil {
-				t.Fatalf("Failed to write golden file %s: %s\n", filename, err)
-			}
-			t.Logf("wrote golden file: %s\n", filename)
-			continue
+		if out, _, _ := buildQuery(test.q); out != test.expect {
+			t.Errorf("%d) mismatch:\nwant: %s\ngot:  %s", i, test.expect, out)
 		}
+	}
 
-		byt, err := ioutil.ReadFile(filename)
-		if err != nil {
-			t.Fatalf("Failed to read golden file %q: %v", filename, err)
+	if test := (&Query{from: []string{"pilots"}, limit: 50}); selectLimit(test) != 20 || test.limit != 50 {
+		t.Error("Expected the limit to be bounded without changing the query")
+	}
+
+	// Only the outermost select is limited, not the ones nested in it
+	boil.SetQueryLimits(100, 0)
+	dia := &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true}
+	nested := []struct {
+		q      func(sub *Query) *Query
+		expect string
+	}{
+		{
+			func(sub *Query) *Query {
+				q := &Query{dialect: dia, from: []string{"jets"}}
+				AppendWhereInQuery(q, "pilot_id", sub)
+				return q
+			},
+			`SELECT * FROM "jets" WHERE ("pilot_id" IN (SELECT "id" FROM "pilots")) LIMIT 100;`,
+		},
+		{
+			func(sub *Query) *Query {
+				q := &Query{dialect: dia, from: []string{"jets"}}
+				AppendWithQuery(q, "p", sub)
+				return q
+			},
+			`WITH p AS (SELECT "id" FROM "pilots") SELECT * FROM "jets" LIMIT 100;`,
+		},
+		{
+			func(sub *Query) *Query {
+				q := &Query{dialect: dia, from: []string{"pilot_archives"}}
+				SetInsertSelect(q, []string{"id"}, sub)
+				return q
+			},
+			`INSERT INTO "pilot_archives" ("id") SELECT "id" FROM "pilots";`,
+		},
+	}
+	for i, test := range nested {
+		sub := &Query{from: []string{"pilots"}}
+		AppendSelect(sub, `"id"`)
+
+		if out, _, err := buildQuery(test.q(sub)); err != nil || out != test.expect {
+			t.Errorf("%d) mismatch:\nwant: %s\ngot:  %s (%v)", i, test.expect, out, err)
 		}
+	}
+
+	q := &Query{dialect: dia, from: []string{"pilots"}}
+	if out, _, err := buildCreateTableAsQuery(q, "pilot_copies", false); err != nil || out != `CREATE TABLE "pilot_copies" AS SELECT * FROM "pilots";` {
+		t.Errorf("Expected create table as to copy all rows, got: %s (%v)", out, err)
+	}
+	if out, _, _ := buildQuery(q); out != `SELECT * FROM "pilots" LIMIT 100;` {
+		t.Errorf("Expected the query to still be limited on its own, got: %s", out)
+	}
 
-		if string(bytes.TrimSpace(byt)) != out {
-			t.Errorf("[%02d] Test failed:\nWant:\n%s\nGot:\n%s", i, byt, out)
+	for _, q := range []*Query{
+		{dialect: &Dialect{LQ: '"', RQ: '"'}, from: []string{"pilots"}, limit: -1},
+		{dialect: &Dialect{LQ: '"', RQ: '"'}, from: []string{"pilots"}, offset: -1},
+	} {
+		if _, _, err := buildQuery(q); err == nil {
+			t.Errorf("Expected an error building LIMIT %d OFFSET %d", q.limit, q.offset)
 		}
+	}
 
-		if !reflect.DeepEqual(args, test.args) {
-			t.Errorf("[%02d] Test failed:\nWant:\n%s\nGot:\n%s", i, spew.Sdump(test.args), spew.Sdump(args))
+	boil.SetQueryLimits(-1, -5)
+	if def, max := boil.GetQueryLimits(); def != 0 || max != 0 {
+		t.Errorf("Expected negative query limits to be taken as zero, got %d and %d", def, max)
+	}
+}
+
+// TestBuildQueryNullsOrder is not parallel because it changes the global
+// nulls order, which is reset before any parallel test resumes.
+func TestBuildQueryNullsOrder(t *testing.T) {
+	defer boil.SetNullsOrder(boil.GetNullsOrder())
+
+	pg := &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true, UseNullsOrder: true, OrderByField: "array_position"}
+	mysql := &Dialect{LQ: '`', RQ: '`'}
+
+	tests := []struct {
+		global  string
+		dialect *Dialect
+		mod     func(q *Query)
+		expect  string
+	}{
+		{boil.NullsDefault, pg, nil, `SELECT * FROM "pilots" ORDER BY name, age desc;`},
+		{boil.NullsLast, pg, nil, `SELECT * FROM "pilots" ORDER BY name NULLS LAST, age desc NULLS LAST;`},
+		{boil.NullsFirst, pg, nil, `SELECT * FROM "pilots" ORDER BY name NULLS FIRST, age desc NULLS FIRST;`},
+		{boil.NullsLast, mysql, nil, "SELECT * FROM `pilots` ORDER BY name, age desc;"},
+		{boil.NullsLast, pg, func(q *Query) { SetNullsOrder(q, boil.NullsFirst) },
+			`SELECT * FROM "pilots" ORDER BY name NULLS FIRST, age desc NULLS FIRST;`},
+		{boil.NullsLast, pg, func(q *Query) { SetNullsOrder(q, boil.NullsDefault) },
+			`SELECT * FROM "pilots" ORDER BY name, age desc;`},
+		{boil.NullsDefault, pg, func(q *Query) { SetNullsOrder(q, boil.NullsLast) },
+			`SELECT * FROM "pilots" ORDER BY name NULLS LAST, age desc NULLS LAST;`},
+		{boil.NullsLast, pg, func(q *Query) { q.SetOrderBy("coalesce(rank, 0), name nulls first", "age DESC NULLS FIRST") },
+			`SELECT * FROM "pilots" ORDER BY coalesce(rank, 0) NULLS LAST, name nulls first, age DESC NULLS FIRST;`},
+		{boil.NullsLast, pg, func(q *Query) { q.SetOrderBy(); AppendOrderByRandom(q); AppendOrderByField(q, "id", 3, 1) },
+			`SELECT * FROM "pilots" ORDER BY RANDOM(), array_position(ARRAY[$1, $2], "id");`},
+	}
+
+	for i, test := range tests {
+		boil.SetNullsOrder(test.global)
+		q := &Query{dialect: test.dialect, from: []string{"pilots"}}
+		AppendOrderBy(q, "name, age desc")
+		if test.mod != nil {
+			test.mod(q)
+		}
+
+		if out, _, _ := buildQuery(q); out != test.expect {
+			t.Errorf("%d) mismatch:\nwant: %s\ngot:  %s", i, test.expect, out)
 		}
 	}
+
+	if got := (&Query{orderBy: []string{"name"}}).OrderBy(); !reflect.DeepEqual(got, []string{"name"}) {
+		t.Errorf("Expected the order by clauses to be unchanged, got: %#v", got)
+	}
+}
+
+func TestSetNullsOrderInvalid(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic setting an invalid nulls order")
+		}
+	}()
+
+	SetNullsOrder(&Query{}, "NULLS LAST")
 }
 
 func TestWriteStars(t *testing.T) {
@@ -147,6 +2072,14 @@ func TestWriteStars(t *testing.T) {
 			In:  Query{from: []string{`a as b`, `c as d`}},
 			Out: []string{`"b".*`, `"d".*`},
 		},
+		{
+			In:  Query{from: []string{fromOnlyPrefix + `a`}},
+			Out: []string{`"a".*`},
+		},
+		{
+			In:  Query{from: []string{fromOnlyPrefix + `a as b`, `only c d`}},
+			Out: []string{`"b".*`, `"d".*`},
+		},
 	}
 
 	for i, test := range tests {
@@ -274,6 +2207,47 @@ func TestWhereClause(t *testing.T) {
 			},
 			expect: " WHERE (a=$1 or b=$2) OR (c=$3 and d=$4) AND (e=$5 or f=$6)",
 		},
+		// Where("a=? OR b=?"), Where("c=?")
+		{
+			q: Query{
+				where: []where{
+					{clause: "a=? OR b=?"},
+					{clause: "c=?"},
+				},
+			},
+			expect: " WHERE (a=$1 OR b=$2) AND (c=$3)",
+		},
+		// Where("a=?"), Where("b=? OR c=?"), Where("d=?")
+		{
+			q: Query{
+				where: []where{
+					{clause: "a=?"},
+					{clause: "b=? OR c=?"},
+					{clause: "d=?"},
+				},
+			},
+			expect: " WHERE (a=$1) AND (b=$2 OR c=$3) AND (d=$4)",
+		},
+		// Where("(a=? OR b=?) AND c=? OR d=?"), Where("e=?")
+		{
+			q: Query{
+				where: []where{
+					{clause: "(a=? OR b=?) AND c=? OR d=?"},
+					{clause: "e=?"},
+				},
+			},
+			expect: " WHERE ((a=$1 OR b=$2) AND c=$3 OR d=$4) AND (e=$5)",
+		},
+		// Where("(a=? OR b=?)"), Where("c=?")
+		{
+			q: Query{
+				where: []where{
+					{clause: "(a=? OR b=?)"},
+					{clause: "c=?"},
+				},
+			},
+			expect: " WHERE ((a=$1 OR b=$2)) AND (c=$3)",
+		},
 	}
 
 	for i, test := range tests {
@@ -453,6 +2427,8 @@ func TestConvertQuestionMarks(t *testing.T) {
 		{clause: `\?\?\?`, start: 1, expect: `???`},
 		{clause: `\??\??\??`, start: 1, expect: `?$1?$2?$3`, count: 3},
 		{clause: `?\??\??\?`, start: 1, expect: `$1?$2?$3?`, count: 3},
+		{clause: `(data->>'age')::int > ?`, start: 1, expect: `(data->>'age')::int > $1`, count: 1},
+		{clause: `data->'meta' \?| array['a'] and id=?`, start: 3, expect: `data->'meta' ?| array['a'] and id=$3`, count: 1},
 	}
 
 	for i, test := range tests {
@@ -466,6 +2442,42 @@ func TestConvertQuestionMarks(t *testing.T) {
 	}
 }
 
+func TestConvertNamedParams(t *testing.T) {
+	t.Parallel()
+
+	params := map[string]interface{}{"a": 1, "b": "two", "id_2": 3}
+
+	tests := []struct {
+		clause string
+		expect string
+		args   []interface{}
+	}{
+		{clause: "a = :a AND b = :b", expect: "a = ? AND b = ?", args: []interface{}{1, "two"}},
+		{clause: "b = :b OR (a > :a AND a < :a + 5)", expect: "b = ? OR (a > ? AND a < ? + 5)", args: []interface{}{"two", 1, 1}},
+		{clause: "id = :id_2::int", expect: "id = ?::int", args: []interface{}{3}},
+		{clause: "t = '12:00' AND x = :a", expect: "t = '12:00' AND x = ?", args: []interface{}{1}},
+		{clause: `data ? 'k?' AND x \? :b`, expect: `data \? 'k\?' AND x \? ?`, args: []interface{}{"two"}},
+		{clause: "hello: friend", expect: "hello: friend"},
+	}
+
+	for i, test := range tests {
+		res, args := convertNamedParams(test.clause, params)
+		if res != test.expect {
+			t.Errorf("%d) Mismatch between expect and result:\n%s\n%s\n", i, test.expect, res)
+		}
+		if !reflect.DeepEqual(args, test.args) {
+			t.Errorf("%d) Expected args %#v, got %#v", i, test.args, args)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for a named parameter without a value")
+		}
+	}()
+	convertNamedParams("a = :missing", params)
+}
+
 func TestConvertInQuestionMarks(t *testing.T) {
 	t.Parallel()
 
@@ -545,3 +2557,164 @@ func TestWriteAsStatements(t *testing.T) {
 		}
 	}
 }
+
+func TestBuildUpsertQueryInserted(t *testing.T) {
+	t.Parallel()
+
+	dia := Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true, UpsertSyntax: "postgres"}
+	mssql := Dialect{LQ: '[', RQ: ']', IndexPlaceholders: true, UpsertSyntax: "mssql"}
+
+	tests := []struct {
+		Got  string
+		Want string
+	}{
+		{
+			dia.BuildUpsert(Upsert{Table: `"pilots"`, Insert: []string{"name"}, Update: []string{"name"}, Conflict: []string{"id"}, Return: []string{"id"}, Inserted: true}),
+			`INSERT INTO "pilots" ("name") VALUES ($1) ON CONFLICT ("id") DO UPDATE SET "name" = EXCLUDED."name" RETURNING "id", (xmax = 0) AS inserted`,
+		},
+		{
+			dia.BuildUpsert(Upsert{Table: `"pilots"`, Insert: []string{"id", "name"}, Conflict: []string{"id"}, Inserted: true}),
+			`INSERT INTO "pilots" ("id", "name") VALUES ($1,$2) ON CONFLICT DO NOTHING RETURNING (xmax = 0) AS inserted`,
+		},
+		{
+			mssql.BuildUpsert(Upsert{Table: "pilots", Insert: []string{"name"}, Update: []string{"name"}, Primary: []string{"id"}, Return: []string{"id"}, Inserted: true}),
+			"MERGE INTO pilots as [t]\nUSING (SELECT $1) as [s] ([id])\nON ([s].[id] = [t].[id])\nWHEN MATCHED THEN UPDATE SET [name]=$2\nWHEN NOT MATCHED THEN INSERT ([name]) VALUES ($3)\nOUTPUT INSERTED.[id],$action;",
+		},
+		{
+			mssql.BuildUpsert(Upsert{Table: "pilots", Insert: []string{"name"}, Update: []string{"name"}, Primary: []string{"id"}, Inserted: true}),
+			"MERGE INTO pilots as [t]\nUSING (SELECT $1) as [s] ([id])\nON ([s].[id] = [t].[id])\nWHEN MATCHED THEN UPDATE SET [name]=$2\nWHEN NOT MATCHED THEN INSERT ([name]) VALUES ($3)\nOUTPUT $action;",
+		},
+	}
+
+	for i, test := range tests {
+		if test.Got != test.Want {
+			t.Errorf("%d) want:\n%s\ngot:\n%s", i, test.Want, test.Got)
+		}
+	}
+}
+
+func TestBuildUpsertQueryUpdateColumns(t *testing.T) {
+	t.Parallel()
+
+	insert := []string{"id", "created_at", "updated_at", "count"}
+	update := []string{"updated_at", "count"}
+
+	tests := []struct {
+		Got  string
+		Want string
+	}{
+		{
+			BuildUpsertQueryPostgres(Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true}, `"pilots"`, true, nil, update, []string{"id"}, insert),
+			`INSERT INTO "pilots" ("id", "created_at", "updated_at", "count") VALUES ($1,$2,$3,$4) ON CONFLICT ("id") DO UPDATE SET "updated_at" = EXCLUDED."updated_at","count" = EXCLUDED."count"`,
+		},
+		{
+			BuildUpsertQueryMySQL(Dialect{LQ: '`', RQ: '`'}, "pilots", update, insert),
+			"INSERT INTO pilots (`id`, `created_at`, `updated_at`, `count`) VALUES (?,?,?,?) ON DUPLICATE KEY UPDATE `updated_at` = VALUES(`updated_at`),`count` = VALUES(`count`)",
+		},
+	}
+
+	for i, test := range tests {
+		if test.Got != test.Want {
+			t.Errorf("%d) want:\n%s\ngot:\n%s", i, test.Want, test.Got)
+		}
+	}
+}
+
+func TestBuildUpsertAllQuery(t *testing.T) {
+	t.Parallel()
+
+	insert := []string{"id", "name", "created_at"}
+	update := []string{"name"}
+	defaults := [][]string{nil, {"created_at"}, nil}
+	postgres := Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true, UpsertSyntax: "postgres"}
+	mysql := Dialect{LQ: '`', RQ: '`', UpsertSyntax: "mysql"}
+
+	tests := []struct {
+		Got  string
+		Want string
+	}{
+		{
+			postgres.BuildUpsert(Upsert{Table: `"pilots"`, Insert: insert, Update: update, Conflict: []string{"id"}, Defaults: defaults}),
+			`INSERT INTO "pilots" ("id", "name", "created_at") VALUES ($1,$2,$3),($4,$5,DEFAULT),($6,$7,$8) ON CONFLICT ("id") DO UPDATE SET "name" = EXCLUDED."name"`,
+		},
+		{
+			postgres.BuildUpsert(Upsert{Table: `"pilots"`, Insert: insert, Conflict: []string{"id"}, Defaults: defaults}),
+			`INSERT INTO "pilots" ("id", "name", "created_at") VALUES ($1,$2,$3),($4,$5,DEFAULT),($6,$7,$8) ON CONFLICT DO NOTHING`,
+		},
+		{
+			mysql.BuildUpsert(Upsert{Table: "pilots", Insert: insert, Update: update, Defaults: defaults}),
+			"INSERT INTO pilots (`id`, `name`, `created_at`) VALUES (?,?,?),(?,?,DEFAULT),(?,?,?) ON DUPLICATE KEY UPDATE `name` = VALUES(`name`)",
+		},
+	}
+
+	for i, test := range tests {
+		if test.Got != test.Want {
+			t.Errorf("%d) want:\n%s\ngot:\n%s", i, test.Want, test.Got)
+		}
+	}
+}
+
+func TestBuildUpsertQueryPostgresConflictTarget(t *testing.T) {
+	t.Parallel()
+
+	dia := Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true, UpsertSyntax: "postgres"}
+
+	tests := []struct {
+		Got  string
+		Want string
+	}{
+		{
+			BuildUpsertQueryPostgres(dia, `"pilots"`, true, nil, []string{"name"}, []string{"id", "code"}, []string{"id", "name"}),
+			`INSERT INTO "pilots" ("id", "name") VALUES ($1,$2) ON CONFLICT ("id", "code") DO UPDATE SET "name" = EXCLUDED."name"`,
+		},
+		{
+			dia.BuildUpsert(Upsert{Table: `"pilots"`, Insert: []string{"id", "name"}, Update: []string{"name"}, Constraint: "pilots_code_key"}),
+			`INSERT INTO "pilots" ("id", "name") VALUES ($1,$2) ON CONFLICT ON CONSTRAINT "pilots_code_key" DO UPDATE SET "name" = EXCLUDED."name"`,
+		},
+		{
+			dia.BuildUpsert(Upsert{Table: `"pilots"`, Insert: []string{"name"}, Constraint: "pilots_code_key", Return: []string{"id"}}),
+			`INSERT INTO "pilots" ("name") VALUES ($1) ON CONFLICT ON CONSTRAINT "pilots_code_key" DO NOTHING RETURNING "id"`,
+		},
+		{
+			dia.BuildUpsert(Upsert{Table: `"pilots"`, Insert: []string{"name"}, Update: []string{"name"}, Constraint: "pilots_code_key", Return: []string{"id"}, Inserted: true}),
+			`INSERT INTO "pilots" ("name") VALUES ($1) ON CONFLICT ON CONSTRAINT "pilots_code_key" DO UPDATE SET "name" = EXCLUDED."name" RETURNING "id", (xmax = 0) AS inserted`,
+		},
+	}
+
+	for i, test := range tests {
+		if test.Got != test.Want {
+			t.Errorf("%d) want:\n%s\ngot:\n%s", i, test.Want, test.Got)
+		}
+	}
+}
+
+func TestBuildUpsertQueryPostgresIfChanged(t *testing.T) {
+	t.Parallel()
+
+	dia := Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true, UpsertSyntax: "postgres"}
+
+	tests := []struct {
+		Got  string
+		Want string
+	}{
+		{
+			dia.BuildUpsert(Upsert{Table: `"public"."pilots"`, Insert: []string{"id", "name", "age"}, Update: []string{"name", "age"}, Conflict: []string{"id"}, Changed: []string{"name", "age"}, Return: []string{"updated_at"}}),
+			`INSERT INTO "public"."pilots" ("id", "name", "age") VALUES ($1,$2,$3) ON CONFLICT ("id") DO UPDATE SET "name" = EXCLUDED."name","age" = EXCLUDED."age"` +
+				` WHERE "public"."pilots"."name" IS DISTINCT FROM EXCLUDED."name" OR "public"."pilots"."age" IS DISTINCT FROM EXCLUDED."age" RETURNING "updated_at"`,
+		},
+		{
+			dia.BuildUpsert(Upsert{Table: `"pilots"`, Insert: []string{"id", "name", "updated_at"}, Update: []string{"name", "updated_at"}, Conflict: []string{"id"}, Changed: []string{"name"}}),
+			`INSERT INTO "pilots" ("id", "name", "updated_at") VALUES ($1,$2,$3) ON CONFLICT ("id") DO UPDATE SET "name" = EXCLUDED."name","updated_at" = EXCLUDED."updated_at" WHERE "pilots"."name" IS DISTINCT FROM EXCLUDED."name"`,
+		},
+		{
+			dia.BuildUpsert(Upsert{Table: `"pilots"`, Insert: []string{"id", "name"}, Conflict: []string{"id"}, Changed: []string{"name"}}),
+			`INSERT INTO "pilots" ("id", "name") VALUES ($1,$2) ON CONFLICT DO NOTHING`,
+		},
+	}
+
+	for i, test := range tests {
+		if test.Got != test.Want {
+			t.Errorf("%d) want:\n%s\ngot:\n%s", i, test.Want, test.Got)
+		}
+	}
+}
@@ -2,8 +2,13 @@ package queries
 
 import (
 	"database/sql"
+	"database/sql/driver"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
+
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
 )
 
 func TestSetLimit(t *testing.T) {
@@ -60,6 +65,24 @@ func TestSetLoad(t *testing.T) {
 	}
 }
 
+func TestAppendWhereNamed(t *testing.T) {
+	t.Parallel()
+
+	q := &Query{dialect: &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true}}
+	SetFrom(q, "pilots")
+	AppendWhere(q, "age > ?", 30)
+	AppendWhereNamed(q, "name = :name OR nick = :name OR code = :code", map[string]interface{}{"code": 7, "name": "bob"})
+
+	out, args, _ := buildQuery(q)
+	expect := `SELECT * FROM "pilots" WHERE (age > $1) AND (name = $2 OR nick = $3 OR code = $4);`
+	if out != expect {
+		t.Errorf("Expected %s, got %s", expect, out)
+	}
+	if !reflect.DeepEqual(args, []interface{}{30, "bob", "bob", 7}) {
+		t.Errorf("args wrong: %#v", args)
+	}
+}
+
 func TestAppendWhere(t *testing.T) {
 	t.Parallel()
 
@@ -246,6 +269,43 @@ func TestAppendOrderBy(t *testing.T) {
 	}
 }
 
+func TestSetOrderBy(t *testing.T) {
+	t.Parallel()
+
+	q := &Query{}
+	AppendOrderBy(q, "col1 desc")
+	AppendOrderBy(q, "col2 asc")
+
+	q.SetOrderBy("col3", "col4 desc")
+	if got := q.OrderBy(); !reflect.DeepEqual(got, []string{"col3", "col4 desc"}) {
+		t.Errorf("Expected the clauses to be replaced, got: %#v", got)
+	}
+
+	AppendOrderBy(q, "col5")
+	if got := q.OrderBy(); !reflect.DeepEqual(got, []string{"col3", "col4 desc", "col5"}) {
+		t.Errorf("Expected appending after a set, got: %#v", got)
+	}
+
+	clauses := []string{"col6"}
+	q.SetOrderBy(clauses...)
+	clauses[0] = "changed"
+	q.OrderBy()[0] = "changed"
+	if got := q.OrderBy(); !reflect.DeepEqual(got, []string{"col6"}) {
+		t.Errorf("Expected the clauses not to be shared, got: %#v", got)
+	}
+
+	q.ClearOrderBy()
+	if got := q.OrderBy(); got != nil {
+		t.Errorf("Expected no clauses, got: %#v", got)
+	}
+
+	q.dialect = &Dialect{RandomFunction: "RAND()"}
+	AppendOrderByRandom(q)
+	if got := q.OrderBy(); !reflect.DeepEqual(got, []string{"RAND()"}) {
+		t.Errorf("Expected the random function, got: %#v", got)
+	}
+}
+
 func TestAppendHaving(t *testing.T) {
 	t.Parallel()
 
@@ -436,3 +496,222 @@ func TestAppendInnerJoin(t *testing.T) {
 		t.Errorf("Got invalid innerJoin on string: %#v", q.joins)
 	}
 }
+
+func TestAppendWithRecursive(t *testing.T) {
+	t.Parallel()
+
+	q := &Query{}
+	AppendWithRecursive(q, "tree", "SELECT id FROM t WHERE id = ?", "SELECT t.id FROM t INNER JOIN tree ON t.parent_id = tree.id", 5)
+
+	if len(q.with) != 1 {
+		t.Fatalf("Expected len 1, got %d", len(q.with))
+	}
+	if w := q.with[0]; w.name != "tree" || len(w.args) != 1 || w.args[0] != 5 {
+		t.Errorf("Got invalid with: %#v", w)
+	}
+}
+
+func TestAppendJoinOn(t *testing.T) {
+	t.Parallel()
+
+	q := &Query{}
+	AppendInnerJoinOn(q, "pilots p", "jets.pilot_id = p.id")
+	AppendJoinOn(q, "p.active = ?", true)
+
+	if len(q.joins) != 1 {
+		t.Fatalf("Expected len 1, got %d", len(q.joins))
+	}
+
+	j := q.joins[0]
+	if j.clause != "pilots p" || !j.targetOnly {
+		t.Errorf("Got invalid join target: %#v", j)
+	}
+	if len(j.on) != 2 || j.on[1].clause != "p.active = ?" || j.on[1].args[0] != true {
+		t.Errorf("Got invalid join conditions: %#v", j.on)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic adding a join condition without a join")
+		}
+	}()
+
+	AppendJoinOn(&Query{}, "p.active = ?", true)
+}
+
+func TestCountGroups(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ret := sqlmock.NewRows([]string{"name", "count"})
+	ret.AddRow(driver.Value([]byte("pat")), driver.Value(int64(3)))
+	ret.AddRow(driver.Value(nil), driver.Value(int64(2)))
+	ret.AddRow(driver.Value("sam"), driver.Value(int64(1)))
+	mock.ExpectQuery(`SELECT "name", COUNT\(\*\) FROM "pilots" WHERE \(age > \$1\) GROUP BY name;`).WillReturnRows(ret)
+
+	q := &Query{}
+	SetExecutor(q, db)
+	SetDialect(q, &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true})
+	SetFrom(q, "pilots")
+	AppendWhere(q, "age > ?", 30)
+	AppendGroupBy(q, "name")
+
+	counts, err := q.CountGroups()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect := map[string]int64{"pat": 3, "NULL": 2, "sam": 1}
+	if !reflect.DeepEqual(counts, expect) {
+		t.Errorf("want: %v, got: %v", expect, counts)
+	}
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+
+	if _, err = (&Query{from: []string{"pilots"}}).CountGroups(); err == nil {
+		t.Error("expected an error counting groups without group by")
+	}
+}
+
+func TestCountEstimate(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newQuery := func() *Query {
+		q := &Query{}
+		SetExecutor(q, db)
+		SetDialect(q, &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true, UseCountEstimate: true})
+		SetFrom(q, `"pilots"`)
+		SetTable(q, `"pilots"`, "pilots")
+		return q
+	}
+
+	estimate := `SELECT reltuples::bigint FROM pg_class WHERE oid = to_regclass\(\$1\)`
+	mock.ExpectQuery(estimate).WithArgs(`"pilots"`).WillReturnRows(sqlmock.NewRows([]string{"reltuples"}).AddRow(int64(1500)))
+
+	q := newQuery()
+	if count, err := q.CountEstimate(); err != nil || count != 1500 {
+		t.Errorf("want an estimate of 1500, got %d: %v", count, err)
+	}
+
+	// A where clause counts exactly
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM "pilots" WHERE \(age > \$1\);`).WithArgs(30).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(7)))
+
+	q = newQuery()
+	AppendWhere(q, "age > ?", 30)
+	if count, err := q.CountEstimate(); err != nil || count != 7 {
+		t.Errorf("want an exact count of 7, got %d: %v", count, err)
+	}
+
+	// A table that was never analyzed has no estimate
+	mock.ExpectQuery(estimate).WillReturnRows(sqlmock.NewRows([]string{"reltuples"}).AddRow(int64(-1)))
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM "pilots";`).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int64(3)))
+
+	q = newQuery()
+	if count, err := q.CountEstimate(); err != nil || count != 3 {
+		t.Errorf("want an exact count of 3, got %d: %v", count, err)
+	}
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCountEstimable(t *testing.T) {
+	t.Parallel()
+
+	newQuery := func() *Query {
+		q := &Query{dialect: &Dialect{UseCountEstimate: true}}
+		SetFrom(q, "pilots")
+		SetTable(q, "pilots", "pilots")
+		return q
+	}
+
+	if !countEstimable(newQuery()) {
+		t.Error("expected a whole table count to be estimable")
+	}
+
+	mods := []func(q *Query){
+		func(q *Query) { AppendWhere(q, "age > ?", 30) },
+		func(q *Query) { AppendIn(q, "id IN ?", 1, 2) },
+		func(q *Query) { AppendInnerJoin(q, "jets on jets.pilot_id = pilots.id") },
+		func(q *Query) { AppendFrom(q, "jets") },
+		func(q *Query) { SetFrom(q, "pilots p") },
+		func(q *Query) { AppendGroupBy(q, "name") },
+		func(q *Query) { SetDistinct(q) },
+		func(q *Query) { SetLimit(q, 10) },
+		func(q *Query) { q.dialect = &Dialect{} },
+	}
+	for i, mod := range mods {
+		q := newQuery()
+		mod(q)
+		if countEstimable(q) {
+			t.Errorf("%d) expected the count to be exact", i)
+		}
+	}
+}
+
+func TestGroupKey(t *testing.T) {
+	t.Parallel()
+
+	date := time.Date(2018, 3, 4, 5, 6, 7, 0, time.UTC)
+	tests := []struct {
+		values []interface{}
+		key    string
+	}{
+		{[]interface{}{"a"}, "a"},
+		{[]interface{}{int64(5)}, "5"},
+		{[]interface{}{[]byte("b")}, "b"},
+		{[]interface{}{nil}, "NULL"},
+		{[]interface{}{date}, "2018-03-04T05:06:07Z"},
+		{[]interface{}{"a,b", int64(5), nil}, `"a,b","5","NULL"`},
+	}
+
+	for i, test := range tests {
+		if key := GroupKey(test.values...); key != test.key {
+			t.Errorf("%d) want: %s, got: %s", i, test.key, key)
+		}
+	}
+}
+
+func TestQueryExecBuildError(t *testing.T) {
+	t.Parallel()
+
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	q := &Query{executor: db}
+	SetDialect(q, &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true})
+	SetFrom(q, "a")
+	SetDelete(q)
+	AppendWhere(q, "a = ? AND b = ?", 1)
+
+	// Nothing is expected by the mock, so an error from the database
+	// wouldn't mention the placeholders
+	isBuildErr := func(err error) bool {
+		return err != nil && strings.Contains(err.Error(), "placeholders")
+	}
+	if _, err := q.Exec(); !isBuildErr(err) {
+		t.Errorf("expected Exec to return the build error, got: %v", err)
+	}
+	if _, err := q.Query(); !isBuildErr(err) {
+		t.Errorf("expected Query to return the build error, got: %v", err)
+	}
+	if err := q.ScanRow(new(int)); !isBuildErr(err) {
+		t.Errorf("expected ScanRow to return the build error, got: %v", err)
+	}
+}
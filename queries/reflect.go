@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 	"sync"
 
@@ -15,9 +16,10 @@ import (
 var (
 	bindAccepts = []reflect.Kind{reflect.Ptr, reflect.Slice, reflect.Ptr, reflect.Struct}
 
-	mut         sync.RWMutex
-	bindingMaps = make(map[string][]uint64)
-	structMaps  = make(map[string]map[string]uint64)
+	mut            sync.RWMutex
+	bindingMaps    = make(map[string][]uint64)
+	relBindingMaps = make(map[string]*relBindings)
+	structMaps     = make(map[string]map[string]uint64)
 )
 
 // Identifies what kind of object we're binding to
@@ -57,6 +59,9 @@ func (q *Query) BindP(obj interface{}) {
 //   - If the "name" of the struct tag is "-", this field will not be bound to.
 //   - If the ",bind" option is specified on a struct field and that field
 //     is a struct itself, it will be recursed into to look for fields for binding.
+//   - Numeric fields take any numeric value that fits them, like an int8
+//     column in an int field or numeric text like "12.00". Values that
+//     would lose anything, like 12.5 or 300 for an int8, are an error.
 //
 // Example Query:
 //
@@ -80,6 +85,18 @@ func (q *Query) BindP(obj interface{}) {
 //
 //   models.Users(qm.InnerJoin("users as friend on users.friend_id = friend.id")).Bind(&joinStruct)
 //
+// Columns that are not bound to anything else, and are prefixed with the
+// name of a to-one relationship in the struct's R struct, are bound into
+// that relationship. This loads a relationship from a single joined query
+// instead of eager loading it with a second one. When all of a
+// relationship's columns are NULL, as with a LEFT JOIN that found nothing,
+// the relationship is left nil.
+//
+//   queries.Raw(db, `select jets.*, pilot.id as "pilot.id", pilot.name as "pilot.name"
+//     from jets left join pilots as pilot on jets.pilot_id = pilot.id`).Bind(&jets)
+//
+//   jets[0].R.Pilot.Name
+//
 // For custom objects that want to use eager loading, please see the
 // loadRelationships function.
 func Bind(rows *sql.Rows, obj interface{}) error {
@@ -117,6 +134,128 @@ func (q *Query) Bind(obj interface{}) error {
 	return nil
 }
 
+// BindEach executes the query and binds its rows one at a time, each into a
+// new object made by newObj, a pointer to a struct, which is passed to fn
+// before the next row is read. An error from fn stops the binding and is
+// returned. The rows are closed whichever way it stops. Eager loading isn't
+// supported, it would need another query while the rows are still open.
+//
+// See documentation for boil.Bind()
+func (q *Query) BindEach(newObj func() interface{}, fn func(obj interface{}) error) error {
+	if len(q.load) != 0 {
+		return errors.New("bind each does not support eager loading")
+	}
+
+	rows, err := q.Query()
+	if err != nil {
+		return errors.Wrap(err, "bind each failed to execute query")
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return errors.Wrap(err, "bind each failed to get column names")
+	}
+
+	var mapping []uint64
+	var rels *relBindings
+	for rows.Next() {
+		obj := newObj()
+		if mapping == nil {
+			structType, _, bkind, err := bindChecks(obj)
+			if err != nil {
+				return err
+			}
+			if bkind != kindStruct {
+				return errors.Errorf("bind each must make pointers to structs, got: %T", obj)
+			}
+			if mapping, rels, err = bindMappings(structType, cols); err != nil {
+				return err
+			}
+		}
+
+		if err := bindRow(rows, cols, reflect.Indirect(reflect.ValueOf(obj)), mapping, rels); err != nil {
+			return err
+		}
+		if err := fn(obj); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// BindRow binds the current row of rows, which rows.Next must already have
+// moved to, into obj, a pointer to a struct. Columns are matched to fields
+// by name like with Bind: a column without a field is skipped, and a field
+// without a column keeps its value. rows is left open.
+func BindRow(rows *sql.Rows, obj interface{}) error {
+	structType, _, bkind, err := bindChecks(obj)
+	if err != nil {
+		return err
+	}
+	if bkind != kindStruct {
+		return errors.Errorf("bind row must bind a pointer to a struct, got: %T", obj)
+	}
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return errors.Wrap(err, "bind row failed to get column names")
+	}
+
+	mapping, rels, err := bindMappings(structType, cols)
+	if err != nil {
+		return err
+	}
+
+	return bindRow(rows, cols, reflect.Indirect(reflect.ValueOf(obj)), mapping, rels)
+}
+
+// ScanAll executes the query selecting columns, the columns of its model
+// table in the order scan reads them, and calls scan for each row to scan
+// it into a new object it appends to the slice of struct pointers obj points
+// to. It is the explicit alternative to the reflection of Bind used by
+// generated code. When the query has raw SQL, selected columns, joins or
+// other from clauses, the columns it reads could differ, so it returns false
+// without executing it and Bind must be used instead. Eager loading works
+// like with Bind.
+func (q *Query) ScanAll(columns []string, obj interface{}, scan func(rows *sql.Rows) error) (bool, error) {
+	if !scannable(q) {
+		return false, nil
+	}
+
+	scanned := *q
+	scanned.selectCols = columns
+	rows, err := scanned.Query()
+	if err != nil {
+		return true, errors.Wrap(err, "scan all failed to execute query")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return true, errors.Wrap(err, "scan all failed to scan row")
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return true, err
+	}
+
+	if len(q.load) != 0 {
+		return true, eagerLoad(q.executor, q.load, obj, kindPtrSliceStruct)
+	}
+
+	return true, nil
+}
+
+// scannable reports whether q selects all the columns of its model table
+// and nothing else, see ScanAll.
+func scannable(q *Query) bool {
+	return len(q.rawSQL.sql) == 0 && len(q.selectCols) == 0 && !q.count &&
+		len(q.tableFrom) != 0 && len(q.from) == 1 && q.from[0] == q.tableFrom &&
+		len(q.joins) == 0 && q.insertSource == nil
+}
+
 // bindChecks resolves information about the bind target, and errors if it's not an object
 // we can bind to.
 func bindChecks(obj interface{}) (structType reflect.Type, sliceType reflect.Type, bkind bindKind, err error) {
@@ -184,6 +323,54 @@ func bind(rows *sql.Rows, obj interface{}, structType, sliceType reflect.Type, b
 		ptrSlice = reflect.Indirect(reflect.ValueOf(obj))
 	}
 
+	mapping, rels, err := bindMappings(structType, cols)
+	if err != nil {
+		return err
+	}
+
+	var oneStruct reflect.Value
+	if bkind == kindSliceStruct {
+		oneStruct = reflect.Indirect(reflect.New(structType))
+	}
+
+	foundOne := false
+	for rows.Next() {
+		foundOne = true
+		var newStruct reflect.Value
+		var target reflect.Value
+
+		switch bkind {
+		case kindStruct:
+			target = reflect.Indirect(reflect.ValueOf(obj))
+		case kindSliceStruct:
+			target = oneStruct
+		case kindPtrSliceStruct:
+			newStruct = reflect.New(structType)
+			target = reflect.Indirect(newStruct)
+		}
+
+		if err := bindRow(rows, cols, target, mapping, rels); err != nil {
+			return err
+		}
+
+		switch bkind {
+		case kindSliceStruct:
+			ptrSlice.Set(reflect.Append(ptrSlice, oneStruct))
+		case kindPtrSliceStruct:
+			ptrSlice.Set(reflect.Append(ptrSlice, newStruct))
+		}
+	}
+
+	if bkind == kindStruct && !foundOne {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// bindMappings returns the mapping of cols to the fields of structType and
+// the relationships bound from the columns left over, cached by both.
+func bindMappings(structType reflect.Type, cols []string) ([]uint64, *relBindings, error) {
 	var strMapping map[string]uint64
 	var sok bool
 	var mapping []uint64
@@ -202,9 +389,10 @@ func bind(rows *sql.Rows, obj interface{}, structType, sliceType reflect.Type, b
 	mut.RUnlock()
 
 	if !ok {
+		var err error
 		mapping, err = BindMapping(structType, strMapping, cols)
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
 
 		mut.Lock()
@@ -215,46 +403,33 @@ func bind(rows *sql.Rows, obj interface{}, structType, sliceType reflect.Type, b
 		mut.Unlock()
 	}
 
-	var oneStruct reflect.Value
-	if bkind == kindSliceStruct {
-		oneStruct = reflect.Indirect(reflect.New(structType))
+	mut.RLock()
+	rels, ok := relBindingMaps[mapKey]
+	mut.RUnlock()
+
+	if !ok {
+		rels = relBindMapping(structType, mapping, cols)
+
+		mut.Lock()
+		relBindingMaps[mapKey] = rels
+		mut.Unlock()
 	}
 
-	foundOne := false
-	for rows.Next() {
-		foundOne = true
-		var newStruct reflect.Value
-		var pointers []interface{}
+	return mapping, rels, nil
+}
 
-		switch bkind {
-		case kindStruct:
-			pointers = PtrsFromMapping(reflect.Indirect(reflect.ValueOf(obj)), mapping)
-		case kindSliceStruct:
-			pointers = PtrsFromMapping(oneStruct, mapping)
-		case kindPtrSliceStruct:
-			newStruct = reflect.New(structType)
-			pointers = PtrsFromMapping(reflect.Indirect(newStruct), mapping)
-		}
-		if err != nil {
-			return err
-		}
+// bindRow scans the current row of rows into target, a struct.
+func bindRow(rows *sql.Rows, cols []string, target reflect.Value, mapping []uint64, rels *relBindings) error {
+	pointers := PtrsFromMapping(target, mapping)
+	rels.setPointers(pointers)
 
-		if err := rows.Scan(pointers...); err != nil {
+	if err := rows.Scan(pointers...); err != nil {
+		if err = coerceScan(rows, cols, pointers); err != nil {
 			return errors.Wrap(err, "failed to bind pointers to obj")
 		}
-
-		switch bkind {
-		case kindSliceStruct:
-			ptrSlice.Set(reflect.Append(ptrSlice, oneStruct))
-		case kindPtrSliceStruct:
-			ptrSlice.Set(reflect.Append(ptrSlice, newStruct))
-		}
-	}
-
-	if bkind == kindStruct && !foundOne {
-		return sql.ErrNoRows
 	}
 
+	rels.assign(target, pointers)
 	return nil
 }
 
@@ -286,6 +461,133 @@ ColLoop:
 	return ptrs, nil
 }
 
+// relBindings routes the columns of a row that are prefixed with the name
+// of a to-one relationship into that relationship in the R struct.
+type relBindings struct {
+	rField int
+	rType  reflect.Type
+	rels   []relBinding
+}
+
+// relBinding holds the columns bound into a single relationship.
+type relBinding struct {
+	field int
+	typ   reflect.Type
+
+	cols     []int
+	mapping  []uint64
+	colTypes []reflect.Type
+}
+
+// relBindMapping finds the columns left unbound by mapping whose prefix
+// names a to-one relationship in the R struct of typ. It returns nil if
+// there are none.
+func relBindMapping(typ reflect.Type, mapping []uint64, cols []string) *relBindings {
+	rField, ok := typ.FieldByName(relationshipStructName)
+	if !ok || len(rField.Index) != 1 || rField.Type.Kind() != reflect.Ptr || rField.Type.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+
+	r := &relBindings{rField: rField.Index[0], rType: rField.Type.Elem()}
+	relIndexes := make(map[string]int)
+
+	for i, c := range cols {
+		if mapping[i] != 0 {
+			continue
+		}
+
+		dot := strings.IndexByte(c, '.')
+		if dot <= 0 {
+			continue
+		}
+
+		relName := c[:dot]
+		field, ok := r.rType.FieldByName(relName)
+		if !ok {
+			relName = strmangle.TitleCase(relName)
+			field, ok = r.rType.FieldByName(relName)
+		}
+		if !ok || field.Type.Kind() != reflect.Ptr || field.Type.Elem().Kind() != reflect.Struct {
+			continue
+		}
+
+		idx, ok := relIndexes[relName]
+		if !ok {
+			idx = len(r.rels)
+			relIndexes[relName] = idx
+			r.rels = append(r.rels, relBinding{field: field.Index[0], typ: field.Type.Elem()})
+		}
+		rel := &r.rels[idx]
+
+		colMapping, _ := BindMapping(rel.typ, MakeStructMapping(rel.typ), []string{c[dot+1:]})
+		if colMapping[0] == 0 {
+			continue
+		}
+
+		zero := reflect.Indirect(reflect.New(rel.typ))
+		rel.cols = append(rel.cols, i)
+		rel.mapping = append(rel.mapping, colMapping[0])
+		rel.colTypes = append(rel.colTypes, ptrFromMapping(zero, colMapping[0], true).Type().Elem())
+	}
+
+	if len(r.rels) == 0 {
+		return nil
+	}
+
+	return r
+}
+
+// setPointers replaces the pointers of the relationship columns with
+// pointers to pointers, so that NULL values can be scanned into them
+// regardless of the type of the field they are bound to.
+func (r *relBindings) setPointers(pointers []interface{}) {
+	if r == nil {
+		return
+	}
+
+	for _, rel := range r.rels {
+		for j, col := range rel.cols {
+			pointers[col] = reflect.New(reflect.PtrTo(rel.colTypes[j])).Interface()
+		}
+	}
+}
+
+// assign sets the relationships of target from the scanned pointers. A
+// relationship whose columns were all NULL is left nil, as is the R struct
+// if every relationship was.
+func (r *relBindings) assign(target reflect.Value, pointers []interface{}) {
+	if r == nil {
+		return
+	}
+
+	rVal := target.Field(r.rField)
+	rVal.Set(reflect.Zero(rVal.Type()))
+
+	for _, rel := range r.rels {
+		var relVal reflect.Value
+		for j, col := range rel.cols {
+			val := reflect.ValueOf(pointers[col]).Elem()
+			if val.IsNil() {
+				continue
+			}
+
+			if !relVal.IsValid() {
+				relVal = reflect.New(rel.typ)
+			}
+			ptrFromMapping(relVal.Elem(), rel.mapping[j], true).Elem().Set(val.Elem())
+		}
+
+		if !relVal.IsValid() {
+			continue
+		}
+
+		if rVal.IsNil() {
+			rVal.Set(reflect.New(r.rType))
+		}
+		rVal.Elem().Field(rel.field).Set(relVal)
+	}
+}
+
 // PtrsFromMapping expects to be passed an addressable struct and a mapping
 // of where to find things. It pulls the pointers out referred to by the mapping.
 func PtrsFromMapping(val reflect.Value, mapping []uint64) []interface{} {
@@ -327,6 +629,10 @@ func ptrFromMapping(val reflect.Value, mapping uint64, addressOf bool) reflect.V
 
 		val = val.Field(int(v))
 		if val.Kind() == reflect.Ptr {
+			// A nil pointer field has no value to point into, it's written as NULL
+			if !addressOf && val.IsNil() && (mapping>>uint((i+1)*8))&sentinel == sentinel {
+				return val
+			}
 			val = reflect.Indirect(val)
 		}
 	}
@@ -351,6 +657,11 @@ func makeStructMappingHelper(typ reflect.Type, prefix string, current uint64, de
 	for i := 0; i < n; i++ {
 		f := typ.Field(i)
 
+		if isEmbeddedModel(f) {
+			makeStructMappingHelper(f.Type, prefix, current|uint64(i)<<depth, depth+8, fieldMaps)
+			continue
+		}
+
 		tag, recurse := getBoilTag(f)
 		if len(tag) == 0 {
 			tag = f.Name
@@ -371,6 +682,48 @@ func makeStructMappingHelper(typ reflect.Type, prefix string, current uint64, de
 	}
 }
 
+// isEmbeddedModel reports whether field embeds a struct of columns, like the
+// ModelBase of generated models, without a boil tag of its own. The fields of
+// such a struct are mapped as if they were fields of the struct embedding it.
+func isEmbeddedModel(field reflect.StructField) bool {
+	if !field.Anonymous || len(field.Tag.Get("boil")) != 0 {
+		return false
+	}
+	if field.Type.Kind() != reflect.Struct {
+		return false
+	}
+
+	for i := 0; i < field.Type.NumField(); i++ {
+		if len(field.Type.Field(i).Tag.Get("boil")) != 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// structFields returns the fields of the struct val with their values, with
+// the fields of embedded models in their place, see isEmbeddedModel.
+func structFields(val reflect.Value) ([]reflect.StructField, []reflect.Value) {
+	typ := val.Type()
+
+	var fields []reflect.StructField
+	var values []reflect.Value
+	for i := 0; i < typ.NumField(); i++ {
+		if field := typ.Field(i); isEmbeddedModel(field) {
+			embeddedFields, embeddedValues := structFields(val.Field(i))
+			fields = append(fields, embeddedFields...)
+			values = append(values, embeddedValues...)
+			continue
+		}
+
+		fields = append(fields, typ.Field(i))
+		values = append(values, val.Field(i))
+	}
+
+	return fields, values
+}
+
 func getBoilTag(field reflect.StructField) (name string, recurse bool) {
 	tag := field.Tag.Get("boil")
 	name = field.Name
@@ -401,3 +754,132 @@ func makeCacheKey(typ string, cols []string) string {
 
 	return mapKey
 }
+
+// WhereStructOptions changes the conditions built by AppendWhereStruct
+// and AppendWhereRow.
+type WhereStructOptions struct {
+	// Table qualifies the column names, to tell them apart
+	// in queries with joins
+	Table string
+	// Columns limits the conditions to these columns when it isn't empty
+	Columns []string
+	// Exclude leaves these columns out, like the primary key columns
+	Exclude []string
+}
+
+// whereStructClause builds the equality conditions of AppendWhereStruct
+// from the fields of obj, sorted by column name so the SQL is the same for
+// every call. It panics if obj is not a struct or a pointer to one.
+func whereStructClause(obj interface{}, opts WhereStructOptions) (string, []interface{}) {
+	val := reflect.Indirect(reflect.ValueOf(obj))
+	if val.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("where struct must be a struct or a pointer to one, got %T", obj))
+	}
+
+	fields, values := structFields(val)
+	conditions := make(map[string]interface{})
+	for i, field := range fields {
+		column, ok := whereStructColumn(field, opts)
+		if !ok {
+			continue
+		}
+
+		if arg, ok := whereStructValue(values[i]); ok {
+			conditions[column] = arg
+		}
+	}
+
+	columns := make([]string, 0, len(conditions))
+	for column := range conditions {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	clauses := make([]string, len(columns))
+	args := make([]interface{}, len(columns))
+	for i, column := range columns {
+		args[i] = conditions[column]
+		if len(opts.Table) != 0 {
+			column = opts.Table + "." + column
+		}
+		clauses[i] = column + " = ?"
+	}
+
+	return strings.Join(clauses, " AND "), args
+}
+
+// whereRowClause builds the equality conditions of AppendWhereRow from the
+// fields of obj, in field order, with IS NULL for the fields holding NULL.
+// It panics if obj is not a struct or a pointer to one.
+func whereRowClause(obj interface{}, opts WhereStructOptions) (string, []interface{}) {
+	val := reflect.Indirect(reflect.ValueOf(obj))
+	if val.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("where row must be a struct or a pointer to one, got %T", obj))
+	}
+
+	fields, values := structFields(val)
+	var clauses []string
+	args := []interface{}{}
+	for i, field := range fields {
+		column, ok := whereStructColumn(field, opts)
+		if !ok {
+			continue
+		}
+		if len(opts.Table) != 0 {
+			column = opts.Table + "." + column
+		}
+
+		value := values[i].Interface()
+		if isNullValue(value) {
+			clauses = append(clauses, column+" IS NULL")
+			continue
+		}
+		clauses = append(clauses, column+" = ?")
+		args = append(args, value)
+	}
+
+	return strings.Join(clauses, " AND "), args
+}
+
+// whereStructColumn returns the column of field from its boil tag, and
+// false if it has none, is unexported or is left out by opts.
+func whereStructColumn(field reflect.StructField, opts WhereStructOptions) (string, bool) {
+	column := field.Tag.Get("boil")
+	if ind := strings.IndexByte(column, ','); ind != -1 {
+		column = column[:ind]
+	}
+	if len(column) == 0 || column == "-" || len(field.PkgPath) != 0 {
+		return "", false
+	}
+	if len(opts.Columns) != 0 && !strmangle.SetInclude(column, opts.Columns) {
+		return "", false
+	}
+	if strmangle.SetInclude(column, opts.Exclude) {
+		return "", false
+	}
+
+	return column, true
+}
+
+// whereStructValue returns the value of a field to compare to, and false
+// for fields that aren't set: zero values, nil pointers and null types
+// that aren't Valid. A pointer to a zero value is set.
+func whereStructValue(field reflect.Value) (interface{}, bool) {
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return nil, false
+		}
+		field = field.Elem()
+	} else if reflect.DeepEqual(field.Interface(), reflect.Zero(field.Type()).Interface()) {
+		return nil, false
+	}
+
+	if field.Kind() == reflect.Struct {
+		valid := field.FieldByName("Valid")
+		if valid.IsValid() && valid.Kind() == reflect.Bool && !valid.Bool() {
+			return nil, false
+		}
+	}
+
+	return field.Interface(), true
+}
@@ -1,13 +1,19 @@
 package queries
 
 import (
+	"bytes"
+	"database/sql"
 	"database/sql/driver"
 	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/pkg/errors"
 	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+	null "gopkg.in/volatiletech/null.v6"
 )
 
 func bin64(i uint64) string {
@@ -73,6 +79,115 @@ func TestBindStruct(t *testing.T) {
 	}
 }
 
+// testModelBase stands in for the ModelBase generated models embed.
+type testModelBase struct {
+	ID        int       `boil:"id"`
+	UpdatedAt time.Time `boil:"updated_at"`
+}
+
+func TestBindEmbeddedModel(t *testing.T) {
+	t.Parallel()
+
+	testResults := struct {
+		testModelBase
+		Name string `boil:"name"`
+	}{}
+
+	query := &Query{
+		from:    []string{"fun"},
+		dialect: &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true},
+	}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+
+	updatedAt := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	ret := sqlmock.NewRows([]string{"id", "name", "updated_at"})
+	ret.AddRow(driver.Value(int64(35)), driver.Value("pat"), driver.Value(updatedAt))
+	mock.ExpectQuery(`SELECT \* FROM "fun";`).WillReturnRows(ret)
+
+	SetExecutor(query, db)
+	err = query.Bind(&testResults)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if id := testResults.ID; id != 35 {
+		t.Error("wrong ID:", id)
+	}
+	if name := testResults.Name; name != "pat" {
+		t.Error("wrong name:", name)
+	}
+	if u := testResults.UpdatedAt; !u.Equal(updatedAt) {
+		t.Error("wrong updated at:", u)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBindBytes(t *testing.T) {
+	t.Parallel()
+
+	testResults := []struct {
+		ID       int
+		Cargo    []byte
+		Manifest null.Bytes
+	}{}
+
+	query := &Query{
+		from:    []string{"jets"},
+		dialect: &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true},
+	}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+
+	ret := sqlmock.NewRows([]string{"id", "cargo", "manifest"})
+	ret.AddRow(driver.Value(int64(1)), driver.Value([]byte{}), driver.Value(nil))
+	ret.AddRow(driver.Value(int64(2)), driver.Value([]byte{0xde, 0xad}), driver.Value([]byte{}))
+	mock.ExpectQuery(`SELECT \* FROM "jets";`).WillReturnRows(ret)
+
+	SetExecutor(query, db)
+	if err = query.Bind(&testResults); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(testResults) != 2 {
+		t.Fatal("wrong number of results:", len(testResults))
+	}
+
+	// An empty but not null value must stay non-nil, or it's written back as NULL
+	if c := testResults[0].Cargo; c == nil || len(c) != 0 {
+		t.Errorf("want empty non-nil cargo, got: %#v", c)
+	}
+	if m := testResults[0].Manifest; m.Valid {
+		t.Errorf("want null manifest, got: %#v", m)
+	}
+	if c := testResults[1].Cargo; !bytes.Equal(c, []byte{0xde, 0xad}) {
+		t.Errorf("wrong cargo: %#v", c)
+	}
+	if m := testResults[1].Manifest; !m.Valid || len(m.Bytes) != 0 {
+		t.Errorf("want valid empty manifest, got: %#v", m)
+	}
+
+	if v, _ := testResults[0].Manifest.Value(); v != nil {
+		t.Errorf("want null manifest to be written as NULL, got: %#v", v)
+	}
+	if v, _ := testResults[1].Manifest.Value(); v == nil {
+		t.Error("want empty manifest to be written as an empty value, got NULL")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
 func TestBindSlice(t *testing.T) {
 	t.Parallel()
 
@@ -175,6 +290,295 @@ func TestBindPtrSlice(t *testing.T) {
 	}
 }
 
+type bindEachResult struct {
+	ID   int
+	Name string `boil:"test"`
+}
+
+func TestBindEach(t *testing.T) {
+	t.Parallel()
+
+	query := &Query{
+		from:    []string{"fun"},
+		dialect: &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true},
+	}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+
+	ret := sqlmock.NewRows([]string{"id", "test"})
+	ret.AddRow(driver.Value(int64(35)), driver.Value("pat"))
+	ret.AddRow(driver.Value(int64(12)), driver.Value("cat"))
+	mock.ExpectQuery(`SELECT \* FROM "fun";`).WillReturnRows(ret)
+
+	SetExecutor(query, db)
+	var results []*bindEachResult
+	err = query.BindEach(func() interface{} { return &bindEachResult{} }, func(obj interface{}) error {
+		results = append(results, obj.(*bindEachResult))
+		return nil
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(results) != 2 {
+		t.Fatal("wrong number of results:", len(results))
+	}
+	if r := results[0]; r.ID != 35 || r.Name != "pat" {
+		t.Errorf("wrong first result: %#v", r)
+	}
+	if r := results[1]; r.ID != 12 || r.Name != "cat" {
+		t.Errorf("wrong second result: %#v", r)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBindEachStop(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+	// Rows left open would hold the only connection and block the next query
+	db.SetMaxOpenConns(1)
+
+	ret := sqlmock.NewRows([]string{"id", "test"})
+	ret.AddRow(driver.Value(int64(35)), driver.Value("pat"))
+	ret.AddRow(driver.Value(int64(12)), driver.Value("cat"))
+	mock.ExpectQuery(`SELECT \* FROM "fun";`).WillReturnRows(ret)
+	mock.ExpectQuery(`SELECT \* FROM "fun";`).WillReturnRows(sqlmock.NewRows([]string{"id", "test"}))
+
+	query := &Query{
+		from:    []string{"fun"},
+		dialect: &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true},
+	}
+	SetExecutor(query, db)
+
+	stop := errors.New("stop")
+	seen := 0
+	err = query.BindEach(func() interface{} { return &bindEachResult{} }, func(obj interface{}) error {
+		seen++
+		return stop
+	})
+	if err != stop {
+		t.Error("want the error that stopped it, got:", err)
+	}
+	if seen != 1 {
+		t.Error("want binding to stop after the first row, saw:", seen)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		var results []*bindEachResult
+		done <- query.Bind(&results)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Error(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("want the rows closed after stopping, the next query is still waiting for the connection")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBindEachChecks(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+
+	ret := sqlmock.NewRows([]string{"id", "test"})
+	ret.AddRow(driver.Value(int64(35)), driver.Value("pat"))
+	mock.ExpectQuery(`SELECT \* FROM "fun";`).WillReturnRows(ret)
+
+	query := &Query{
+		from:    []string{"fun"},
+		dialect: &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true},
+	}
+	SetExecutor(query, db)
+
+	err = query.BindEach(func() interface{} { return &[]bindEachResult{} }, func(interface{}) error { return nil })
+	if err == nil {
+		t.Error("want an error making something other than struct pointers")
+	}
+
+	AppendLoad(query, "Pilot")
+	err = query.BindEach(func() interface{} { return &bindEachResult{} }, func(interface{}) error { return nil })
+	if err == nil {
+		t.Error("want an error eager loading")
+	}
+}
+
+func TestBindRow(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// extra has no field and Name has no column
+	ret := sqlmock.NewRows([]string{"extra", "id"})
+	ret.AddRow(driver.Value("skipped"), driver.Value(int64(35)))
+	ret.AddRow(driver.Value("skipped"), driver.Value(int64(12)))
+	mock.ExpectQuery(`select extra, id from fun`).WillReturnRows(ret)
+
+	rows, err := db.Query("select extra, id from fun")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	var results []*bindEachResult
+	for rows.Next() {
+		result := &bindEachResult{Name: "kept"}
+		if err := BindRow(rows, result); err != nil {
+			t.Fatal(err)
+		}
+		results = append(results, result)
+	}
+
+	if len(results) != 2 {
+		t.Fatal("wrong number of results:", len(results))
+	}
+	if r := results[0]; r.ID != 35 || r.Name != "kept" {
+		t.Errorf("wrong first result: %#v", r)
+	}
+	if r := results[1]; r.ID != 12 || r.Name != "kept" {
+		t.Errorf("wrong second result: %#v", r)
+	}
+
+	if err := BindRow(rows, &results); err == nil {
+		t.Error("want an error binding a row into a slice")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func scanAllQuery(exec *sql.DB) *Query {
+	query := &Query{dialect: &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true}}
+	SetExecutor(query, exec)
+	SetFrom(query, `"fun"`)
+	SetTable(query, `"fun"`, "fun")
+	return query
+}
+
+func scanAllResult(o *[]*bindEachResult) func(rows *sql.Rows) error {
+	return func(rows *sql.Rows) error {
+		obj := &bindEachResult{}
+		if err := rows.Scan(&obj.ID, &obj.Name); err != nil {
+			return err
+		}
+		*o = append(*o, obj)
+		return nil
+	}
+}
+
+func TestScanAll(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rows := func() *sqlmock.Rows {
+		ret := sqlmock.NewRows([]string{"id", "test"})
+		ret.AddRow(driver.Value(int64(35)), driver.Value("pat"))
+		ret.AddRow(driver.Value(int64(12)), driver.Value("cat"))
+		return ret
+	}
+	mock.ExpectQuery(`SELECT "id", "test" FROM "fun";`).WillReturnRows(rows())
+	mock.ExpectQuery(`SELECT \* FROM "fun";`).WillReturnRows(rows())
+
+	var scanned []*bindEachResult
+	ok, err := scanAllQuery(db).ScanAll([]string{"id", "test"}, &scanned, scanAllResult(&scanned))
+	if !ok || err != nil {
+		t.Fatalf("want the query scanned, got %t: %v", ok, err)
+	}
+
+	var bound []*bindEachResult
+	if err = scanAllQuery(db).Bind(&bound); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(scanned) != 2 || !reflect.DeepEqual(scanned, bound) {
+		t.Errorf("want the scanned results to equal the bound ones\n%#v\n%#v", scanned, bound)
+	}
+
+	// The columns read are unknown, so it must be bound
+	for _, mod := range []func(q *Query){
+		func(q *Query) { AppendSelect(q, "id") },
+		func(q *Query) { AppendInnerJoin(q, "pilots on pilots.fun_id = fun.id") },
+		func(q *Query) { AppendFrom(q, "pilots") },
+		func(q *Query) { SetSQL(q, "select * from fun") },
+	} {
+		query := scanAllQuery(db)
+		mod(query)
+		if ok, err := query.ScanAll([]string{"id", "test"}, &scanned, scanAllResult(&scanned)); ok || err != nil {
+			t.Errorf("want the query left to bind, got %t: %v", ok, err)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func benchmarkScanAllRows(mock sqlmock.Sqlmock, query string) {
+	ret := sqlmock.NewRows([]string{"id", "test"})
+	for i := 0; i < 100; i++ {
+		ret.AddRow(driver.Value(int64(i)), driver.Value("pat"))
+	}
+	mock.ExpectQuery(query).WillReturnRows(ret)
+}
+
+func BenchmarkBindAll(b *testing.B) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	for i := 0; i < b.N; i++ {
+		benchmarkScanAllRows(mock, `SELECT \* FROM "fun";`)
+		var o []*bindEachResult
+		if err := scanAllQuery(db).Bind(&o); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkScanAll(b *testing.B) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	for i := 0; i < b.N; i++ {
+		benchmarkScanAllRows(mock, `SELECT "id", "test" FROM "fun";`)
+		var o []*bindEachResult
+		if _, err := scanAllQuery(db).ScanAll([]string{"id", "test"}, &o, scanAllResult(&o)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
 func testMakeMapping(byt ...byte) uint64 {
 	var x uint64
 	for i, b := range byt {
@@ -230,6 +634,42 @@ func TestMakeStructMapping(t *testing.T) {
 	}
 }
 
+func TestMakeStructMappingEmbeddedModel(t *testing.T) {
+	t.Parallel()
+
+	var testStruct = struct {
+		Name string `boil:"name"`
+		testModelBase
+		Time time.Time
+		Nose string
+	}{}
+
+	got := MakeStructMapping(reflect.TypeOf(testStruct))
+
+	expectMap := map[string]uint64{
+		"Name":      testMakeMapping(0),
+		"ID":        testMakeMapping(1, 0),
+		"UpdatedAt": testMakeMapping(1, 1),
+		"Time":      testMakeMapping(2),
+		"Nose":      testMakeMapping(3),
+	}
+
+	if len(got) != len(expectMap) {
+		t.Errorf("want %d fields, got %#v", len(expectMap), got)
+	}
+	for expName, expVal := range expectMap {
+		gotVal, ok := got[expName]
+		if !ok {
+			t.Errorf("%s) had no value", expName)
+			continue
+		}
+
+		if gotVal != expVal {
+			t.Errorf("%s) wrong value,\nwant: %x (%s)\ngot:  %x (%s)", expName, expVal, bin64(expVal), gotVal, bin64(gotVal))
+		}
+	}
+}
+
 func TestPtrFromMapping(t *testing.T) {
 	t.Parallel()
 
@@ -585,3 +1025,286 @@ func TestBind_InnerJoinSelect(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+type bindRelPilot struct {
+	ID   int
+	Name string
+}
+
+type bindRelJetR struct {
+	Pilot *bindRelPilot
+	Jets  []*bindRelPilot
+}
+
+type bindRelJet struct {
+	ID int
+
+	R *bindRelJetR `boil:"-"`
+}
+
+func TestBind_Relationship(t *testing.T) {
+	t.Parallel()
+
+	testResults := []bindRelJet{}
+
+	query := &Query{
+		dialect: &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true},
+		from:    []string{"jets"},
+	}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+
+	ret := sqlmock.NewRows([]string{"id", "pilot.id", "pilot.name", "pilot.unknown"})
+	ret.AddRow(driver.Value(int64(1)), driver.Value(int64(5)), driver.Value("pat"), driver.Value("x"))
+	ret.AddRow(driver.Value(int64(2)), nil, nil, nil)
+	mock.ExpectQuery(`SELECT \* FROM "jets";`).WillReturnRows(ret)
+
+	SetExecutor(query, db)
+	err = query.Bind(&testResults)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(testResults) != 2 {
+		t.Fatal("wrong number of results:", len(testResults))
+	}
+
+	if r := testResults[0].R; r == nil || r.Pilot == nil {
+		t.Fatal("expected the pilot relationship to be bound")
+	}
+	if id := testResults[0].R.Pilot.ID; id != 5 {
+		t.Error("wrong ID:", id)
+	}
+	if name := testResults[0].R.Pilot.Name; name != "pat" {
+		t.Error("wrong name:", name)
+	}
+
+	if testResults[1].ID != 2 {
+		t.Errorf("wrong jet: %#v", testResults[1])
+	}
+	if testResults[1].R != nil {
+		t.Errorf("expected no relationships for a NULL pilot, got: %#v", testResults[1].R)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestWhereStructClause(t *testing.T) {
+	t.Parallel()
+
+	zero := 0
+	type search struct {
+		Name    string      `boil:"name"`
+		Age     int         `boil:"age"`
+		MinAge  *int        `boil:"min_age"`
+		Nick    null.String `boil:"nick"`
+		Code    null.Int    `boil:"code"`
+		Active  bool        `boil:"active"`
+		Page    int
+		Skipped string `boil:"-"`
+		hidden  string `boil:"hidden"`
+	}
+
+	tests := []struct {
+		obj    interface{}
+		opts   WhereStructOptions
+		clause string
+		args   []interface{}
+	}{
+		{search{}, WhereStructOptions{}, "", []interface{}{}},
+		{
+			search{Name: "bob", Page: 2, Skipped: "x", hidden: "y", Code: null.Int{Int: 5, Valid: false}},
+			WhereStructOptions{},
+			"name = ?",
+			[]interface{}{"bob"},
+		},
+		{
+			&search{Name: "bob", Age: 30, MinAge: &zero, Nick: null.StringFrom(""), Active: true},
+			WhereStructOptions{},
+			"active = ? AND age = ? AND min_age = ? AND name = ? AND nick = ?",
+			[]interface{}{true, 30, 0, "bob", null.StringFrom("")},
+		},
+		{
+			search{Name: "bob", Age: 30, Code: null.IntFrom(7)},
+			WhereStructOptions{Table: "p", Columns: []string{"code", "name"}},
+			"p.code = ? AND p.name = ?",
+			[]interface{}{null.IntFrom(7), "bob"},
+		},
+	}
+
+	for i, test := range tests {
+		clause, args := whereStructClause(test.obj, test.opts)
+		if clause != test.clause {
+			t.Errorf("%d) clause mismatch:\nwant: %s\ngot:  %s", i, test.clause, clause)
+		}
+		if !reflect.DeepEqual(args, test.args) {
+			t.Errorf("%d) args mismatch:\nwant: %#v\ngot:  %#v", i, test.args, args)
+		}
+	}
+
+	q := &Query{}
+	AppendWhereStruct(q, search{Page: 3}, WhereStructOptions{})
+	if len(q.where) != 0 {
+		t.Errorf("Expected no where clause for an unset struct, got: %#v", q.where)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for a value that is not a struct")
+		}
+	}()
+	whereStructClause("bob", WhereStructOptions{})
+}
+
+func TestWhereRowClause(t *testing.T) {
+	t.Parallel()
+
+	type pilot struct {
+		ID      int         `boil:"id"`
+		Name    string      `boil:"name"`
+		Nick    null.String `boil:"nick"`
+		Avatar  []byte      `boil:"avatar"`
+		Age     null.Int    `boil:"age"`
+		Skipped string      `boil:"-"`
+		hidden  string      `boil:"hidden"`
+	}
+
+	p := &pilot{ID: 5, Name: "bob", Nick: null.String{String: "x", Valid: false}, Age: null.IntFrom(30), hidden: "y"}
+
+	tests := []struct {
+		opts   WhereStructOptions
+		clause string
+		args   []interface{}
+	}{
+		{
+			WhereStructOptions{},
+			"id = ? AND name = ? AND nick IS NULL AND avatar IS NULL AND age = ?",
+			[]interface{}{5, "bob", null.IntFrom(30)},
+		},
+		{
+			WhereStructOptions{Exclude: []string{"id"}},
+			"name = ? AND nick IS NULL AND avatar IS NULL AND age = ?",
+			[]interface{}{"bob", null.IntFrom(30)},
+		},
+		{
+			WhereStructOptions{Table: "p", Columns: []string{"age", "nick", "id"}, Exclude: []string{"id"}},
+			"p.nick IS NULL AND p.age = ?",
+			[]interface{}{null.IntFrom(30)},
+		},
+		{
+			WhereStructOptions{Columns: []string{"nick"}, Exclude: []string{"nick"}},
+			"",
+			[]interface{}{},
+		},
+	}
+
+	for i, test := range tests {
+		clause, args := whereRowClause(p, test.opts)
+		if clause != test.clause {
+			t.Errorf("%d) clause mismatch:\nwant: %s\ngot:  %s", i, test.clause, clause)
+		}
+		if !reflect.DeepEqual(args, test.args) {
+			t.Errorf("%d) args mismatch:\nwant: %#v\ngot:  %#v", i, test.args, args)
+		}
+	}
+
+	q := &Query{}
+	SetDialect(q, &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true})
+	SetFrom(q, "pilots")
+	SetUpdate(q, map[string]interface{}{"name": "alice", "age": 31})
+	AppendWhere(q, "id = ?", 5)
+	AppendWhereRow(q, p, WhereStructOptions{Exclude: []string{"id"}})
+
+	out, args, _ := buildQuery(q)
+	expect := `UPDATE "pilots" SET "age" = $1, "name" = $2 WHERE (id = $3) AND (name = $4 AND nick IS NULL AND avatar IS NULL AND age = $5);`
+	if out != expect {
+		t.Errorf("mismatch:\nwant: %s\ngot:  %s", expect, out)
+	}
+	if want := []interface{}{31, "alice", 5, "bob", null.IntFrom(30)}; !reflect.DeepEqual(args, want) {
+		t.Errorf("args mismatch:\nwant: %#v\ngot:  %#v", want, args)
+	}
+
+	q = &Query{}
+	AppendWhereRow(q, pilot{}, WhereStructOptions{Columns: []string{"skipped"}})
+	if len(q.where) != 0 {
+		t.Errorf("Expected no where clause without columns, got: %#v", q.where)
+	}
+}
+
+func TestWhereClauseEmbeddedModel(t *testing.T) {
+	t.Parallel()
+
+	updatedAt := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	obj := struct {
+		testModelBase
+		Name string `boil:"name"`
+	}{testModelBase{ID: 5, UpdatedAt: updatedAt}, "bob"}
+
+	clause, args := whereStructClause(obj, WhereStructOptions{})
+	if want := "id = ? AND name = ? AND updated_at = ?"; clause != want {
+		t.Errorf("clause mismatch:\nwant: %s\ngot:  %s", want, clause)
+	}
+	if want := []interface{}{5, "bob", updatedAt}; !reflect.DeepEqual(args, want) {
+		t.Errorf("args mismatch:\nwant: %#v\ngot:  %#v", want, args)
+	}
+
+	clause, args = whereRowClause(obj, WhereStructOptions{Exclude: []string{"id"}})
+	if want := "updated_at = ? AND name = ?"; clause != want {
+		t.Errorf("clause mismatch:\nwant: %s\ngot:  %s", want, clause)
+	}
+	if want := []interface{}{updatedAt, "bob"}; !reflect.DeepEqual(args, want) {
+		t.Errorf("args mismatch:\nwant: %#v\ngot:  %#v", want, args)
+	}
+}
+
+func TestBind_ToOneJoin(t *testing.T) {
+	t.Parallel()
+
+	testResults := []bindRelJet{}
+
+	query := &Query{
+		dialect: &Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true},
+		from:    []string{`"jets"`},
+	}
+	AppendToOneJoin(query, `"jets"`, "Pilot", `"pilots" as "Pilot" on "Pilot"."id" = "jets"."pilot_id"`, []string{"id", "name"})
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+
+	ret := sqlmock.NewRows([]string{"id", "Pilot.id", "Pilot.name"})
+	ret.AddRow(driver.Value(int64(1)), driver.Value(int64(5)), driver.Value("pat"))
+	ret.AddRow(driver.Value(int64(2)), nil, nil)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "jets".*, "Pilot"."id" as "Pilot.id", "Pilot"."name" as "Pilot.name" FROM "jets"` +
+		` LEFT JOIN "pilots" as "Pilot" on "Pilot"."id" = "jets"."pilot_id";`)).WillReturnRows(ret)
+
+	SetExecutor(query, db)
+	err = query.Bind(&testResults)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(testResults) != 2 {
+		t.Fatal("wrong number of results:", len(testResults))
+	}
+
+	if r := testResults[0].R; r == nil || r.Pilot == nil {
+		t.Fatal("expected the pilot relationship to be bound")
+	}
+	if pilot := testResults[0].R.Pilot; pilot.ID != 5 || pilot.Name != "pat" {
+		t.Errorf("wrong pilot: %#v", pilot)
+	}
+	if testResults[1].R != nil {
+		t.Errorf("expected no relationships for a jet without a pilot, got: %#v", testResults[1].R)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,98 @@
+package queries
+
+import "fmt"
+
+// Upsert describes an upsert statement for an UpsertBuilder. Fields a
+// dialect has no use for are ignored by its builder.
+type Upsert struct {
+	// Table is the name of the table, quoted as it should be written
+	Table string
+	// Insert are the columns of the row proposed for insertion
+	Insert []string
+	// Update are the columns set from the row proposed for insertion on
+	// conflict, the conflict is ignored when there are none
+	Update []string
+	// UpdateExprs are SQL expressions that the Update columns keyed by
+	// them are set to instead of the value proposed for insertion, like
+	// "count + EXCLUDED.count". They're written verbatim, except that
+	// the EXCLUDED.column references to the row proposed for insertion
+	// are written as VALUES(column) on MySQL. MS SQL ignores them.
+	UpdateExprs map[string]string
+	// Conflict are the columns of the conflict target, or Constraint
+	// the name of a constraint used as the conflict target instead
+	Conflict   []string
+	Constraint string
+	// Changed are the columns compared with the row proposed for
+	// insertion, the conflicting row is only updated when one of them
+	// is distinct. It is always updated when there are none.
+	Changed []string
+	// Primary are the primary key columns that rows are matched on
+	Primary []string
+	// Return are the columns returned
+	Return []string
+	// Inserted returns a final column telling whether the row was
+	// inserted or updated
+	Inserted bool
+	// Defaults makes this a multi row upsert with a row for every
+	// element, which holds the columns of the row written as DEFAULT
+	// instead of being bound
+	Defaults [][]string
+}
+
+// UpsertBuilder builds the upsert statements of a dialect.
+type UpsertBuilder interface {
+	BuildUpsert(dia Dialect, up Upsert) string
+}
+
+// upsertBuilders are the upsert builders by the UpsertSyntax of dialects.
+var upsertBuilders = map[string]UpsertBuilder{
+	"postgres": postgresUpsertBuilder{},
+	"mysql":    mysqlUpsertBuilder{},
+	"mssql":    mssqlUpsertBuilder{},
+}
+
+// RegisterUpsertBuilder makes builder build the upsert statements of
+// dialects with the UpsertSyntax syntax, replacing any builder already
+// registered for it. It is meant to be called during initialization, it is
+// not safe to call while queries are being built.
+func RegisterUpsertBuilder(syntax string, builder UpsertBuilder) {
+	upsertBuilders[syntax] = builder
+}
+
+// BuildUpsert builds the upsert statement described by up with the upsert
+// builder registered for the UpsertSyntax of the dialect. It panics if
+// there is none.
+func (d Dialect) BuildUpsert(up Upsert) string {
+	builder, ok := upsertBuilders[d.UpsertSyntax]
+	if !ok {
+		panic(fmt.Sprintf("no upsert builder is registered for the %q syntax", d.UpsertSyntax))
+	}
+
+	return builder.BuildUpsert(d, up)
+}
+
+// postgresUpsertBuilder builds INSERT ... ON CONFLICT statements.
+type postgresUpsertBuilder struct{}
+
+func (postgresUpsertBuilder) BuildUpsert(dia Dialect, up Upsert) string {
+	conflict := up.Conflict
+	if len(up.Constraint) != 0 {
+		conflict = nil
+	}
+
+	return buildUpsertQueryPostgres(dia, up.Table, len(up.Update) != 0, up.Return, up.Update, conflict, up.Insert, up.Constraint, up.Inserted, up.Changed, up.Defaults, up.UpdateExprs)
+}
+
+// mysqlUpsertBuilder builds INSERT ... ON DUPLICATE KEY UPDATE statements.
+type mysqlUpsertBuilder struct{}
+
+func (mysqlUpsertBuilder) BuildUpsert(dia Dialect, up Upsert) string {
+	return buildUpsertQueryMySQL(dia, up.Table, up.Update, up.Insert, up.Defaults, up.UpdateExprs)
+}
+
+// mssqlUpsertBuilder builds MERGE statements.
+type mssqlUpsertBuilder struct{}
+
+func (mssqlUpsertBuilder) BuildUpsert(dia Dialect, up Upsert) string {
+	return buildUpsertQueryMSSQL(dia, up.Table, up.Primary, up.Update, up.Insert, up.Return, up.Inserted)
+}
@@ -0,0 +1,173 @@
+package queries
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBuildUpsert(t *testing.T) {
+	t.Parallel()
+
+	postgres := Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true, UpsertSyntax: "postgres"}
+	mysql := Dialect{LQ: '`', RQ: '`', UpsertSyntax: "mysql"}
+	mssql := Dialect{LQ: '[', RQ: ']', IndexPlaceholders: true, UseTopClause: true, UpsertSyntax: "mssql"}
+
+	tests := []struct {
+		// legacy is the statement of the builder function that predates
+		// Dialect.BuildUpsert, if there is one
+		legacy string
+		dia    Dialect
+		up     Upsert
+	}{
+		{
+			BuildUpsertQueryPostgres(postgres, `"pilots"`, true, []string{"created_at"}, []string{"name", "age"}, []string{"id"}, []string{"id", "name", "age"}),
+			postgres,
+			Upsert{Table: `"pilots"`, Insert: []string{"id", "name", "age"}, Update: []string{"name", "age"}, Conflict: []string{"id"}, Return: []string{"created_at"}},
+		},
+		{
+			BuildUpsertQueryPostgres(postgres, `"pilots"`, false, nil, []string{"name"}, []string{"id"}, []string{"id", "name"}),
+			postgres,
+			Upsert{Table: `"pilots"`, Insert: []string{"id", "name"}, Conflict: []string{"id"}},
+		},
+		{
+			"",
+			postgres,
+			Upsert{Table: `"pilots"`, Insert: []string{"code", "name"}, Update: []string{"name"}, Conflict: []string{"code"}, Return: []string{"id"}, Inserted: true},
+		},
+		{
+			"",
+			postgres,
+			Upsert{Table: `"pilots"`, Insert: []string{"code", "name"}, Update: []string{"name"}, Conflict: []string{"id"}, Constraint: "pilots_code_key"},
+		},
+		{
+			"",
+			postgres,
+			Upsert{Table: `"pilots"`, Insert: []string{"code", "name"}, Update: []string{"name"}, Constraint: "pilots_code_key", Return: []string{"id"}, Inserted: true},
+		},
+		{
+			"",
+			postgres,
+			Upsert{Table: `"pilots"`, Insert: []string{"id", "name", "updated_at"}, Update: []string{"name", "updated_at"}, Conflict: []string{"id"}, Changed: []string{"name"}},
+		},
+		{
+			"",
+			postgres,
+			Upsert{Table: `"pilots"`, Insert: []string{"id", "name", "age"}, Update: []string{"name"}, Conflict: []string{"id"}, Defaults: [][]string{nil, {"age"}}},
+		},
+		{
+			BuildUpsertQueryMySQL(mysql, "pilots", []string{"name", "age"}, []string{"id", "name", "age"}),
+			mysql,
+			Upsert{Table: "pilots", Insert: []string{"id", "name", "age"}, Update: []string{"name", "age"}},
+		},
+		{
+			BuildUpsertQueryMySQL(mysql, "pilots", nil, []string{"id", "name"}),
+			mysql,
+			Upsert{Table: "pilots", Insert: []string{"id", "name"}},
+		},
+		{
+			"",
+			mysql,
+			Upsert{Table: "pilots", Insert: []string{"id", "name", "age"}, Update: []string{"name"}, Defaults: [][]string{{"age"}, nil}},
+		},
+		{
+			BuildUpsertQueryMSSQL(mssql, "pilots", []string{"id"}, []string{"name"}, []string{"name"}, []string{"id"}),
+			mssql,
+			Upsert{Table: "pilots", Insert: []string{"name"}, Update: []string{"name"}, Primary: []string{"id"}, Return: []string{"id"}},
+		},
+		{
+			"",
+			mssql,
+			Upsert{Table: "pilots", Insert: []string{"name"}, Update: []string{"name"}, Primary: []string{"id"}, Inserted: true},
+		},
+	}
+
+	for i, test := range tests {
+		filename := filepath.Join("_fixtures", fmt.Sprintf("upsert_%02d.sql", i))
+		got := test.dia.BuildUpsert(test.up)
+
+		if *writeGoldenFiles {
+			if err := ioutil.WriteFile(filename, []byte(got), 0664); err != nil {
+				t.Fatalf("Failed to write golden file %s: %s\n", filename, err)
+			}
+			t.Logf("wrote golden file: %s\n", filename)
+			continue
+		}
+
+		byt, err := ioutil.ReadFile(filename)
+		if err != nil {
+			t.Fatalf("Failed to read golden file %q: %v", filename, err)
+		}
+		want := string(bytes.TrimSpace(byt))
+
+		if len(test.legacy) != 0 && test.legacy != want {
+			t.Errorf("[%02d] builder function changed:\nWant:\n%s\nGot:\n%s", i, want, test.legacy)
+		}
+		if got != want {
+			t.Errorf("[%02d] dialect builder mismatch:\nWant:\n%s\nGot:\n%s", i, want, got)
+		}
+	}
+}
+
+// replaceUpsertBuilder upserts with INSERT OR REPLACE, like SQLite can.
+type replaceUpsertBuilder struct{}
+
+func (replaceUpsertBuilder) BuildUpsert(dia Dialect, up Upsert) string {
+	return fmt.Sprintf("INSERT OR REPLACE INTO %s (%s) VALUES (%s)", up.Table, strings.Join(up.Insert, ", "),
+		strings.TrimSuffix(strings.Repeat("?,", len(up.Insert)), ","))
+}
+
+func TestBuildUpsertUpdateExprs(t *testing.T) {
+	t.Parallel()
+
+	up := Upsert{
+		Table:  `"counters"`,
+		Insert: []string{"name", "count", "updated_at"},
+		Update: []string{"count", "updated_at"},
+		UpdateExprs: map[string]string{
+			"count": `"counters"."count" + EXCLUDED."count" * excluded.weight`,
+		},
+		Conflict: []string{"name"},
+	}
+
+	postgres := Dialect{LQ: '"', RQ: '"', IndexPlaceholders: true, UpsertSyntax: "postgres"}
+	want := `INSERT INTO "counters" ("name", "count", "updated_at") VALUES ($1,$2,$3) ON CONFLICT ("name") DO UPDATE SET` +
+		` "count" = "counters"."count" + EXCLUDED."count" * excluded.weight,"updated_at" = EXCLUDED."updated_at"`
+	if got := postgres.BuildUpsert(up); got != want {
+		t.Errorf("want:\n%s\ngot:\n%s", want, got)
+	}
+
+	up.Table = "counters"
+	up.UpdateExprs = map[string]string{"count": "counters.count + EXCLUDED.count * EXCLUDED.`weight`"}
+	mysql := Dialect{LQ: '`', RQ: '`', UpsertSyntax: "mysql"}
+	want = "INSERT INTO counters (`name`, `count`, `updated_at`) VALUES (?,?,?) ON DUPLICATE KEY UPDATE" +
+		" `count` = counters.count + VALUES(`count`) * VALUES(`weight`),`updated_at` = VALUES(`updated_at`)"
+	if got := mysql.BuildUpsert(up); got != want {
+		t.Errorf("want:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestRegisterUpsertBuilder(t *testing.T) {
+	RegisterUpsertBuilder("test_replace", replaceUpsertBuilder{})
+
+	dia := Dialect{LQ: '"', RQ: '"', UpsertSyntax: "test_replace"}
+	got := dia.BuildUpsert(Upsert{Table: `"pilots"`, Insert: []string{"id", "name"}, Update: []string{"name"}})
+	if want := `INSERT OR REPLACE INTO "pilots" (id, name) VALUES (?,?)`; got != want {
+		t.Errorf("want:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestBuildUpsertUnknownSyntax(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic building an upsert without a builder for the syntax")
+		}
+	}()
+
+	Dialect{LQ: '"', RQ: '"', UpsertSyntax: "unknown"}.BuildUpsert(Upsert{Table: "pilots"})
+}
@@ -49,6 +49,10 @@ var (
 	typeFloat64Array = reflect.TypeOf(types.Float64Array{})
 	typeStringArray  = reflect.TypeOf(types.StringArray{})
 	typeHStore       = reflect.TypeOf(types.HStore{})
+	typeInterval     = reflect.TypeOf(types.Interval{})
+	typeNullInterval = reflect.TypeOf(types.NullInterval{})
+	typeRange        = reflect.TypeOf(types.Range{})
+	typeNullRange    = reflect.TypeOf(types.NullRange{})
 	rgxValidTime     = regexp.MustCompile(`[2-9]+`)
 
 	validatedTypes = []string{
@@ -56,6 +60,18 @@ var (
 		"json", "jsonb", "box", "cidr", "circle",
 		"lseg", "macaddr", "path", "pg_lsn", "point",
 		"polygon", "txid_snapshot", "money", "hstore",
+		"int4range", "int8range", "numrange", "tsrange",
+		"tstzrange", "daterange",
+	}
+
+	// compositeStringTypes are the attribute types of a composite type that
+	// can be randomized when they're read as a null.String. Other attributes
+	// kept as raw text are left NULL.
+	compositeStringTypes = []string{
+		"text", "character varying", "character",
+		"inet", "line", "uuid", "interval", "box", "cidr",
+		"circle", "lseg", "macaddr", "path", "pg_lsn",
+		"point", "polygon", "txid_snapshot", "money",
 	}
 )
 
@@ -100,6 +116,12 @@ func Struct(s *Seed, str interface{}, colTypes map[string]string, canBeNull bool
 		return errors.Errorf("Inner element should be a struct, given a non-struct: %T", str)
 	}
 
+	return randomizeFields(s, value, colTypes, canBeNull, blacklist)
+}
+
+// randomizeFields randomizes the fields of the struct value, and the fields
+// of the models it embeds, see isEmbeddedModel.
+func randomizeFields(s *Seed, value reflect.Value, colTypes map[string]string, canBeNull bool, blacklist []string) error {
 	typ := value.Type()
 	nFields := value.NumField()
 
@@ -108,6 +130,13 @@ func Struct(s *Seed, str interface{}, colTypes map[string]string, canBeNull bool
 		fieldVal := value.Field(i)
 		fieldTyp := typ.Field(i)
 
+		if isEmbeddedModel(fieldTyp) {
+			if err := randomizeFields(s, fieldVal, colTypes, canBeNull, blacklist); err != nil {
+				return err
+			}
+			continue
+		}
+
 		var found bool
 		for _, v := range blacklist {
 			if strmangle.TitleCase(v) == fieldTyp.Name {
@@ -133,6 +162,25 @@ func Struct(s *Seed, str interface{}, colTypes map[string]string, canBeNull bool
 	return nil
 }
 
+// isEmbeddedModel reports whether field embeds a struct of columns, like the
+// ModelBase of generated models, without a boil tag of its own.
+func isEmbeddedModel(field reflect.StructField) bool {
+	if !field.Anonymous || len(field.Tag.Get("boil")) != 0 {
+		return false
+	}
+	if field.Type.Kind() != reflect.Struct {
+		return false
+	}
+
+	for i := 0; i < field.Type.NumField(); i++ {
+		if len(field.Type.Field(i).Tag.Get("boil")) != 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
 // randDate generates a random time.Time between 1850 and 2050.
 // Only the Day/Month/Year columns are set so that Dates and DateTimes do
 // not cause mismatches in the test data comparisons.
@@ -151,6 +199,33 @@ func randDate(s *Seed) time.Time {
 	return t
 }
 
+// randInterval generates a random types.Interval of whole seconds under
+// a day. Only the Duration is set so that the value also fits a MySQL TIME.
+func randInterval(s *Seed) types.Interval {
+	return types.Interval{Duration: time.Duration(1+s.nextInt()%86399) * time.Second}
+}
+
+// randRange generates a random types.Range of the postgres range type
+// fieldType. It is in the canonical [lower,upper) form, so that it reads
+// back the same from the database.
+func randRange(s *Seed, fieldType string) types.Range {
+	switch fieldType {
+	case "daterange":
+		lower := randDate(s)
+		return types.NewRange(lower.Format("2006-01-02"), lower.AddDate(0, 0, 1).Format("2006-01-02"))
+	case "tsrange":
+		lower := randDate(s)
+		return types.NewRange(lower.Format("2006-01-02 15:04:05"), lower.Add(time.Hour).Format("2006-01-02 15:04:05"))
+	case "tstzrange":
+		// Bounds are read back in the time zone of the session, only an
+		// unbounded range reads back the same everywhere
+		return types.Range{}
+	}
+
+	lower := s.nextInt() % math.MaxInt16
+	return types.NewRange(strconv.Itoa(lower), strconv.Itoa(lower+10))
+}
+
 // randomizeField changes the value at field to a "randomized" value.
 //
 // If canBeNull is false:
@@ -162,6 +237,22 @@ func randomizeField(s *Seed, field reflect.Value, fieldType string, canBeNull bo
 	kind := field.Kind()
 	typ := field.Type()
 
+	// Pointers stand in for nullable types, nil being NULL
+	if kind == reflect.Ptr {
+		if canBeNull && s.nextInt()%3 == 0 {
+			field.Set(reflect.Zero(typ))
+			return nil
+		}
+
+		value := reflect.New(typ.Elem())
+		if err := randomizeField(s, value.Elem(), fieldType, false); err != nil {
+			return err
+		}
+
+		field.Set(value)
+		return nil
+	}
+
 	if strings.HasPrefix(fieldType, "enum") {
 		enum, err := randEnumValue(s, fieldType)
 		if err != nil {
@@ -178,6 +269,10 @@ func randomizeField(s *Seed, field reflect.Value, fieldType string, canBeNull bo
 		return nil
 	}
 
+	if strings.HasPrefix(fieldType, "composite.") {
+		return randComposite(s, field, fieldType, canBeNull)
+	}
+
 	var value interface{}
 	var isNull bool
 
@@ -257,6 +352,12 @@ func randomizeField(s *Seed, field reflect.Value, fieldType string, canBeNull bo
 				value[randStr(s, 3)] = sql.NullString{String: randStr(s, 3), Valid: s.nextInt()%3 == 0}
 				field.Set(reflect.ValueOf(value))
 				return nil
+			case typeRange:
+				field.Set(reflect.ValueOf(randRange(s, fieldType)))
+				return nil
+			case typeNullRange:
+				field.Set(reflect.ValueOf(types.NewNullRange(randRange(s, fieldType), true)))
+				return nil
 			}
 
 		} else {
@@ -478,6 +579,14 @@ func getStructNullValue(s *Seed, typ reflect.Type) interface{} {
 		return null.NewBytes(nil, false)
 	case typeNullByte:
 		return null.NewByte(byte(0), false)
+	case typeInterval:
+		return types.Interval{}
+	case typeNullInterval:
+		return types.NewNullInterval(types.Interval{}, false)
+	case typeRange:
+		return types.Range{}
+	case typeNullRange:
+		return types.NewNullRange(types.Range{}, false)
 	}
 
 	return nil
@@ -524,6 +633,10 @@ func getStructRandValue(s *Seed, typ reflect.Type) interface{} {
 		return null.NewBytes(randByteSlice(s, 1), true)
 	case typeNullByte:
 		return null.NewByte(byte(rand.Intn(125-65)+65), true)
+	case typeInterval:
+		return randInterval(s)
+	case typeNullInterval:
+		return types.NewNullInterval(randInterval(s), true)
 	}
 
 	return nil
@@ -622,6 +735,45 @@ func getVariableRandValue(s *Seed, kind reflect.Kind, typ reflect.Type) interfac
 	return nil
 }
 
+// randComposite fills a generated composite type struct with random
+// attributes. If field is the Null variant, which holds the composite next
+// to a Valid flag, it may be left NULL instead when canBeNull is true.
+func randComposite(s *Seed, field reflect.Value, composite string, canBeNull bool) error {
+	typ := field.Type()
+	if typ.NumField() == 2 && typ.Field(1).Name == "Valid" && typ.Field(1).Type.Kind() == reflect.Bool {
+		if canBeNull && s.nextInt()%3 == 0 {
+			field.Set(reflect.Zero(typ))
+			return nil
+		}
+
+		field.Field(1).SetBool(true)
+		field = field.Field(0)
+		typ = field.Type()
+	}
+
+	names, dbTypes := strmangle.ParseCompositeAttrs(composite)
+
+	colTypes := make(map[string]string, len(names))
+	var blacklist []string
+	for i, name := range names {
+		colTypes[strmangle.TitleCase(name)] = dbTypes[i]
+
+		attr, ok := typ.FieldByName(strmangle.TitleCase(name))
+		if ok && attr.Type == typeNullString && !strmangle.SetInclude(dbTypes[i], compositeStringTypes) {
+			blacklist = append(blacklist, name)
+		}
+	}
+
+	// Attributes are always nullable, so they may be randomized to NULL
+	value := reflect.New(typ)
+	if err := Struct(s, value.Interface(), colTypes, true, blacklist...); err != nil {
+		return err
+	}
+
+	field.Set(value.Elem())
+	return nil
+}
+
 func randEnumValue(s *Seed, enum string) (string, error) {
 	vals := strmangle.ParseEnumVals(enum)
 	if vals == nil || len(vals) == 0 {
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/volatiletech/sqlboiler/types"
 	null "gopkg.in/volatiletech/null.v6"
 )
 
@@ -81,6 +82,37 @@ func TestRandomizeStruct(t *testing.T) {
 	}
 }
 
+func TestRandomizeStructEmbeddedModel(t *testing.T) {
+	t.Parallel()
+
+	type base struct {
+		ID        int       `boil:"id"`
+		UpdatedAt time.Time `boil:"updated_at"`
+	}
+
+	var testStruct = struct {
+		base
+		Name string `boil:"name"`
+	}{}
+
+	fieldTypes := map[string]string{
+		"ID":        "integer",
+		"UpdatedAt": "timestamp",
+		"Name":      "character varying",
+	}
+
+	if err := Struct(NewSeed(), &testStruct, fieldTypes, false, "name"); err != nil {
+		t.Fatal(err)
+	}
+
+	if testStruct.ID == 0 || testStruct.UpdatedAt.IsZero() {
+		t.Errorf("the embedded values are not being randomized: %#v", testStruct)
+	}
+	if testStruct.Name != "" {
+		t.Error("blacklisted value was filled in:", testStruct.Name)
+	}
+}
+
 func TestRandomizeField(t *testing.T) {
 	t.Parallel()
 
@@ -107,6 +139,7 @@ func TestRandomizeField(t *testing.T) {
 		{In: &null.Uint16{}, Out: null.Uint16{}, Typs: []string{"integer"}},
 		{In: &null.Uint32{}, Out: null.Uint32{}, Typs: []string{"integer"}},
 		{In: &null.Uint64{}, Out: null.Uint64{}, Typs: []string{"integer"}},
+		{In: &types.NullInterval{}, Out: types.NullInterval{}, Typs: []string{"interval", "time"}},
 
 		{In: new(float32), Out: float32(0), Typs: []string{"real"}},
 		{In: new(float64), Out: float64(0), Typs: []string{"numeric"}},
@@ -125,6 +158,7 @@ func TestRandomizeField(t *testing.T) {
 		{In: new(string), Out: ""},
 		{In: new([]byte), Out: new([]byte)},
 		{In: &time.Time{}, Out: &time.Time{}},
+		{In: &types.Interval{}, Out: types.Interval{}, Typs: []string{"interval", "time"}},
 	}
 
 	for i := 0; i < len(inputs); i++ {
@@ -145,6 +179,73 @@ func TestRandomizeField(t *testing.T) {
 	}
 }
 
+func TestRandRange(t *testing.T) {
+	t.Parallel()
+
+	s := NewSeed()
+	for _, typ := range []string{"int4range", "int8range", "numrange", "daterange", "tsrange", "tstzrange"} {
+		var n types.NullRange
+		if err := randomizeField(s, reflect.ValueOf(&n).Elem(), typ, false); err != nil {
+			t.Errorf("%s: %s", typ, err)
+		}
+		if !n.Valid {
+			t.Errorf("%s: Expected a valid range", typ)
+		}
+
+		parsed, err := types.ParseRange(n.Range.String())
+		if err != nil {
+			t.Errorf("%s: %s", typ, err)
+		}
+		if parsed != n.Range {
+			t.Errorf("%s: Expected %#v to parse back the same, got %#v", typ, n.Range, parsed)
+		}
+	}
+}
+
+func TestRandomizePointers(t *testing.T) {
+	t.Parallel()
+
+	type Pointers struct {
+		Name     *string
+		Age      *int
+		Day      *time.Time
+		Interval *types.Interval
+		Kind     *string
+	}
+
+	s := NewSeed()
+	colTypes := map[string]string{
+		"Name":     "text",
+		"Age":      "integer",
+		"Day":      "date",
+		"Interval": "interval",
+		"Kind":     "enum('a','b')",
+	}
+
+	var p Pointers
+	if err := Struct(s, &p, colTypes, false); err != nil {
+		t.Fatal(err)
+	}
+	if p.Name == nil || p.Age == nil || p.Day == nil || p.Interval == nil || p.Kind == nil {
+		t.Fatalf("Expected no nil pointers, got: %#v", p)
+	}
+	if *p.Kind != "a" && *p.Kind != "b" {
+		t.Errorf("Expected an enum value, got: %q", *p.Kind)
+	}
+
+	sawNil := false
+	for i := 0; i < 20 && !sawNil; i++ {
+		p = Pointers{}
+		if err := Struct(s, &p, colTypes, true); err != nil {
+			t.Fatal(err)
+		}
+		sawNil = p.Name == nil || p.Age == nil || p.Day == nil || p.Interval == nil || p.Kind == nil
+	}
+	if !sawNil {
+		t.Error("Expected nil pointers when values can be null")
+	}
+}
+
 func TestRandEnumValue(t *testing.T) {
 	t.Parallel()
 
@@ -181,3 +282,43 @@ func TestRandEnumValue(t *testing.T) {
 		t.Errorf("Expected monday got: %q", r3)
 	}
 }
+
+func TestRandComposite(t *testing.T) {
+	t.Parallel()
+
+	type addressComposite struct {
+		Street null.String
+		Zip    null.Int
+		Moved  null.String
+		ID     null.String
+	}
+	type nullAddressComposite struct {
+		AddressComposite addressComposite
+		Valid            bool
+	}
+
+	s := NewSeed()
+	composite := "composite.address(street text,zip integer,moved date,id uuid)"
+
+	var val struct {
+		Address     addressComposite
+		NullAddress nullAddressComposite
+	}
+	colTypes := map[string]string{"Address": composite, "NullAddress": composite}
+
+	for i := 0; i < 10; i++ {
+		if err := Struct(s, &val, colTypes, false); err != nil {
+			t.Fatal(err)
+		}
+
+		if val.Address.Moved.Valid || val.NullAddress.AddressComposite.Moved.Valid {
+			t.Errorf("Expected the date attribute to be left NULL, got: %#v", val)
+		}
+		if !val.NullAddress.Valid {
+			t.Errorf("Expected the composite to be set when it can't be null, got: %#v", val)
+		}
+		if val.Address.ID.Valid && len(val.Address.ID.String) != 36 {
+			t.Errorf("Expected a uuid attribute, got: %q", val.Address.ID.String)
+		}
+	}
+}
@@ -20,6 +20,8 @@ var (
 	rgxEnum            = regexp.MustCompile(`^enum(\.[a-z_]+)?\((,?'[^']+')+\)$`)
 	rgxEnumIsOK        = regexp.MustCompile(`^(?i)[a-z][a-z0-9_]*$`)
 	rgxEnumShouldTitle = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+	rgxComposite = regexp.MustCompile(`^composite\.([^(]+)\((.*)\)$`)
 )
 
 var uppercaseWords = map[string]struct{}{
@@ -478,6 +480,37 @@ func Placeholders(indexPlaceholders bool, count int, start int, group int) strin
 	return buf.String()
 }
 
+// PlaceholdersWithDefaults generates the SQL statement placeholders for the
+// VALUES list of an insert into columns. Columns that are also in defaults are
+// written as the DEFAULT keyword and do not consume a placeholder.
+// For example, $1,DEFAULT,$2 for columns a, b, c where b is a default.
+// It will start counting placeholders at "start".
+// If indexPlaceholders is false, it will convert to ? instead of $1 etc.
+func PlaceholdersWithDefaults(indexPlaceholders bool, columns, defaults []string, start int) string {
+	buf := GetBuffer()
+	defer PutBuffer(buf)
+
+	if start == 0 {
+		panic("Invalid start number supplied.")
+	}
+
+	for i, c := range columns {
+		if i != 0 {
+			buf.WriteByte(',')
+		}
+		if SetInclude(c, defaults) {
+			buf.WriteString("DEFAULT")
+		} else if indexPlaceholders {
+			buf.WriteString(fmt.Sprintf("$%d", start))
+			start++
+		} else {
+			buf.WriteByte('?')
+		}
+	}
+
+	return buf.String()
+}
+
 // SetParamNames takes a slice of columns and returns a comma separated
 // list of parameter names for a template statement SET clause.
 // eg: "col1"=$1, "col2"=$2, "col3"=$3
@@ -668,6 +701,41 @@ func ParseEnumName(s string) string {
 	return s[startIndex+1:]
 }
 
+// ParseCompositeName returns the type name of a postgres composite
+// type string of the form: composite.type_name(attr type,...)
+func ParseCompositeName(s string) string {
+	matches := rgxComposite.FindStringSubmatch(s)
+	if matches == nil {
+		return ""
+	}
+
+	return matches[1]
+}
+
+// ParseCompositeAttrs returns the attribute names and their database types
+// from a postgres composite type string of the form:
+// composite.type_name(attr type,...)
+func ParseCompositeAttrs(s string) (names []string, dbTypes []string) {
+	matches := rgxComposite.FindStringSubmatch(s)
+	if matches == nil || len(matches[2]) == 0 {
+		return nil, nil
+	}
+
+	for _, attr := range strings.Split(matches[2], ",") {
+		split := strings.IndexByte(attr, ' ')
+		if split < 0 {
+			names = append(names, attr)
+			dbTypes = append(dbTypes, "")
+			continue
+		}
+
+		names = append(names, attr[:split])
+		dbTypes = append(dbTypes, attr[split+1:])
+	}
+
+	return names, dbTypes
+}
+
 // IsEnumNormal checks a set of eval values to see if they're "normal"
 func IsEnumNormal(values []string) bool {
 	for _, v := range values {
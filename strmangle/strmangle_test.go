@@ -1,6 +1,7 @@
 package strmangle
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -129,6 +130,31 @@ func TestPlaceholders(t *testing.T) {
 	}
 }
 
+func TestPlaceholdersWithDefaults(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Index    bool
+		Columns  []string
+		Defaults []string
+		Start    int
+		Want     string
+	}{
+		{true, []string{"a", "b", "c"}, nil, 1, "$1,$2,$3"},
+		{true, []string{"a", "b", "c"}, []string{"b"}, 1, "$1,DEFAULT,$2"},
+		{true, []string{"a", "b", "c"}, []string{"a", "c"}, 3, "DEFAULT,$3,DEFAULT"},
+		{true, []string{"a", "b"}, []string{"a", "b", "z"}, 1, "DEFAULT,DEFAULT"},
+		{false, []string{"a", "b", "c"}, []string{"b"}, 1, "?,DEFAULT,?"},
+	}
+
+	for i, test := range tests {
+		x := PlaceholdersWithDefaults(test.Index, test.Columns, test.Defaults, test.Start)
+		if x != test.Want {
+			t.Errorf("%d) want %s, got %s", i, test.Want, x)
+		}
+	}
+}
+
 func TestSingular(t *testing.T) {
 	t.Parallel()
 
@@ -544,6 +570,36 @@ func TestParseEnum(t *testing.T) {
 	}
 }
 
+func TestParseComposite(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Composite string
+		Name      string
+		Names     []string
+		DBTypes   []string
+	}{
+		{"composite.address(street text,zip integer)", "address", []string{"street", "zip"}, []string{"text", "integer"}},
+		{"composite.stamp(at timestamp with time zone)", "stamp", []string{"at"}, []string{"timestamp with time zone"}},
+		{"composite.empty()", "empty", nil, nil},
+		{"enum.working('one')", "", nil, nil},
+	}
+
+	for i, test := range tests {
+		name := ParseCompositeName(test.Composite)
+		names, dbTypes := ParseCompositeAttrs(test.Composite)
+		if name != test.Name {
+			t.Errorf("%d) name was wrong, want: %s got: %s (%s)", i, test.Name, name, test.Composite)
+		}
+		if !reflect.DeepEqual(names, test.Names) {
+			t.Errorf("%d) names were wrong, want: %#v got: %#v", i, test.Names, names)
+		}
+		if !reflect.DeepEqual(dbTypes, test.DBTypes) {
+			t.Errorf("%d) types were wrong, want: %#v got: %#v", i, test.DBTypes, dbTypes)
+		}
+	}
+}
+
 func TestIsEnumNormal(t *testing.T) {
 	t.Parallel()
 
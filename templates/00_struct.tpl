@@ -7,8 +7,16 @@
 {{- $modelNameCamel := $tableNameSingular | camelCase -}}
 
 // {{$modelName}} is an object representing the database table.
+{{- if and (not .Table.IsView) (not .Table.PKey)}}
+// {{.Table.Name}} has no primary key, so Update, Delete and their slice
+// variants match rows on all of their columns, and Find, Reload, Exists and
+// Upsert are not generated.
+{{- end}}
 type {{$modelName}} struct {
-	{{range $column := .Table.Columns }}
+	{{if .EmbedsBase .Table -}}
+	ModelBase `yaml:",inline"`
+	{{end -}}
+	{{range $column := .StructColumns }}
 	{{- if eq $dot.StructTagCasing "camel"}}
 	{{titleCase $column.Name}} {{$column.Type}} `{{generateTags $dot.Tags $column.Name}}boil:"{{$column.Name}}" json:"{{$column.Name | camelCase}}{{if $column.Nullable}},omitempty{{end}}" toml:"{{$column.Name | camelCase}}" yaml:"{{$column.Name | camelCase}}{{if $column.Nullable}},omitempty{{end}}"`
 	{{- else -}}
@@ -32,6 +40,18 @@ var {{$modelName}}Columns = struct {
 	{{end -}}
 }
 
+var {{$modelNameCamel}}ColumnInfos = []ColumnInfo{
+	{{range $column := .Table.Columns -}}
+	{Name: "{{$column.Name}}", FieldName: "{{titleCase $column.Name}}", DBType: {{printf "%q" $column.DBType}}, Nullable: {{$column.Nullable}}, IsPK: {{if $dot.Table.PKey}}{{containsAny $dot.Table.PKey.Columns $column.Name}}{{else}}false{{end}}},
+	{{end -}}
+}
+
+// {{$modelName}}ColumnInfo returns the metadata of every {{$modelName}} column
+// in table order. The slice is shared and must not be modified.
+func {{$modelName}}ColumnInfo() []ColumnInfo {
+	return {{$modelNameCamel}}ColumnInfos
+}
+
 {{- if .Table.IsJoinTable -}}
 {{- else}}
 // {{$modelNameCamel}}R is where relationships are stored.
@@ -9,7 +9,12 @@ var (
 	{{end -}}
 	{{$varNameSingular}}ColumnsWithoutDefault = []string{{"{"}}{{.Table.Columns | filterColumnsByDefault false | columnNames | stringMap .StringFuncs.quoteWrap | join ","}}{{"}"}}
 	{{$varNameSingular}}ColumnsWithDefault    = []string{{"{"}}{{.Table.Columns | filterColumnsByDefault true | columnNames | stringMap .StringFuncs.quoteWrap | join ","}}{{"}"}}
-	{{$varNameSingular}}PrimaryKeyColumns     = []string{{"{"}}{{.Table.PKey.Columns | stringMap .StringFuncs.quoteWrap | join ", "}}{{"}"}}
+	{{$varNameSingular}}PrimaryKeyColumns     = []string{{"{"}}{{if .Table.PKey}}{{.Table.PKey.Columns | stringMap .StringFuncs.quoteWrap | join ", "}}{{end}}{{"}"}}
+	{{$varNameSingular}}LiteralDefaults       = map[string]string{
+		{{- range $column, $literal := .Table.Columns | literalDefaults}}
+		{{printf "%q" $column}}: {{printf "%q" $literal}},
+		{{- end}}
+	}
 )
 
 type (
@@ -31,6 +36,9 @@ var (
 	{{$varNameSingular}}Type = reflect.TypeOf(&{{$tableNameSingular}}{})
 	{{$varNameSingular}}Mapping = queries.MakeStructMapping({{$varNameSingular}}Type)
 	{{$varNameSingular}}PrimaryKeyMapping, _ = queries.BindMapping({{$varNameSingular}}Type, {{$varNameSingular}}Mapping, {{$varNameSingular}}PrimaryKeyColumns)
+	// All of the columns are mapped for Diff, and to match the rows of tables
+	// without a primary key on all of their columns
+	{{$varNameSingular}}ColumnsMapping, _ = queries.BindMapping({{$varNameSingular}}Type, {{$varNameSingular}}Mapping, {{$varNameSingular}}Columns)
 	{{$varNameSingular}}InsertCacheMut sync.RWMutex
 	{{$varNameSingular}}InsertCache = make(map[string]insertCache)
 	{{$varNameSingular}}UpdateCacheMut sync.RWMutex
@@ -44,5 +52,15 @@ var (
 	_ = time.Second
 	// Force bytes in case of primary key column that uses []byte (for relationship compares)
 	_ = bytes.MinRead
+	{{- if .AddContext}}
+	// Force context for tables without relationships
+	_ = context.Background
+	{{- end}}
+	{{- if .Table.IsView}}
+	// Force the packages used by the write methods, which views don't have
+	_ = fmt.Sprintf
+	_ = strings.Join
+	_ = strmangle.SetMerge
+	{{- end}}
 )
 {{end -}}
@@ -1,5 +1,6 @@
 {{- $tableNameSingular := .Table.Name | singular | titleCase -}}
 {{- $varNameSingular := .Table.Name | singular | camelCase -}}
+{{- $dot := . -}}
 // OneP returns a single {{$varNameSingular}} record from the query, and panics on error.
 func (q {{$varNameSingular}}Query) OneP() (*{{$tableNameSingular}}) {
 	o, err := q.One()
@@ -11,18 +12,32 @@ func (q {{$varNameSingular}}Query) OneP() (*{{$tableNameSingular}}) {
 }
 
 // One returns a single {{$varNameSingular}} record from the query.
+// boil.ErrNoRows is returned when no record matches.
 func (q {{$varNameSingular}}Query) One() (*{{$tableNameSingular}}, error) {
+	{{if not .ExplicitScan -}}
 	o := &{{$tableNameSingular}}{}
 
+	{{end -}}
 	queries.SetLimit(q.Query, 1)
 
+	{{if .ExplicitScan -}}
+	var found []*{{$tableNameSingular}}
+	err := q.bindAll(&found)
+	if err == nil && len(found) == 0 {
+		err = sql.ErrNoRows
+	}
+	{{- else -}}
 	err := q.Bind(o)
+	{{- end}}
 	if err != nil {
 		if errors.Cause(err) == sql.ErrNoRows {
-			return nil, sql.ErrNoRows
+			return nil, boil.ErrNoRows
 		}
 		return nil, errors.Wrap(err, "{{.PkgName}}: failed to execute a one query for {{.Table.Name}}")
 	}
+	{{- if .ExplicitScan}}
+	o := found[0]
+	{{- end}}
 
 	{{if not .NoHooks -}}
 	if err := o.doAfterSelectHooks(queries.GetExecutor(q.Query)); err != nil {
@@ -47,7 +62,7 @@ func (q {{$varNameSingular}}Query) AllP() {{$tableNameSingular}}Slice {
 func (q {{$varNameSingular}}Query) All() ({{$tableNameSingular}}Slice, error) {
 	var o []*{{$tableNameSingular}}
 
-	err := q.Bind(&o)
+	err := q.{{if .ExplicitScan}}bindAll{{else}}Bind{{end}}(&o)
 	if err != nil {
 		return nil, errors.Wrap(err, "{{.PkgName}}: failed to assign all query results to {{$tableNameSingular}} slice")
 	}
@@ -65,6 +80,158 @@ func (q {{$varNameSingular}}Query) All() ({{$tableNameSingular}}Slice, error) {
 	return o, nil
 }
 
+{{if .ExplicitScan -}}
+// bindAll binds the records of the query into o. When the query selects all
+// the columns of {{.Table.Name}} and nothing else, they are scanned into their
+// fields directly instead of being bound by reflection.
+func (q {{$varNameSingular}}Query) bindAll(o *[]*{{$tableNameSingular}}) error {
+	ok, err := q.ScanAll({{$varNameSingular}}Columns, o, func(rows *sql.Rows) error {
+		obj := &{{$tableNameSingular}}{}
+		err := rows.Scan(
+			{{- range $column := .Table.Columns}}
+			&obj.{{titleCase $column.Name}},
+			{{- end}}
+		)
+		if err != nil {
+			return err
+		}
+
+		*o = append(*o, obj)
+		return nil
+	})
+	if ok {
+		return err
+	}
+
+	return q.Bind(o)
+}
+
+{{end -}}
+{{range .Table.Columns | filterColumnsByMapKey -}}
+{{- $colName := .Name | titleCase -}}
+// AllAsMapBy{{$colName}}P returns all {{$tableNameSingular}} records from the query keyed by
+// {{.Name}}, and panics on error. See {{$tableNameSingular}}Slice.ToMapBy{{$colName}}.
+func (q {{$varNameSingular}}Query) AllAsMapBy{{$colName}}P() map[{{.Type}}]*{{$tableNameSingular}} {
+	m, err := q.AllAsMapBy{{$colName}}()
+	if err != nil {
+		panic(boil.WrapErr(err))
+	}
+
+	return m
+}
+
+// AllAsMapBy{{$colName}} returns all {{$tableNameSingular}} records from the query keyed by
+// {{.Name}}. See {{$tableNameSingular}}Slice.ToMapBy{{$colName}}.
+func (q {{$varNameSingular}}Query) AllAsMapBy{{$colName}}() (map[{{.Type}}]*{{$tableNameSingular}}, error) {
+	o, err := q.All()
+	if err != nil {
+		return nil, err
+	}
+
+	return o.ToMapBy{{$colName}}(), nil
+}
+
+{{end -}}
+// Stream reads the {{$tableNameSingular}} records of the query in a goroutine and sends each
+// one on ch as soon as it's read, closing ch when done. Closing done stops it
+// early, like a context's Done channel. The rows are closed whichever way it stops.
+// The returned channel receives the error that stopped it, if any, and is closed
+// after ch. Eager loading isn't supported.
+func (q {{$varNameSingular}}Query) Stream(done <-chan struct{}, ch chan<- *{{$tableNameSingular}}) <-chan error {
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(ch)
+
+		err := q.BindEach(func() interface{} { return &{{$tableNameSingular}}{} }, func(obj interface{}) error {
+			o := obj.(*{{$tableNameSingular}})
+			{{- if not .NoHooks}}
+			if err := o.doAfterSelectHooks(queries.GetExecutor(q.Query)); err != nil {
+				return err
+			}
+			{{- end}}
+
+			select {
+			case ch <- o:
+				return nil
+			case <-done:
+				return errStreamStopped
+			}
+		})
+		if err != nil && err != errStreamStopped {
+			errs <- errors.Wrap(err, "{{.PkgName}}: failed to stream {{$tableNameSingular}} records")
+		}
+	}()
+
+	return errs
+}
+
+{{range .Table.Columns | filterColumnsByMapKey -}}
+{{- $colName := .Name | titleCase -}}
+// ToMapBy{{$colName}} returns the records keyed by {{.Name}}. If several records share a
+// key the last one wins, use ToMapBy{{$colName}}Strict to get an error instead.
+func (o {{$tableNameSingular}}Slice) ToMapBy{{$colName}}() map[{{.Type}}]*{{$tableNameSingular}} {
+	m := make(map[{{.Type}}]*{{$tableNameSingular}}, len(o))
+	for _, obj := range o {
+		m[obj.{{$colName}}] = obj
+	}
+
+	return m
+}
+
+// ToMapBy{{$colName}}Strict is like ToMapBy{{$colName}}, but returns an error if several
+// records share a key.
+func (o {{$tableNameSingular}}Slice) ToMapBy{{$colName}}Strict() (map[{{.Type}}]*{{$tableNameSingular}}, error) {
+	m := make(map[{{.Type}}]*{{$tableNameSingular}}, len(o))
+	for _, obj := range o {
+		if _, ok := m[obj.{{$colName}}]; ok {
+			return nil, errors.Errorf("{{$dot.PkgName}}: duplicate {{$dot.Table.Name}} {{.Name}} %v in map", obj.{{$colName}})
+		}
+		m[obj.{{$colName}}] = obj
+	}
+
+	return m, nil
+}
+
+{{end -}}
+// Filter returns a new slice of the records for which keep returns true, in
+// the same order. o is left untouched.
+func (o {{$tableNameSingular}}Slice) Filter(keep func(*{{$tableNameSingular}}) bool) {{$tableNameSingular}}Slice {
+	var filtered {{$tableNameSingular}}Slice
+	for _, obj := range o {
+		if keep(obj) {
+			filtered = append(filtered, obj)
+		}
+	}
+
+	return filtered
+}
+
+// Chunk splits the records into consecutive slices of size records, the last
+// of which may be shorter, to process them in batches. A size of zero or less
+// is a single chunk of all the records, and an empty slice has no chunks. The
+// chunks share the records of o, but appending to one doesn't overwrite the next.
+func (o {{$tableNameSingular}}Slice) Chunk(size int) []{{$tableNameSingular}}Slice {
+	if len(o) == 0 {
+		return nil
+	}
+	if size <= 0 || size > len(o) {
+		size = len(o)
+	}
+
+	chunks := make([]{{$tableNameSingular}}Slice, 0, (len(o)+size-1)/size)
+	for i := 0; i < len(o); i += size {
+		end := i + size
+		if end > len(o) {
+			end = len(o)
+		}
+		chunks = append(chunks, o[i:end:end])
+	}
+
+	return chunks
+}
+
 // CountP returns the count of all {{$tableNameSingular}} records in the query, and panics on error.
 func (q {{$varNameSingular}}Query) CountP() int64 {
 	c, err := q.Count()
@@ -82,7 +249,7 @@ func (q {{$varNameSingular}}Query) Count() (int64, error) {
 	queries.SetSelect(q.Query, nil)
 	queries.SetCount(q.Query)
 
-	err := q.Query.QueryRow().Scan(&count)
+	err := q.Query.ScanRow(&count)
 	if err != nil {
 		return 0, errors.Wrap(err, "{{.PkgName}}: failed to count {{.Table.Name}} rows")
 	}
@@ -90,6 +257,51 @@ func (q {{$varNameSingular}}Query) Count() (int64, error) {
 	return count, nil
 }
 
+// CountEstimateP returns the count of all {{$tableNameSingular}} records in the query, estimated
+// when it can be, and panics on error.
+func (q {{$varNameSingular}}Query) CountEstimateP() int64 {
+	c, err := q.CountEstimate()
+	if err != nil {
+		panic(boil.WrapErr(err))
+	}
+
+	return c
+}
+
+// CountEstimate returns the count of all {{$tableNameSingular}} records in the query. It is
+// estimated from the table statistics, without scanning the table, when the query counts
+// all of {{.Table.Name}} and the database keeps them, and counted exactly otherwise.
+// See queries.Query.CountEstimate.
+func (q {{$varNameSingular}}Query) CountEstimate() (int64, error) {
+	count, err := q.Query.CountEstimate()
+	if err != nil {
+		return 0, errors.Wrap(err, "{{.PkgName}}: failed to estimate the count of {{.Table.Name}} rows")
+	}
+
+	return count, nil
+}
+
+// CountGroupsP returns the count of {{$tableNameSingular}} records in each group of the query, and panics on error.
+func (q {{$varNameSingular}}Query) CountGroupsP() map[string]int64 {
+	c, err := q.CountGroups()
+	if err != nil {
+		panic(boil.WrapErr(err))
+	}
+
+	return c
+}
+
+// CountGroups returns the count of {{$tableNameSingular}} records in each group of the query,
+// which must use qm.GroupBy. The counts are keyed by queries.GroupKey of the group's values.
+func (q {{$varNameSingular}}Query) CountGroups() (map[string]int64, error) {
+	counts, err := q.Query.CountGroups()
+	if err != nil {
+		return nil, errors.Wrap(err, "{{.PkgName}}: failed to count {{.Table.Name}} groups")
+	}
+
+	return counts, nil
+}
+
 // Exists checks if the row exists in the table, and panics on error.
 func (q {{$varNameSingular}}Query) ExistsP() bool {
 	e, err := q.Exists()
@@ -107,7 +319,7 @@ func (q {{$varNameSingular}}Query) Exists() (bool, error) {
 	queries.SetCount(q.Query)
 	queries.SetLimit(q.Query, 1)
 
-	err := q.Query.QueryRow().Scan(&count)
+	err := q.Query.ScanRow(&count)
 	if err != nil {
 		return false, errors.Wrap(err, "{{.PkgName}}: failed to check if {{.Table.Name}} exists")
 	}
@@ -22,5 +22,23 @@ func (o *{{$txt.LocalTable.NameGo}}) {{$txt.Function.Name}}(exec boil.Executor,
 
 	return query
 }
+{{- end -}}
+	{{- range .SchemaToOnes}}
+// {{.Function.Name}}G pointed to by the foreign key, from the {{.Package}} package.
+func (o *{{.LocalTable.NameGo}}) {{.Function.Name}}G(mods ...qm.QueryMod) (*{{.Package}}.{{.ForeignTable.NameGo}}, error) {
+	return o.{{.Function.Name}}(boil.GetDB(), mods...)
+}
+
+// {{.Function.Name}} pointed to by the foreign key, from the {{.Package}} package.
+// The query type of that package isn't exported, so it returns the row.
+func (o *{{.LocalTable.NameGo}}) {{.Function.Name}}(exec boil.Executor, mods ...qm.QueryMod) (*{{.Package}}.{{.ForeignTable.NameGo}}, error) {
+	queryMods := []qm.QueryMod{
+		qm.Where("{{.ForeignTable.ColumnName}}=?", o.{{.LocalTable.ColumnNameGo}}),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return {{.Package}}.{{.ForeignTable.NamePluralGo}}(exec, queryMods...).One()
+}
 {{- end -}}
 {{- end -}}
@@ -5,6 +5,14 @@
 		{{- $txt := txtsFromFKey $dot.Tables $dot.Table . -}}
 		{{- $varNameSingular := $dot.Table.Name | singular | camelCase -}}
 		{{- $arg := printf "maybe%s" $txt.LocalTable.NameGo -}}
+{{if $dot.AddContext -}}
+// Load{{$txt.Function.Name}}Context is Load{{$txt.Function.Name}} with its queries, including the ones of
+// nested eager loads, run with ctx so they are cancelled along with it.
+func ({{$varNameSingular}}L) Load{{$txt.Function.Name}}Context(ctx context.Context, e boil.Executor, singular bool, {{$arg}} interface{}) error {
+	return {{$varNameSingular}}L{}.Load{{$txt.Function.Name}}(boil.WithContext(ctx, e), singular, {{$arg}})
+}
+
+{{end -}}
 // Load{{$txt.Function.Name}} allows an eager lookup of values, cached into the
 // loaded structs of the objects.
 func ({{$varNameSingular}}L) Load{{$txt.Function.Name}}(e boil.Executor, singular bool, {{$arg}} interface{}) error {
@@ -34,35 +42,49 @@ func ({{$varNameSingular}}L) Load{{$txt.Function.Name}}(e boil.Executor, singula
 		}
 	}
 
-	query := fmt.Sprintf(
-		"select * from {{.ForeignTable | $dot.SchemaTable}} where {{.ForeignColumn | $dot.Quotes}} in (%s)",
-		strmangle.Placeholders(dialect.IndexPlaceholders, count, 1, 1),
-	)
-
-	if boil.DebugMode {
-		fmt.Fprintf(boil.DebugWriter, "%s\n%v\n", query, args)
+	exec, cache := queries.FromEagerLoad(e)
+	var resultSlice []*{{$txt.ForeignTable.NameGo}}
+	loaded, args := cache.Split("{{.ForeignTable}}.{{.ForeignColumn}}", args)
+	for _, obj := range loaded {
+		resultSlice = append(resultSlice, obj.(*{{$txt.ForeignTable.NameGo}}))
 	}
 
-	results, err := e.Query(query, args...)
-	if err != nil {
-		return errors.Wrap(err, "failed to eager load {{$txt.ForeignTable.NameGo}}")
-	}
-	defer results.Close()
+	if len(args) != 0 {
+		query := fmt.Sprintf(
+			"select * from {{.ForeignTable | $dot.SchemaTable}} where {{.ForeignColumn | $dot.Quotes}} in (%s)",
+			strmangle.Placeholders(dialect.IndexPlaceholders, len(args), 1, 1),
+		)
 
-	var resultSlice []*{{$txt.ForeignTable.NameGo}}
-	if err = queries.Bind(results, &resultSlice); err != nil {
-		return errors.Wrap(err, "failed to bind eager loaded slice {{$txt.ForeignTable.NameGo}}")
-	}
+		if boil.DebugMode {
+			fmt.Fprintf(boil.DebugWriter, "%s\n%v\n", query, args)
+		}
+
+		results, err := exec.Query(query, args...)
+		if err != nil {
+			return errors.Wrap(err, "failed to eager load {{$txt.ForeignTable.NameGo}}")
+		}
+		defer results.Close()
 
-	{{if not $dot.NoHooks -}}
-	if len({{$varNameSingular}}AfterSelectHooks) != 0 {
-		for _, obj := range resultSlice {
-			if err := obj.doAfterSelectHooks(e); err != nil {
-				return err
+		var queried []*{{$txt.ForeignTable.NameGo}}
+		if err = queries.Bind(results, &queried); err != nil {
+			return errors.Wrap(err, "failed to bind eager loaded slice {{$txt.ForeignTable.NameGo}}")
+		}
+
+		{{if not $dot.NoHooks -}}
+		if len({{$varNameSingular}}AfterSelectHooks) != 0 {
+			for _, obj := range queried {
+				if err := obj.doAfterSelectHooks(exec); err != nil {
+					return err
+				}
 			}
 		}
+
+		{{end -}}
+		for _, obj := range queried {
+			cache.Put("{{.ForeignTable}}.{{.ForeignColumn}}", obj.{{$txt.Function.ForeignAssignment}}, obj)
+		}
+		resultSlice = append(resultSlice, queried...)
 	}
-	{{- end}}
 
 	if len(resultSlice) == 0 {
 		return nil
@@ -6,6 +6,14 @@
 		{{- $txt := txtsFromToMany $dot.Tables $dot.Table . -}}
 		{{- $arg := printf "maybe%s" $txt.LocalTable.NameGo -}}
 		{{- $schemaForeignTable := .ForeignTable | $dot.SchemaTable}}
+{{if $dot.AddContext -}}
+// Load{{$txt.Function.Name}}Context is Load{{$txt.Function.Name}} with its queries, including the ones of
+// nested eager loads, run with ctx so they are cancelled along with it.
+func ({{$varNameSingular}}L) Load{{$txt.Function.Name}}Context(ctx context.Context, e boil.Executor, singular bool, {{$arg}} interface{}) error {
+	return {{$varNameSingular}}L{}.Load{{$txt.Function.Name}}(boil.WithContext(ctx, e), singular, {{$arg}})
+}
+
+{{end -}}
 // Load{{$txt.Function.Name}} allows an eager lookup of values, cached into the
 // loaded structs of the objects.
 func ({{$varNameSingular}}L) Load{{$txt.Function.Name}}(e boil.Executor, singular bool, {{$arg}} interface{}) error {
@@ -52,6 +60,8 @@ func ({{$varNameSingular}}L) Load{{$txt.Function.Name}}(e boil.Executor, singula
 		fmt.Fprintf(boil.DebugWriter, "%s\n%v\n", query, args)
 	}
 
+	// To many relationships aren't cached, only unwrap the executor
+	e, _ = queries.FromEagerLoad(e)
 	results, err := e.Query(query, args...)
 	if err != nil {
 		return errors.Wrap(err, "failed to eager load {{.ForeignTable}}")
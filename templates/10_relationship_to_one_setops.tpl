@@ -1,4 +1,4 @@
-{{- if .Table.IsJoinTable -}}
+{{- if or .Table.IsJoinTable (not .Table.PKey) -}}
 {{- else -}}
 	{{- $dot := . -}}
 	{{- range .Table.FKeys -}}
@@ -34,6 +34,14 @@ func (o *{{$txt.LocalTable.NameGo}}) Set{{$txt.Function.Name}}GP(insert bool, re
 	}
 }
 
+{{if $dot.AddContext -}}
+// Set{{$txt.Function.Name}}Context is Set{{$txt.Function.Name}} with its queries run with ctx,
+// so they are cancelled along with it.
+func (o *{{$txt.LocalTable.NameGo}}) Set{{$txt.Function.Name}}Context(ctx context.Context, exec boil.Executor, insert bool, related *{{$txt.ForeignTable.NameGo}}) error {
+	return o.Set{{$txt.Function.Name}}(boil.WithContext(ctx, exec), insert, related)
+}
+
+{{end -}}
 // Set{{$txt.Function.Name}} of the {{.Table | singular}} to the related item.
 // Sets o.R.{{$txt.Function.Name}} to related.
 // Adds o to related.R.{{$txt.Function.ForeignName}}.
@@ -124,6 +132,14 @@ func (o *{{$txt.LocalTable.NameGo}}) Remove{{$txt.Function.Name}}GP(related *{{$
 	}
 }
 
+{{if $dot.AddContext -}}
+// Remove{{$txt.Function.Name}}Context is Remove{{$txt.Function.Name}} with its queries run with ctx,
+// so they are cancelled along with it.
+func (o *{{$txt.LocalTable.NameGo}}) Remove{{$txt.Function.Name}}Context(ctx context.Context, exec boil.Executor, related *{{$txt.ForeignTable.NameGo}}) error {
+	return o.Remove{{$txt.Function.Name}}(boil.WithContext(ctx, exec), related)
+}
+
+{{end -}}
 // Remove{{$txt.Function.Name}} relationship.
 // Sets o.R.{{$txt.Function.Name}} to nil.
 // Removes o from all passed in related items' relationships struct (Optional).
@@ -166,4 +182,4 @@ func (o *{{$txt.LocalTable.NameGo}}) Remove{{$txt.Function.Name}}(exec boil.Exec
 }
 {{end -}}{{/* if foreignkey nullable */}}
 {{- end -}}{{/* range */}}
-{{- end -}}{{/* join table */}}
+{{- end -}}{{/* join table or no primary key */}}
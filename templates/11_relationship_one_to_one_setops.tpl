@@ -5,7 +5,9 @@
 		{{- $txt := txtsFromOneToOne $dot.Tables $dot.Table . -}}
 		{{- $varNameSingular := .Table | singular | camelCase -}}
 		{{- $foreignVarNameSingular := .ForeignTable | singular | camelCase -}}
-		{{- $foreignPKeyCols := (getTable $dot.Tables .ForeignTable).PKey.Columns -}}
+		{{- $foreignTable := getTable $dot.Tables .ForeignTable -}}
+		{{- if $foreignTable.PKey -}}
+		{{- $foreignPKeyCols := $foreignTable.PKey.Columns -}}
 		{{- $foreignSchemaTable := .ForeignTable | $dot.SchemaTable}}
 // Set{{$txt.Function.Name}}G of the {{.Table | singular}} to the related item.
 // Sets o.R.{{$txt.Function.Name}} to related.
@@ -35,6 +37,14 @@ func (o *{{$txt.LocalTable.NameGo}}) Set{{$txt.Function.Name}}GP(insert bool, re
 	}
 }
 
+{{if $dot.AddContext -}}
+// Set{{$txt.Function.Name}}Context is Set{{$txt.Function.Name}} with its queries run with ctx,
+// so they are cancelled along with it.
+func (o *{{$txt.LocalTable.NameGo}}) Set{{$txt.Function.Name}}Context(ctx context.Context, exec boil.Executor, insert bool, related *{{$txt.ForeignTable.NameGo}}) error {
+	return o.Set{{$txt.Function.Name}}(boil.WithContext(ctx, exec), insert, related)
+}
+
+{{end -}}
 // Set{{$txt.Function.Name}} of the {{.Table | singular}} to the related item.
 // Sets o.R.{{$txt.Function.Name}} to related.
 // Adds o to related.R.{{$txt.Function.ForeignName}}.
@@ -121,6 +131,14 @@ func (o *{{$txt.LocalTable.NameGo}}) Remove{{$txt.Function.Name}}GP(related *{{$
 	}
 }
 
+{{if $dot.AddContext -}}
+// Remove{{$txt.Function.Name}}Context is Remove{{$txt.Function.Name}} with its queries run with ctx,
+// so they are cancelled along with it.
+func (o *{{$txt.LocalTable.NameGo}}) Remove{{$txt.Function.Name}}Context(ctx context.Context, exec boil.Executor, related *{{$txt.ForeignTable.NameGo}}) error {
+	return o.Remove{{$txt.Function.Name}}(boil.WithContext(ctx, exec), related)
+}
+
+{{end -}}
 // Remove{{$txt.Function.Name}} relationship.
 // Sets o.R.{{$txt.Function.Name}} to nil.
 // Removes o from all passed in related items' relationships struct (Optional).
@@ -142,5 +160,6 @@ func (o *{{$txt.LocalTable.NameGo}}) Remove{{$txt.Function.Name}}(exec boil.Exec
 	return nil
 }
 {{end -}}{{/* if foreignkey nullable */}}
+{{- end -}}{{/* if foreign primary key */}}
 {{- end -}}{{/* range */}}
 {{- end -}}{{/* join table */}}
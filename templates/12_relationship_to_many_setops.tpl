@@ -6,7 +6,9 @@
 		{{- $txt := txtsFromToMany $dot.Tables $table . -}}
 		{{- $varNameSingular := .Table | singular | camelCase -}}
 		{{- $foreignVarNameSingular := .ForeignTable | singular | camelCase}}
-		{{- $foreignPKeyCols := (getTable $dot.Tables .ForeignTable).PKey.Columns -}}
+		{{- $foreignTable := getTable $dot.Tables .ForeignTable -}}
+		{{- if $foreignTable.PKey -}}
+		{{- $foreignPKeyCols := $foreignTable.PKey.Columns -}}
 		{{- $foreignSchemaTable := .ForeignTable | $dot.SchemaTable}}
 // Add{{$txt.Function.Name}}G adds the given related objects to the existing relationships
 // of the {{$table.Name | singular}}, optionally inserting them as new records.
@@ -39,6 +41,14 @@ func (o *{{$txt.LocalTable.NameGo}}) Add{{$txt.Function.Name}}GP(insert bool, re
 	}
 }
 
+{{if $dot.AddContext -}}
+// Add{{$txt.Function.Name}}Context is Add{{$txt.Function.Name}} with its queries run with ctx,
+// so they are cancelled along with it.
+func (o *{{$txt.LocalTable.NameGo}}) Add{{$txt.Function.Name}}Context(ctx context.Context, exec boil.Executor, insert bool, related ...*{{$txt.ForeignTable.NameGo}}) error {
+	return o.Add{{$txt.Function.Name}}(boil.WithContext(ctx, exec), insert, related...)
+}
+
+{{end -}}
 // Add{{$txt.Function.Name}} adds the given related objects to the existing relationships
 // of the {{$table.Name | singular}}, optionally inserting them as new records.
 // Appends related to o.R.{{$txt.Function.Name}}.
@@ -169,6 +179,14 @@ func (o *{{$txt.LocalTable.NameGo}}) Set{{$txt.Function.Name}}GP(insert bool, re
 	}
 }
 
+{{if $dot.AddContext -}}
+// Set{{$txt.Function.Name}}Context is Set{{$txt.Function.Name}} with its queries run with ctx,
+// so they are cancelled along with it.
+func (o *{{$txt.LocalTable.NameGo}}) Set{{$txt.Function.Name}}Context(ctx context.Context, exec boil.Executor, insert bool, related ...*{{$txt.ForeignTable.NameGo}}) error {
+	return o.Set{{$txt.Function.Name}}(boil.WithContext(ctx, exec), insert, related...)
+}
+
+{{end -}}
 // Set{{$txt.Function.Name}} removes all previously related items of the
 // {{$table.Name | singular}} replacing them completely with the passed
 // in related items, optionally inserting them as new records.
@@ -244,6 +262,14 @@ func (o *{{$txt.LocalTable.NameGo}}) Remove{{$txt.Function.Name}}GP(related ...*
 	}
 }
 
+{{if $dot.AddContext -}}
+// Remove{{$txt.Function.Name}}Context is Remove{{$txt.Function.Name}} with its queries run with ctx,
+// so they are cancelled along with it.
+func (o *{{$txt.LocalTable.NameGo}}) Remove{{$txt.Function.Name}}Context(ctx context.Context, exec boil.Executor, related ...*{{$txt.ForeignTable.NameGo}}) error {
+	return o.Remove{{$txt.Function.Name}}(boil.WithContext(ctx, exec), related...)
+}
+
+{{end -}}
 // Remove{{$txt.Function.Name}} relationships from objects passed in.
 // Removes related items from R.{{$txt.Function.Name}} (uses pointer comparison, removal does not keep order)
 // Sets related.R.{{$txt.Function.ForeignName}}.
@@ -333,5 +359,6 @@ func remove{{$txt.Function.Name}}From{{$txt.Function.ForeignName}}Slice(o *{{$tx
 }
 				{{end -}}{{- /* if ToJoinTable */ -}}
 			{{- end -}}{{- /* if nullable foreign key */ -}}
+		{{- end -}}{{- /* if foreign primary key */ -}}
 	{{- end -}}{{- /* range relationships */ -}}
 {{- end -}}{{- /* if IsJoinTable */ -}}
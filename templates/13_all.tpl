@@ -1,4 +1,5 @@
 {{- $tableNamePlural := .Table.Name | plural | titleCase -}}
+{{- $tableNameSingular := .Table.Name | singular | titleCase -}}
 {{- $varNameSingular := .Table.Name | singular | camelCase}}
 // {{$tableNamePlural}}G retrieves all records.
 func {{$tableNamePlural}}G(mods ...qm.QueryMod) {{$varNameSingular}}Query {
@@ -8,5 +9,38 @@ func {{$tableNamePlural}}G(mods ...qm.QueryMod) {{$varNameSingular}}Query {
 // {{$tableNamePlural}} retrieves all the records using an executor.
 func {{$tableNamePlural}}(exec boil.Executor, mods ...qm.QueryMod) {{$varNameSingular}}Query {
 	mods = append(mods, qm.From("{{.Table.Name | .SchemaTable}}"))
-	return {{$varNameSingular}}Query{NewQuery(exec, mods...)}
+	q := NewQuery(exec, mods...)
+	queries.SetKeyColumns(q, {{$varNameSingular}}PrimaryKeyColumns...)
+	queries.SetTable(q, "{{.Table.Name | .SchemaTable}}", "{{.Table.Name}}")
+	return {{$varNameSingular}}Query{q}
+}
+
+// {{$tableNameSingular}}FilterableColumns are the columns {{$tableNameSingular}}ApplyFilters
+// accepts filters on. Delete columns from it during setup to keep them from
+// being filtered on.
+var {{$tableNameSingular}}FilterableColumns = map[string]bool{
+	{{- range $column := .Table.Columns}}
+	{{printf "%q" $column.Name}}: true,
+	{{- end}}
+}
+
+// {{$tableNameSingular}}ApplyFilters adds an equality where clause to q for
+// every filter, keyed by column name, like the parameters of a request. A
+// filter on a column missing from {{$tableNameSingular}}FilterableColumns is
+// an error, and q is left unchanged.
+func {{$tableNameSingular}}ApplyFilters(q {{$varNameSingular}}Query, filters map[string]string) error {
+	for column := range filters {
+		if !{{$tableNameSingular}}FilterableColumns[column] {
+			return errors.Errorf("{{.PkgName}}: cannot filter {{.Table.Name}} on unknown column %q", column)
+		}
+	}
+
+	// In table order, so the same filters always build the same query
+	for _, column := range {{$varNameSingular}}Columns {
+		if value, ok := filters[column]; ok {
+			queries.AppendWhereOp(q.Query, column, "=", value)
+		}
+	}
+
+	return nil
 }
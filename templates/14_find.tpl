@@ -1,3 +1,4 @@
+{{- if .Table.PKey -}}
 {{- $tableNameSingular := .Table.Name | singular | titleCase -}}
 {{- $varNameSingular := .Table.Name | singular | camelCase -}}
 {{- $colDefs := sqlColDefinitions .Table.Columns .Table.PKey.Columns -}}
@@ -19,12 +20,24 @@ func Find{{$tableNameSingular}}GP({{$pkArgs}}, selectCols ...string) *{{$tableNa
 }
 
 // Find{{$tableNameSingular}} retrieves a single record by ID with an executor.
-// If selectCols is empty Find will return all columns.
+// If selectCols is empty Find will return all columns, otherwise only the
+// selected columns and the primary key columns are fetched and bound.
+// boil.ErrNoRows is returned when there is no such record.
+// When exec carries a boil.IdentityMap the record found with all of its
+// columns is kept in it, and finding it again returns the same pointer.
 func Find{{$tableNameSingular}}(exec boil.Executor, {{$pkArgs}}, selectCols ...string) (*{{$tableNameSingular}}, error) {
+	identities := boil.IdentityMapOf(exec)
+	if identities != nil {
+		if mapped, ok := identities.Get("{{.Table.Name}}", {{$pkNames | join ", "}}); ok {
+			return mapped.(*{{$tableNameSingular}}), nil
+		}
+	}
+
 	{{$varNameSingular}}Obj := &{{$tableNameSingular}}{}
 
 	sel := "*"
 	if len(selectCols) > 0 {
+		selectCols = strmangle.SetMerge({{$varNameSingular}}PrimaryKeyColumns, selectCols)
 		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
 	}
 	query := fmt.Sprintf(
@@ -36,11 +49,15 @@ func Find{{$tableNameSingular}}(exec boil.Executor, {{$pkArgs}}, selectCols ...s
 	err := q.Bind({{$varNameSingular}}Obj)
 	if err != nil {
 		if errors.Cause(err) == sql.ErrNoRows {
-			return nil, sql.ErrNoRows
+			return nil, boil.ErrNoRows
 		}
 		return nil, errors.Wrap(err, "{{.PkgName}}: unable to select from {{.Table.Name}}")
 	}
 
+	if identities != nil && len(selectCols) == 0 {
+		identities.Set("{{.Table.Name}}", {{$varNameSingular}}Obj, {{$pkNames | join ", "}})
+	}
+
 	return {{$varNameSingular}}Obj, nil
 }
 
@@ -53,3 +70,4 @@ func Find{{$tableNameSingular}}P(exec boil.Executor, {{$pkArgs}}, selectCols ...
 
 	return retobj
 }
+{{- end -}}{{- /* if PKey */ -}}
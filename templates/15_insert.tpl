@@ -1,3 +1,4 @@
+{{- if not .Table.IsView -}}
 {{- $tableNameSingular := .Table.Name | singular | titleCase -}}
 {{- $varNameSingular := .Table.Name | singular | camelCase -}}
 {{- $schemaTable := .Table.Name | .SchemaTable}}
@@ -28,10 +29,279 @@ func (o *{{$tableNameSingular}}) InsertP(exec boil.Executor, whitelist ... strin
 // - All columns without a default value are included (i.e. name, age)
 // - All columns with a default, but non-zero are included (i.e. health = 75)
 func (o *{{$tableNameSingular}}) Insert(exec boil.Executor, whitelist ... string) error {
+	return o.InsertWithDefaults(exec, nil, whitelist...)
+}
+
+// {{$tableNameSingular}}InsertReturningG inserts o and returns the inserted record.
+// See {{$tableNameSingular}}InsertReturning for behavior description.
+func {{$tableNameSingular}}InsertReturningG(o *{{$tableNameSingular}}, whitelist ... string) (*{{$tableNameSingular}}, error) {
+	return {{$tableNameSingular}}InsertReturning(boil.GetDB(), o, whitelist...)
+}
+
+// {{$tableNameSingular}}InsertReturningGP inserts o and returns the inserted record,
+// and panics on error. See {{$tableNameSingular}}InsertReturning for behavior description.
+func {{$tableNameSingular}}InsertReturningGP(o *{{$tableNameSingular}}, whitelist ... string) *{{$tableNameSingular}} {
+	ret, err := {{$tableNameSingular}}InsertReturning(boil.GetDB(), o, whitelist...)
+	if err != nil {
+		panic(boil.WrapErr(err))
+	}
+
+	return ret
+}
+
+// {{$tableNameSingular}}InsertReturningP inserts o using an executor and returns the
+// inserted record, and panics on error. See {{$tableNameSingular}}InsertReturning for
+// behavior description.
+func {{$tableNameSingular}}InsertReturningP(exec boil.Executor, o *{{$tableNameSingular}}, whitelist ... string) *{{$tableNameSingular}} {
+	ret, err := {{$tableNameSingular}}InsertReturning(exec, o, whitelist...)
+	if err != nil {
+		panic(boil.WrapErr(err))
+	}
+
+	return ret
+}
+
+// {{$tableNameSingular}}InsertReturning inserts o using an executor like Insert, but
+// leaves o untouched and returns a new {{$tableNameSingular}} instead, holding the
+// inserted values and the ones set by the database. Hooks run on the returned
+// record.
+{{- if .UseLastInsertID}}
+{{- if .Table.PKey}}
+// Since there is no RETURNING clause, the record is found again by its primary
+// key after the insert.
+{{- else}}
+// Since there is no RETURNING clause and {{.Table.Name}} has no primary key,
+// values set by the database are not read back.
+{{- end}}
+{{- end}}
+func {{$tableNameSingular}}InsertReturning(exec boil.Executor, o *{{$tableNameSingular}}, whitelist ... string) (*{{$tableNameSingular}}, error) {
+	if o == nil {
+		return nil, errors.New("{{.PkgName}}: no {{.Table.Name}} provided for insertion")
+	}
+
+	ret := *o
+	ret.R = nil
+	if err := ret.Insert(exec, whitelist...); err != nil {
+		return nil, err
+	}
+	{{- if and .UseLastInsertID .Table.PKey}}
+
+	if err := ret.Reload(boil.Primary(exec)); err != nil {
+		return nil, errors.Wrap(err, "{{.PkgName}}: unable to find inserted {{.Table.Name}}")
+	}
+	{{- end}}
+
+	return &ret, nil
+}
+
+// {{$tableNameSingular}}InsertReturningIDG inserts o and returns its new ID.
+// See {{$tableNameSingular}}InsertReturningID for behavior description.
+func {{$tableNameSingular}}InsertReturningIDG(o *{{$tableNameSingular}}, whitelist ... string) (int64, error) {
+	return {{$tableNameSingular}}InsertReturningID(boil.GetDB(), o, whitelist...)
+}
+
+// {{$tableNameSingular}}InsertReturningIDGP inserts o and returns its new ID,
+// and panics on error. See {{$tableNameSingular}}InsertReturningID for behavior
+// description.
+func {{$tableNameSingular}}InsertReturningIDGP(o *{{$tableNameSingular}}, whitelist ... string) int64 {
+	id, err := {{$tableNameSingular}}InsertReturningID(boil.GetDB(), o, whitelist...)
+	if err != nil {
+		panic(boil.WrapErr(err))
+	}
+
+	return id
+}
+
+// {{$tableNameSingular}}InsertReturningIDP inserts o using an executor and returns
+// its new ID, and panics on error. See {{$tableNameSingular}}InsertReturningID for
+// behavior description.
+func {{$tableNameSingular}}InsertReturningIDP(exec boil.Executor, o *{{$tableNameSingular}}, whitelist ... string) int64 {
+	id, err := {{$tableNameSingular}}InsertReturningID(exec, o, whitelist...)
+	if err != nil {
+		panic(boil.WrapErr(err))
+	}
+
+	return id
+}
+
+{{if .Table.CanLastInsertID -}}
+{{- $pkName := index .Table.PKey.Columns 0 -}}
+// {{$tableNameSingular}}InsertReturningID inserts o using an executor, choosing the
+// columns like Insert, and returns the {{$pkName}} the database gave the new row.
+// It is lighter than Insert: only the ID is read back, o is left untouched
+// apart from its automatic timestamps, and hooks aren't run.
+func {{$tableNameSingular}}InsertReturningID(exec boil.Executor, o *{{$tableNameSingular}}, whitelist ... string) (int64, error) {
+	if o == nil {
+		return 0, errors.New("{{.PkgName}}: no {{.Table.Name}} provided for insertion")
+	}
+	{{- template "timestamp_insert_helper" . }}
+
+	wl, _ := strmangle.InsertColumnSet(
+		{{$varNameSingular}}Columns,
+		{{$varNameSingular}}ColumnsWithDefault,
+		{{$varNameSingular}}ColumnsWithoutDefault,
+		queries.NonZeroDefaultSet({{$varNameSingular}}ColumnsWithDefault, o),
+		whitelist,
+	)
+	mapping, err := queries.BindMapping({{$varNameSingular}}Type, {{$varNameSingular}}Mapping, wl)
+	if err != nil {
+		return 0, err
+	}
+	vals := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), mapping)
+
+	{{if eq .DriverName "mssql" -}}
+	query := "INSERT INTO {{$schemaTable}} OUTPUT INSERTED.{{.LQ}}{{$pkName}}{{.RQ}} DEFAULT VALUES"
+	if len(wl) != 0 {
+		query = fmt.Sprintf("INSERT INTO {{$schemaTable}} ({{.LQ}}%s{{.RQ}}) OUTPUT INSERTED.{{.LQ}}{{$pkName}}{{.RQ}} VALUES (%s)", strings.Join(wl, "{{.RQ}},{{.LQ}}"), strmangle.PlaceholdersWithDefaults(dialect.IndexPlaceholders, wl, nil, 1))
+	}
+	{{- else if eq .DriverName "mysql" -}}
+	query := "INSERT INTO {{$schemaTable}} () VALUES ()"
+	if len(wl) != 0 {
+		query = fmt.Sprintf("INSERT INTO {{$schemaTable}} ({{.LQ}}%s{{.RQ}}) VALUES (%s)", strings.Join(wl, "{{.RQ}},{{.LQ}}"), strmangle.PlaceholdersWithDefaults(dialect.IndexPlaceholders, wl, nil, 1))
+	}
+	{{- else -}}
+	query := "INSERT INTO {{$schemaTable}} DEFAULT VALUES RETURNING {{.LQ}}{{$pkName}}{{.RQ}}"
+	if len(wl) != 0 {
+		query = fmt.Sprintf("INSERT INTO {{$schemaTable}} ({{.LQ}}%s{{.RQ}}) VALUES (%s) RETURNING {{.LQ}}{{$pkName}}{{.RQ}}", strings.Join(wl, "{{.RQ}},{{.LQ}}"), strmangle.PlaceholdersWithDefaults(dialect.IndexPlaceholders, wl, nil, 1))
+	}
+	{{- end}}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+
+	{{if .UseLastInsertID -}}
+	result, err := exec.Exec(query, vals...)
+	if err != nil {
+		return 0, errors.Wrap(err, "{{.PkgName}}: unable to insert into {{.Table.Name}}")
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, ErrSyncFail
+	}
+	{{- else -}}
+	var id int64
+	if err = exec.QueryRow(query, vals...).Scan(&id); err != nil {
+		return 0, errors.Wrap(err, "{{.PkgName}}: unable to insert into {{.Table.Name}}")
+	}
+	{{- end}}
+
+	return id, nil
+}
+{{- else -}}
+// {{$tableNameSingular}}InsertReturningID always fails, it needs a single auto
+// increment integer primary key column and {{.Table.Name}} has none. Use
+// {{$tableNameSingular}}InsertReturning instead.
+func {{$tableNameSingular}}InsertReturningID(exec boil.Executor, o *{{$tableNameSingular}}, whitelist ... string) (int64, error) {
+	return 0, errors.New("{{.PkgName}}: unable to insert into {{.Table.Name}} returning its ID, it has no single auto increment primary key")
+}
+{{- end}}
+
+// InsertWithDefaultsG a single record. See InsertWithDefaults for behavior description.
+func (o *{{$tableNameSingular}}) InsertWithDefaultsG(defaults []string, whitelist ... string) error {
+	return o.InsertWithDefaults(boil.GetDB(), defaults, whitelist...)
+}
+
+// InsertWithDefaultsGP a single record, and panics on error. See
+// InsertWithDefaults for behavior description.
+func (o *{{$tableNameSingular}}) InsertWithDefaultsGP(defaults []string, whitelist ... string) {
+	if err := o.InsertWithDefaults(boil.GetDB(), defaults, whitelist...); err != nil {
+		panic(boil.WrapErr(err))
+	}
+}
+
+// InsertWithDefaultsP a single record using an executor, and panics on error.
+// See InsertWithDefaults for behavior description.
+func (o *{{$tableNameSingular}}) InsertWithDefaultsP(exec boil.Executor, defaults []string, whitelist ... string) {
+	if err := o.InsertWithDefaults(exec, defaults, whitelist...); err != nil {
+		panic(boil.WrapErr(err))
+	}
+}
+
+// InsertOmitDefaultsG a single record. See InsertOmitDefaults for behavior description.
+func (o *{{$tableNameSingular}}) InsertOmitDefaultsG(whitelist ... string) error {
+	return o.InsertOmitDefaults(boil.GetDB(), whitelist...)
+}
+
+// InsertOmitDefaultsGP a single record, and panics on error. See
+// InsertOmitDefaults for behavior description.
+func (o *{{$tableNameSingular}}) InsertOmitDefaultsGP(whitelist ... string) {
+	if err := o.InsertOmitDefaults(boil.GetDB(), whitelist...); err != nil {
+		panic(boil.WrapErr(err))
+	}
+}
+
+// InsertOmitDefaultsP a single record using an executor, and panics on error.
+// See InsertOmitDefaults for behavior description.
+func (o *{{$tableNameSingular}}) InsertOmitDefaultsP(exec boil.Executor, whitelist ... string) {
+	if err := o.InsertOmitDefaults(exec, whitelist...); err != nil {
+		panic(boil.WrapErr(err))
+	}
+}
+
+// InsertOmitDefaults a single record using an executor. It behaves like
+// InsertWithDefaults where defaults are the columns whose value equals
+// their literal database default, so the database applies the default
+// itself. Only literal numbers, booleans and strings qualify, columns with
+// defaults like now() are inserted as usual.
+func (o *{{$tableNameSingular}}) InsertOmitDefaults(exec boil.Executor, whitelist ... string) error {
 	if o == nil {
 		return errors.New("{{.PkgName}}: no {{.Table.Name}} provided for insertion")
 	}
 
+	defaults := queries.LiteralDefaultSet({{$varNameSingular}}LiteralDefaults, o)
+	return o.InsertWithDefaults(exec, defaults, whitelist...)
+}
+
+// InsertWithDefaults a single record using an executor. Columns are chosen
+// the same way as Insert, but any of them that are also in defaults are
+// inserted with the DEFAULT keyword instead of the struct's value, and are
+// then read back from the database like other columns with defaults.
+func (o *{{$tableNameSingular}}) InsertWithDefaults(exec boil.Executor, defaults []string, whitelist ... string) error {
+	return o.insert(exec, "", defaults, whitelist)
+}
+
+// InsertInSchemaG a single record. See InsertInSchema for behavior description.
+func (o *{{$tableNameSingular}}) InsertInSchemaG(schema string, whitelist ... string) error {
+	return o.InsertInSchema(boil.GetDB(), schema, whitelist...)
+}
+
+// InsertInSchemaGP a single record, and panics on error. See InsertInSchema
+// for behavior description.
+func (o *{{$tableNameSingular}}) InsertInSchemaGP(schema string, whitelist ... string) {
+	if err := o.InsertInSchema(boil.GetDB(), schema, whitelist...); err != nil {
+		panic(boil.WrapErr(err))
+	}
+}
+
+// InsertInSchemaP a single record using an executor, and panics on error.
+// See InsertInSchema for behavior description.
+func (o *{{$tableNameSingular}}) InsertInSchemaP(exec boil.Executor, schema string, whitelist ... string) {
+	if err := o.InsertInSchema(exec, schema, whitelist...); err != nil {
+		panic(boil.WrapErr(err))
+	}
+}
+
+// InsertInSchema a single record using an executor, into the {{.Table.Name}}
+// table of schema instead of the schema it was generated for, like the one
+// of a tenant. Columns are chosen the same way as Insert. Use qm.Schema to
+// query the table of that schema.
+func (o *{{$tableNameSingular}}) InsertInSchema(exec boil.Executor, schema string, whitelist ... string) error {
+	return o.insert(exec, schema, nil, whitelist)
+}
+
+// insert a single record using an executor, into the table of schema if it
+// isn't empty. See InsertWithDefaults for behavior description.
+func (o *{{$tableNameSingular}}) insert(exec boil.Executor, schema string, defaults, whitelist []string) error {
+	if o == nil {
+		return errors.New("{{.PkgName}}: no {{.Table.Name}} provided for insertion")
+	}
+
+	// The inserted row is read back, so keep everything on the primary
+	exec = boil.Primary(exec)
+
 	var err error
 	{{- template "timestamp_insert_helper" . }}
 
@@ -44,11 +314,22 @@ func (o *{{$tableNameSingular}}) Insert(exec boil.Executor, whitelist ... string
 	nzDefaults := queries.NonZeroDefaultSet({{$varNameSingular}}ColumnsWithDefault, o)
 
 	key := makeCacheKey(whitelist, nzDefaults)
+	if len(defaults) != 0 {
+		key += ":" + strings.Join(defaults, ",")
+	}
+	if len(schema) != 0 {
+		key += "@" + schema
+	}
 	{{$varNameSingular}}InsertCacheMut.RLock()
 	cache, cached := {{$varNameSingular}}InsertCache[key]
 	{{$varNameSingular}}InsertCacheMut.RUnlock()
 
 	if !cached {
+		table := "{{$schemaTable}}"
+		if len(schema) != 0 {
+			table = queries.SchemaTable(&dialect, schema, "{{.Table.Name}}")
+		}
+
 		wl, returnColumns := strmangle.InsertColumnSet(
 			{{$varNameSingular}}Columns,
 			{{$varNameSingular}}ColumnsWithDefault,
@@ -57,7 +338,11 @@ func (o *{{$tableNameSingular}}) Insert(exec boil.Executor, whitelist ... string
 			whitelist,
 		)
 
-		cache.valueMapping, err = queries.BindMapping({{$varNameSingular}}Type, {{$varNameSingular}}Mapping, wl)
+		// Columns written as DEFAULT are not bound, and are returned instead
+		bound := strmangle.SetComplement(wl, defaults)
+		returnColumns = strmangle.SetMerge(returnColumns, strmangle.SetComplement(wl, bound))
+
+		cache.valueMapping, err = queries.BindMapping({{$varNameSingular}}Type, {{$varNameSingular}}Mapping, bound)
 		if err != nil {
 			return err
 		}
@@ -66,20 +351,22 @@ func (o *{{$tableNameSingular}}) Insert(exec boil.Executor, whitelist ... string
 			return err
 		}
 		if len(wl) != 0 {
-			cache.query = fmt.Sprintf("INSERT INTO {{$schemaTable}} ({{.LQ}}%s{{.RQ}}) %%sVALUES (%s)%%s", strings.Join(wl, "{{.RQ}},{{.LQ}}"), strmangle.Placeholders(dialect.IndexPlaceholders, len(wl), 1, 1))
+			// The query is formatted again below, so escape the schema
+			cache.query = fmt.Sprintf("INSERT INTO %s ({{.LQ}}%s{{.RQ}}) %%sVALUES (%s)%%s", strings.Replace(table, "%", "%%", -1), strings.Join(wl, "{{.RQ}},{{.LQ}}"), strmangle.PlaceholdersWithDefaults(dialect.IndexPlaceholders, wl, defaults, 1))
 		} else {
 			{{if eq .DriverName "mysql" -}}
-			cache.query = "INSERT INTO {{$schemaTable}} () VALUES ()"
+			cache.query = "INSERT INTO " + table + " () VALUES ()"
 			{{else -}}
-			cache.query = "INSERT INTO {{$schemaTable}} DEFAULT VALUES"
+			cache.query = "INSERT INTO " + table + " DEFAULT VALUES"
 			{{end -}}
 		}
 
 		var queryOutput, queryReturning string
 
+		{{if or (not .UseLastInsertID) .Table.PKey -}}
 		if len(cache.retMapping) != 0 {
 			{{if .UseLastInsertID -}}
-			cache.retQuery = fmt.Sprintf("SELECT {{.LQ}}%s{{.RQ}} FROM {{$schemaTable}} WHERE %s", strings.Join(returnColumns, "{{.RQ}},{{.LQ}}"), strmangle.WhereClause("{{.LQ}}", "{{.RQ}}", {{if .Dialect.IndexPlaceholders}}1{{else}}0{{end}}, {{$varNameSingular}}PrimaryKeyColumns))
+			cache.retQuery = fmt.Sprintf("SELECT {{.LQ}}%s{{.RQ}} FROM %s WHERE %s", strings.Join(returnColumns, "{{.RQ}},{{.LQ}}"), table, strmangle.WhereClause("{{.LQ}}", "{{.RQ}}", {{if .Dialect.IndexPlaceholders}}1{{else}}0{{end}}, {{$varNameSingular}}PrimaryKeyColumns))
 			{{else -}}
 				{{if ne .DriverName "mssql" -}}
 			queryReturning = fmt.Sprintf(" RETURNING {{.LQ}}%s{{.RQ}}", strings.Join(returnColumns, "{{.RQ}},{{.LQ}}"))
@@ -88,7 +375,7 @@ func (o *{{$tableNameSingular}}) Insert(exec boil.Executor, whitelist ... string
 				{{end -}}
 			{{end -}}
 		}
-
+		{{end}}
 		if len(wl) != 0 {
 			cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
 		}
@@ -113,6 +400,7 @@ func (o *{{$tableNameSingular}}) Insert(exec boil.Executor, whitelist ... string
 		return errors.Wrap(err, "{{.PkgName}}: unable to insert into {{.Table.Name}}")
 	}
 	
+	{{if .Table.PKey -}}
 	{{if $canLastInsertID -}}
 	var lastID int64
 	{{- end}}
@@ -152,6 +440,7 @@ func (o *{{$tableNameSingular}}) Insert(exec boil.Executor, whitelist ... string
 	if err != nil {
 		return errors.Wrap(err, "{{.PkgName}}: unable to populate default values for {{.Table.Name}}")
 	}
+	{{- end}}
 	{{else}}
 	if len(cache.retMapping) != 0 {
 		err = exec.QueryRow(cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
@@ -164,7 +453,7 @@ func (o *{{$tableNameSingular}}) Insert(exec boil.Executor, whitelist ... string
 	}
 	{{end}}
 
-{{if .UseLastInsertID -}}
+{{if and .UseLastInsertID .Table.PKey -}}
 CacheNoHooks:
 {{- end}}
 	if !cached {
@@ -179,3 +468,81 @@ CacheNoHooks:
 	return nil
 	{{- end}}
 }
+
+// InsertEachG inserts the rows of the slice one at a time. See InsertEach
+// for behavior description.
+func (o {{$tableNameSingular}}Slice) InsertEachG(whitelist ...string) error {
+	return o.InsertEach(boil.GetDB(), whitelist...)
+}
+
+// InsertEachGP inserts the rows of the slice one at a time, and panics on
+// error. See InsertEach for behavior description.
+func (o {{$tableNameSingular}}Slice) InsertEachGP(whitelist ...string) {
+	if err := o.InsertEach(boil.GetDB(), whitelist...); err != nil {
+		panic(boil.WrapErr(err))
+	}
+}
+
+// InsertEachP inserts the rows of the slice one at a time using an executor,
+// and panics on error. See InsertEach for behavior description.
+func (o {{$tableNameSingular}}Slice) InsertEachP(exec boil.Executor, whitelist ...string) {
+	if err := o.InsertEach(exec, whitelist...); err != nil {
+		panic(boil.WrapErr(err))
+	}
+}
+
+// InsertEach inserts the rows of the slice one at a time using an executor,
+// the same way Insert does, so the values set by the database are read back
+// into every row. Each distinct insert statement is prepared once and reused
+// for the rows after it, and the statements are closed before returning.
+// It stops at the first row that fails to insert, run it in a transaction to
+// roll back the rows inserted before that one.
+func (o {{$tableNameSingular}}Slice) InsertEach(exec boil.Executor, whitelist ...string) (err error) {
+	if len(o) == 0 {
+		return nil
+	}
+
+	stmts := boil.WithPrepared(boil.Primary(exec))
+	defer func() {
+		if closeErr := stmts.Close(); err == nil && closeErr != nil {
+			err = errors.Wrap(closeErr, "{{.PkgName}}: unable to close the insert statements of {{.Table.Name}}")
+		}
+	}()
+
+	for i, row := range o {
+		if err = row.Insert(stmts, whitelist...); err != nil {
+			return errors.Wrapf(err, "{{.PkgName}}: unable to insert row %d of %d into {{.Table.Name}}", i+1, len(o))
+		}
+	}
+
+	return nil
+}
+
+// InsertAllFromP inserts the rows selected by source, and panics on error.
+// See InsertAllFrom for behavior description.
+func (q {{$varNameSingular}}Query) InsertAllFromP(source *queries.Query, columns ...string) {
+	if err := q.InsertAllFrom(source, columns...); err != nil {
+		panic(boil.WrapErr(err))
+	}
+}
+
+// InsertAllFrom inserts the rows selected by source into {{.Table.Name}} with
+// a single INSERT INTO ... SELECT statement, for example to copy rows between
+// tables. The selected columns of source fill columns in order, or all of the
+// columns of {{.Table.Name}} when none are given. The args of source are used,
+// the statement runs with the executor of q and ignores its other mods.
+func (q {{$varNameSingular}}Query) InsertAllFrom(source *queries.Query, columns ...string) error {
+	if source == nil {
+		return errors.New("{{.PkgName}}: no source query provided for insert all from")
+	}
+
+	queries.SetInsertSelect(q.Query, columns, source)
+
+	_, err := q.Query.Exec()
+	if err != nil {
+		return errors.Wrap(err, "{{.PkgName}}: unable to insert all from query into {{.Table.Name}}")
+	}
+
+	return nil
+}
+{{- end -}}{{- /* if not IsView */ -}}
@@ -1,3 +1,4 @@
+{{- if not .Table.IsView -}}
 {{- $tableNameSingular := .Table.Name | singular | titleCase -}}
 {{- $varNameSingular := .Table.Name | singular | camelCase -}}
 {{- $schemaTable := .Table.Name | .SchemaTable}}
@@ -25,6 +26,7 @@ func (o *{{$tableNameSingular}}) UpdateP(exec boil.Executor, whitelist ... strin
 	}
 }
 
+{{if .Table.PKey -}}
 // Update uses an executor to update the {{$tableNameSingular}}.
 // Whitelist behavior: If a whitelist is provided, only the columns given are updated.
 // No whitelist behavior: Without a whitelist, columns are inferred by the following rules:
@@ -86,6 +88,7 @@ func (o *{{$tableNameSingular}}) Update(exec boil.Executor, whitelist ... string
 	if err != nil {
 		return errors.Wrap(err, "{{.PkgName}}: unable to update {{.Table.Name}} row")
 	}
+	o.evictIdentity(exec)
 
 	if !cached {
 		{{$varNameSingular}}UpdateCacheMut.Lock()
@@ -99,6 +102,64 @@ func (o *{{$tableNameSingular}}) Update(exec boil.Executor, whitelist ... string
 	return nil
 	{{- end}}
 }
+{{- else -}}
+// Update uses an executor to update the {{$tableNameSingular}}.
+// {{.Table.Name}} has no primary key, so the whitelist must name the columns to
+// update and the row is matched on all of the other columns, using their
+// values in o before the update. Every row identical on those columns is updated.
+func (o *{{$tableNameSingular}}) Update(exec boil.Executor, whitelist ... string) error {
+	if len(whitelist) == 0 {
+		return errors.New("{{.PkgName}}: unable to update {{.Table.Name}}, a whitelist is required for tables without a primary key")
+	}
+
+	matchColumns := strmangle.SetComplement({{$varNameSingular}}Columns, whitelist)
+	if len(matchColumns) == 0 {
+		return errors.New("{{.PkgName}}: unable to update {{.Table.Name}}, no columns are left to match the row on")
+	}
+
+	matchMapping, err := queries.BindMapping({{$varNameSingular}}Type, {{$varNameSingular}}Mapping, matchColumns)
+	if err != nil {
+		return err
+	}
+	matchValues := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), matchMapping)
+	{{- template "timestamp_update_helper" . }}
+
+	{{if not .NoHooks -}}
+	if err = o.doBeforeUpdateHooks(exec); err != nil {
+		return err
+	}
+
+	{{end -}}
+	valueMapping, err := queries.BindMapping({{$varNameSingular}}Type, {{$varNameSingular}}Mapping, whitelist)
+	if err != nil {
+		return err
+	}
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), valueMapping)
+
+	where, whereArgs := queries.WhereClauseRows(dialect.LQ, dialect.RQ, {{if .Dialect.IndexPlaceholders}}len(whitelist)+1{{else}}0{{end}}, matchColumns, matchValues)
+	sql := fmt.Sprintf("UPDATE {{$schemaTable}} SET %s WHERE %s",
+		strmangle.SetParamNames("{{.LQ}}", "{{.RQ}}", {{if .Dialect.IndexPlaceholders}}1{{else}}0{{end}}, whitelist),
+		where,
+	)
+	values = append(values, whereArgs...)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+
+	_, err = exec.Exec(sql, values...)
+	if err != nil {
+		return errors.Wrap(err, "{{.PkgName}}: unable to update {{.Table.Name}} row")
+	}
+
+	{{if not .NoHooks -}}
+	return o.doAfterUpdateHooks(exec)
+	{{- else -}}
+	return nil
+	{{- end}}
+}
+{{- end}}
 
 // UpdateAllP updates all rows with matching column names, and panics on error.
 func (q {{$varNameSingular}}Query) UpdateAllP(cols M) {
@@ -116,9 +177,46 @@ func (q {{$varNameSingular}}Query) UpdateAll(cols M) error {
 		return errors.Wrap(err, "{{.PkgName}}: unable to update all for {{.Table.Name}}")
 	}
 
+	// The updated rows aren't known, so none of the table's are kept
+	if identities := boil.IdentityMapOf(queries.GetExecutor(q.Query)); identities != nil {
+		identities.EvictTable("{{.Table.Name}}")
+	}
+
 	return nil
 }
 
+// UpdateAllFromP updates all rows with the values of the whitelisted columns of o,
+// and panics on error.
+func (q {{$varNameSingular}}Query) UpdateAllFromP(o *{{$tableNameSingular}}, whitelist ...string) {
+	if err := q.UpdateAllFrom(o, whitelist...); err != nil {
+		panic(boil.WrapErr(err))
+	}
+}
+
+// UpdateAllFrom updates all rows with the values of the whitelisted columns of o,
+// which must name at least one column.
+func (q {{$varNameSingular}}Query) UpdateAllFrom(o *{{$tableNameSingular}}, whitelist ...string) error {
+	if o == nil {
+		return errors.New("{{.PkgName}}: no {{$tableNameSingular}} provided for update all")
+	}
+	if len(whitelist) == 0 {
+		return errors.New("{{.PkgName}}: unable to update all for {{.Table.Name}}, no columns given")
+	}
+
+	mapping, err := queries.BindMapping({{$varNameSingular}}Type, {{$varNameSingular}}Mapping, whitelist)
+	if err != nil {
+		return err
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), mapping)
+	cols := make(M, len(whitelist))
+	for i, column := range whitelist {
+		cols[column] = values[i]
+	}
+
+	return q.UpdateAll(cols)
+}
+
 // UpdateAllG updates all rows with the specified column values.
 func (o {{$tableNameSingular}}Slice) UpdateAllG(cols M) error {
 	return o.UpdateAll(boil.GetDB(), cols)
@@ -159,6 +257,7 @@ func (o {{$tableNameSingular}}Slice) UpdateAll(exec boil.Executor, cols M) error
 		i++
 	}
 
+	{{if .Table.PKey -}}
 	// Append all of the primary key values for each column
 	for _, obj := range o {
 		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), {{$varNameSingular}}PrimaryKeyMapping)
@@ -168,6 +267,20 @@ func (o {{$tableNameSingular}}Slice) UpdateAll(exec boil.Executor, cols M) error
 	sql := fmt.Sprintf("UPDATE {{$schemaTable}} SET %s WHERE %s",
 		strmangle.SetParamNames("{{.LQ}}", "{{.RQ}}", {{if .Dialect.IndexPlaceholders}}1{{else}}0{{end}}, colNames),
 		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), {{if .Dialect.IndexPlaceholders}}len(colNames)+1{{else}}0{{end}}, {{$varNameSingular}}PrimaryKeyColumns, len(o)))
+	{{- else -}}
+	// Without a primary key each row is matched on all of its columns
+	rows := make([][]interface{}, len(o))
+	for i, obj := range o {
+		rows[i] = queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), {{$varNameSingular}}ColumnsMapping)
+	}
+
+	where, whereArgs := queries.WhereClauseRows(dialect.LQ, dialect.RQ, {{if .Dialect.IndexPlaceholders}}len(colNames)+1{{else}}0{{end}}, {{$varNameSingular}}Columns, rows...)
+	args = append(args, whereArgs...)
+
+	sql := fmt.Sprintf("UPDATE {{$schemaTable}} SET %s WHERE %s",
+		strmangle.SetParamNames("{{.LQ}}", "{{.RQ}}", {{if .Dialect.IndexPlaceholders}}1{{else}}0{{end}}, colNames),
+		where)
+	{{- end}}
 
 	if boil.DebugMode {
 		fmt.Fprintln(boil.DebugWriter, sql)
@@ -179,5 +292,13 @@ func (o {{$tableNameSingular}}Slice) UpdateAll(exec boil.Executor, cols M) error
 		return errors.Wrap(err, "{{.PkgName}}: unable to update all in {{$varNameSingular}} slice")
 	}
 
+	{{if .Table.PKey -}}
+	for _, obj := range o {
+		obj.evictIdentity(exec)
+	}
+
+	{{end -}}
+
 	return nil
 }
+{{- end -}}{{- /* if not IsView */ -}}
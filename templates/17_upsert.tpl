@@ -1,3 +1,4 @@
+{{- if and (not .Table.IsView) .Table.PKey -}}
 {{- $tableNameSingular := .Table.Name | singular | titleCase -}}
 {{- $varNameSingular := .Table.Name | singular | camelCase -}}
 {{- $schemaTable := .Table.Name | .SchemaTable}}
@@ -22,24 +23,191 @@ func (o *{{$tableNameSingular}}) UpsertP(exec boil.Executor, {{if eq .DriverName
 }
 
 // Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// On conflict only updateColumns are updated, or all non-primary key columns when it's empty.
 func (o *{{$tableNameSingular}}) Upsert(exec boil.Executor, {{if eq .DriverName "postgres"}}updateOnConflict bool, conflictColumns []string, {{end}}updateColumns []string, whitelist ...string) error {
+	_, err := o.upsert(exec, false, {{if eq .DriverName "postgres"}}updateOnConflict, conflictColumns{{else}}true, nil{{end}}, "", false, nil, updateColumns, whitelist...)
+	return err
+}
+{{- if ne .DriverName "mssql"}}
+
+// UpsertExprsG attempts an insert, and sets columns to expressions on conflict.
+// See UpsertExprs for the expressions.
+func (o *{{$tableNameSingular}}) UpsertExprsG({{if eq .DriverName "postgres"}}conflictColumns []string, {{end}}updateExprs map[string]string, whitelist ...string) error {
+	return o.UpsertExprs(boil.GetDB(), {{if eq .DriverName "postgres"}}conflictColumns, {{end}}updateExprs, whitelist...)
+}
+
+// UpsertExprsGP attempts an insert, and sets columns to expressions on conflict. Panics on error.
+// See UpsertExprs for the expressions.
+func (o *{{$tableNameSingular}}) UpsertExprsGP({{if eq .DriverName "postgres"}}conflictColumns []string, {{end}}updateExprs map[string]string, whitelist ...string) {
+	if err := o.UpsertExprs(boil.GetDB(), {{if eq .DriverName "postgres"}}conflictColumns, {{end}}updateExprs, whitelist...); err != nil {
+		panic(boil.WrapErr(err))
+	}
+}
+
+// UpsertExprsP attempts an insert using an executor, and sets columns to expressions on conflict.
+// UpsertExprsP panics on error. See UpsertExprs for the expressions.
+func (o *{{$tableNameSingular}}) UpsertExprsP(exec boil.Executor, {{if eq .DriverName "postgres"}}conflictColumns []string, {{end}}updateExprs map[string]string, whitelist ...string) {
+	if err := o.UpsertExprs(exec, {{if eq .DriverName "postgres"}}conflictColumns, {{end}}updateExprs, whitelist...); err != nil {
+		panic(boil.WrapErr(err))
+	}
+}
+
+// UpsertExprs attempts an insert using an executor, and on conflict sets each column of
+// updateExprs to its SQL expression instead of the value in o, like
+// "{{.Table.Name}}.count + EXCLUDED.count" to add the count of o to the existing row's.
+// The expressions are written verbatim, EXCLUDED.column refers to the row proposed for
+// insertion{{if eq .DriverName "mysql"}} and is written as VALUES(column){{end}}, and {{.Table.Name}}.column to the existing row.
+// Only the columns of updateExprs are updated.
+func (o *{{$tableNameSingular}}) UpsertExprs(exec boil.Executor, {{if eq .DriverName "postgres"}}conflictColumns []string, {{end}}updateExprs map[string]string, whitelist ...string) error {
+	var updateColumns []string
+	for _, c := range {{$varNameSingular}}Columns {
+		if _, ok := updateExprs[c]; ok {
+			updateColumns = append(updateColumns, c)
+		}
+	}
+	if len(updateColumns) == 0 || len(updateColumns) != len(updateExprs) {
+		return errors.New("{{.PkgName}}: the update expressions of a {{.Table.Name}} upsert must be of its columns")
+	}
+
+	_, err := o.upsert(exec, false, true, {{if eq .DriverName "postgres"}}conflictColumns{{else}}nil{{end}}, "", false, updateExprs, updateColumns, whitelist...)
+	return err
+}
+{{- end}}
+{{- if eq .DriverName "postgres"}}
+
+// UpsertOnConstraintG attempts an insert, and does an update or ignore on conflict.
+// See UpsertOnConstraint for the conflict target.
+func (o *{{$tableNameSingular}}) UpsertOnConstraintG(updateOnConflict bool, constraint string, updateColumns []string, whitelist ...string) error {
+	return o.UpsertOnConstraint(boil.GetDB(), updateOnConflict, constraint, updateColumns, whitelist...)
+}
+
+// UpsertOnConstraintGP attempts an insert, and does an update or ignore on conflict. Panics on error.
+// See UpsertOnConstraint for the conflict target.
+func (o *{{$tableNameSingular}}) UpsertOnConstraintGP(updateOnConflict bool, constraint string, updateColumns []string, whitelist ...string) {
+	if err := o.UpsertOnConstraint(boil.GetDB(), updateOnConflict, constraint, updateColumns, whitelist...); err != nil {
+		panic(boil.WrapErr(err))
+	}
+}
+
+// UpsertOnConstraintP attempts an insert using an executor, and does an update or ignore on conflict.
+// UpsertOnConstraintP panics on error. See UpsertOnConstraint for the conflict target.
+func (o *{{$tableNameSingular}}) UpsertOnConstraintP(exec boil.Executor, updateOnConflict bool, constraint string, updateColumns []string, whitelist ...string) {
+	if err := o.UpsertOnConstraint(exec, updateOnConflict, constraint, updateColumns, whitelist...); err != nil {
+		panic(boil.WrapErr(err))
+	}
+}
+
+// UpsertOnConstraint attempts an insert using an executor, and does an update or ignore on conflict.
+// Unlike Upsert the conflict target is the named constraint (ON CONFLICT ON CONSTRAINT)
+// instead of a list of columns.
+func (o *{{$tableNameSingular}}) UpsertOnConstraint(exec boil.Executor, updateOnConflict bool, constraint string, updateColumns []string, whitelist ...string) error {
+	if len(constraint) == 0 {
+		return errors.New("{{.PkgName}}: no constraint provided for {{.Table.Name}} upsert")
+	}
+
+	_, err := o.upsert(exec, false, updateOnConflict, nil, constraint, false, nil, updateColumns, whitelist...)
+	return err
+}
+
+// UpsertIfChangedG attempts an insert, and does an update or ignore on conflict.
+// See UpsertIfChanged for when the conflicting row is updated.
+func (o *{{$tableNameSingular}}) UpsertIfChangedG(updateOnConflict bool, conflictColumns []string, updateColumns []string, whitelist ...string) error {
+	return o.UpsertIfChanged(boil.GetDB(), updateOnConflict, conflictColumns, updateColumns, whitelist...)
+}
+
+// UpsertIfChangedGP attempts an insert, and does an update or ignore on conflict. Panics on error.
+// See UpsertIfChanged for when the conflicting row is updated.
+func (o *{{$tableNameSingular}}) UpsertIfChangedGP(updateOnConflict bool, conflictColumns []string, updateColumns []string, whitelist ...string) {
+	if err := o.UpsertIfChanged(boil.GetDB(), updateOnConflict, conflictColumns, updateColumns, whitelist...); err != nil {
+		panic(boil.WrapErr(err))
+	}
+}
+
+// UpsertIfChangedP attempts an insert using an executor, and does an update or ignore on conflict.
+// UpsertIfChangedP panics on error. See UpsertIfChanged for when the conflicting row is updated.
+func (o *{{$tableNameSingular}}) UpsertIfChangedP(exec boil.Executor, updateOnConflict bool, conflictColumns []string, updateColumns []string, whitelist ...string) {
+	if err := o.UpsertIfChanged(exec, updateOnConflict, conflictColumns, updateColumns, whitelist...); err != nil {
+		panic(boil.WrapErr(err))
+	}
+}
+
+// UpsertIfChanged attempts an insert using an executor, and does an update or ignore on conflict.
+// Unlike Upsert the conflicting row is only updated when one of the update columns
+// is distinct from o{{if and (not .NoAutoTimestamps) (containsAny (.Table.Columns | columnNames) "updated_at")}}, leaving out updated_at{{end}}, so an unchanged row isn't rewritten.
+// The returned columns of o aren't set when the row is left alone.
+func (o *{{$tableNameSingular}}) UpsertIfChanged(exec boil.Executor, updateOnConflict bool, conflictColumns []string, updateColumns []string, whitelist ...string) error {
+	_, err := o.upsert(exec, false, updateOnConflict, conflictColumns, "", true, nil, updateColumns, whitelist...)
+	return err
+}
+{{- end}}
+
+// UpsertInsertedG attempts an insert, and does an update or ignore on conflict.
+// See UpsertInserted for the meaning of the returned bool.
+func (o *{{$tableNameSingular}}) UpsertInsertedG({{if eq .DriverName "postgres"}}updateOnConflict bool, conflictColumns []string, {{end}}updateColumns []string, whitelist ...string) (bool, error) {
+	return o.UpsertInserted(boil.GetDB(), {{if eq .DriverName "postgres"}}updateOnConflict, conflictColumns, {{end}}updateColumns, whitelist...)
+}
+
+// UpsertInsertedGP attempts an insert, and does an update or ignore on conflict. Panics on error.
+// See UpsertInserted for the meaning of the returned bool.
+func (o *{{$tableNameSingular}}) UpsertInsertedGP({{if eq .DriverName "postgres"}}updateOnConflict bool, conflictColumns []string, {{end}}updateColumns []string, whitelist ...string) bool {
+	inserted, err := o.UpsertInserted(boil.GetDB(), {{if eq .DriverName "postgres"}}updateOnConflict, conflictColumns, {{end}}updateColumns, whitelist...)
+	if err != nil {
+		panic(boil.WrapErr(err))
+	}
+
+	return inserted
+}
+
+// UpsertInsertedP attempts an insert using an executor, and does an update or ignore on conflict.
+// UpsertInsertedP panics on error. See UpsertInserted for the meaning of the returned bool.
+func (o *{{$tableNameSingular}}) UpsertInsertedP(exec boil.Executor, {{if eq .DriverName "postgres"}}updateOnConflict bool, conflictColumns []string, {{end}}updateColumns []string, whitelist ...string) bool {
+	inserted, err := o.UpsertInserted(exec, {{if eq .DriverName "postgres"}}updateOnConflict, conflictColumns, {{end}}updateColumns, whitelist...)
+	if err != nil {
+		panic(boil.WrapErr(err))
+	}
+
+	return inserted
+}
+
+// UpsertInserted attempts an insert using an executor, and does an update or ignore on conflict.
+// It returns true if a new row was inserted, and false if an existing row was updated or
+// the conflict was ignored.
+{{- if eq .DriverName "mysql"}}
+// MySQL reports this through the affected row count, so connections using the
+// CLIENT_FOUND_ROWS flag cannot tell an insert apart from an update that changed nothing.
+{{- end}}
+func (o *{{$tableNameSingular}}) UpsertInserted(exec boil.Executor, {{if eq .DriverName "postgres"}}updateOnConflict bool, conflictColumns []string, {{end}}updateColumns []string, whitelist ...string) (bool, error) {
+	return o.upsert(exec, true, {{if eq .DriverName "postgres"}}updateOnConflict, conflictColumns{{else}}true, nil{{end}}, "", false, nil, updateColumns, whitelist...)
+}
+
+// upsert builds its statement through the upsert builder of the dialect, which
+// ignores the arguments the database has no use for.
+func (o *{{$tableNameSingular}}) upsert(exec boil.Executor, wantInserted, updateOnConflict bool, conflictColumns []string, conflictConstraint string, ifChanged bool, updateExprs map[string]string, updateColumns []string, whitelist ...string) (bool, error) {
 	if o == nil {
-		return errors.New("{{.PkgName}}: no {{.Table.Name}} provided for upsert")
+		return false, errors.New("{{.PkgName}}: no {{.Table.Name}} provided for upsert")
 	}
 
+	// The inserted row is read back, so keep everything on the primary
+	exec = boil.Primary(exec)
+
 	{{- template "timestamp_upsert_helper" . }}
 
 	{{if not .NoHooks -}}
 	if err := o.doBeforeUpsertHooks(exec); err != nil {
-		return err
+		return false, err
 	}
 	{{- end}}
 
 	nzDefaults := queries.NonZeroDefaultSet({{$varNameSingular}}ColumnsWithDefault, o)
 
-	// Build cache key in-line uglily - mysql vs postgres problems
+	// Build cache key in-line uglily
 	buf := strmangle.GetBuffer()
-	{{if eq .DriverName "postgres"}}
+	if wantInserted {
+		buf.WriteString("inserted.")
+	}
+	if ifChanged {
+		buf.WriteString("changed.")
+	}
 	if updateOnConflict {
 		buf.WriteByte('t')
 	} else {
@@ -50,11 +218,19 @@ func (o *{{$tableNameSingular}}) Upsert(exec boil.Executor, {{if eq .DriverName
 		buf.WriteString(c)
 	}
 	buf.WriteByte('.')
-	{{end -}}
+	buf.WriteString(conflictConstraint)
+	buf.WriteByte('.')
 	for _, c := range updateColumns {
 		buf.WriteString(c)
 	}
 	buf.WriteByte('.')
+	for _, c := range updateColumns {
+		if expr, ok := updateExprs[c]; ok {
+			buf.WriteString(expr)
+			buf.WriteByte(',')
+		}
+	}
+	buf.WriteByte('.')
 	for _, c := range whitelist {
 		buf.WriteString(c)
 	}
@@ -87,7 +263,7 @@ func (o *{{$tableNameSingular}}) Upsert(exec boil.Executor, {{if eq .DriverName
 			}
 		}
 		if len(insert) == 0 {
-			return errors.New("{{.PkgName}}: unable to upsert {{.Table.Name}}, could not build insert column list")
+			return false, errors.New("{{.PkgName}}: unable to upsert {{.Table.Name}}, could not build insert column list")
 		}
 
 		ret = strmangle.SetMerge(ret, {{$varNameSingular}}ColumnsWithAuto)
@@ -104,24 +280,54 @@ func (o *{{$tableNameSingular}}) Upsert(exec boil.Executor, {{if eq .DriverName
 		{{end -}}
 
 		if len(update) == 0 {
-			return errors.New("{{.PkgName}}: unable to upsert {{.Table.Name}}, could not build update column list")
+			return false, errors.New("{{.PkgName}}: unable to upsert {{.Table.Name}}, could not build update column list")
 		}
+		{{- if ne .DriverName "mssql"}}
+
+		// The update copies the values of the row proposed for insertion, so
+		// the update columns have to be inserted or they'd get their defaults
+		insert = strmangle.SetMerge(insert, updateColumns)
+		ret = strmangle.SetComplement(ret, insert)
+		{{- end}}
 
-		{{if eq .DriverName "postgres"}}
 		conflict := conflictColumns
 		if len(conflict) == 0 {
 			conflict = make([]string, len({{$varNameSingular}}PrimaryKeyColumns))
 			copy(conflict, {{$varNameSingular}}PrimaryKeyColumns)
 		}
-		cache.query = queries.BuildUpsertQueryPostgres(dialect, "{{$schemaTable}}", updateOnConflict, ret, update, conflict, insert)
-		{{else if eq .DriverName "mysql"}}
-		cache.query = queries.BuildUpsertQueryMySQL(dialect, "{{.Table.Name}}", update, insert)
+		up := queries.Upsert{
+			Table:       "{{$schemaTable}}",
+			Insert:      insert,
+			Update:      update,
+			UpdateExprs: updateExprs,
+			Conflict:    conflict,
+			Constraint:  conflictConstraint,
+			Primary:     {{$varNameSingular}}PrimaryKeyColumns,
+			Return:      ret,
+			Inserted:    wantInserted,
+		}
+		if !updateOnConflict {
+			up.Update = nil
+		}
+		if ifChanged {
+			{{- if and (not .NoAutoTimestamps) (containsAny (.Table.Columns | columnNames) "updated_at")}}
+			// updated_at was just set, comparing it would always update the row
+			up.Changed = strmangle.SetComplement(update, []string{"updated_at"})
+			if len(up.Changed) == 0 {
+				up.Changed = update
+			}
+			{{- else}}
+			up.Changed = update
+			{{- end}}
+		}
+		cache.query = dialect.BuildUpsert(up)
+		{{- if .UseLastInsertID}}
 		cache.retQuery = fmt.Sprintf(
 			"SELECT %s FROM {{.LQ}}{{.Table.Name}}{{.RQ}} WHERE {{whereClause .LQ .RQ 0 .Table.PKey.Columns}}",
 			strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, ret), ","),
 		)
-		{{else if eq .DriverName "mssql"}}
-		cache.query = queries.BuildUpsertQueryMSSQL(dialect, "{{.Table.Name}}", {{$varNameSingular}}PrimaryKeyColumns, update, insert, ret)
+		{{- end}}
+		{{- if eq .DriverName "mssql"}}
 
 		whitelist = append({{$varNameSingular}}PrimaryKeyColumns, update...)
 		whitelist = append(whitelist, insert...)
@@ -129,12 +335,12 @@ func (o *{{$tableNameSingular}}) Upsert(exec boil.Executor, {{if eq .DriverName
 
 		cache.valueMapping, err = queries.BindMapping({{$varNameSingular}}Type, {{$varNameSingular}}Mapping, {{if eq .DriverName "mssql"}}whitelist{{else}}insert{{end}})
 		if err != nil {
-			return err
+			return false, err
 		}
 		if len(ret) != 0 {
 			cache.retMapping, err = queries.BindMapping({{$varNameSingular}}Type, {{$varNameSingular}}Mapping, ret)
 			if err != nil {
-				return err
+				return false, err
 			}
 		}
 	}
@@ -151,15 +357,22 @@ func (o *{{$tableNameSingular}}) Upsert(exec boil.Executor, {{if eq .DriverName
 		fmt.Fprintln(boil.DebugWriter, vals)
 	}
 
+	var inserted bool
 	{{if .UseLastInsertID -}}
 	{{- $canLastInsertID := .Table.CanLastInsertID -}}
-	{{if $canLastInsertID -}}
 	result, err := exec.Exec(cache.query, vals...)
-	{{else -}}
-	_, err = exec.Exec(cache.query, vals...)
-	{{- end}}
 	if err != nil {
-		return errors.Wrap(err, "{{.PkgName}}: unable to upsert for {{.Table.Name}}")
+		return false, errors.Wrap(err, "{{.PkgName}}: unable to upsert for {{.Table.Name}}")
+	}
+
+	if wantInserted {
+		var rowsAff int64
+		rowsAff, err = result.RowsAffected()
+		if err != nil {
+			return false, errors.Wrap(err, "{{.PkgName}}: failed to get rows affected by upsert for {{.Table.Name}}")
+		}
+		// MySQL counts 1 affected row for an insert and 2 for an update
+		inserted = rowsAff == 1
 	}
 
 	{{if $canLastInsertID -}}
@@ -174,7 +387,7 @@ func (o *{{$tableNameSingular}}) Upsert(exec boil.Executor, {{if eq .DriverName
 	{{if $canLastInsertID -}}
 	lastID, err = result.LastInsertId()
 	if err != nil {
-		return ErrSyncFail
+		return false, ErrSyncFail
 	}
 
 	{{$colName := index .Table.PKey.Columns 0 -}}
@@ -199,10 +412,21 @@ func (o *{{$tableNameSingular}}) Upsert(exec boil.Executor, {{if eq .DriverName
 
 	err = exec.QueryRow(cache.retQuery, identifierCols...).Scan(returns...)
 	if err != nil {
-		return errors.Wrap(err, "{{.PkgName}}: unable to populate default values for {{.Table.Name}}")
+		return false, errors.Wrap(err, "{{.PkgName}}: unable to populate default values for {{.Table.Name}}")
 	}
 	{{- else}}
-	if len(cache.retMapping) != 0 {
+	{{if eq .DriverName "mssql" -}}
+	var action string
+	if wantInserted {
+		returns = append(returns, &action)
+	}
+	{{- else -}}
+	if wantInserted {
+		returns = append(returns, &inserted)
+	}
+	{{- end}}
+
+	if len(returns) != 0 {
 		err = exec.QueryRow(cache.query, vals...).Scan(returns...)
 		if err == sql.ErrNoRows {
 			err = nil // Postgres doesn't return anything when there's no update
@@ -211,13 +435,22 @@ func (o *{{$tableNameSingular}}) Upsert(exec boil.Executor, {{if eq .DriverName
 		_, err = exec.Exec(cache.query, vals...)
 	}
 	if err != nil {
-		return errors.Wrap(err, "{{.PkgName}}: unable to upsert {{.Table.Name}}")
+		return false, errors.Wrap(err, "{{.PkgName}}: unable to upsert {{.Table.Name}}")
 	}
+	{{- if eq .DriverName "mssql"}}
+
+	inserted = action == "INSERT"
+	{{- end}}
 	{{- end}}
 
 {{if .UseLastInsertID -}}
 CacheNoHooks:
 {{end -}}
+	// The row may have conflicted on other columns than the primary key
+	if identities := boil.IdentityMapOf(exec); identities != nil {
+		identities.EvictTable("{{.Table.Name}}")
+	}
+
 	if !cached {
 		{{$varNameSingular}}UpsertCacheMut.Lock()
 		{{$varNameSingular}}UpsertCache[key] = cache
@@ -225,8 +458,170 @@ CacheNoHooks:
 	}
 
 	{{if not .NoHooks -}}
-	return o.doAfterUpsertHooks(exec)
+	return inserted, o.doAfterUpsertHooks(exec)
 	{{- else -}}
-	return nil
+	return inserted, nil
+	{{- end}}
+}
+{{- if ne .DriverName "mssql"}}
+{{- $colNames := .Table.Columns | columnNames}}
+
+// UpsertAllG upserts all rows in the slice with a multi row insert.
+// See UpsertAll for how the rows are upserted.
+func (o {{$tableNameSingular}}Slice) UpsertAllG({{if eq .DriverName "postgres"}}updateOnConflict bool, conflictColumns []string, {{end}}updateColumns []string, whitelist ...string) error {
+	return o.UpsertAll(boil.GetDB(), {{if eq .DriverName "postgres"}}updateOnConflict, conflictColumns, {{end}}updateColumns, whitelist...)
+}
+
+// UpsertAllGP upserts all rows in the slice with a multi row insert, and panics on error.
+// See UpsertAll for how the rows are upserted.
+func (o {{$tableNameSingular}}Slice) UpsertAllGP({{if eq .DriverName "postgres"}}updateOnConflict bool, conflictColumns []string, {{end}}updateColumns []string, whitelist ...string) {
+	if err := o.UpsertAll(boil.GetDB(), {{if eq .DriverName "postgres"}}updateOnConflict, conflictColumns, {{end}}updateColumns, whitelist...); err != nil {
+		panic(boil.WrapErr(err))
+	}
+}
+
+// UpsertAllP upserts all rows in the slice with a multi row insert using an executor,
+// and panics on error. See UpsertAll for how the rows are upserted.
+func (o {{$tableNameSingular}}Slice) UpsertAllP(exec boil.Executor, {{if eq .DriverName "postgres"}}updateOnConflict bool, conflictColumns []string, {{end}}updateColumns []string, whitelist ...string) {
+	if err := o.UpsertAll(exec, {{if eq .DriverName "postgres"}}updateOnConflict, conflictColumns, {{end}}updateColumns, whitelist...); err != nil {
+		panic(boil.WrapErr(err))
+	}
+}
+
+// UpsertAll upserts all rows in the slice with a multi row insert using an executor,
+// and does an update or ignore on conflict for each of them. The arguments apply to
+// every row and are the same as the arguments of Upsert.
+// Without a whitelist the columns with defaults are inserted when any row sets them,
+// and rows that leave them zero insert DEFAULT instead. Unlike Upsert nothing is read
+// back from the database. Large slices are upserted in chunks, to stay within the
+// limit of bound parameters of a statement.
+{{- if eq .DriverName "postgres"}}
+// Postgres fails the statement if two of its rows conflict on the same row.
+{{- end}}
+func (o {{$tableNameSingular}}Slice) UpsertAll(exec boil.Executor, {{if eq .DriverName "postgres"}}updateOnConflict bool, conflictColumns []string, {{end}}updateColumns []string, whitelist ...string) error {
+	return o.upsertAll(exec, {{if eq .DriverName "postgres"}}updateOnConflict, conflictColumns{{else}}true, nil{{end}}, updateColumns, whitelist...)
+}
+
+// upsertAll builds its statements through the upsert builder of the dialect,
+// which ignores the arguments the database has no use for.
+func (o {{$tableNameSingular}}Slice) upsertAll(exec boil.Executor, updateOnConflict bool, conflictColumns []string, updateColumns []string, whitelist ...string) error {
+	if len(o) == 0 {
+		return nil
+	}
+	{{- $timestamps := and (not .NoAutoTimestamps) (containsAny $colNames "created_at" "updated_at")}}
+	{{- if or (not .NoHooks) $timestamps}}
+
+	for _, o := range o {
+		{{- if $timestamps}}
+		{{- template "timestamp_upsert_helper" . }}
+		{{- end}}
+		{{- if not .NoHooks}}
+		if err := o.doBeforeUpsertHooks(exec); err != nil {
+			return err
+		}
+		{{- end}}
+	}
+	{{- end}}
+
+	var nzDefaults []string
+	for _, obj := range o {
+		nzDefaults = strmangle.SetMerge(nzDefaults, queries.NonZeroDefaultSet({{$varNameSingular}}ColumnsWithDefault, obj))
+	}
+
+	insert, _ := strmangle.InsertColumnSet(
+		{{$varNameSingular}}Columns,
+		{{$varNameSingular}}ColumnsWithDefault,
+		{{$varNameSingular}}ColumnsWithoutDefault,
+		nzDefaults,
+		whitelist,
+	)
+	update := strmangle.UpdateColumnSet(
+		{{$varNameSingular}}Columns,
+		{{$varNameSingular}}PrimaryKeyColumns,
+		updateColumns,
+	)
+	if len(update) == 0 {
+		return errors.New("{{.PkgName}}: unable to upsert all {{.Table.Name}}, could not build update column list")
+	}
+
+	// The update copies the values of the rows proposed for insertion, so
+	// the update columns have to be inserted or they'd get their defaults
+	insert = strmangle.SetMerge(insert, updateColumns)
+	if len(insert) == 0 {
+		return errors.New("{{.PkgName}}: unable to upsert all {{.Table.Name}}, could not build insert column list")
+	}
+
+	// Columns with defaults that a row leaves zero are inserted as DEFAULT
+	// for it, unless they're whitelisted or updated
+	var defaultable []string
+	if len(whitelist) == 0 {
+		defaultable = strmangle.SetComplement(strmangle.SetComplement(insert, {{$varNameSingular}}ColumnsWithoutDefault), updateColumns)
+	}
+
+	conflict := conflictColumns
+	if len(conflict) == 0 {
+		conflict = make([]string, len({{$varNameSingular}}PrimaryKeyColumns))
+		copy(conflict, {{$varNameSingular}}PrimaryKeyColumns)
+	}
+
+	mapping, err := queries.BindMapping({{$varNameSingular}}Type, {{$varNameSingular}}Mapping, insert)
+	if err != nil {
+		return err
+	}
+
+	// The most parameters {{.DriverName}} can bind in a single statement
+	const maxParams = 65535
+	chunkSize := maxParams / len(insert)
+
+	for rows := o; len(rows) != 0; {
+		chunk := rows
+		if len(chunk) > chunkSize {
+			chunk = rows[:chunkSize]
+		}
+		rows = rows[len(chunk):]
+
+		var args []interface{}
+		defaults := make([][]string, len(chunk))
+		for i, obj := range chunk {
+			defaults[i] = strmangle.SetComplement(defaultable, queries.NonZeroDefaultSet({{$varNameSingular}}ColumnsWithDefault, obj))
+
+			vals := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), mapping)
+			for j, c := range insert {
+				if !strmangle.SetInclude(c, defaults[i]) {
+					args = append(args, vals[j])
+				}
+			}
+		}
+
+		up := queries.Upsert{Table: "{{$schemaTable}}", Insert: insert, Update: update, Conflict: conflict, Defaults: defaults}
+		if !updateOnConflict {
+			up.Update = nil
+		}
+		query := dialect.BuildUpsert(up)
+
+		if boil.DebugMode {
+			fmt.Fprintln(boil.DebugWriter, query)
+			fmt.Fprintln(boil.DebugWriter, args)
+		}
+
+		if _, err = exec.Exec(query, args...); err != nil {
+			return errors.Wrap(err, "{{.PkgName}}: unable to upsert all for {{.Table.Name}}")
+		}
+	}
+
+	if identities := boil.IdentityMapOf(exec); identities != nil {
+		identities.EvictTable("{{.Table.Name}}")
+	}
+	{{- if not .NoHooks}}
+
+	for _, obj := range o {
+		if err := obj.doAfterUpsertHooks(exec); err != nil {
+			return err
+		}
+	}
 	{{- end}}
+
+	return nil
 }
+{{- end}}
+{{- end -}}{{- /* if not IsView and PKey */ -}}
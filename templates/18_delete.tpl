@@ -1,8 +1,9 @@
+{{- if not .Table.IsView -}}
 {{- $tableNameSingular := .Table.Name | singular | titleCase -}}
 {{- $varNameSingular := .Table.Name | singular | camelCase -}}
 {{- $schemaTable := .Table.Name | .SchemaTable}}
 // DeleteP deletes a single {{$tableNameSingular}} record with an executor.
-// DeleteP will match against the primary key column to find the record to delete.
+// DeleteP will match against the {{if .Table.PKey}}primary key column{{else}}all columns{{end}} to find the record to delete.
 // Panics on error.
 func (o *{{$tableNameSingular}}) DeleteP(exec boil.Executor) {
 	if err := o.Delete(exec); err != nil {
@@ -11,7 +12,7 @@ func (o *{{$tableNameSingular}}) DeleteP(exec boil.Executor) {
 }
 
 // DeleteG deletes a single {{$tableNameSingular}} record.
-// DeleteG will match against the primary key column to find the record to delete.
+// DeleteG will match against the {{if .Table.PKey}}primary key column{{else}}all columns{{end}} to find the record to delete.
 func (o *{{$tableNameSingular}}) DeleteG() error {
 	if o == nil {
 	return errors.New("{{.PkgName}}: no {{$tableNameSingular}} provided for deletion")
@@ -21,7 +22,7 @@ func (o *{{$tableNameSingular}}) DeleteG() error {
 }
 
 // DeleteGP deletes a single {{$tableNameSingular}} record.
-// DeleteGP will match against the primary key column to find the record to delete.
+// DeleteGP will match against the {{if .Table.PKey}}primary key column{{else}}all columns{{end}} to find the record to delete.
 // Panics on error.
 func (o *{{$tableNameSingular}}) DeleteGP() {
 	if err := o.DeleteG(); err != nil {
@@ -30,7 +31,7 @@ func (o *{{$tableNameSingular}}) DeleteGP() {
 }
 
 // Delete deletes a single {{$tableNameSingular}} record with an executor.
-// Delete will match against the primary key column to find the record to delete.
+// Delete will match against the {{if .Table.PKey}}primary key column{{else}}all columns{{end}} to find the record to delete.
 func (o *{{$tableNameSingular}}) Delete(exec boil.Executor) error {
 	if o == nil {
 	return errors.New("{{.PkgName}}: no {{$tableNameSingular}} provided for delete")
@@ -42,8 +43,16 @@ func (o *{{$tableNameSingular}}) Delete(exec boil.Executor) error {
 	}
 	{{- end}}
 
+	{{if .Table.PKey -}}
 	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), {{$varNameSingular}}PrimaryKeyMapping)
 	sql := "DELETE FROM {{$schemaTable}} WHERE {{if .Dialect.IndexPlaceholders}}{{whereClause .LQ .RQ 1 .Table.PKey.Columns}}{{else}}{{whereClause .LQ .RQ 0 .Table.PKey.Columns}}{{end}}"
+	{{- else -}}
+	// Without a primary key the row is matched on all of its columns, which
+	// deletes every identical row
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), {{$varNameSingular}}ColumnsMapping)
+	where, args := queries.WhereClauseRows(dialect.LQ, dialect.RQ, {{if .Dialect.IndexPlaceholders}}1{{else}}0{{end}}, {{$varNameSingular}}Columns, values)
+	sql := "DELETE FROM {{$schemaTable}} WHERE " + where
+	{{- end}}
 
 	if boil.DebugMode {
 	fmt.Fprintln(boil.DebugWriter, sql)
@@ -54,6 +63,9 @@ func (o *{{$tableNameSingular}}) Delete(exec boil.Executor) error {
 	if err != nil {
 	return errors.Wrap(err, "{{.PkgName}}: unable to delete from {{.Table.Name}}")
 	}
+	{{- if .Table.PKey}}
+	o.evictIdentity(exec)
+	{{- end}}
 
 	{{if not .NoHooks -}}
 	if err := o.doAfterDeleteHooks(exec); err != nil {
@@ -84,6 +96,11 @@ func (q {{$varNameSingular}}Query) DeleteAll() error {
 	return errors.Wrap(err, "{{.PkgName}}: unable to delete all from {{.Table.Name}}")
 	}
 
+	// The deleted rows aren't known, so none of the table's are kept
+	if identities := boil.IdentityMapOf(queries.GetExecutor(q.Query)); identities != nil {
+	identities.EvictTable("{{.Table.Name}}")
+	}
+
 	return nil
 }
 
@@ -129,6 +146,7 @@ func (o {{$tableNameSingular}}Slice) DeleteAll(exec boil.Executor) error {
 	}
 	{{- end}}
 
+	{{if .Table.PKey -}}
 	var args []interface{}
 	for _, obj := range o {
 		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), {{$varNameSingular}}PrimaryKeyMapping)
@@ -137,6 +155,16 @@ func (o {{$tableNameSingular}}Slice) DeleteAll(exec boil.Executor) error {
 
 	sql := "DELETE FROM {{$schemaTable}} WHERE " +
 		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), {{if .Dialect.IndexPlaceholders}}1{{else}}0{{end}}, {{$varNameSingular}}PrimaryKeyColumns, len(o))
+	{{- else -}}
+	// Without a primary key each row is matched on all of its columns
+	rows := make([][]interface{}, len(o))
+	for i, obj := range o {
+		rows[i] = queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), {{$varNameSingular}}ColumnsMapping)
+	}
+
+	where, args := queries.WhereClauseRows(dialect.LQ, dialect.RQ, {{if .Dialect.IndexPlaceholders}}1{{else}}0{{end}}, {{$varNameSingular}}Columns, rows...)
+	sql := "DELETE FROM {{$schemaTable}} WHERE " + where
+	{{- end}}
 
 	if boil.DebugMode {
 		fmt.Fprintln(boil.DebugWriter, sql)
@@ -148,6 +176,13 @@ func (o {{$tableNameSingular}}Slice) DeleteAll(exec boil.Executor) error {
 		return errors.Wrap(err, "{{.PkgName}}: unable to delete all from {{$varNameSingular}} slice")
 	}
 
+	{{if .Table.PKey -}}
+	for _, obj := range o {
+		obj.evictIdentity(exec)
+	}
+
+	{{end -}}
+
 	{{if not .NoHooks -}}
 	if len({{$varNameSingular}}AfterDeleteHooks) != 0 {
 		for _, obj := range o {
@@ -160,3 +195,4 @@ func (o {{$tableNameSingular}}Slice) DeleteAll(exec boil.Executor) error {
 
 	return nil
 }
+{{- end -}}{{- /* if not IsView */ -}}
@@ -1,3 +1,4 @@
+{{- if .Table.PKey -}}
 {{- $tableNameSingular := .Table.Name | singular | titleCase -}}
 {{- $varNameSingular := .Table.Name | singular | camelCase -}}
 {{- $varNamePlural := .Table.Name | plural | camelCase -}}
@@ -38,8 +39,8 @@ func (o *{{$tableNameSingular}}) Reload(exec boil.Executor) error {
 }
 
 // ReloadAllGP refetches every row with matching primary key column values
-// and overwrites the original object slice with the newly updated slice.
-// Panics on error.
+// and overwrites the objects of the slice in place, dropping the ones whose
+// row no longer exists. Panics on error.
 func (o *{{$tableNameSingular}}Slice) ReloadAllGP() {
 	if err := o.ReloadAllG(); err != nil {
 		panic(boil.WrapErr(err))
@@ -47,8 +48,8 @@ func (o *{{$tableNameSingular}}Slice) ReloadAllGP() {
 }
 
 // ReloadAllP refetches every row with matching primary key column values
-// and overwrites the original object slice with the newly updated slice.
-// Panics on error.
+// and overwrites the objects of the slice in place, dropping the ones whose
+// row no longer exists. Panics on error.
 func (o *{{$tableNameSingular}}Slice) ReloadAllP(exec boil.Executor) {
 	if err := o.ReloadAll(exec); err != nil {
 		panic(boil.WrapErr(err))
@@ -56,7 +57,8 @@ func (o *{{$tableNameSingular}}Slice) ReloadAllP(exec boil.Executor) {
 }
 
 // ReloadAllG refetches every row with matching primary key column values
-// and overwrites the original object slice with the newly updated slice.
+// and overwrites the objects of the slice in place, dropping the ones whose
+// row no longer exists.
 func (o *{{$tableNameSingular}}Slice) ReloadAllG() error {
 	if o == nil {
 		return errors.New("{{.PkgName}}: empty {{$tableNameSingular}}Slice provided for reload all")
@@ -66,8 +68,42 @@ func (o *{{$tableNameSingular}}Slice) ReloadAllG() error {
 }
 
 // ReloadAll refetches every row with matching primary key column values
-// and overwrites the original object slice with the newly updated slice.
+// and overwrites the objects of the slice in place, dropping the ones whose
+// row no longer exists. The order of the slice is kept.
 func (o *{{$tableNameSingular}}Slice) ReloadAll(exec boil.Executor) error {
+	return o.reloadAll(exec, false)
+}
+
+// ReloadAllStrictGP is like ReloadAllGP but panics if any row no longer exists.
+func (o *{{$tableNameSingular}}Slice) ReloadAllStrictGP() {
+	if err := o.ReloadAllStrictG(); err != nil {
+		panic(boil.WrapErr(err))
+	}
+}
+
+// ReloadAllStrictP is like ReloadAllP but panics if any row no longer exists.
+func (o *{{$tableNameSingular}}Slice) ReloadAllStrictP(exec boil.Executor) {
+	if err := o.ReloadAllStrict(exec); err != nil {
+		panic(boil.WrapErr(err))
+	}
+}
+
+// ReloadAllStrictG is like ReloadAllG but errors if any row no longer exists.
+func (o *{{$tableNameSingular}}Slice) ReloadAllStrictG() error {
+	if o == nil {
+		return errors.New("{{.PkgName}}: empty {{$tableNameSingular}}Slice provided for reload all")
+	}
+
+	return o.ReloadAllStrict(boil.GetDB())
+}
+
+// ReloadAllStrict is like ReloadAll but errors if any row no longer exists,
+// leaving the slice untouched.
+func (o *{{$tableNameSingular}}Slice) ReloadAllStrict(exec boil.Executor) error {
+	return o.reloadAll(exec, true)
+}
+
+func (o *{{$tableNameSingular}}Slice) reloadAll(exec boil.Executor, strict bool) error {
 	if o == nil || len(*o) == 0 {
 		return nil
 	}
@@ -79,8 +115,14 @@ func (o *{{$tableNameSingular}}Slice) ReloadAll(exec boil.Executor) error {
 		args = append(args, pkeyArgs...)
 	}
 
+	{{if eq .DriverName "mssql" -}}
 	sql := "SELECT {{$schemaTable}}.* FROM {{$schemaTable}} WHERE " +
 		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), {{if .Dialect.IndexPlaceholders}}1{{else}}0{{end}}, {{$varNameSingular}}PrimaryKeyColumns, len(*o))
+	{{- else -}}
+	sql := "SELECT {{$schemaTable}}.* FROM {{$schemaTable}} WHERE (" +
+		strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, {{$varNameSingular}}PrimaryKeyColumns), ",") + ") IN (" +
+		strmangle.Placeholders(dialect.IndexPlaceholders, len(args), 1, len({{$varNameSingular}}PrimaryKeyColumns)) + ")"
+	{{- end}}
 
 	q := queries.Raw(exec, sql, args...)
 
@@ -89,7 +131,32 @@ func (o *{{$tableNameSingular}}Slice) ReloadAll(exec boil.Executor) error {
 		return errors.Wrap(err, "{{.PkgName}}: unable to reload all in {{$tableNameSingular}}Slice")
 	}
 
-	*o = {{$varNamePlural}}
+	found := make(map[string]*{{$tableNameSingular}}, len({{$varNamePlural}}))
+	for _, obj := range {{$varNamePlural}} {
+		found[{{$varNameSingular}}PrimaryKeyString(obj)] = obj
+	}
+
+	reloaded := make({{$tableNameSingular}}Slice, 0, len(*o))
+	for _, obj := range *o {
+		if _, ok := found[{{$varNameSingular}}PrimaryKeyString(obj)]; ok {
+			reloaded = append(reloaded, obj)
+		}
+	}
+	if strict && len(reloaded) != len(*o) {
+		return errors.Errorf("{{.PkgName}}: %d of %d rows no longer exist in reload all of {{$tableNameSingular}}Slice", len(*o)-len(reloaded), len(*o))
+	}
+
+	for _, obj := range reloaded {
+		*obj = *found[{{$varNameSingular}}PrimaryKeyString(obj)]
+	}
+	*o = reloaded
 
 	return nil
 }
+
+// {{$varNameSingular}}PrimaryKeyString returns the primary key values of o as a
+// string, to match rows returned by the database back to their objects.
+func {{$varNameSingular}}PrimaryKeyString(o *{{$tableNameSingular}}) string {
+	return fmt.Sprintf("%#v", queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), {{$varNameSingular}}PrimaryKeyMapping))
+}
+{{- end -}}{{- /* if PKey */ -}}
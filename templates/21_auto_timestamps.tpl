@@ -5,7 +5,12 @@
 	currTime := time.Now().In(boil.GetLocation())
 		{{range $ind, $col := .Table.Columns}}
 			{{- if eq $col.Name "created_at" -}}
-				{{- if $col.Nullable}}
+				{{- if eq $col.Type "*time.Time"}}
+	if o.CreatedAt == nil || o.CreatedAt.IsZero() {
+		createdAt := currTime
+		o.CreatedAt = &createdAt
+	}
+				{{- else if $col.Nullable}}
 	if o.CreatedAt.Time.IsZero() {
 		o.CreatedAt.Time = currTime
 		o.CreatedAt.Valid = true
@@ -17,7 +22,11 @@
 				{{- end -}}
 			{{- end -}}
 			{{- if eq $col.Name "updated_at" -}}
-				{{- if $col.Nullable}}
+				{{- if eq $col.Type "*time.Time"}}
+	if o.UpdatedAt == nil || o.UpdatedAt.IsZero() {
+		o.UpdatedAt = &currTime
+	}
+				{{- else if $col.Nullable}}
 	if o.UpdatedAt.Time.IsZero() {
 		o.UpdatedAt.Time = currTime
 		o.UpdatedAt.Valid = true
@@ -39,7 +48,9 @@
 	currTime := time.Now().In(boil.GetLocation())
 		{{range $ind, $col := .Table.Columns}}
 			{{- if eq $col.Name "updated_at" -}}
-				{{- if $col.Nullable}}
+				{{- if eq $col.Type "*time.Time"}}
+	o.UpdatedAt = &currTime
+				{{- else if $col.Nullable}}
 	o.UpdatedAt.Time = currTime
 	o.UpdatedAt.Valid = true
 				{{- else}}
@@ -57,7 +68,12 @@
 	currTime := time.Now().In(boil.GetLocation())
 		{{range $ind, $col := .Table.Columns}}
 			{{- if eq $col.Name "created_at" -}}
-				{{- if $col.Nullable}}
+				{{- if eq $col.Type "*time.Time"}}
+	if o.CreatedAt == nil || o.CreatedAt.IsZero() {
+		createdAt := currTime
+		o.CreatedAt = &createdAt
+	}
+				{{- else if $col.Nullable}}
 	if o.CreatedAt.Time.IsZero() {
 		o.CreatedAt.Time = currTime
 		o.CreatedAt.Valid = true
@@ -69,7 +85,9 @@
 				{{- end -}}
 			{{- end -}}
 			{{- if eq $col.Name "updated_at" -}}
-				{{- if $col.Nullable}}
+				{{- if eq $col.Type "*time.Time"}}
+	o.UpdatedAt = &currTime
+				{{- else if $col.Nullable}}
 	o.UpdatedAt.Time = currTime
 	o.UpdatedAt.Valid = true
 				{{- else}}
@@ -0,0 +1,68 @@
+{{- if .Table.PKey -}}
+{{- $tableNameSingular := .Table.Name | singular | titleCase -}}
+{{- $varNameSingular := .Table.Name | singular | camelCase -}}
+{{- $colDefs := sqlColDefinitions .Table.Columns .Table.PKey.Columns -}}
+{{- $pkNames := $colDefs.Names | stringMap .StringFuncs.camelCase | stringMap .StringFuncs.replaceReserved -}}
+{{- $dot := .}}
+// PrimaryKeyValues returns the values of the primary key columns
+// in the order they are declared on the table.
+func (o *{{$tableNameSingular}}) PrimaryKeyValues() []interface{} {
+	return queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), {{$varNameSingular}}PrimaryKeyMapping)
+}
+
+// evictIdentity removes the record with the primary key of o from the
+// boil.IdentityMap of exec, if it has one, after o is written.
+func (o *{{$tableNameSingular}}) evictIdentity(exec boil.Executor) {
+	if identities := boil.IdentityMapOf(exec); identities != nil {
+		identities.Evict("{{.Table.Name}}", o.PrimaryKeyValues()...)
+	}
+}
+
+// SetPrimaryKey sets the primary key columns from values, which must be given
+// in the same order as PrimaryKeyValues returns them. An error is returned if
+// the number of values does not match the number of primary key columns, or if
+// a value is not of the column's type.
+func (o *{{$tableNameSingular}}) SetPrimaryKey(values ...interface{}) error {
+	if len(values) != len({{$varNameSingular}}PrimaryKeyColumns) {
+		return errors.Errorf("{{.PkgName}}: {{.Table.Name}} primary key has %d columns, got %d values", len({{$varNameSingular}}PrimaryKeyColumns), len(values))
+	}
+
+	{{range $i, $name := $pkNames -}}
+	{{- $colName := index $dot.Table.PKey.Columns $i -}}
+	{{- $colType := index $colDefs.Types $i -}}
+	{{$name}}, ok := values[{{$i}}].({{$colType}})
+	if !ok {
+		return errors.Errorf("{{$dot.PkgName}}: {{$dot.Table.Name}} primary key column {{$colName}} must be {{$colType}}, got %T", values[{{$i}}])
+	}
+	{{end}}
+	{{- range $i, $name := $pkNames}}
+	o.{{index $dot.Table.PKey.Columns $i | titleCase}} = {{$name}}
+	{{- end}}
+
+	return nil
+}
+
+{{if eq (len $pkNames) 1 -}}
+{{- $colType := index $colDefs.Types 0 -}}
+// PrimaryKeyValues returns the primary key of each record, in slice order.
+func (o {{$tableNameSingular}}Slice) PrimaryKeyValues() []{{$colType}} {
+	values := make([]{{$colType}}, len(o))
+	for i, obj := range o {
+		values[i] = obj.{{index .Table.PKey.Columns 0 | titleCase}}
+	}
+
+	return values
+}
+{{- else -}}
+// PrimaryKeyValues returns the values of the primary key columns of each
+// record, in slice order. See {{$tableNameSingular}}.PrimaryKeyValues.
+func (o {{$tableNameSingular}}Slice) PrimaryKeyValues() [][]interface{} {
+	values := make([][]interface{}, len(o))
+	for i, obj := range o {
+		values[i] = obj.PrimaryKeyValues()
+	}
+
+	return values
+}
+{{- end}}
+{{- end -}}{{- /* if PKey */ -}}
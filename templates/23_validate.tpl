@@ -0,0 +1,41 @@
+{{- if not .Table.IsView -}}
+{{- $tableNameSingular := .Table.Name | singular | titleCase -}}
+{{- $dot := .}}
+// Validate checks o against the constraints of {{.Table.Name}} that can be
+// checked without the database, returning the first one it violates. NOT NULL
+// columns without a default must not be nil, and the simple conditions of the
+// CHECK constraints, comparing a column or its length with a number, must hold
+// for the columns that are set. Like Insert, columns with a default are left
+// to the database while they hold their zero value. Other CHECK constraints
+// are only checked by the database.
+func (o *{{$tableNameSingular}}) Validate() error {
+	{{- range $column := filterColumnsByRequired .Table.Columns}}
+	if o.{{titleCase $column.Name}} == nil {
+		return errors.New("{{$dot.PkgName}}: {{$dot.Table.Name}}.{{$column.Name}} must not be null")
+	}
+	{{- end}}
+	{{- range $check := .Table.Checks}}
+	{{- $column := $dot.Table.GetColumn $check.Column}}
+	{{- $field := printf "o.%s" (titleCase $check.Column)}}
+	{{- $pointer := ne ($column.Type | trimPrefix "*") $column.Type}}
+	{{- if $check.Length}}
+	{{- if $pointer}}
+	if {{$field}} != nil && !(float64(len([]rune(*{{$field}}))) {{$check.Op}} {{$check.Value}}) {
+	{{- else}}
+	if {{if $column.Nullable}}{{$field}}.Valid && {{else if $column.Default}}{{$field}} != "" && {{end -}}
+	!(float64(len([]rune({{$field}}{{if $column.Nullable}}.String{{end}}))) {{$check.Op}} {{$check.Value}}) {
+	{{- end}}
+	{{- else if $pointer}}
+	if {{$field}} != nil && !(float64(*{{$field}}) {{$check.Op}} {{$check.Value}}) {
+	{{- else}}
+	if {{if $column.Nullable}}{{$field}}.Valid && {{else if $column.Default}}{{$field}} != 0 && {{end -}}
+	!(float64({{$field}}{{if $column.Nullable}}.{{$column.Type | trimPrefix "null."}}{{end}}) {{$check.Op}} {{$check.Value}}) {
+	{{- end}}
+		return errors.New("{{$dot.PkgName}}: {{$dot.Table.Name}}.{{$check.Column}} violates check constraint {{$check.Name}}")
+	}
+	{{- end}}
+	{{- if or (filterColumnsByRequired .Table.Columns) .Table.Checks}}
+	{{end}}
+	return nil
+}
+{{- end -}}
@@ -0,0 +1,24 @@
+{{- $tableNameSingular := .Table.Name | singular | titleCase -}}
+{{- $varNameSingular := .Table.Name | singular | camelCase}}
+// Diff returns the columns of {{.Table.Name}} that hold different values in o
+// and other, keyed by column name, with the value in o as Old and the value in
+// other as New. Nullable columns that are NULL in both are unchanged.
+func (o *{{$tableNameSingular}}) Diff(other *{{$tableNameSingular}}) map[string]queries.ColumnChange {
+	return queries.Diff({{$varNameSingular}}Columns, {{$varNameSingular}}ColumnsMapping, o, other)
+}
+
+// ColumnValues returns the values of the columns cols of o, in the order of
+// cols, the way Insert binds them: null types give their value, which is nil
+// when they're invalid. It panics on a column {{.Table.Name}} doesn't have.
+func (o *{{$tableNameSingular}}) ColumnValues(cols []string) []interface{} {
+	if unknown := strmangle.SetComplement(cols, {{$varNameSingular}}Columns); len(unknown) != 0 {
+		panic(fmt.Sprintf("{{.PkgName}}: unknown columns %v of {{.Table.Name}}", unknown))
+	}
+
+	mapping, err := queries.BindMapping({{$varNameSingular}}Type, {{$varNameSingular}}Mapping, cols)
+	if err != nil {
+		panic(boil.WrapErr(err))
+	}
+
+	return queries.ColumnValues(o, mapping)
+}
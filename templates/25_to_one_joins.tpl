@@ -0,0 +1,64 @@
+{{- if .Table.IsJoinTable -}}
+{{- else if .Table.FKeys -}}
+{{- $dot := . -}}
+{{- $tableNameSingular := .Table.Name | singular | titleCase -}}
+{{- $varNameSingular := .Table.Name | singular | camelCase -}}
+{{- $schemaTable := .Table.Name | .SchemaTable}}
+// {{$varNameSingular}}ToOneJoins are the to-one relationships of {{$tableNameSingular}}
+// that AllWithJoins can join, named as in its R struct.
+var {{$varNameSingular}}ToOneJoins = []struct {
+	name    string
+	clause  string
+	columns []string
+}{
+	{{- range .Table.FKeys -}}
+	{{- $txt := txtsFromFKey $dot.Tables $dot.Table . -}}
+	{{- $foreignVarNameSingular := .ForeignTable | singular | camelCase -}}
+	{{- $alias := $txt.Function.Name | $dot.Quotes}}
+	{
+		name:    "{{$txt.Function.Name}}",
+		clause:  "{{.ForeignTable | $dot.SchemaTable}} as {{$alias}} on {{$alias}}.{{.ForeignColumn | $dot.Quotes}} = {{$schemaTable}}.{{.Column | $dot.Quotes}}",
+		columns: {{$foreignVarNameSingular}}Columns,
+	},
+	{{- end}}
+}
+
+// AllWithJoinsP returns all {{$tableNameSingular}} records from the query with
+// their to-one relationships, and panics on error. See AllWithJoins.
+func (q {{$varNameSingular}}Query) AllWithJoinsP(relationships ...string) {{$tableNameSingular}}Slice {
+	o, err := q.AllWithJoins(relationships...)
+	if err != nil {
+		panic(boil.WrapErr(err))
+	}
+
+	return o
+}
+
+// AllWithJoins returns all {{$tableNameSingular}} records from the query with the
+// to-one relationships named by relationships, or all of them when none are
+// named, set in their R struct. The relationships are left joined into the
+// same query instead of being eager loaded with a query each, and are left
+// nil for a record that has none. To-many relationships still need qm.Load.
+func (q {{$varNameSingular}}Query) AllWithJoins(relationships ...string) ({{$tableNameSingular}}Slice, error) {
+	joins := {{$varNameSingular}}ToOneJoins
+	if len(relationships) != 0 {
+		joins = joins[:0:0]
+	Relationships:
+		for _, name := range relationships {
+			for _, join := range {{$varNameSingular}}ToOneJoins {
+				if join.name == name {
+					joins = append(joins, join)
+					continue Relationships
+				}
+			}
+			return nil, errors.Errorf("{{.PkgName}}: {{.Table.Name}} has no to-one relationship %s", name)
+		}
+	}
+
+	for _, join := range joins {
+		queries.AppendToOneJoin(q.Query, "{{$schemaTable}}", join.name, join.clause, join.columns)
+	}
+
+	return q.All()
+}
+{{- end -}}
@@ -0,0 +1,30 @@
+{{- $tableNameSingular := .Table.Name | singular | titleCase -}}
+{{- $tableNamePlural := .Table.Name | plural | titleCase}}
+// {{$tableNameSingular}}FromRow binds the current row of rows, which rows.Next must
+// already have moved to, into a new {{$tableNameSingular}}, for rows read by SQL of
+// your own. Columns are matched to fields by name, so columns {{.Table.Name}} doesn't
+// have are skipped and fields without a column are left zero. rows is left open,
+// and after select hooks are not run.
+func {{$tableNameSingular}}FromRow(rows *sql.Rows) (*{{$tableNameSingular}}, error) {
+	o := &{{$tableNameSingular}}{}
+	if err := queries.BindRow(rows, o); err != nil {
+		return nil, errors.Wrap(err, "{{.PkgName}}: failed to bind a row into {{$tableNameSingular}}")
+	}
+
+	return o, nil
+}
+
+// {{$tableNamePlural}}FromRows binds the rest of the rows of rows into a {{$tableNameSingular}}Slice,
+// matching columns to fields like {{$tableNameSingular}}FromRow. rows is left open, but
+// it's closed by reading them all.
+func {{$tableNamePlural}}FromRows(rows *sql.Rows) ({{$tableNameSingular}}Slice, error) {
+	var o []*{{$tableNameSingular}}
+	if err := queries.Bind(rows, &o); err != nil {
+		return nil, errors.Wrap(err, "{{.PkgName}}: failed to bind rows into {{$tableNameSingular}} slice")
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "{{.PkgName}}: failed to read rows into {{$tableNameSingular}} slice")
+	}
+
+	return o, nil
+}
@@ -3,6 +3,23 @@ var dialect = queries.Dialect{
 	RQ: 0x{{printf "%x" .Dialect.RQ}},
 	IndexPlaceholders: {{.Dialect.IndexPlaceholders}},
 	UseTopClause: {{.Dialect.UseTopClause}},
+	ShareLock: {{printf "%q" .Dialect.ShareLock}},
+	RandomFunction: {{printf "%q" .Dialect.RandomFunction}},
+	UseTableSample: {{.Dialect.UseTableSample}},
+	FullTextSearch: {{printf "%q" .Dialect.FullTextSearch}},
+	UseArrayParams: {{.Dialect.UseArrayParams}},
+	UseGroupingSets: {{.Dialect.UseGroupingSets}},
+	UseFromOnly: {{.Dialect.UseFromOnly}},
+	UseNullsOrder: {{.Dialect.UseNullsOrder}},
+	JSONContains: {{printf "%q" .Dialect.JSONContains}},
+	OrderByField: {{printf "%q" .Dialect.OrderByField}},
+	UpsertSyntax: {{printf "%q" .Dialect.UpsertSyntax}},
+	UseRowValues: {{.Dialect.UseRowValues}},
+	UseDistinctOn: {{.Dialect.UseDistinctOn}},
+	UseIndexHints: {{.Dialect.UseIndexHints}},
+	UsePostGIS: {{.Dialect.UsePostGIS}},
+	UseCountEstimate: {{.Dialect.UseCountEstimate}},
+	UseNullSafeEqual: {{.Dialect.UseNullSafeEqual}},
 }
 
 // NewQueryG initializes a new Query using the passed in QueryMods
@@ -19,3 +36,13 @@ func NewQuery(exec boil.Executor, mods ...qm.QueryMod) *queries.Query {
 
 	return q
 }
+
+// DeferConstraints is a boil.TxOption that defers all deferrable constraints
+// until the transaction commits. Only postgres supports deferred constraints.
+func DeferConstraints(tx boil.Transactor) error {
+	{{if eq .DriverName "postgres" -}}
+	return errors.Wrap(boil.TxExec("SET CONSTRAINTS ALL DEFERRED")(tx), "{{.PkgName}}: unable to defer constraints")
+	{{- else -}}
+	return errors.New("{{.PkgName}}: {{.DriverName}} does not support deferred constraints")
+	{{- end}}
+}
@@ -1,11 +1,47 @@
 // M type is for providing columns and column values to UpdateAll.
 type M map[string]interface{}
 
+// ColumnInfo describes a column of a generated model.
+type ColumnInfo struct {
+	// Name is the column name in the database
+	Name string
+	// FieldName is the name of the struct field holding the column
+	FieldName string
+	DBType    string
+	Nullable  bool
+	IsPK      bool
+}
+{{- if .BaseColumns}}
+{{- $dot := .}}
+
+// ModelBase holds the columns shared by many tables, which their models embed
+// instead of declaring them. Its getters let code work with any of them.
+type ModelBase struct {
+	{{range $column := .BaseColumns -}}
+	{{- if eq $dot.StructTagCasing "camel"}}
+	{{titleCase $column.Name}} {{$column.Type}} `{{generateTags $dot.Tags $column.Name}}boil:"{{$column.Name}}" json:"{{$column.Name | camelCase}}{{if $column.Nullable}},omitempty{{end}}" toml:"{{$column.Name | camelCase}}" yaml:"{{$column.Name | camelCase}}{{if $column.Nullable}},omitempty{{end}}"`
+	{{- else}}
+	{{titleCase $column.Name}} {{$column.Type}} `{{generateTags $dot.Tags $column.Name}}boil:"{{$column.Name}}" json:"{{$column.Name}}{{if $column.Nullable}},omitempty{{end}}" toml:"{{$column.Name}}" yaml:"{{$column.Name}}{{if $column.Nullable}},omitempty{{end}}"`
+	{{- end}}
+	{{- end}}
+}
+{{range $column := .BaseColumns}}
+// Get{{titleCase $column.Name}} returns the {{$column.Name}} column of the model.
+func (o *ModelBase) Get{{titleCase $column.Name}}() {{$column.Type}} {
+	return o.{{titleCase $column.Name}}
+}
+{{end -}}
+{{- end}}
+
 // ErrSyncFail occurs during insert when the record could not be retrieved in
 // order to populate default value information. This usually happens when LastInsertId
 // fails or there was a primary key configuration that was not resolvable.
 var ErrSyncFail = errors.New("{{.PkgName}}: failed to synchronize data after insert")
 
+// errStreamStopped stops reading the rows of a stream once its done channel
+// is closed.
+var errStreamStopped = errors.New("{{.PkgName}}: stream stopped")
+
 type insertCache struct {
 	query        string
 	retQuery     string
@@ -56,7 +92,10 @@ debugging.
 Postgres output looks like: EnumNameEnumValue = "enumvalue"
 MySQL output looks like:    TableNameColNameEnumValue = "enumvalue"
 
-It only titlecases the EnumValue portion if it's snake-cased.
+It only titlecases the EnumValue portion if it's snake-cased. Each enum also
+gets a string type named like the constant prefix with an IsValid method and
+an All function, which fall back to the raw values when no constants exist.
+The type scans labels it doesn't know as is, IsValid reports them.
 */}}
 {{$dot := . -}}
 {{$once := onceNew}}
@@ -70,17 +109,126 @@ It only titlecases the EnumValue portion if it's snake-cased.
 			{{- if $isNamed -}}
 				{{$_ := oncePut $once $name}}
 			{{- end -}}
-{{- if and (gt (len $vals) 0) (isEnumNormal $vals)}}
+{{- $enumName := titleCase (or $name (printf "%s_%s" $table.Name $col.Name)) -}}
+{{- $isNormal := and (gt (len $vals) 0) (isEnumNormal $vals) -}}
+{{- if $isNormal}}
 // Enum values for {{if $isNamed}}{{$name}}{{else}}{{$table.Name}}.{{$col.Name}}{{end}}
 const (
 	{{- range $val := $vals -}}
-	{{- if $isNamed}}{{titleCase $name}}{{else}}{{titleCase $table.Name}}{{titleCase $col.Name}}{{end -}}
-	{{if shouldTitleCaseEnum $val}}{{titleCase $val}}{{else}}{{$val}}{{end}} = "{{$val}}"
+	{{$enumName}}{{if shouldTitleCaseEnum $val}}{{titleCase $val}}{{else}}{{$val}}{{end}} = "{{$val}}"
 	{{end -}}
 )
 {{- else}}
 // Enum values for {{if $isNamed}}{{$name}}{{else}}{{$table.Name}}.{{$col.Name}}{{end}} are not proper Go identifiers, cannot emit constants
+{{- end}}
+{{- if gt (len $vals) 0}}
+
+// {{$enumName}} is a value of the {{if $isNamed}}{{$name}}{{else}}{{$table.Name}}.{{$col.Name}}{{end}} enum.
+type {{$enumName}} string
+
+// IsValid reports whether e is one of the {{$enumName}} values.
+func (e {{$enumName}}) IsValid() bool {
+	switch e {
+	case {{range $i, $val := $vals}}{{if $i}}, {{end -}}
+		{{- if $isNormal}}{{$enumName}}{{if shouldTitleCaseEnum $val}}{{titleCase $val}}{{else}}{{$val}}{{end}}{{else}}{{printf "%q" $val}}{{end -}}
+	{{- end}}:
+		return true
+	default:
+		return false
+	}
+}
+
+// Scan implements the sql.Scanner interface. A label missing from the
+// {{$enumName}} values, like one added to the enum since the code was
+// generated, is kept as is and reported by IsValid instead of failing.
+func (e *{{$enumName}}) Scan(value interface{}) error {
+	label, err := types.ScanEnum(value)
+	if err != nil {
+		return err
+	}
+
+	*e = {{$enumName}}(label)
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (e {{$enumName}}) Value() (driver.Value, error) {
+	return string(e), nil
+}
+
+// All{{$enumName}} returns all the {{$enumName}} values.
+func All{{$enumName}}() []{{$enumName}} {
+	return []{{$enumName}}{
+		{{- range $val := $vals}}
+		{{if $isNormal}}{{$enumName}}{{if shouldTitleCaseEnum $val}}{{titleCase $val}}{{else}}{{$val}}{{end}}{{else}}{{printf "%q" $val}}{{end}},
+		{{- end}}
+	}
+}
+{{- end -}}
+		{{- end -}}
+	{{- end -}}
 {{- end -}}
+
+{{/*
+Postgres composite types are emitted once per type, no matter how many
+columns use them. Each gets a struct with one nullable field per attribute
+and a Null variant for nullable columns, both reading and writing the
+(a,b,c) row literal.
+*/}}
+{{- $onceComposite := onceNew}}
+{{- range $table := .Tables -}}
+	{{- range $col := $table.Columns | filterColumnsByComposite -}}
+		{{- $name := parseCompositeName $col.DBType -}}
+		{{- if not (onceHas $onceComposite $name) -}}
+			{{- $_ := oncePut $onceComposite $name -}}
+			{{- $typeName := printf "%sComposite" (titleCase $name)}}
+
+// {{$typeName}} is the Postgres composite type {{$name}}.
+type {{$typeName}} struct {
+	{{range $attr := $col.Composite -}}
+	{{- if eq $dot.StructTagCasing "camel" -}}
+	{{titleCase $attr.Name}} {{$attr.Type}} `json:"{{$attr.Name | camelCase}},omitempty" toml:"{{$attr.Name | camelCase}}" yaml:"{{$attr.Name | camelCase}},omitempty"`
+	{{else -}}
+	{{titleCase $attr.Name}} {{$attr.Type}} `json:"{{$attr.Name}},omitempty" toml:"{{$attr.Name}}" yaml:"{{$attr.Name}},omitempty"`
+	{{end -}}
+	{{end -}}
+}
+
+// Null{{$typeName}} is a nullable {{$typeName}}.
+type Null{{$typeName}} struct {
+	{{$typeName}} {{$typeName}}
+	Valid bool
+}
+
+// Scan implements the sql.Scanner interface.
+func (o *{{$typeName}}) Scan(value interface{}) error {
+	return types.ScanComposite(value{{range $attr := $col.Composite}}, &o.{{titleCase $attr.Name}}{{end}})
+}
+
+// Value implements the driver.Valuer interface.
+func (o {{$typeName}}) Value() (driver.Value, error) {
+	return types.CompositeValue({{range $i, $attr := $col.Composite}}{{if $i}}, {{end}}o.{{titleCase $attr.Name}}{{end}})
+}
+
+// Scan implements the sql.Scanner interface.
+func (o *Null{{$typeName}}) Scan(value interface{}) error {
+	if value == nil {
+		o.{{$typeName}}, o.Valid = {{$typeName}}{}, false
+		return nil
+	}
+
+	o.Valid = true
+	return o.{{$typeName}}.Scan(value)
+}
+
+// Value implements the driver.Valuer interface.
+func (o Null{{$typeName}}) Value() (driver.Value, error) {
+	if !o.Valid {
+		return nil, nil
+	}
+
+	return o.{{$typeName}}.Value()
+}
 		{{- end -}}
 	{{- end -}}
 {{- end -}}
@@ -11,3 +11,19 @@ func test{{$tableNamePlural}}(t *testing.T) {
 		t.Error("expected a query, got nothing")
 	}
 }
+
+func test{{$tableNamePlural}}ApplyFilters(t *testing.T) {
+	t.Parallel()
+
+	query := {{$tableNamePlural}}(nil)
+
+	err := {{$tableNameSingular}}ApplyFilters(query, map[string]string{"1=1; --": "1"})
+	if err == nil {
+		t.Error("expected an unknown column to be rejected")
+	}
+
+	err = {{$tableNameSingular}}ApplyFilters(query, map[string]string{{"{"}}{{printf "%q" (index .Table.Columns 0).Name}}: "1"})
+	if err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,70 @@
+{{- $tableNameSingular := .Table.Name | singular | titleCase -}}
+{{- $tableNamePlural := .Table.Name | plural | titleCase -}}
+{{- $varNameSingular := .Table.Name | singular | camelCase -}}
+func test{{$tableNamePlural}}Diff(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	{{$varNameSingular}} := &{{$tableNameSingular}}{}
+	if err = randomize.Struct(seed, {{$varNameSingular}}, {{$varNameSingular}}DBTypes, true); err != nil {
+		t.Errorf("Unable to randomize {{$tableNameSingular}} struct: %s", err)
+	}
+
+	same := *{{$varNameSingular}}
+	if changes := {{$varNameSingular}}.Diff(&same); len(changes) != 0 {
+		t.Errorf("Expected no changes to a copy, got %v", changes)
+	}
+
+	other := &{{$tableNameSingular}}{}
+	if err = randomize.Struct(seed, other, {{$varNameSingular}}DBTypes, true); err != nil {
+		t.Errorf("Unable to randomize {{$tableNameSingular}} struct: %s", err)
+	}
+
+	changes := {{$varNameSingular}}.Diff(other)
+	for _, c := range {{$varNameSingular}}Columns {
+		change, ok := changes[c]
+		if !ok {
+			continue
+		}
+		if reflect.DeepEqual(change.Old, change.New) {
+			t.Errorf("Expected %s to hold different values, got %v twice", c, change.Old)
+		}
+	}
+	if len(changes) > len({{$varNameSingular}}Columns) {
+		t.Errorf("Expected at most %d changes, got %d", len({{$varNameSingular}}Columns), len(changes))
+	}
+}
+
+func test{{$tableNamePlural}}ColumnValues(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	{{$varNameSingular}} := &{{$tableNameSingular}}{}
+	if err := randomize.Struct(seed, {{$varNameSingular}}, {{$varNameSingular}}DBTypes, true); err != nil {
+		t.Errorf("Unable to randomize {{$tableNameSingular}} struct: %s", err)
+	}
+
+	values := {{$varNameSingular}}.ColumnValues({{$varNameSingular}}Columns)
+	if len(values) != len({{$varNameSingular}}Columns) {
+		t.Fatalf("want %d values, got %d", len({{$varNameSingular}}Columns), len(values))
+	}
+
+	reversed := make([]string, len({{$varNameSingular}}Columns))
+	for i, c := range {{$varNameSingular}}Columns {
+		reversed[len(reversed)-1-i] = c
+	}
+	reversedValues := {{$varNameSingular}}.ColumnValues(reversed)
+	for i, c := range reversed {
+		if !reflect.DeepEqual(reversedValues[i], values[len(values)-1-i]) {
+			t.Errorf("want the value of %s at %d, got %v", c, i, reversedValues[i])
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("want a panic on an unknown column")
+		}
+	}()
+	{{$varNameSingular}}.ColumnValues([]string{"not_a_column"})
+}
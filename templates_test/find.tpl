@@ -26,4 +26,53 @@ func test{{$tableNamePlural}}Find(t *testing.T) {
 	if {{$varNameSingular}}Found == nil {
 		t.Error("want a record, got nil")
 	}
+
+	// The primary key is always selected, even when it's left out of selectCols
+	selectCols := strmangle.SetComplement({{$varNameSingular}}Columns, {{$varNameSingular}}PrimaryKeyColumns)
+	{{$varNameSingular}}Found, err = Find{{$tableNameSingular}}(tx, {{.Table.PKey.Columns | stringMap .StringFuncs.titleCase | prefixStringSlice (printf "%s." $varNameSingular) | join ", "}}, selectCols...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	{{range .Table.PKey.Columns -}}
+	if !reflect.DeepEqual({{$varNameSingular}}Found.{{titleCase .}}, {{$varNameSingular}}.{{titleCase .}}) {
+		t.Errorf("want {{.}} %v, got %v", {{$varNameSingular}}.{{titleCase .}}, {{$varNameSingular}}Found.{{titleCase .}})
+	}
+	{{end -}}
+}
+
+func test{{$tableNamePlural}}FindIdentityMap(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	{{$varNameSingular}} := &{{$tableNameSingular}}{}
+	if err = randomize.Struct(seed, {{$varNameSingular}}, {{$varNameSingular}}DBTypes, true, {{$varNameSingular}}ColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize {{$tableNameSingular}} struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer tx.Rollback()
+	if err = {{$varNameSingular}}.Insert(tx); err != nil {
+		t.Error(err)
+	}
+
+	exec := boil.WithIdentityMap(tx, boil.NewIdentityMap())
+	first, err := Find{{$tableNameSingular}}(exec, {{.Table.PKey.Columns | stringMap .StringFuncs.titleCase | prefixStringSlice (printf "%s." $varNameSingular) | join ", "}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := Find{{$tableNameSingular}}(exec, {{.Table.PKey.Columns | stringMap .StringFuncs.titleCase | prefixStringSlice (printf "%s." $varNameSingular) | join ", "}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != second {
+		t.Errorf("want the same pointer from both finds, got %p and %p", first, second)
+	}
+
+	if err = {{$varNameSingular}}.Delete(exec); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = Find{{$tableNameSingular}}(exec, {{.Table.PKey.Columns | stringMap .StringFuncs.titleCase | prefixStringSlice (printf "%s." $varNameSingular) | join ", "}}); err != boil.ErrNoRows {
+		t.Errorf("want boil.ErrNoRows once the record is deleted, got %v", err)
+	}
 }
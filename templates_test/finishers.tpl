@@ -79,6 +79,175 @@ func test{{$tableNamePlural}}All(t *testing.T) {
 	}
 }
 
+func test{{$tableNamePlural}}AllAsMap(t *testing.T) {
+	t.Parallel()
+
+	{{- $keyColumns := .Table.Columns | filterColumnsByMapKey}}
+	{{- if not $keyColumns}}
+
+	t.Skip("{{.Table.Name}} has no string or integer columns")
+	{{- else}}
+	{{- $keyColumn := index $keyColumns 0}}
+
+	seed := randomize.NewSeed()
+	var err error
+	{{$varNameSingular}}One := &{{$tableNameSingular}}{}
+	{{$varNameSingular}}Two := &{{$tableNameSingular}}{}
+	if err = randomize.Struct(seed, {{$varNameSingular}}One, {{$varNameSingular}}DBTypes, false, {{$varNameSingular}}ColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize {{$tableNameSingular}} struct: %s", err)
+	}
+	if err = randomize.Struct(seed, {{$varNameSingular}}Two, {{$varNameSingular}}DBTypes, false, {{$varNameSingular}}ColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize {{$tableNameSingular}} struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer tx.Rollback()
+	if err = {{$varNameSingular}}One.Insert(tx); err != nil {
+		t.Error(err)
+	}
+	if err = {{$varNameSingular}}Two.Insert(tx); err != nil {
+		t.Error(err)
+	}
+
+	{{- $keyName := $keyColumn.Name | titleCase}}
+
+	m, err := {{$tableNamePlural}}(tx).AllAsMapBy{{$keyName}}()
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(m) == 0 || len(m) > 2 {
+		t.Error("want 1 or 2 records, got:", len(m))
+	}
+
+	for key, obj := range m {
+		if key != obj.{{$keyName}} {
+			t.Errorf("record keyed by %v has {{$keyColumn.Name}} %v", key, obj.{{$keyName}})
+		}
+	}
+
+	slice := {{$tableNameSingular}}Slice{ {{- $varNameSingular}}One, {{$varNameSingular}}One}
+	if m = slice.ToMapBy{{$keyName}}(); len(m) != 1 || m[{{$varNameSingular}}One.{{$keyName}}] != {{$varNameSingular}}One {
+		t.Error("want the record keyed by its {{$keyColumn.Name}}, got:", m)
+	}
+	if _, err = slice.ToMapBy{{$keyName}}Strict(); err == nil {
+		t.Error("expected an error for a duplicate key")
+	}
+	{{- end}}
+}
+
+{{if .ExplicitScan -}}
+func test{{$tableNamePlural}}ExplicitScan(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	{{$varNameSingular}}One := &{{$tableNameSingular}}{}
+	{{$varNameSingular}}Two := &{{$tableNameSingular}}{}
+	if err = randomize.Struct(seed, {{$varNameSingular}}One, {{$varNameSingular}}DBTypes, false, {{$varNameSingular}}ColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize {{$tableNameSingular}} struct: %s", err)
+	}
+	if err = randomize.Struct(seed, {{$varNameSingular}}Two, {{$varNameSingular}}DBTypes, false, {{$varNameSingular}}ColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize {{$tableNameSingular}} struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer tx.Rollback()
+	if err = {{$varNameSingular}}One.Insert(tx); err != nil {
+		t.Error(err)
+	}
+	if err = {{$varNameSingular}}Two.Insert(tx); err != nil {
+		t.Error(err)
+	}
+
+	query := func() {{$varNameSingular}}Query {
+		q := {{$tableNamePlural}}(tx)
+		q.SetOrderBy({{$varNameSingular}}PrimaryKeyColumns...)
+		return q
+	}
+
+	scanned, err := query().All()
+	if err != nil {
+		t.Error(err)
+	}
+	var bound []*{{$tableNameSingular}}
+	if err = query().Bind(&bound); err != nil {
+		t.Error(err)
+	}
+	if len(scanned) != 2 || !reflect.DeepEqual([]*{{$tableNameSingular}}(scanned), bound) {
+		t.Errorf("want the scanned records to equal the bound ones\n%#v\n%#v", scanned, bound)
+	}
+
+	one, err := query().One()
+	if err != nil {
+		t.Error(err)
+	}
+	boundOne := &{{$tableNameSingular}}{}
+	if err = query().Bind(boundOne); err != nil {
+		t.Error(err)
+	}
+	if !reflect.DeepEqual(one, boundOne) {
+		t.Errorf("want the scanned record to equal the bound one\n%#v\n%#v", one, boundOne)
+	}
+}
+
+{{end -}}
+func test{{$tableNamePlural}}Stream(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	{{$varNameSingular}}One := &{{$tableNameSingular}}{}
+	{{$varNameSingular}}Two := &{{$tableNameSingular}}{}
+	if err = randomize.Struct(seed, {{$varNameSingular}}One, {{$varNameSingular}}DBTypes, false, {{$varNameSingular}}ColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize {{$tableNameSingular}} struct: %s", err)
+	}
+	if err = randomize.Struct(seed, {{$varNameSingular}}Two, {{$varNameSingular}}DBTypes, false, {{$varNameSingular}}ColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize {{$tableNameSingular}} struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer tx.Rollback()
+	if err = {{$varNameSingular}}One.Insert(tx); err != nil {
+		t.Error(err)
+	}
+	if err = {{$varNameSingular}}Two.Insert(tx); err != nil {
+		t.Error(err)
+	}
+
+	ch := make(chan *{{$tableNameSingular}})
+	errs := {{$tableNamePlural}}(tx).Stream(nil, ch)
+	count := 0
+	for range ch {
+		count++
+	}
+	if err = <-errs; err != nil {
+		t.Error(err)
+	}
+	if count != 2 {
+		t.Error("want 2 records, got:", count)
+	}
+
+	// Stop after the first record, the rows must be closed for the
+	// transaction to be usable again
+	done := make(chan struct{})
+	ch = make(chan *{{$tableNameSingular}})
+	errs = {{$tableNamePlural}}(tx).Stream(done, ch)
+	if _, ok := <-ch; !ok {
+		t.Error("want a record before stopping")
+	}
+	close(done)
+	for range ch {
+	}
+	if err = <-errs; err != nil {
+		t.Error("want no error stopping early, got:", err)
+	}
+
+	if _, err = {{$tableNamePlural}}(tx).Count(); err != nil {
+		t.Error(err)
+	}
+}
+
 func test{{$tableNamePlural}}Count(t *testing.T) {
 	t.Parallel()
 
@@ -110,4 +279,8 @@ func test{{$tableNamePlural}}Count(t *testing.T) {
 	if count != 2 {
 		t.Error("want 2 records, got:", count)
 	}
+
+	if _, err = {{$tableNamePlural}}(tx).CountEstimate(); err != nil {
+		t.Error(err)
+	}
 }
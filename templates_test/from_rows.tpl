@@ -0,0 +1,60 @@
+{{- $tableNameSingular := .Table.Name | singular | titleCase -}}
+{{- $tableNamePlural := .Table.Name | plural | titleCase -}}
+{{- $varNamePlural := .Table.Name | plural | camelCase -}}
+{{- $varNameSingular := .Table.Name | singular | camelCase -}}
+{{- $dot := . -}}
+func test{{$tableNamePlural}}FromRows(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	{{$varNameSingular}} := &{{$tableNameSingular}}{}
+	if err = randomize.Struct(seed, {{$varNameSingular}}, {{$varNameSingular}}DBTypes, true, {{$varNameSingular}}ColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize {{$tableNameSingular}} struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer tx.Rollback()
+	if err = {{$varNameSingular}}.Insert(tx); err != nil {
+		t.Error(err)
+	}
+
+	rows, err := {{$tableNamePlural}}(tx).Query.Query()
+	if err != nil {
+		t.Fatal(err)
+	}
+	slice, err := {{$tableNamePlural}}FromRows(rows)
+	rows.Close()
+	if err != nil {
+		t.Error(err)
+	}
+	if len(slice) != 1 {
+		t.Fatal("want 1 record, got:", len(slice))
+	}
+	{{range .Table.PKey.Columns -}}
+	if !reflect.DeepEqual(slice[0].{{titleCase .}}, {{$varNameSingular}}.{{titleCase .}}) {
+		t.Errorf("want {{.}} %v, got %v", {{$varNameSingular}}.{{titleCase .}}, slice[0].{{titleCase .}})
+	}
+	{{end -}}
+
+	// An extra column is skipped and the missing ones are left zero
+	rows, err = tx.Query("select 1 as {{"extra_column" | $dot.Quotes}}, {{range $i, $column := .Table.PKey.Columns}}{{if $i}}, {{end}}{{$column | $dot.Quotes}}{{end}} from {{.Table.Name | .SchemaTable}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		t.Fatal("want a row", rows.Err())
+	}
+	found, err := {{$tableNameSingular}}FromRow(rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &{{$tableNameSingular}}{}
+	{{range .Table.PKey.Columns -}}
+	want.{{titleCase .}} = {{$varNameSingular}}.{{titleCase .}}
+	{{end -}}
+	if !reflect.DeepEqual(found, want) {
+		t.Errorf("want only the primary key bound\n%#v\n%#v", found, want)
+	}
+}
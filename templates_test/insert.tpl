@@ -29,6 +29,69 @@ func test{{$tableNamePlural}}Insert(t *testing.T) {
 	}
 }
 
+func test{{$tableNamePlural}}InsertReturning(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	{{$varNameSingular}} := &{{$tableNameSingular}}{}
+	if err = randomize.Struct(seed, {{$varNameSingular}}, {{$varNameSingular}}DBTypes, true, {{$varNameSingular}}ColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize {{$tableNameSingular}} struct: %s", err)
+	}
+	original := *{{$varNameSingular}}
+
+	tx := MustTx(boil.Begin())
+	defer tx.Rollback()
+	ret, err := {{$tableNameSingular}}InsertReturning(tx, {{$varNameSingular}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ret == {{$varNameSingular}} {
+		t.Error("want a new {{$tableNameSingular}} returned")
+	}
+	if !reflect.DeepEqual(*{{$varNameSingular}}, original) {
+		t.Error("want the inserted {{$tableNameSingular}} untouched")
+	}
+
+	if err = ret.Reload(tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func test{{$tableNamePlural}}InsertReturningID(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	{{$varNameSingular}} := &{{$tableNameSingular}}{}
+	if err = randomize.Struct(seed, {{$varNameSingular}}, {{$varNameSingular}}DBTypes, true, {{$varNameSingular}}ColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize {{$tableNameSingular}} struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer tx.Rollback()
+	id, err := {{$tableNameSingular}}InsertReturningID(tx, {{$varNameSingular}})
+	{{- if .Table.CanLastInsertID}}
+	{{- $pkName := index .Table.PKey.Columns 0}}
+	{{- $pkCol := .Table.GetColumn $pkName}}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual({{$varNameSingular}}.{{$pkName | titleCase}}, {{$pkCol.Type}}(0)) {
+		t.Error("want the inserted {{$tableNameSingular}} left without its ID")
+	}
+	if _, err = Find{{$tableNameSingular}}(tx, {{$pkCol.Type}}(id)); err != nil {
+		t.Errorf("want the inserted {{$tableNameSingular}} found by its ID %d, got %v", id, err)
+	}
+	{{- else}}
+	if err == nil {
+		t.Errorf("want an error without a single auto increment primary key, got ID %d", id)
+	}
+	{{- end}}
+}
+
 func test{{$tableNamePlural}}InsertWhitelist(t *testing.T) {
 	t.Parallel()
 
@@ -54,3 +117,121 @@ func test{{$tableNamePlural}}InsertWhitelist(t *testing.T) {
 		t.Error("want one record, got:", count)
 	}
 }
+
+func test{{$tableNamePlural}}InsertWithDefaults(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	{{$varNameSingular}} := &{{$tableNameSingular}}{}
+	if err = randomize.Struct(seed, {{$varNameSingular}}, {{$varNameSingular}}DBTypes, true); err != nil {
+		t.Errorf("Unable to randomize {{$tableNameSingular}} struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer tx.Rollback()
+	if err = {{$varNameSingular}}.InsertWithDefaults(tx, {{$varNameSingular}}ColumnsWithDefault, {{$varNameSingular}}Columns...); err != nil {
+		t.Error(err)
+	}
+
+	count, err := {{$tableNamePlural}}(tx).Count()
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func test{{$tableNamePlural}}InsertOmitDefaults(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	{{$varNameSingular}} := &{{$tableNameSingular}}{}
+	if err = randomize.Struct(seed, {{$varNameSingular}}, {{$varNameSingular}}DBTypes, true); err != nil {
+		t.Errorf("Unable to randomize {{$tableNameSingular}} struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer tx.Rollback()
+	if err = {{$varNameSingular}}.InsertOmitDefaults(tx); err != nil {
+		t.Error(err)
+	}
+
+	count, err := {{$tableNamePlural}}(tx).Count()
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func test{{$tableNamePlural}}InsertInSchema(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	{{$varNameSingular}} := &{{$tableNameSingular}}{}
+	if err = randomize.Struct(seed, {{$varNameSingular}}, {{$varNameSingular}}DBTypes, true, {{$varNameSingular}}ColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize {{$tableNameSingular}} struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer tx.Rollback()
+
+	{{if eq .DriverName "mysql" -}}
+	// The tests run on a copy of the database, under another name
+	var schema string
+	if err = tx.QueryRow("SELECT DATABASE()").Scan(&schema); err != nil {
+		t.Fatal(err)
+	}
+	{{- else -}}
+	schema := "{{.Schema}}"
+	{{- end}}
+
+	if err = {{$varNameSingular}}.InsertInSchema(tx, schema); err != nil {
+		t.Error(err)
+	}
+
+	count, err := {{$tableNamePlural}}(tx).Count()
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func test{{$tableNamePlural}}InsertEach(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	{{$varNamePlural}} := make({{$tableNameSingular}}Slice, 3)
+	for i := range {{$varNamePlural}} {
+		{{$varNamePlural}}[i] = &{{$tableNameSingular}}{}
+		if err = randomize.Struct(seed, {{$varNamePlural}}[i], {{$varNameSingular}}DBTypes, true, {{$varNameSingular}}ColumnsWithDefault...); err != nil {
+			t.Errorf("Unable to randomize {{$tableNameSingular}} struct: %s", err)
+		}
+	}
+
+	tx := MustTx(boil.Begin())
+	defer tx.Rollback()
+	if err = {{$varNamePlural}}.InsertEach(tx); err != nil {
+		t.Error(err)
+	}
+
+	count, err := {{$tableNamePlural}}(tx).Count()
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 3 {
+		t.Error("want 3 records, got:", count)
+	}
+}
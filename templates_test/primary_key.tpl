@@ -0,0 +1,97 @@
+{{- $tableNameSingular := .Table.Name | singular | titleCase -}}
+{{- $tableNamePlural := .Table.Name | plural | titleCase -}}
+{{- $varNameSingular := .Table.Name | singular | camelCase -}}
+{{- $pkeyCount := len .Table.PKey.Columns -}}
+func test{{$tableNamePlural}}PrimaryKey(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	{{$varNameSingular}} := &{{$tableNameSingular}}{}
+	if err = randomize.Struct(seed, {{$varNameSingular}}, {{$varNameSingular}}DBTypes, true); err != nil {
+		t.Errorf("Unable to randomize {{$tableNameSingular}} struct: %s", err)
+	}
+
+	values := {{$varNameSingular}}.PrimaryKeyValues()
+	if len(values) != len({{$varNameSingular}}PrimaryKeyColumns) {
+		t.Errorf("want %d primary key values, got %d", len({{$varNameSingular}}PrimaryKeyColumns), len(values))
+	}
+
+	other := &{{$tableNameSingular}}{}
+	if err = other.SetPrimaryKey(values...); err != nil {
+		t.Error(err)
+	}
+
+	if !reflect.DeepEqual(values, other.PrimaryKeyValues()) {
+		t.Errorf("want primary key %v, got %v", values, other.PrimaryKeyValues())
+	}
+
+	if err = other.SetPrimaryKey(append(values, nil)...); err == nil {
+		t.Error("want an error when too many values are given")
+	}
+}
+
+func test{{$tableNamePlural}}SliceHelpers(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	slice := make({{$tableNameSingular}}Slice, 3)
+	for i := range slice {
+		slice[i] = &{{$tableNameSingular}}{}
+		if err := randomize.Struct(seed, slice[i], {{$varNameSingular}}DBTypes, true); err != nil {
+			t.Errorf("Unable to randomize {{$tableNameSingular}} struct: %s", err)
+		}
+	}
+
+	values := slice.PrimaryKeyValues()
+	if len(values) != len(slice) {
+		t.Fatalf("want %d primary keys, got %d", len(slice), len(values))
+	}
+	for i, obj := range slice {
+		{{if eq $pkeyCount 1 -}}
+		want := obj.PrimaryKeyValues()[0]
+		if !reflect.DeepEqual(interface{}(values[i]), want) {
+		{{- else -}}
+		want := obj.PrimaryKeyValues()
+		if !reflect.DeepEqual(values[i], want) {
+		{{- end}}
+			t.Errorf("%d) want primary key %v, got %v", i, want, values[i])
+		}
+	}
+
+	filtered := slice.Filter(func(o *{{$tableNameSingular}}) bool { return o != slice[1] })
+	if len(filtered) != 2 || filtered[0] != slice[0] || filtered[1] != slice[2] {
+		t.Errorf("want the first and last records, got %v", filtered)
+	}
+	if len(slice) != 3 || slice[1] == nil {
+		t.Error("want Filter to leave the slice untouched")
+	}
+
+	for _, test := range []struct {
+		size int
+		lens []int
+	}{
+		{2, []int{2, 1}},
+		{1, []int{1, 1, 1}},
+		{3, []int{3}},
+		{5, []int{3}},
+		{0, []int{3}},
+		{-1, []int{3}},
+	} {
+		var lens []int
+		var joined {{$tableNameSingular}}Slice
+		for _, chunk := range slice.Chunk(test.size) {
+			lens = append(lens, len(chunk))
+			joined = append(joined, chunk...)
+		}
+		if !reflect.DeepEqual(lens, test.lens) {
+			t.Errorf("want chunks of %v records for a size of %d, got %v", test.lens, test.size, lens)
+		}
+		if !reflect.DeepEqual(joined, slice) {
+			t.Errorf("want the chunks of size %d to hold the records in order", test.size)
+		}
+	}
+	if chunks := ({{$tableNameSingular}}Slice{}).Chunk(2); len(chunks) != 0 {
+		t.Errorf("want no chunks of an empty slice, got %d", len(chunks))
+	}
+}
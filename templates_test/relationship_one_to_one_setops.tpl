@@ -5,7 +5,9 @@
 		{{- $txt := txtsFromOneToOne $dot.Tables $dot.Table .}}
 {{- $varNameSingular := .Table | singular | camelCase -}}
 {{- $foreignVarNameSingular := .ForeignTable | singular | camelCase -}}
-{{- $foreignPKeyCols := (getTable $dot.Tables .ForeignTable).PKey.Columns}}
+{{- $foreignTable := getTable $dot.Tables .ForeignTable -}}
+{{- if $foreignTable.PKey -}}
+{{- $foreignPKeyCols := $foreignTable.PKey.Columns}}
 func test{{$txt.LocalTable.NameGo}}OneToOneSetOp{{$txt.ForeignTable.NameGo}}Using{{$txt.Function.Name}}(t *testing.T) {
 	var err error
 
@@ -134,5 +136,6 @@ func test{{$txt.LocalTable.NameGo}}OneToOneRemoveOp{{$txt.ForeignTable.NameGo}}U
 	}
 }
 {{end -}}{{/* end if foreign key nullable */}}
+{{- end -}}{{/* if foreign primary key */}}
 {{- end -}}{{/* range */}}
 {{- end -}}{{/* join table */}}
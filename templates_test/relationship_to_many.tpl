@@ -3,6 +3,7 @@
 	{{- $dot := . }}
 	{{- $table := .Table }}
 	{{- range .Table.ToManyRelationships -}}
+	{{- if (getTable $dot.Tables .ForeignTable).PKey -}}
 	{{- $txt := txtsFromToMany $dot.Tables $table .}}
 	{{- $varNameSingular := .Table | singular | camelCase -}}
 	{{- $foreignVarNameSingular := .ForeignTable | singular | camelCase -}}
@@ -107,5 +108,6 @@ func test{{$txt.LocalTable.NameGo}}ToMany{{$txt.Function.Name}}(t *testing.T) {
 	}
 }
 
-{{end -}}{{- /* range */ -}}
+{{end -}}{{- /* if foreign primary key */ -}}
+{{- end -}}{{- /* range */ -}}
 {{- end -}}{{- /* outer if join table */ -}}
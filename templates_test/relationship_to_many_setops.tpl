@@ -5,6 +5,7 @@
 	{{- range .Table.ToManyRelationships -}}
 	{{- $varNameSingular := .Table | singular | camelCase -}}
 	{{- $foreignVarNameSingular := .ForeignTable | singular | camelCase -}}
+	{{- if (getTable $dot.Tables .ForeignTable).PKey -}}
 	{{- $txt := txtsFromToMany $dot.Tables $table .}}
 func test{{$txt.LocalTable.NameGo}}ToManyAddOp{{$txt.Function.Name}}(t *testing.T) {
 	var err error
@@ -321,5 +322,6 @@ func test{{$txt.LocalTable.NameGo}}ToManyRemoveOp{{$txt.Function.Name}}(t *testi
 	}
 }
 {{end -}}
+{{- end -}}{{- /* if foreign primary key */ -}}
 {{- end -}}{{- /* range relationships */ -}}
 {{- end -}}{{- /* outer if join table */ -}}
@@ -4,6 +4,7 @@
 	{{- range .Table.FKeys -}}
 		{{- $txt := txtsFromFKey $dot.Tables $dot.Table . -}}
 		{{- $varNameSingular := .Table | singular | camelCase -}}
+		{{- $localTableNamePlural := .Table | plural | titleCase -}}
 		{{- $foreignVarNameSingular := .ForeignTable | singular | camelCase}}
 func test{{$txt.LocalTable.NameGo}}ToOne{{$txt.ForeignTable.NameGo}}Using{{$txt.Function.Name}}(t *testing.T) {
 	tx := MustTx(boil.Begin())
@@ -64,6 +65,18 @@ func test{{$txt.LocalTable.NameGo}}ToOne{{$txt.ForeignTable.NameGo}}Using{{$txt.
 	if local.R.{{$txt.Function.Name}} == nil {
 		t.Error("struct should have been eager loaded")
 	}
+
+	joined, err := {{$localTableNamePlural}}(tx).AllWithJoins("{{$txt.Function.Name}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var found bool
+	for _, o := range joined {
+		found = found || (o.R != nil && o.R.{{$txt.Function.Name}} != nil)
+	}
+	if !found {
+		t.Error("struct should have been bound from the join")
+	}
 }
 
 {{end -}}{{/* range */}}
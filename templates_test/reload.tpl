@@ -28,20 +28,41 @@ func test{{$tableNamePlural}}ReloadAll(t *testing.T) {
 
 	seed := randomize.NewSeed()
 	var err error
-	{{$varNameSingular}} := &{{$tableNameSingular}}{}
-	if err = randomize.Struct(seed, {{$varNameSingular}}, {{$varNameSingular}}DBTypes, true, {{$varNameSingular}}ColumnsWithDefault...); err != nil {
-		t.Errorf("Unable to randomize {{$tableNameSingular}} struct: %s", err)
-	}
-
 	tx := MustTx(boil.Begin())
 	defer tx.Rollback()
-	if err = {{$varNameSingular}}.Insert(tx); err != nil {
+
+	slice := make({{$tableNameSingular}}Slice, 3)
+	for i := range slice {
+		slice[i] = &{{$tableNameSingular}}{}
+		if err = randomize.Struct(seed, slice[i], {{$varNameSingular}}DBTypes, true, {{$varNameSingular}}ColumnsWithDefault...); err != nil {
+			t.Errorf("Unable to randomize {{$tableNameSingular}} struct: %s", err)
+		}
+		if err = slice[i].Insert(tx); err != nil {
+			t.Error(err)
+		}
+	}
+
+	first, deleted, last := slice[0], slice[1], slice[2]
+	if err = deleted.Delete(tx); err != nil {
 		t.Error(err)
 	}
 
-	slice := {{$tableNameSingular}}Slice{{"{"}}{{$varNameSingular}}{{"}"}}
+	// Reverse the slice so it isn't in insertion order
+	slice = {{$tableNameSingular}}Slice{last, deleted, first}
+	if err = slice.ReloadAllStrict(tx); err == nil {
+		t.Error("want an error reloading a deleted row strictly")
+	}
+	if len(slice) != 3 {
+		t.Error("want the slice untouched after a failed strict reload, got length", len(slice))
+	}
 
 	if err = slice.ReloadAll(tx); err != nil {
 		t.Error(err)
 	}
+	if len(slice) != 2 || slice[0] != last || slice[1] != first {
+		t.Error("want the reloaded slice to keep its order and objects without the deleted row")
+	}
+	if err = slice.ReloadAllStrict(tx); err != nil {
+		t.Error(err)
+	}
 }
@@ -7,7 +7,7 @@
 // Separating the tests thusly grants avoidance of Postgres deadlocks.
 func TestParent(t *testing.T) {
   {{- range $index, $table := .Tables}}
-  {{- if $table.IsJoinTable -}}
+  {{- if or $table.IsJoinTable $table.IsView (not $table.PKey) -}}
   {{- else -}}
   {{- $tableName := $table.Name | plural | titleCase -}}
   t.Run("{{$tableName}}", test{{$tableName}})
@@ -15,9 +15,29 @@ func TestParent(t *testing.T) {
   {{- end -}}
 }
 
+func TestStream(t *testing.T) {
+  {{- range $index, $table := .Tables}}
+  {{- if or $table.IsJoinTable $table.IsView (not $table.PKey) -}}
+  {{- else -}}
+  {{- $tableName := $table.Name | plural | titleCase -}}
+  t.Run("{{$tableName}}", test{{$tableName}}Stream)
+  {{end -}}
+  {{- end -}}
+}
+
+func TestApplyFilters(t *testing.T) {
+  {{- range $index, $table := .Tables}}
+  {{- if or $table.IsJoinTable $table.IsView (not $table.PKey) -}}
+  {{- else -}}
+  {{- $tableName := $table.Name | plural | titleCase -}}
+  t.Run("{{$tableName}}", test{{$tableName}}ApplyFilters)
+  {{end -}}
+  {{- end -}}
+}
+
 func TestDelete(t *testing.T) {
   {{- range $index, $table := .Tables}}
-  {{- if $table.IsJoinTable -}}
+  {{- if or $table.IsJoinTable $table.IsView (not $table.PKey) -}}
   {{- else -}}
   {{- $tableName := $table.Name | plural | titleCase -}}
   t.Run("{{$tableName}}", test{{$tableName}}Delete)
@@ -27,7 +47,7 @@ func TestDelete(t *testing.T) {
 
 func TestQueryDeleteAll(t *testing.T) {
   {{- range $index, $table := .Tables}}
-  {{- if $table.IsJoinTable -}}
+  {{- if or $table.IsJoinTable $table.IsView (not $table.PKey) -}}
   {{- else -}}
   {{- $tableName := $table.Name | plural | titleCase -}}
   t.Run("{{$tableName}}", test{{$tableName}}QueryDeleteAll)
@@ -37,7 +57,7 @@ func TestQueryDeleteAll(t *testing.T) {
 
 func TestSliceDeleteAll(t *testing.T) {
   {{- range $index, $table := .Tables}}
-  {{- if $table.IsJoinTable -}}
+  {{- if or $table.IsJoinTable $table.IsView (not $table.PKey) -}}
   {{- else -}}
   {{- $tableName := $table.Name | plural | titleCase -}}
   t.Run("{{$tableName}}", test{{$tableName}}SliceDeleteAll)
@@ -47,7 +67,7 @@ func TestSliceDeleteAll(t *testing.T) {
 
 func TestExists(t *testing.T) {
   {{- range $index, $table := .Tables}}
-  {{- if $table.IsJoinTable -}}
+  {{- if or $table.IsJoinTable $table.IsView (not $table.PKey) -}}
   {{- else -}}
   {{- $tableName := $table.Name | plural | titleCase -}}
   t.Run("{{$tableName}}", test{{$tableName}}Exists)
@@ -55,9 +75,19 @@ func TestExists(t *testing.T) {
   {{- end -}}
 }
 
+func TestValidate(t *testing.T) {
+  {{- range $index, $table := .Tables}}
+  {{- if or $table.IsJoinTable $table.IsView (not $table.PKey) -}}
+  {{- else -}}
+  {{- $tableName := $table.Name | plural | titleCase -}}
+  t.Run("{{$tableName}}", test{{$tableName}}Validate)
+  {{end -}}
+  {{- end -}}
+}
+
 func TestFind(t *testing.T) {
   {{- range $index, $table := .Tables}}
-  {{- if $table.IsJoinTable -}}
+  {{- if or $table.IsJoinTable $table.IsView (not $table.PKey) -}}
   {{- else -}}
   {{- $tableName := $table.Name | plural | titleCase -}}
   t.Run("{{$tableName}}", test{{$tableName}}Find)
@@ -65,9 +95,29 @@ func TestFind(t *testing.T) {
   {{- end -}}
 }
 
+func TestFindIdentityMap(t *testing.T) {
+  {{- range $index, $table := .Tables}}
+  {{- if or $table.IsJoinTable $table.IsView (not $table.PKey) -}}
+  {{- else -}}
+  {{- $tableName := $table.Name | plural | titleCase -}}
+  t.Run("{{$tableName}}", test{{$tableName}}FindIdentityMap)
+  {{end -}}
+  {{- end -}}
+}
+
+func TestFromRows(t *testing.T) {
+  {{- range $index, $table := .Tables}}
+  {{- if or $table.IsJoinTable $table.IsView (not $table.PKey) -}}
+  {{- else -}}
+  {{- $tableName := $table.Name | plural | titleCase -}}
+  t.Run("{{$tableName}}", test{{$tableName}}FromRows)
+  {{end -}}
+  {{- end -}}
+}
+
 func TestBind(t *testing.T) {
   {{- range $index, $table := .Tables}}
-  {{- if $table.IsJoinTable -}}
+  {{- if or $table.IsJoinTable $table.IsView (not $table.PKey) -}}
   {{- else -}}
   {{- $tableName := $table.Name | plural | titleCase -}}
   t.Run("{{$tableName}}", test{{$tableName}}Bind)
@@ -77,7 +127,7 @@ func TestBind(t *testing.T) {
 
 func TestOne(t *testing.T) {
   {{- range $index, $table := .Tables}}
-  {{- if $table.IsJoinTable -}}
+  {{- if or $table.IsJoinTable $table.IsView (not $table.PKey) -}}
   {{- else -}}
   {{- $tableName := $table.Name | plural | titleCase -}}
   t.Run("{{$tableName}}", test{{$tableName}}One)
@@ -87,17 +137,21 @@ func TestOne(t *testing.T) {
 
 func TestAll(t *testing.T) {
   {{- range $index, $table := .Tables}}
-  {{- if $table.IsJoinTable -}}
+  {{- if or $table.IsJoinTable $table.IsView (not $table.PKey) -}}
   {{- else -}}
   {{- $tableName := $table.Name | plural | titleCase -}}
   t.Run("{{$tableName}}", test{{$tableName}}All)
+  t.Run("{{$tableName}}", test{{$tableName}}AllAsMap)
+  {{if $dot.ExplicitScan -}}
+  t.Run("{{$tableName}}", test{{$tableName}}ExplicitScan)
+  {{end -}}
   {{end -}}
   {{- end -}}
 }
 
 func TestCount(t *testing.T) {
   {{- range $index, $table := .Tables}}
-  {{- if $table.IsJoinTable -}}
+  {{- if or $table.IsJoinTable $table.IsView (not $table.PKey) -}}
   {{- else -}}
   {{- $tableName := $table.Name | plural | titleCase -}}
   t.Run("{{$tableName}}", test{{$tableName}}Count)
@@ -108,7 +162,7 @@ func TestCount(t *testing.T) {
 {{if not .NoHooks -}}
 func TestHooks(t *testing.T) {
   {{- range $index, $table := .Tables}}
-  {{- if $table.IsJoinTable -}}
+  {{- if or $table.IsJoinTable $table.IsView (not $table.PKey) -}}
   {{- else -}}
   {{- $tableName := $table.Name | plural | titleCase -}}
   t.Run("{{$tableName}}", test{{$tableName}}Hooks)
@@ -119,11 +173,17 @@ func TestHooks(t *testing.T) {
 
 func TestInsert(t *testing.T) {
   {{- range $index, $table := .Tables}}
-  {{- if $table.IsJoinTable -}}
+  {{- if or $table.IsJoinTable $table.IsView (not $table.PKey) -}}
   {{- else -}}
   {{- $tableName := $table.Name | plural | titleCase -}}
   t.Run("{{$tableName}}", test{{$tableName}}Insert)
+  t.Run("{{$tableName}}", test{{$tableName}}InsertReturning)
+  t.Run("{{$tableName}}", test{{$tableName}}InsertReturningID)
   t.Run("{{$tableName}}", test{{$tableName}}InsertWhitelist)
+  t.Run("{{$tableName}}", test{{$tableName}}InsertWithDefaults)
+  t.Run("{{$tableName}}", test{{$tableName}}InsertOmitDefaults)
+  t.Run("{{$tableName}}", test{{$tableName}}InsertInSchema)
+  t.Run("{{$tableName}}", test{{$tableName}}InsertEach)
   {{end -}}
   {{- end -}}
 }
@@ -132,7 +192,7 @@ func TestInsert(t *testing.T) {
 // or deadlocks can occur.
 func TestToOne(t *testing.T) {
 {{- range $index, $table := .Tables}}
-  {{- if $table.IsJoinTable -}}
+  {{- if or $table.IsJoinTable $table.IsView (not $table.PKey) -}}
   {{- else -}}
     {{- range $table.FKeys -}}
       {{- $txt := txtsFromFKey $dot.Tables $table . -}}
@@ -146,7 +206,7 @@ func TestToOne(t *testing.T) {
 // or deadlocks can occur.
 func TestOneToOne(t *testing.T) {
   {{- range $index, $table := .Tables}}
-	{{- if $table.IsJoinTable -}}
+	{{- if or $table.IsJoinTable $table.IsView (not $table.PKey) -}}
 	{{- else -}}
 	  {{- range $table.ToOneRelationships -}}
 		{{- $txt := txtsFromOneToOne $dot.Tables $table . -}}
@@ -160,11 +220,13 @@ func TestOneToOne(t *testing.T) {
 // or deadlocks can occur.
 func TestToMany(t *testing.T) {
   {{- range $index, $table := .Tables}}
-    {{- if $table.IsJoinTable -}}
+    {{- if or $table.IsJoinTable $table.IsView (not $table.PKey) -}}
     {{- else -}}
       {{- range $table.ToManyRelationships -}}
         {{- $txt := txtsFromToMany $dot.Tables $table . -}}
+  {{- if (getTable $dot.Tables .ForeignTable).PKey}}
   t.Run("{{$txt.LocalTable.NameGo}}To{{$txt.Function.Name}}", test{{$txt.LocalTable.NameGo}}ToMany{{$txt.Function.Name}})
+  {{- end}}
       {{end -}}{{- /* range */ -}}
     {{- end -}}{{- /* outer if join table */ -}}
   {{- end -}}{{- /* outer tables range */ -}}
@@ -174,7 +236,7 @@ func TestToMany(t *testing.T) {
 // or deadlocks can occur.
 func TestToOneSet(t *testing.T) {
 {{- range $index, $table := .Tables}}
-  {{- if $table.IsJoinTable -}}
+  {{- if or $table.IsJoinTable $table.IsView (not $table.PKey) -}}
   {{- else -}}
     {{- range $table.FKeys -}}
       {{- $txt := txtsFromFKey $dot.Tables $table . -}}
@@ -188,7 +250,7 @@ func TestToOneSet(t *testing.T) {
 // or deadlocks can occur.
 func TestToOneRemove(t *testing.T) {
 {{- range $index, $table := .Tables}}
-  {{- if $table.IsJoinTable -}}
+  {{- if or $table.IsJoinTable $table.IsView (not $table.PKey) -}}
   {{- else -}}
     {{- range $table.FKeys -}}
       {{- $txt := txtsFromFKey $dot.Tables $table . -}}
@@ -204,11 +266,13 @@ func TestToOneRemove(t *testing.T) {
 // or deadlocks can occur.
 func TestOneToOneSet(t *testing.T) {
   {{- range $index, $table := .Tables}}
-	{{- if $table.IsJoinTable -}}
+	{{- if or $table.IsJoinTable $table.IsView (not $table.PKey) -}}
 	{{- else -}}
 	  {{- range $table.ToOneRelationships -}}
 		  {{- $txt := txtsFromOneToOne $dot.Tables $table . -}}
+	{{- if (getTable $dot.Tables .ForeignTable).PKey}}
 	t.Run("{{$txt.LocalTable.NameGo}}To{{$txt.ForeignTable.NameGo}}Using{{$txt.Function.Name}}", test{{$txt.LocalTable.NameGo}}OneToOneSetOp{{$txt.ForeignTable.NameGo}}Using{{$txt.Function.Name}})
+	{{- end}}
 	  {{end -}}{{- /* range to one relationships */ -}}
 	{{- end -}}{{- /* outer if join table */ -}}
   {{- end -}}{{- /* outer tables range */ -}}
@@ -218,12 +282,14 @@ func TestOneToOneSet(t *testing.T) {
 // or deadlocks can occur.
 func TestOneToOneRemove(t *testing.T) {
   {{- range $index, $table := .Tables}}
-	{{- if $table.IsJoinTable -}}
+	{{- if or $table.IsJoinTable $table.IsView (not $table.PKey) -}}
 	{{- else -}}
 	  {{- range $table.ToOneRelationships -}}
 		{{- if .ForeignColumnNullable -}}
 		  {{- $txt := txtsFromOneToOne $dot.Tables $table . -}}
+	{{- if (getTable $dot.Tables .ForeignTable).PKey}}
 	t.Run("{{$txt.LocalTable.NameGo}}To{{$txt.ForeignTable.NameGo}}Using{{$txt.Function.Name}}", test{{$txt.LocalTable.NameGo}}OneToOneRemoveOp{{$txt.ForeignTable.NameGo}}Using{{$txt.Function.Name}})
+	{{- end}}
 		{{end -}}{{- /* if foreign column nullable */ -}}
 	  {{- end -}}{{- /* range */ -}}
 	{{- end -}}{{- /* outer if join table */ -}}
@@ -234,11 +300,13 @@ func TestOneToOneRemove(t *testing.T) {
 // or deadlocks can occur.
 func TestToManyAdd(t *testing.T) {
   {{- range $index, $table := .Tables}}
-    {{- if $table.IsJoinTable -}}
+    {{- if or $table.IsJoinTable $table.IsView (not $table.PKey) -}}
     {{- else -}}
       {{- range $table.ToManyRelationships -}}
         {{- $txt := txtsFromToMany $dot.Tables $table . -}}
+  {{- if (getTable $dot.Tables .ForeignTable).PKey}}
   t.Run("{{$txt.LocalTable.NameGo}}To{{$txt.Function.Name}}", test{{$txt.LocalTable.NameGo}}ToManyAddOp{{$txt.Function.Name}})
+  {{- end}}
       {{end -}}{{- /* range */ -}}
     {{- end -}}{{- /* outer if join table */ -}}
   {{- end -}}{{- /* outer tables range */ -}}
@@ -248,13 +316,15 @@ func TestToManyAdd(t *testing.T) {
 // or deadlocks can occur.
 func TestToManySet(t *testing.T) {
   {{- range $index, $table := .Tables}}
-    {{- if $table.IsJoinTable -}}
+    {{- if or $table.IsJoinTable $table.IsView (not $table.PKey) -}}
     {{- else -}}
       {{- range $table.ToManyRelationships -}}
         {{- if not (or .ForeignColumnNullable .ToJoinTable)}}
         {{- else -}}
           {{- $txt := txtsFromToMany $dot.Tables $table . -}}
+    {{- if (getTable $dot.Tables .ForeignTable).PKey}}
     t.Run("{{$txt.LocalTable.NameGo}}To{{$txt.Function.Name}}", test{{$txt.LocalTable.NameGo}}ToManySetOp{{$txt.Function.Name}})
+    {{- end}}
         {{end -}}{{- /* if foreign column nullable */ -}}
       {{- end -}}{{- /* range */ -}}
     {{- end -}}{{- /* outer if join table */ -}}
@@ -265,13 +335,15 @@ func TestToManySet(t *testing.T) {
 // or deadlocks can occur.
 func TestToManyRemove(t *testing.T) {
   {{- range $index, $table := .Tables}}
-    {{- if $table.IsJoinTable -}}
+    {{- if or $table.IsJoinTable $table.IsView (not $table.PKey) -}}
     {{- else -}}
       {{- range $table.ToManyRelationships -}}
         {{- if not (or .ForeignColumnNullable .ToJoinTable)}}
         {{- else -}}
           {{- $txt := txtsFromToMany $dot.Tables $table . -}}
+    {{- if (getTable $dot.Tables .ForeignTable).PKey}}
     t.Run("{{$txt.LocalTable.NameGo}}To{{$txt.Function.Name}}", test{{$txt.LocalTable.NameGo}}ToManyRemoveOp{{$txt.Function.Name}})
+    {{- end}}
         {{end -}}{{- /* if foreign column nullable */ -}}
       {{- end -}}{{- /* range */ -}}
     {{- end -}}{{- /* outer if join table */ -}}
@@ -280,7 +352,7 @@ func TestToManyRemove(t *testing.T) {
 
 func TestReload(t *testing.T) {
   {{- range $index, $table := .Tables}}
-  {{- if $table.IsJoinTable -}}
+  {{- if or $table.IsJoinTable $table.IsView (not $table.PKey) -}}
   {{- else -}}
   {{- $tableName := $table.Name | plural | titleCase -}}
   t.Run("{{$tableName}}", test{{$tableName}}Reload)
@@ -290,7 +362,7 @@ func TestReload(t *testing.T) {
 
 func TestReloadAll(t *testing.T) {
   {{- range $index, $table := .Tables}}
-  {{- if $table.IsJoinTable -}}
+  {{- if or $table.IsJoinTable $table.IsView (not $table.PKey) -}}
   {{- else -}}
   {{- $tableName := $table.Name | plural | titleCase -}}
   t.Run("{{$tableName}}", test{{$tableName}}ReloadAll)
@@ -298,9 +370,39 @@ func TestReloadAll(t *testing.T) {
   {{- end -}}
 }
 
+func TestPrimaryKey(t *testing.T) {
+  {{- range $index, $table := .Tables}}
+  {{- if or $table.IsJoinTable $table.IsView (not $table.PKey) -}}
+  {{- else -}}
+  {{- $tableName := $table.Name | plural | titleCase -}}
+  t.Run("{{$tableName}}", test{{$tableName}}PrimaryKey)
+  {{end -}}
+  {{- end -}}
+}
+
+func TestSliceHelpers(t *testing.T) {
+  {{- range $index, $table := .Tables}}
+  {{- if or $table.IsJoinTable $table.IsView (not $table.PKey) -}}
+  {{- else -}}
+  {{- $tableName := $table.Name | plural | titleCase -}}
+  t.Run("{{$tableName}}", test{{$tableName}}SliceHelpers)
+  {{end -}}
+  {{- end -}}
+}
+
+func TestColumnInfo(t *testing.T) {
+  {{- range $index, $table := .Tables}}
+  {{- if or $table.IsJoinTable $table.IsView (not $table.PKey) -}}
+  {{- else -}}
+  {{- $tableName := $table.Name | plural | titleCase -}}
+  t.Run("{{$tableName}}", test{{$tableName}}ColumnInfo)
+  {{end -}}
+  {{- end -}}
+}
+
 func TestSelect(t *testing.T) {
   {{- range $index, $table := .Tables}}
-  {{- if $table.IsJoinTable -}}
+  {{- if or $table.IsJoinTable $table.IsView (not $table.PKey) -}}
   {{- else -}}
   {{- $tableName := $table.Name | plural | titleCase -}}
   t.Run("{{$tableName}}", test{{$tableName}}Select)
@@ -310,7 +412,7 @@ func TestSelect(t *testing.T) {
 
 func TestUpdate(t *testing.T) {
   {{- range $index, $table := .Tables}}
-  {{- if $table.IsJoinTable -}}
+  {{- if or $table.IsJoinTable $table.IsView (not $table.PKey) -}}
   {{- else -}}
   {{- $tableName := $table.Name | plural | titleCase -}}
   t.Run("{{$tableName}}", test{{$tableName}}Update)
@@ -320,7 +422,7 @@ func TestUpdate(t *testing.T) {
 
 func TestSliceUpdateAll(t *testing.T) {
   {{- range $index, $table := .Tables}}
-  {{- if $table.IsJoinTable -}}
+  {{- if or $table.IsJoinTable $table.IsView (not $table.PKey) -}}
   {{- else -}}
   {{- $tableName := $table.Name | plural | titleCase -}}
   t.Run("{{$tableName}}", test{{$tableName}}SliceUpdateAll)
@@ -328,12 +430,53 @@ func TestSliceUpdateAll(t *testing.T) {
   {{- end -}}
 }
 
+func TestQueryUpdateAllFrom(t *testing.T) {
+  {{- range $index, $table := .Tables}}
+  {{- if or $table.IsJoinTable $table.IsView (not $table.PKey) -}}
+  {{- else -}}
+  {{- $tableName := $table.Name | plural | titleCase -}}
+  t.Run("{{$tableName}}", test{{$tableName}}QueryUpdateAllFrom)
+  {{end -}}
+  {{- end -}}
+}
+
 func TestUpsert(t *testing.T) {
   {{- range $index, $table := .Tables}}
-  {{- if $table.IsJoinTable -}}
+  {{- if or $table.IsJoinTable $table.IsView (not $table.PKey) -}}
   {{- else -}}
   {{- $tableName := $table.Name | plural | titleCase -}}
   t.Run("{{$tableName}}", test{{$tableName}}Upsert)
+  t.Run("{{$tableName}}", test{{$tableName}}UpsertInserted)
+  {{if and (eq $.DriverName "postgres") $table.PKey.Name -}}
+  t.Run("{{$tableName}}", test{{$tableName}}UpsertOnConstraint)
+  {{end -}}
+  {{if eq $.DriverName "postgres" -}}
+  t.Run("{{$tableName}}", test{{$tableName}}UpsertIfChanged)
+  {{end -}}
+  {{if ne $.DriverName "mssql" -}}
+  t.Run("{{$tableName}}", test{{$tableName}}UpsertExprs)
+  t.Run("{{$tableName}}", test{{$tableName}}UpsertAll)
+  {{end -}}
+  {{end -}}
+  {{- end -}}
+}
+
+func TestDiff(t *testing.T) {
+  {{- range $index, $table := .Tables}}
+  {{- if or $table.IsJoinTable $table.IsView (not $table.PKey) -}}
+  {{- else -}}
+  {{- $tableName := $table.Name | plural | titleCase -}}
+  t.Run("{{$tableName}}", test{{$tableName}}Diff)
+  {{end -}}
+  {{- end -}}
+}
+
+func TestColumnValues(t *testing.T) {
+  {{- range $index, $table := .Tables}}
+  {{- if or $table.IsJoinTable $table.IsView (not $table.PKey) -}}
+  {{- else -}}
+  {{- $tableName := $table.Name | plural | titleCase -}}
+  t.Run("{{$tableName}}", test{{$tableName}}ColumnValues)
   {{end -}}
   {{- end -}}
 }
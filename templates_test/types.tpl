@@ -3,3 +3,40 @@ var (
 	{{$varNameSingular}}DBTypes = map[string]string{{"{"}}{{.Table.Columns | columnDBTypes | makeStringMap}}{{"}"}}
 	_ = bytes.MinRead
 )
+{{- $tableNameSingular := .Table.Name | singular | titleCase -}}
+{{- $tableNamePlural := .Table.Name | plural | titleCase}}
+
+func test{{$tableNamePlural}}ColumnInfo(t *testing.T) {
+	t.Parallel()
+
+	info := {{$tableNameSingular}}ColumnInfo()
+	if len(info) != len({{$varNameSingular}}Columns) {
+		t.Fatalf("want %d columns, got %d", len({{$varNameSingular}}Columns), len(info))
+	}
+
+	typ := reflect.TypeOf({{$tableNameSingular}}{})
+	pks := 0
+	for i, c := range info {
+		if c.Name != {{$varNameSingular}}Columns[i] {
+			t.Errorf("want column %s at %d, got %s", {{$varNameSingular}}Columns[i], i, c.Name)
+		}
+		if c.DBType != {{$varNameSingular}}DBTypes[c.FieldName] {
+			t.Errorf("want db type %s for %s, got %s", {{$varNameSingular}}DBTypes[c.FieldName], c.Name, c.DBType)
+		}
+
+		field, ok := typ.FieldByName(c.FieldName)
+		if !ok {
+			t.Errorf("field %s not found on {{$tableNameSingular}}", c.FieldName)
+		} else if tag := field.Tag.Get("boil"); tag != c.Name {
+			t.Errorf("want field %s to map to %s, got %s", c.FieldName, c.Name, tag)
+		}
+
+		if c.IsPK {
+			pks++
+		}
+	}
+
+	if pks != len({{$varNameSingular}}PrimaryKeyColumns) {
+		t.Errorf("want %d primary key columns, got %d", len({{$varNameSingular}}PrimaryKeyColumns), pks)
+	}
+}
@@ -101,3 +101,44 @@ func test{{$tableNamePlural}}SliceUpdateAll(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func test{{$tableNamePlural}}QueryUpdateAllFrom(t *testing.T) {
+	t.Parallel()
+
+	if len({{$varNameSingular}}Columns) == len({{$varNameSingular}}PrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	{{$varNameSingular}} := &{{$tableNameSingular}}{}
+	if err = randomize.Struct(seed, {{$varNameSingular}}, {{$varNameSingular}}DBTypes, true, {{$varNameSingular}}ColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize {{$tableNameSingular}} struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer tx.Rollback()
+	if err = {{$varNameSingular}}.Insert(tx); err != nil {
+		t.Error(err)
+	}
+
+	if err = randomize.Struct(seed, {{$varNameSingular}}, {{$varNameSingular}}DBTypes, true, {{$varNameSingular}}PrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize {{$tableNameSingular}} struct: %s", err)
+	}
+
+	fields := strmangle.SetComplement({{$varNameSingular}}Columns, {{$varNameSingular}}PrimaryKeyColumns)
+	{{- if eq .DriverName "mssql"}}
+	fields = strmangle.SetComplement(fields, {{$varNameSingular}}ColumnsWithAuto)
+	{{- end}}
+	if len(fields) == 0 {
+		t.Skip("Skipping table with no updatable columns")
+	}
+
+	if err = {{$tableNamePlural}}(tx).UpdateAllFrom({{$varNameSingular}}, fields...); err != nil {
+		t.Error(err)
+	}
+
+	if err = {{$tableNamePlural}}(tx).UpdateAllFrom({{$varNameSingular}}); err == nil {
+		t.Error("want an error updating all without columns")
+	}
+}
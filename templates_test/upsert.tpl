@@ -48,3 +48,230 @@ func test{{$tableNamePlural}}Upsert(t *testing.T) {
 		t.Error("want one record, got:", count)
 	}
 }
+{{- if ne .DriverName "mssql"}}
+
+func test{{$tableNamePlural}}UpsertExprs(t *testing.T) {
+	t.Parallel()
+
+	if len({{$varNameSingular}}Columns) == len({{$varNameSingular}}PrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	// Set every column to the value proposed for insertion, like Upsert
+	exprs := make(map[string]string)
+	for _, c := range strmangle.SetComplement({{$varNameSingular}}Columns, {{$varNameSingular}}PrimaryKeyColumns) {
+		exprs[c] = "EXCLUDED." + strmangle.IdentQuote(dialect.LQ, dialect.RQ, c)
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	{{$varNameSingular}} := {{$tableNameSingular}}{}
+	if err = randomize.Struct(seed, &{{$varNameSingular}}, {{$varNameSingular}}DBTypes, true); err != nil {
+		t.Errorf("Unable to randomize {{$tableNameSingular}} struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer tx.Rollback()
+	if err = {{$varNameSingular}}.UpsertExprs(tx, {{if eq .DriverName "postgres"}}nil, {{end}}exprs); err != nil {
+		t.Errorf("Unable to upsert {{$tableNameSingular}}: %s", err)
+	}
+
+	if err = randomize.Struct(seed, &{{$varNameSingular}}, {{$varNameSingular}}DBTypes, false, {{$varNameSingular}}PrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize {{$tableNameSingular}} struct: %s", err)
+	}
+
+	if err = {{$varNameSingular}}.UpsertExprs(tx, {{if eq .DriverName "postgres"}}nil, {{end}}exprs); err != nil {
+		t.Errorf("Unable to upsert {{$tableNameSingular}}: %s", err)
+	}
+
+	count, err := {{$tableNamePlural}}(tx).Count()
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = {{$varNameSingular}}.UpsertExprs(tx, {{if eq .DriverName "postgres"}}nil, {{end}}map[string]string{"not_a_column": "1"}); err == nil {
+		t.Error("want an error for an expression of an unknown column")
+	}
+}
+{{- end}}
+
+func test{{$tableNamePlural}}UpsertInserted(t *testing.T) {
+	t.Parallel()
+
+	if len({{$varNameSingular}}Columns) == len({{$varNameSingular}}PrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	{{$varNameSingular}} := {{$tableNameSingular}}{}
+	if err = randomize.Struct(seed, &{{$varNameSingular}}, {{$varNameSingular}}DBTypes, true); err != nil {
+		t.Errorf("Unable to randomize {{$tableNameSingular}} struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer tx.Rollback()
+	inserted, err := {{$varNameSingular}}.UpsertInserted(tx, {{if eq .DriverName "postgres"}}false, nil, {{end}}nil)
+	if err != nil {
+		t.Errorf("Unable to upsert {{$tableNameSingular}}: %s", err)
+	}
+	if !inserted {
+		t.Error("want the first upsert to insert")
+	}
+
+	if err = randomize.Struct(seed, &{{$varNameSingular}}, {{$varNameSingular}}DBTypes, false, {{$varNameSingular}}PrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize {{$tableNameSingular}} struct: %s", err)
+	}
+
+	inserted, err = {{$varNameSingular}}.UpsertInserted(tx, {{if eq .DriverName "postgres"}}true, nil, {{end}}nil)
+	if err != nil {
+		t.Errorf("Unable to upsert {{$tableNameSingular}}: %s", err)
+	}
+	if inserted {
+		t.Error("want the second upsert to update")
+	}
+}
+{{- if and (eq .DriverName "postgres") .Table.PKey.Name}}
+
+func test{{$tableNamePlural}}UpsertOnConstraint(t *testing.T) {
+	t.Parallel()
+
+	if len({{$varNameSingular}}Columns) == len({{$varNameSingular}}PrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	{{$varNameSingular}} := {{$tableNameSingular}}{}
+	if err = randomize.Struct(seed, &{{$varNameSingular}}, {{$varNameSingular}}DBTypes, true); err != nil {
+		t.Errorf("Unable to randomize {{$tableNameSingular}} struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer tx.Rollback()
+	if err = {{$varNameSingular}}.UpsertOnConstraint(tx, true, "{{.Table.PKey.Name}}", nil); err != nil {
+		t.Errorf("Unable to upsert {{$tableNameSingular}}: %s", err)
+	}
+
+	if err = randomize.Struct(seed, &{{$varNameSingular}}, {{$varNameSingular}}DBTypes, false, {{$varNameSingular}}PrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize {{$tableNameSingular}} struct: %s", err)
+	}
+
+	if err = {{$varNameSingular}}.UpsertOnConstraint(tx, true, "{{.Table.PKey.Name}}", nil); err != nil {
+		t.Errorf("Unable to upsert {{$tableNameSingular}}: %s", err)
+	}
+
+	count, err := {{$tableNamePlural}}(tx).Count()
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = {{$varNameSingular}}.UpsertOnConstraint(tx, true, "", nil); err == nil {
+		t.Error("want an error when no constraint is given")
+	}
+}
+{{- end}}
+{{- if eq .DriverName "postgres"}}
+
+func test{{$tableNamePlural}}UpsertIfChanged(t *testing.T) {
+	t.Parallel()
+
+	if len({{$varNameSingular}}Columns) == len({{$varNameSingular}}PrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	{{$varNameSingular}} := {{$tableNameSingular}}{}
+	if err = randomize.Struct(seed, &{{$varNameSingular}}, {{$varNameSingular}}DBTypes, true); err != nil {
+		t.Errorf("Unable to randomize {{$tableNameSingular}} struct: %s", err)
+	}
+
+	tx := MustTx(boil.Begin())
+	defer tx.Rollback()
+	if err = {{$varNameSingular}}.UpsertIfChanged(tx, true, nil, nil); err != nil {
+		t.Errorf("Unable to upsert {{$tableNameSingular}}: %s", err)
+	}
+
+	// Nothing changed, so the row is left alone
+	if err = {{$varNameSingular}}.UpsertIfChanged(tx, true, nil, nil); err != nil {
+		t.Errorf("Unable to upsert unchanged {{$tableNameSingular}}: %s", err)
+	}
+
+	if err = randomize.Struct(seed, &{{$varNameSingular}}, {{$varNameSingular}}DBTypes, false, {{$varNameSingular}}PrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize {{$tableNameSingular}} struct: %s", err)
+	}
+
+	if err = {{$varNameSingular}}.UpsertIfChanged(tx, true, nil, nil); err != nil {
+		t.Errorf("Unable to upsert changed {{$tableNameSingular}}: %s", err)
+	}
+
+	count, err := {{$tableNamePlural}}(tx).Count()
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+{{- end}}
+{{- if ne .DriverName "mssql"}}
+
+func test{{$tableNamePlural}}UpsertAll(t *testing.T) {
+	t.Parallel()
+
+	if len({{$varNameSingular}}Columns) == len({{$varNameSingular}}PrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	// Attempt the INSERT side of an UPSERT for every row
+	o := make({{$tableNameSingular}}Slice, 3)
+	for i := range o {
+		o[i] = &{{$tableNameSingular}}{}
+		if err = randomize.Struct(seed, o[i], {{$varNameSingular}}DBTypes, true); err != nil {
+			t.Errorf("Unable to randomize {{$tableNameSingular}} struct: %s", err)
+		}
+	}
+
+	tx := MustTx(boil.Begin())
+	defer tx.Rollback()
+	if err = o.UpsertAll(tx, {{if eq .DriverName "postgres"}}false, nil, {{end}}nil); err != nil {
+		t.Errorf("Unable to upsert all {{$tableNameSingular}}: %s", err)
+	}
+
+	count, err := {{$tableNamePlural}}(tx).Count()
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 3 {
+		t.Error("want 3 records, got:", count)
+	}
+
+	// Attempt the UPDATE side of an UPSERT for every row
+	for i := range o {
+		if err = randomize.Struct(seed, o[i], {{$varNameSingular}}DBTypes, false, {{$varNameSingular}}PrimaryKeyColumns...); err != nil {
+			t.Errorf("Unable to randomize {{$tableNameSingular}} struct: %s", err)
+		}
+	}
+
+	if err = o.UpsertAll(tx, {{if eq .DriverName "postgres"}}true, nil, {{end}}nil); err != nil {
+		t.Errorf("Unable to upsert all {{$tableNameSingular}}: %s", err)
+	}
+
+	count, err = {{$tableNamePlural}}(tx).Count()
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 3 {
+		t.Error("want 3 records, got:", count)
+	}
+}
+{{- end}}
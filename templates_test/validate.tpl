@@ -0,0 +1,41 @@
+{{- $tableNameSingular := .Table.Name | singular | titleCase -}}
+{{- $tableNamePlural := .Table.Name | plural | titleCase -}}
+{{- $varNameSingular := .Table.Name | singular | camelCase -}}
+{{- $dot := . -}}
+func test{{$tableNamePlural}}Validate(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	{{$varNameSingular}} := &{{$tableNameSingular}}{}
+	if err = randomize.Struct(seed, {{$varNameSingular}}, {{$varNameSingular}}DBTypes, true, {{$varNameSingular}}ColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize {{$tableNameSingular}} struct: %s", err)
+	}
+
+	{{- if .Table.Checks}}
+
+	// randomize ignores the CHECK constraints of {{.Table.Name}}, so the random values can
+	// violate them, and only the NOT NULL columns are tested
+	{{- else}}
+
+	if err = {{$varNameSingular}}.Validate(); err != nil {
+		t.Errorf("Expected a randomized {{$tableNameSingular}} to be valid: %s", err)
+	}
+	{{- end}}
+	{{- range $column := filterColumnsByRequired .Table.Columns}}
+	{{- $colName := titleCase $column.Name}}
+
+	{
+		notNull := "{{$dot.PkgName}}: {{$dot.Table.Name}}.{{$column.Name}} must not be null"
+		invalid := *{{$varNameSingular}}
+		invalid.{{$colName}} = nil
+		if err = invalid.Validate(); err == nil || err.Error() != notNull {
+			t.Errorf("Expected a {{$tableNameSingular}} without {{$colName}} to be invalid, got: %v", err)
+		}
+		invalid.{{$colName}} = {{$column.Type}}{}
+		if err = invalid.Validate(); err != nil{{if $dot.Table.Checks}} && err.Error() == notNull{{end}} {
+			t.Errorf("Expected a {{$tableNameSingular}} with an empty {{$colName}} to be valid: %s", err)
+		}
+	}
+	{{- end}}
+}
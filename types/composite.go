@@ -0,0 +1,173 @@
+package types
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ScanComposite parses a Postgres composite type row literal like
+// (a,"b c",) and scans each of its attributes into dest, in order.
+// Attributes are passed to dest as strings, or nil when they are NULL.
+func ScanComposite(src interface{}, dest ...sql.Scanner) error {
+	var literal string
+	switch v := src.(type) {
+	case []byte:
+		literal = string(v)
+	case string:
+		literal = v
+	case nil:
+		return errors.New("types: cannot scan NULL into a composite type")
+	default:
+		return fmt.Errorf("types: cannot scan %T into a composite type", src)
+	}
+
+	attrs, err := parseComposite(literal)
+	if err != nil {
+		return err
+	}
+	if len(attrs) != len(dest) {
+		return fmt.Errorf("types: composite type has %d attributes, expected %d", len(attrs), len(dest))
+	}
+
+	for i, attr := range attrs {
+		var val interface{}
+		if attr != nil {
+			val = *attr
+		}
+
+		if err := dest[i].Scan(val); err != nil {
+			return fmt.Errorf("types: unable to scan composite attribute %d: %s", i+1, err)
+		}
+	}
+
+	return nil
+}
+
+// CompositeValue builds a Postgres composite type row literal from the
+// values of src, quoting them where needed. Values that are nil are
+// written as NULL attributes.
+func CompositeValue(src ...driver.Valuer) (driver.Value, error) {
+	attrs := make([]*string, len(src))
+	for i, valuer := range src {
+		val, err := valuer.Value()
+		if err != nil {
+			return nil, err
+		}
+
+		var attr string
+		switch v := val.(type) {
+		case nil:
+			continue
+		case string:
+			attr = v
+		case []byte:
+			attr = string(v)
+		case int64:
+			attr = strconv.FormatInt(v, 10)
+		case float64:
+			attr = strconv.FormatFloat(v, 'g', -1, 64)
+		case bool:
+			attr = strconv.FormatBool(v)
+		case time.Time:
+			attr = v.Format(time.RFC3339Nano)
+		default:
+			return nil, fmt.Errorf("types: cannot use %T as a composite attribute", val)
+		}
+
+		attrs[i] = &attr
+	}
+
+	return formatComposite(attrs), nil
+}
+
+// parseComposite splits a row literal into its attributes, the way Postgres
+// reads it: an empty unquoted attribute is NULL, quotes may surround any part
+// of an attribute, "" inside quotes is a literal quote and a backslash
+// escapes the character after it.
+func parseComposite(s string) ([]*string, error) {
+	if len(s) < 2 || s[0] != '(' || s[len(s)-1] != ')' {
+		return nil, fmt.Errorf("types: invalid composite literal %q", s)
+	}
+
+	var attrs []*string
+	buf := &bytes.Buffer{}
+	quoted, inQuotes := false, false
+
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+
+		switch {
+		case c == '\\':
+			i++
+			if i >= len(s)-1 {
+				return nil, fmt.Errorf("types: unexpected end of composite literal %q", s)
+			}
+			buf.WriteByte(s[i])
+		case c == '"' && inQuotes && i+1 < len(s) && s[i+1] == '"':
+			buf.WriteByte('"')
+			i++
+		case c == '"':
+			inQuotes = !inQuotes
+			quoted = true
+		case !inQuotes && (c == ',' || c == ')'):
+			if c == ')' && i != len(s)-1 {
+				return nil, fmt.Errorf("types: unexpected characters after composite literal %q", s)
+			}
+
+			if quoted || buf.Len() != 0 {
+				attr := buf.String()
+				attrs = append(attrs, &attr)
+			} else {
+				attrs = append(attrs, nil)
+			}
+			buf.Reset()
+			quoted = false
+		default:
+			buf.WriteByte(c)
+		}
+	}
+
+	if inQuotes {
+		return nil, fmt.Errorf("types: unterminated quote in composite literal %q", s)
+	}
+
+	return attrs, nil
+}
+
+// formatComposite writes attrs as a row literal, nil attributes are NULL.
+func formatComposite(attrs []*string) string {
+	buf := &bytes.Buffer{}
+
+	buf.WriteByte('(')
+	for i, attr := range attrs {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if attr == nil {
+			continue
+		}
+
+		if len(*attr) != 0 && !strings.ContainsAny(*attr, "\"\\(), \t\n\r\v\f") {
+			buf.WriteString(*attr)
+			continue
+		}
+
+		buf.WriteByte('"')
+		for j := 0; j < len(*attr); j++ {
+			if c := (*attr)[j]; c == '"' || c == '\\' {
+				buf.WriteByte(c)
+			}
+			buf.WriteByte((*attr)[j])
+		}
+		buf.WriteByte('"')
+	}
+	buf.WriteByte(')')
+
+	return buf.String()
+}
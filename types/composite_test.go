@@ -0,0 +1,98 @@
+package types
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+)
+
+func TestParseComposite(t *testing.T) {
+	t.Parallel()
+
+	str := func(s string) *string { return &s }
+
+	tests := []struct {
+		In  string
+		Out []*string
+	}{
+		{`(a,b)`, []*string{str("a"), str("b")}},
+		{`(,)`, []*string{nil, nil}},
+		{`()`, []*string{nil}},
+		{`("",x)`, []*string{str(""), str("x")}},
+		{`("a ""b"" c",d)`, []*string{str(`a "b" c`), str("d")}},
+		{`("a\\b\"c",)`, []*string{str(`a\b"c`), nil}},
+		{`(1,"(2,""x, y"",)")`, []*string{str("1"), str(`(2,"x, y",)`)}},
+		{`(ab"c,d"e)`, []*string{str("abc,de")}},
+	}
+
+	for i, test := range tests {
+		out, err := parseComposite(test.In)
+		if err != nil {
+			t.Errorf("%d) %s", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(out, test.Out) {
+			t.Errorf("%d) mismatch for %s:\nwant: %v\ngot:  %v", i, test.In, test.Out, out)
+		}
+	}
+
+	for _, bad := range []string{``, `a,b`, `("a,b)`, `(a)b)`, `(a\)`} {
+		if _, err := parseComposite(bad); err == nil {
+			t.Errorf("expected an error parsing %q", bad)
+		}
+	}
+}
+
+func TestCompositeRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	in := []sql.NullString{
+		{String: "plain", Valid: true},
+		{},
+		{String: "", Valid: true},
+		{String: `say "hi", \o/`, Valid: true},
+		{String: `(1,"x y",)`, Valid: true},
+	}
+
+	val, err := CompositeValue(in[0], in[1], in[2], in[3], in[4])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `(plain,,"","say ""hi"", \\o/","(1,""x y"",)")`
+	if val != want {
+		t.Errorf("value mismatch:\nwant: %s\ngot:  %s", want, val)
+	}
+
+	out := make([]sql.NullString, len(in))
+	err = ScanComposite([]byte(val.(string)), &out[0], &out[1], &out[2], &out[3], &out[4])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(in, out) {
+		t.Errorf("round trip mismatch:\nwant: %#v\ngot:  %#v", in, out)
+	}
+}
+
+func TestScanCompositeTypes(t *testing.T) {
+	t.Parallel()
+
+	var i sql.NullInt64
+	var f sql.NullFloat64
+	var b sql.NullBool
+	if err := ScanComposite(`(5,,t)`, &i, &f, &b); err != nil {
+		t.Fatal(err)
+	}
+
+	if !i.Valid || i.Int64 != 5 || f.Valid || !b.Valid || !b.Bool {
+		t.Errorf("got invalid values: %#v %#v %#v", i, f, b)
+	}
+
+	if err := ScanComposite(`(5,1)`, &i, &f, &b); err == nil {
+		t.Error("expected an error scanning too few attributes")
+	}
+	if err := ScanComposite(nil, &i); err == nil {
+		t.Error("expected an error scanning NULL")
+	}
+}
@@ -0,0 +1,23 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ScanEnum returns the label of the enum value src, which is read as is.
+// Labels added to the enum after the code was generated are returned like
+// any other, so that a generated enum type holds them and reports them
+// with IsValid instead of failing the scan of the whole row.
+func ScanEnum(src interface{}) (string, error) {
+	switch v := src.(type) {
+	case []byte:
+		return string(v), nil
+	case string:
+		return v, nil
+	case nil:
+		return "", errors.New("types: cannot scan NULL into an enum")
+	default:
+		return "", fmt.Errorf("types: cannot scan %T into an enum", src)
+	}
+}
@@ -0,0 +1,68 @@
+package types
+
+import (
+	"database/sql"
+	"testing"
+)
+
+// testEnum is an enum type like the generated ones, which knows the labels
+// a and b.
+type testEnum string
+
+func (e *testEnum) Scan(value interface{}) error {
+	label, err := ScanEnum(value)
+	if err != nil {
+		return err
+	}
+
+	*e = testEnum(label)
+	return nil
+}
+
+func (e testEnum) IsValid() bool {
+	return e == "a" || e == "b"
+}
+
+var _ sql.Scanner = (*testEnum)(nil)
+
+func TestScanEnum(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		In    interface{}
+		Out   testEnum
+		Valid bool
+	}{
+		{"a", "a", true},
+		{[]byte("b"), "b", true},
+		{"added_later", "added_later", false},
+		{[]byte("C"), "C", false},
+		{"", "", false},
+	}
+
+	for i, test := range tests {
+		var e testEnum
+		if err := e.Scan(test.In); err != nil {
+			t.Errorf("%d) unexpected error: %s", i, err)
+			continue
+		}
+
+		if e != test.Out {
+			t.Errorf("%d) want %q, got %q", i, test.Out, e)
+		}
+		if e.IsValid() != test.Valid {
+			t.Errorf("%d) want IsValid %t for %q", i, test.Valid, e)
+		}
+	}
+}
+
+func TestScanEnumErrors(t *testing.T) {
+	t.Parallel()
+
+	for i, src := range []interface{}{nil, 5, 1.5} {
+		var e testEnum
+		if err := e.Scan(src); err == nil {
+			t.Errorf("%d) expected an error scanning %#v", i, src)
+		}
+	}
+}
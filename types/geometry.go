@@ -0,0 +1,202 @@
+package types
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// EWKB type flags and the type of a point, see ParsePoint.
+const (
+	wkbZ     = 0x80000000
+	wkbM     = 0x40000000
+	wkbSRID  = 0x20000000
+	wkbPoint = 1
+)
+
+// Geometry is a PostGIS geometry or geography value of any shape, kept in
+// its well-known binary (WKB) encoding, or the extended one (EWKB) that
+// also holds an SRID. It is read from the hex encoded text Postgres returns
+// geometries as, or from raw WKB, and written as hex encoded text, which
+// PostGIS accepts for both geometry and geography columns.
+type Geometry []byte
+
+// Scan stores the WKB of src in *g, nil for NULL.
+func (g *Geometry) Scan(src interface{}) error {
+	if src == nil {
+		*g = nil
+		return nil
+	}
+
+	wkb, err := decodeWKB(src)
+	if err != nil {
+		return err
+	}
+
+	*g = wkb
+	return nil
+}
+
+// Value returns g as hex encoded WKB, or nil if g is nil.
+func (g Geometry) Value() (driver.Value, error) {
+	if g == nil {
+		return nil, nil
+	}
+
+	return hex.EncodeToString(g), nil
+}
+
+// Point is a two dimensional PostGIS point. On a geography, or a geometry
+// in SRID 4326, X is the longitude and Y the latitude. SRID is 0 when the
+// point has none.
+type Point struct {
+	X, Y float64
+	SRID int
+}
+
+// NullPoint is a nullable Point.
+type NullPoint struct {
+	Point Point
+	Valid bool
+}
+
+// NewNullPoint creates a new NullPoint.
+func NewNullPoint(p Point, valid bool) NullPoint {
+	return NullPoint{Point: p, Valid: valid}
+}
+
+// ParsePoint parses the WKB or EWKB of a two dimensional point.
+func ParsePoint(wkb []byte) (Point, error) {
+	var p Point
+
+	if len(wkb) < 5 {
+		return p, errors.New("types: WKB is too short for a point")
+	}
+
+	var order binary.ByteOrder
+	switch wkb[0] {
+	case 0:
+		order = binary.BigEndian
+	case 1:
+		order = binary.LittleEndian
+	default:
+		return p, fmt.Errorf("types: invalid WKB byte order %d", wkb[0])
+	}
+
+	typ := order.Uint32(wkb[1:5])
+	wkb = wkb[5:]
+	if typ&(wkbZ|wkbM) != 0 || typ&0xffff > 1000 {
+		return p, errors.New("types: only two dimensional points are supported")
+	}
+	if typ&0xffff != wkbPoint {
+		return p, fmt.Errorf("types: WKB of geometry type %d is not a point", typ&0xffff)
+	}
+
+	if typ&wkbSRID != 0 {
+		if len(wkb) < 4 {
+			return p, errors.New("types: WKB is too short for a point")
+		}
+		p.SRID = int(order.Uint32(wkb))
+		wkb = wkb[4:]
+	}
+
+	if len(wkb) != 16 {
+		return p, errors.New("types: WKB has the wrong length for a point")
+	}
+	p.X = math.Float64frombits(order.Uint64(wkb))
+	p.Y = math.Float64frombits(order.Uint64(wkb[8:]))
+
+	return p, nil
+}
+
+// WKB returns p encoded as little endian WKB, or EWKB when it has an SRID.
+func (p Point) WKB() []byte {
+	buf := &bytes.Buffer{}
+	buf.WriteByte(1)
+
+	typ := uint32(wkbPoint)
+	if p.SRID != 0 {
+		typ |= wkbSRID
+	}
+	binary.Write(buf, binary.LittleEndian, typ)
+	if p.SRID != 0 {
+		binary.Write(buf, binary.LittleEndian, uint32(p.SRID))
+	}
+	binary.Write(buf, binary.LittleEndian, p.X)
+	binary.Write(buf, binary.LittleEndian, p.Y)
+
+	return buf.Bytes()
+}
+
+// Value returns p as hex encoded WKB.
+func (p Point) Value() (driver.Value, error) {
+	return hex.EncodeToString(p.WKB()), nil
+}
+
+// Scan stores the src in *p.
+func (p *Point) Scan(src interface{}) error {
+	wkb, err := decodeWKB(src)
+	if err != nil {
+		return err
+	}
+
+	parsed, err := ParsePoint(wkb)
+	if err != nil {
+		return err
+	}
+
+	*p = parsed
+	return nil
+}
+
+// Value returns n.Point as hex encoded WKB, or nil if invalid.
+func (n NullPoint) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+
+	return n.Point.Value()
+}
+
+// Scan stores the src in *n.
+func (n *NullPoint) Scan(src interface{}) error {
+	if src == nil {
+		n.Point, n.Valid = Point{}, false
+		return nil
+	}
+
+	if err := n.Point.Scan(src); err != nil {
+		return err
+	}
+
+	n.Valid = true
+	return nil
+}
+
+// decodeWKB returns the WKB of src, which is either hex encoded text or
+// raw WKB, which starts with a byte order of 0 or 1.
+func decodeWKB(src interface{}) ([]byte, error) {
+	var b []byte
+	switch v := src.(type) {
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return nil, fmt.Errorf("types: cannot scan %T into a geometry", src)
+	}
+
+	if len(b) != 0 && b[0] > 1 {
+		wkb := make([]byte, hex.DecodedLen(len(b)))
+		if _, err := hex.Decode(wkb, b); err != nil {
+			return nil, fmt.Errorf("types: invalid hex encoded WKB: %s", err)
+		}
+		return wkb, nil
+	}
+
+	return append([]byte(nil), b...), nil
+}
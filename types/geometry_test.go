@@ -0,0 +1,116 @@
+package types
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestPointScan(t *testing.T) {
+	t.Parallel()
+
+	raw, _ := hex.DecodeString("0101000020E6100000000000000000F03F0000000000000040")
+
+	tests := []struct {
+		In  interface{}
+		Out Point
+	}{
+		// POINT(1 2) with SRID 4326, as Postgres returns it
+		{"0101000020E6100000000000000000F03F0000000000000040", Point{X: 1, Y: 2, SRID: 4326}},
+		{[]byte("0101000020e6100000000000000000f03f0000000000000040"), Point{X: 1, Y: 2, SRID: 4326}},
+		{raw, Point{X: 1, Y: 2, SRID: 4326}},
+		// POINT(-71.06 42.36) as big endian WKB without an SRID
+		{"0000000001C051C3D70A3D70A440452E147AE147AE", Point{X: -71.06, Y: 42.36}},
+	}
+
+	for i, test := range tests {
+		var got Point
+		if err := got.Scan(test.In); err != nil {
+			t.Errorf("%d) %s", i, err)
+			continue
+		}
+
+		if got != test.Out {
+			t.Errorf("%d) Expected %#v, got %#v", i, test.Out, got)
+		}
+	}
+
+	invalid := []interface{}{
+		nil,
+		5,
+		"zz",
+		"0101",
+		// LINESTRING(0 0, 1 1)
+		"010200000002000000000000000000000000000000000000000000000000000000F03F000000000000F03F",
+		// POINT Z(1 2 3)
+		"01010000A0E6100000000000000000F03F00000000000000400000000000000840",
+	}
+	for i, in := range invalid {
+		var p Point
+		if err := p.Scan(in); err == nil {
+			t.Errorf("%d) expected an error scanning %#v", i, in)
+		}
+	}
+}
+
+func TestPointValue(t *testing.T) {
+	t.Parallel()
+
+	val, err := Point{X: 1, Y: 2, SRID: 4326}.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "0101000020e6100000000000000000f03f0000000000000040"; val != want {
+		t.Errorf("want %s, got %v", want, val)
+	}
+
+	val, err = Point{X: 1, Y: 2}.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "0101000000000000000000f03f0000000000000040"; val != want {
+		t.Errorf("want %s, got %v", want, val)
+	}
+
+	var p Point
+	if err := p.Scan(val); err != nil || p != (Point{X: 1, Y: 2}) {
+		t.Errorf("round trip failed: %#v, %v", p, err)
+	}
+}
+
+func TestNullPoint(t *testing.T) {
+	t.Parallel()
+
+	var n NullPoint
+	if err := n.Scan(nil); err != nil || n.Valid {
+		t.Errorf("expected an invalid point scanning NULL: %#v, %v", n, err)
+	}
+	if val, err := n.Value(); err != nil || val != nil {
+		t.Errorf("expected a nil value, got %v, %v", val, err)
+	}
+
+	if err := n.Scan("0101000000000000000000f03f0000000000000040"); err != nil || !n.Valid || n.Point != (Point{X: 1, Y: 2}) {
+		t.Errorf("wrong point: %#v, %v", n, err)
+	}
+}
+
+func TestGeometry(t *testing.T) {
+	t.Parallel()
+
+	line := "010200000002000000000000000000000000000000000000000000000000000000f03f000000000000f03f"
+
+	var g Geometry
+	if err := g.Scan(strings.ToUpper(line)); err != nil {
+		t.Fatal(err)
+	}
+	if val, err := g.Value(); err != nil || val != line {
+		t.Errorf("want %s, got %v, %v", line, val, err)
+	}
+
+	if err := g.Scan(nil); err != nil || g != nil {
+		t.Errorf("expected a nil geometry scanning NULL: %#v, %v", g, err)
+	}
+	if val, err := g.Value(); err != nil || val != nil {
+		t.Errorf("expected a nil value, got %v, %v", val, err)
+	}
+}
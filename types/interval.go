@@ -0,0 +1,299 @@
+package types
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Interval represents a Postgres INTERVAL or a MySQL TIME value.
+//
+// Months and Days are kept apart from the clock Duration because their
+// length depends on the point in time they are applied to (a month is not
+// always 30 days, and a day is not always 24 hours across DST changes).
+// MySQL TIME values only ever use the Duration component.
+type Interval struct {
+	Months   int
+	Days     int
+	Duration time.Duration
+}
+
+// NullInterval is a nullable Interval.
+type NullInterval struct {
+	Interval Interval
+	Valid    bool
+}
+
+// NewNullInterval creates a new NullInterval.
+func NewNullInterval(i Interval, valid bool) NullInterval {
+	return NullInterval{Interval: i, Valid: valid}
+}
+
+// ParseInterval parses the textual output of a Postgres interval in the
+// default "postgres" IntervalStyle, for example "1 year 2 mons -3 days 04:05:06.5",
+// as well as MySQL TIME values like "-838:59:59".
+func ParseInterval(s string) (Interval, error) {
+	var i Interval
+
+	s = strings.TrimSpace(s)
+	if len(s) == 0 {
+		return i, errors.New("types: cannot parse empty interval")
+	}
+
+	toks := strings.Fields(s)
+	for n := 0; n < len(toks); n++ {
+		tok := toks[n]
+
+		if strings.ContainsRune(tok, ':') {
+			d, err := parseClock(tok)
+			if err != nil {
+				return i, err
+			}
+			i.Duration += d
+			continue
+		}
+
+		if n+1 >= len(toks) {
+			return i, fmt.Errorf("types: interval quantity %q has no unit", tok)
+		}
+
+		qty, err := strconv.Atoi(strings.TrimPrefix(tok, "+"))
+		if err != nil {
+			return i, fmt.Errorf("types: invalid interval quantity %q", tok)
+		}
+
+		n++
+		switch toks[n] {
+		case "year", "years":
+			i.Months += qty * 12
+		case "mon", "mons", "month", "months":
+			i.Months += qty
+		case "day", "days":
+			i.Days += qty
+		default:
+			return i, fmt.Errorf("types: unknown interval unit %q", toks[n])
+		}
+	}
+
+	return i, nil
+}
+
+// parseClock parses a signed [h]hh:mm[:ss[.ffffff]] value into a duration.
+// The hour component is allowed to exceed 24.
+func parseClock(s string) (time.Duration, error) {
+	neg := false
+	switch {
+	case strings.HasPrefix(s, "-"):
+		neg = true
+		s = s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("types: invalid interval time %q", s)
+	}
+
+	var frac string
+	if len(parts) == 3 {
+		if dot := strings.IndexByte(parts[2], '.'); dot >= 0 {
+			parts[2], frac = parts[2][:dot], parts[2][dot+1:]
+		}
+	}
+
+	var d time.Duration
+	units := []time.Duration{time.Hour, time.Minute, time.Second}
+	for idx, p := range parts {
+		v, err := strconv.Atoi(p)
+		if err != nil || v < 0 {
+			return 0, fmt.Errorf("types: invalid interval time %q", s)
+		}
+		d += time.Duration(v) * units[idx]
+	}
+
+	if len(frac) != 0 {
+		if len(frac) > 9 {
+			frac = frac[:9]
+		}
+		v, err := strconv.Atoi(frac + strings.Repeat("0", 9-len(frac)))
+		if err != nil {
+			return 0, fmt.Errorf("types: invalid interval time %q", s)
+		}
+		d += time.Duration(v)
+	}
+
+	if neg {
+		d = -d
+	}
+
+	return d, nil
+}
+
+// String outputs the interval in the Postgres "postgres" IntervalStyle, which
+// both Postgres and, for Duration-only intervals, MySQL accept as input.
+func (i Interval) String() string {
+	buf := &bytes.Buffer{}
+
+	years, months := i.Months/12, i.Months%12
+	writeIntervalUnit(buf, years, "year")
+	writeIntervalUnit(buf, months, "mon")
+	writeIntervalUnit(buf, i.Days, "day")
+
+	if i.Duration == 0 && buf.Len() != 0 {
+		return buf.String()
+	}
+
+	if buf.Len() != 0 {
+		buf.WriteByte(' ')
+	}
+
+	d := i.Duration
+	if d < 0 {
+		buf.WriteByte('-')
+		d = -d
+	}
+
+	hours := d / time.Hour
+	d -= hours * time.Hour
+	mins := d / time.Minute
+	d -= mins * time.Minute
+	secs := d / time.Second
+	d -= secs * time.Second
+
+	fmt.Fprintf(buf, "%02d:%02d:%02d", hours, mins, secs)
+	if d != 0 {
+		buf.WriteString(strings.TrimRight(fmt.Sprintf(".%09d", d), "0"))
+	}
+
+	return buf.String()
+}
+
+func writeIntervalUnit(buf *bytes.Buffer, qty int, unit string) {
+	if qty == 0 {
+		return
+	}
+
+	if buf.Len() != 0 {
+		buf.WriteByte(' ')
+	}
+
+	fmt.Fprintf(buf, "%d %s", qty, unit)
+	if qty != 1 && qty != -1 {
+		buf.WriteByte('s')
+	}
+}
+
+// AddTo returns t with the interval applied to it.
+func (i Interval) AddTo(t time.Time) time.Time {
+	return t.AddDate(0, i.Months, i.Days).Add(i.Duration)
+}
+
+// MarshalJSON returns the interval as a JSON string.
+func (i Interval) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+// UnmarshalJSON parses a JSON string into *i.
+func (i *Interval) UnmarshalJSON(data []byte) error {
+	if i == nil {
+		return errors.New("json: unmarshal json on nil pointer to interval")
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	parsed, err := ParseInterval(s)
+	if err != nil {
+		return err
+	}
+
+	*i = parsed
+	return nil
+}
+
+// Value returns i as a driver.Value.
+func (i Interval) Value() (driver.Value, error) {
+	return i.String(), nil
+}
+
+// Scan stores the src in *i.
+func (i *Interval) Scan(src interface{}) error {
+	var s string
+
+	switch src.(type) {
+	case string:
+		s = src.(string)
+	case []byte:
+		s = string(src.([]byte))
+	default:
+		return errors.New("incompatible type for interval")
+	}
+
+	parsed, err := ParseInterval(s)
+	if err != nil {
+		return err
+	}
+
+	*i = parsed
+	return nil
+}
+
+// MarshalJSON returns the interval as a JSON string, or null if invalid.
+func (n NullInterval) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+
+	return n.Interval.MarshalJSON()
+}
+
+// UnmarshalJSON parses a JSON string or null into *n.
+func (n *NullInterval) UnmarshalJSON(data []byte) error {
+	if n == nil {
+		return errors.New("json: unmarshal json on nil pointer to null interval")
+	}
+
+	if bytes.Equal(data, []byte("null")) {
+		n.Interval, n.Valid = Interval{}, false
+		return nil
+	}
+
+	if err := n.Interval.UnmarshalJSON(data); err != nil {
+		return err
+	}
+
+	n.Valid = true
+	return nil
+}
+
+// Value returns n as a driver.Value, nil if it is invalid.
+func (n NullInterval) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+
+	return n.Interval.Value()
+}
+
+// Scan stores the src in *n.
+func (n *NullInterval) Scan(src interface{}) error {
+	if src == nil {
+		n.Interval, n.Valid = Interval{}, false
+		return nil
+	}
+
+	if err := n.Interval.Scan(src); err != nil {
+		return err
+	}
+
+	n.Valid = true
+	return nil
+}
@@ -0,0 +1,199 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParseInterval(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		In  string
+		Out Interval
+	}{
+		{"00:00:00", Interval{}},
+		{"3 days", Interval{Days: 3}},
+		{"1 day 02:03:04", Interval{Days: 1, Duration: 2*time.Hour + 3*time.Minute + 4*time.Second}},
+		{"1 year 2 mons", Interval{Months: 14}},
+		{"1 year 2 mons 3 days 04:05:06.789", Interval{Months: 14, Days: 3, Duration: 4*time.Hour + 5*time.Minute + 6*time.Second + 789*time.Millisecond}},
+		{"-1 years -2 mons +3 days -04:05:06", Interval{Months: -14, Days: 3, Duration: -(4*time.Hour + 5*time.Minute + 6*time.Second)}},
+		{"-00:00:01", Interval{Duration: -time.Second}},
+		{"-838:59:59", Interval{Duration: -(838*time.Hour + 59*time.Minute + 59*time.Second)}},
+		{"12:34:56.5", Interval{Duration: 12*time.Hour + 34*time.Minute + 56*time.Second + 500*time.Millisecond}},
+	}
+
+	for i, test := range tests {
+		got, err := ParseInterval(test.In)
+		if err != nil {
+			t.Errorf("%d) %s", i, err)
+			continue
+		}
+
+		if got != test.Out {
+			t.Errorf("%d) Expected %#v, got %#v", i, test.Out, got)
+		}
+	}
+}
+
+func TestParseIntervalErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []string{
+		"",
+		"3",
+		"3 weeks",
+		"x days",
+		"1:2:3:4",
+		"12:xx:00",
+	}
+
+	for i, test := range tests {
+		if _, err := ParseInterval(test); err == nil {
+			t.Errorf("%d) Expected an error for %q", i, test)
+		}
+	}
+}
+
+func TestIntervalString(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		In  Interval
+		Out string
+	}{
+		{Interval{}, "00:00:00"},
+		{Interval{Days: 1}, "1 day"},
+		{Interval{Days: -3}, "-3 days"},
+		{Interval{Months: 14, Days: 3, Duration: 4*time.Hour + 5*time.Minute + 6*time.Second + 789*time.Millisecond}, "1 year 2 mons 3 days 04:05:06.789"},
+		{Interval{Months: -1, Duration: -time.Second}, "-1 mon -00:00:01"},
+		{Interval{Duration: 838*time.Hour + 59*time.Minute + 59*time.Second}, "838:59:59"},
+	}
+
+	for i, test := range tests {
+		if got := test.In.String(); got != test.Out {
+			t.Errorf("%d) Expected %q, got %q", i, test.Out, got)
+		}
+
+		parsed, err := ParseInterval(test.In.String())
+		if err != nil {
+			t.Errorf("%d) %s", i, err)
+		}
+
+		if parsed != test.In {
+			t.Errorf("%d) Expected round trip to give %#v, got %#v", i, test.In, parsed)
+		}
+	}
+}
+
+func TestIntervalAddTo(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2017, time.January, 31, 0, 0, 0, 0, time.UTC)
+	i := Interval{Months: 1, Days: 1, Duration: time.Hour}
+
+	want := time.Date(2017, time.March, 4, 1, 0, 0, 0, time.UTC)
+	if got := i.AddTo(start); !got.Equal(want) {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
+
+func TestIntervalJSON(t *testing.T) {
+	t.Parallel()
+
+	i := Interval{Days: 1, Duration: 2 * time.Hour}
+	res, err := json.Marshal(i)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if string(res) != `"1 day 02:00:00"` {
+		t.Errorf("Expected %q, got %s", `"1 day 02:00:00"`, res)
+	}
+
+	var out Interval
+	if err = json.Unmarshal(res, &out); err != nil {
+		t.Error(err)
+	}
+
+	if out != i {
+		t.Errorf("Expected %#v, got %#v", i, out)
+	}
+}
+
+func TestIntervalValue(t *testing.T) {
+	t.Parallel()
+
+	i := Interval{Months: 2, Duration: -time.Minute}
+	v, err := i.Value()
+	if err != nil {
+		t.Error(err)
+	}
+
+	if v.(string) != "2 mons -00:01:00" {
+		t.Errorf("Expected %q, got %v", "2 mons -00:01:00", v)
+	}
+}
+
+func TestIntervalScan(t *testing.T) {
+	t.Parallel()
+
+	var i Interval
+	if err := i.Scan([]byte("-2 days 01:00:00")); err != nil {
+		t.Error(err)
+	}
+
+	if i.Days != -2 || i.Duration != time.Hour {
+		t.Errorf("Expected -2 days and 1h, got %#v", i)
+	}
+
+	if err := i.Scan(5); err == nil {
+		t.Error("Expected an error scanning an int")
+	}
+}
+
+func TestNullInterval(t *testing.T) {
+	t.Parallel()
+
+	var n NullInterval
+	if err := n.Scan(nil); err != nil {
+		t.Error(err)
+	}
+
+	if n.Valid {
+		t.Error("Expected scanning nil to be invalid")
+	}
+
+	v, err := n.Value()
+	if err != nil {
+		t.Error(err)
+	}
+	if v != nil {
+		t.Errorf("Expected nil value, got %v", v)
+	}
+
+	res, err := json.Marshal(n)
+	if err != nil {
+		t.Error(err)
+	}
+	if string(res) != "null" {
+		t.Errorf("Expected null, got %s", res)
+	}
+
+	if err = n.Scan("3 days"); err != nil {
+		t.Error(err)
+	}
+
+	if !n.Valid || n.Interval.Days != 3 {
+		t.Errorf("Expected a valid 3 day interval, got %#v", n)
+	}
+
+	v, err = n.Value()
+	if err != nil {
+		t.Error(err)
+	}
+	if v.(string) != "3 days" {
+		t.Errorf("Expected %q, got %v", "3 days", v)
+	}
+}
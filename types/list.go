@@ -0,0 +1,58 @@
+package types
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+// StringList is a list of strings kept in a single comma separated value,
+// like the results of string_agg(name, ',') on Postgres or GROUP_CONCAT(name)
+// on MySQL. Select the aggregate into a StringList field to bind it as a
+// slice. NULL, which both return when there is nothing to aggregate, and the
+// empty string are both read as an empty list.
+type StringList []string
+
+// Scan stores the split src in *l.
+func (l *StringList) Scan(src interface{}) error {
+	list, err := SplitList(src, ",")
+	if err != nil {
+		return err
+	}
+
+	*l = list
+	return nil
+}
+
+// Value returns the strings of l joined by commas.
+func (l StringList) Value() (driver.Value, error) {
+	return strings.Join(l, ","), nil
+}
+
+// SplitList returns the strings of the delimited value src split on sep.
+// NULL and the empty string are an empty list. It is for the Scan method of
+// a list type of your own that is delimited by something other than a comma:
+//
+//	type Tags []string
+//
+//	func (t *Tags) Scan(src interface{}) (err error) {
+//	  *t, err = types.SplitList(src, "|")
+//	  return err
+//	}
+func SplitList(src interface{}, sep string) ([]string, error) {
+	var s string
+	switch v := src.(type) {
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	case nil:
+	default:
+		return nil, fmt.Errorf("types: cannot scan %T into a list", src)
+	}
+
+	if len(s) == 0 {
+		return []string{}, nil
+	}
+	return strings.Split(s, sep), nil
+}
@@ -0,0 +1,72 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStringListScan(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		In  interface{}
+		Out StringList
+	}{
+		{nil, StringList{}},
+		{"", StringList{}},
+		{[]byte(""), StringList{}},
+		{"alice", StringList{"alice"}},
+		{[]byte("alice"), StringList{"alice"}},
+		{"alice,bob,carol", StringList{"alice", "bob", "carol"}},
+		{"alice,,carol", StringList{"alice", "", "carol"}},
+	}
+
+	for i, test := range tests {
+		var got StringList
+		if err := got.Scan(test.In); err != nil {
+			t.Errorf("%d) %s", i, err)
+			continue
+		}
+
+		if got == nil || !reflect.DeepEqual(got, test.Out) {
+			t.Errorf("%d) Expected %#v, got %#v", i, test.Out, got)
+		}
+	}
+
+	var l StringList
+	if err := l.Scan(5); err == nil {
+		t.Error("expected an error scanning an int")
+	}
+}
+
+func TestStringListValue(t *testing.T) {
+	t.Parallel()
+
+	v, err := StringList{"alice", "bob"}.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != "alice,bob" {
+		t.Errorf("Expected alice,bob, got %#v", v)
+	}
+}
+
+func TestSplitList(t *testing.T) {
+	t.Parallel()
+
+	got, err := SplitList([]byte("a|b|c"), "|")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %#v, got %#v", want, got)
+	}
+
+	got, err = SplitList("a,b", "|")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"a,b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %#v, got %#v", want, got)
+	}
+}
@@ -0,0 +1,223 @@
+package types
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Range represents a Postgres range type, like int4range or tstzrange.
+//
+// The bounds are kept as text in the format of the range's element type,
+// for example "5" or "2018-01-01 00:00:00+00". An empty bound is unbounded,
+// so the zero value is the range containing every value.
+type Range struct {
+	Lower string
+	Upper string
+
+	// LowerInclusive and UpperInclusive are true for the [ and ] bounds,
+	// and false for the ( and ) bounds.
+	LowerInclusive bool
+	UpperInclusive bool
+
+	// Empty is true for the empty range, which contains no values. The
+	// bounds are ignored when it is set.
+	Empty bool
+}
+
+// NullRange is a nullable Range.
+type NullRange struct {
+	Range Range
+	Valid bool
+}
+
+// NewNullRange creates a new NullRange.
+func NewNullRange(r Range, valid bool) NullRange {
+	return NullRange{Range: r, Valid: valid}
+}
+
+// NewRange creates a range from lower, inclusive, to upper, exclusive,
+// the canonical form of the Postgres discrete range types.
+func NewRange(lower, upper string) Range {
+	return Range{Lower: lower, Upper: upper, LowerInclusive: true}
+}
+
+// ParseRange parses the textual output of a Postgres range, like "[1,10)",
+// `["2018-01-01 00:00:00+00",)` or "empty".
+func ParseRange(s string) (Range, error) {
+	var r Range
+
+	s = strings.TrimSpace(s)
+	if strings.EqualFold(s, "empty") {
+		r.Empty = true
+		return r, nil
+	}
+
+	if len(s) < 3 {
+		return r, fmt.Errorf("types: invalid range %q", s)
+	}
+
+	switch s[0] {
+	case '[':
+		r.LowerInclusive = true
+	case '(':
+	default:
+		return r, fmt.Errorf("types: invalid range lower bound %q", s)
+	}
+
+	switch s[len(s)-1] {
+	case ']':
+		r.UpperInclusive = true
+	case ')':
+	default:
+		return r, fmt.Errorf("types: invalid range upper bound %q", s)
+	}
+
+	lower, rest, err := parseRangeBound(s[1:len(s)-1], ',')
+	if err != nil {
+		return r, err
+	}
+	upper, rest, err := parseRangeBound(rest, 0)
+	if err != nil {
+		return r, err
+	}
+	if len(rest) != 0 {
+		return r, fmt.Errorf("types: invalid range %q", s)
+	}
+
+	r.Lower, r.Upper = lower, upper
+	return r, nil
+}
+
+// parseRangeBound reads one bound from the start of s up to the end byte,
+// unquoting it, and returns the bound and what follows end. An end of 0
+// reads to the end of s.
+func parseRangeBound(s string, end byte) (string, string, error) {
+	buf := &bytes.Buffer{}
+	inQuotes := false
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+
+		switch {
+		case c == '\\' && i+1 < len(s):
+			i++
+			buf.WriteByte(s[i])
+		case c == '"' && inQuotes && i+1 < len(s) && s[i+1] == '"':
+			i++
+			buf.WriteByte('"')
+		case c == '"':
+			inQuotes = !inQuotes
+		case c == end && !inQuotes:
+			return buf.String(), s[i+1:], nil
+		default:
+			buf.WriteByte(c)
+		}
+	}
+
+	if inQuotes {
+		return "", "", fmt.Errorf("types: unterminated quote in range bound %q", s)
+	}
+	if end != 0 {
+		return "", "", fmt.Errorf("types: range %q has no upper bound", s)
+	}
+
+	return buf.String(), "", nil
+}
+
+// String outputs the range in the Postgres range input format.
+func (r Range) String() string {
+	if r.Empty {
+		return "empty"
+	}
+
+	buf := &bytes.Buffer{}
+	if r.LowerInclusive {
+		buf.WriteByte('[')
+	} else {
+		buf.WriteByte('(')
+	}
+
+	writeRangeBound(buf, r.Lower)
+	buf.WriteByte(',')
+	writeRangeBound(buf, r.Upper)
+
+	if r.UpperInclusive {
+		buf.WriteByte(']')
+	} else {
+		buf.WriteByte(')')
+	}
+
+	return buf.String()
+}
+
+// writeRangeBound writes bound to buf, quoted when it has characters that
+// are special in the range format, like the spaces of a timestamp.
+func writeRangeBound(buf *bytes.Buffer, bound string) {
+	if !strings.ContainsAny(bound, ` ",\()[]`) {
+		buf.WriteString(bound)
+		return
+	}
+
+	buf.WriteByte('"')
+	for i := 0; i < len(bound); i++ {
+		if bound[i] == '"' || bound[i] == '\\' {
+			buf.WriteByte('\\')
+		}
+		buf.WriteByte(bound[i])
+	}
+	buf.WriteByte('"')
+}
+
+// Value returns r as a driver.Value.
+func (r Range) Value() (driver.Value, error) {
+	return r.String(), nil
+}
+
+// Scan stores the src in *r.
+func (r *Range) Scan(src interface{}) error {
+	var s string
+
+	switch src.(type) {
+	case string:
+		s = src.(string)
+	case []byte:
+		s = string(src.([]byte))
+	default:
+		return errors.New("incompatible type for range")
+	}
+
+	parsed, err := ParseRange(s)
+	if err != nil {
+		return err
+	}
+
+	*r = parsed
+	return nil
+}
+
+// Value returns n as a driver.Value, nil if it is invalid.
+func (n NullRange) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+
+	return n.Range.Value()
+}
+
+// Scan stores the src in *n.
+func (n *NullRange) Scan(src interface{}) error {
+	if src == nil {
+		n.Range, n.Valid = Range{}, false
+		return nil
+	}
+
+	if err := n.Range.Scan(src); err != nil {
+		return err
+	}
+
+	n.Valid = true
+	return nil
+}
@@ -0,0 +1,119 @@
+package types
+
+import "testing"
+
+func TestParseRange(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		In  string
+		Out Range
+	}{
+		{"[1,10)", Range{Lower: "1", Upper: "10", LowerInclusive: true}},
+		{"(1,10]", Range{Lower: "1", Upper: "10", UpperInclusive: true}},
+		{"[5,)", Range{Lower: "5", LowerInclusive: true}},
+		{"(,)", Range{}},
+		{"empty", Range{Empty: true}},
+		{
+			`["2018-01-01 00:00:00+00","2018-02-01 00:00:00+00")`,
+			Range{Lower: "2018-01-01 00:00:00+00", Upper: "2018-02-01 00:00:00+00", LowerInclusive: true},
+		},
+		{`["a\"b","c""d"]`, Range{Lower: `a"b`, Upper: `c"d`, LowerInclusive: true, UpperInclusive: true}},
+		{`["a,b",)`, Range{Lower: "a,b", LowerInclusive: true}},
+	}
+
+	for i, test := range tests {
+		got, err := ParseRange(test.In)
+		if err != nil {
+			t.Errorf("%d) %s", i, err)
+			continue
+		}
+
+		if got != test.Out {
+			t.Errorf("%d) Expected %#v, got %#v", i, test.Out, got)
+		}
+	}
+}
+
+func TestParseRangeErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []string{
+		"",
+		"1,10",
+		"[1,10",
+		"{1,10)",
+		"[110)",
+		`["1,10)`,
+	}
+
+	for i, test := range tests {
+		if _, err := ParseRange(test); err == nil {
+			t.Errorf("%d) Expected an error for %q", i, test)
+		}
+	}
+}
+
+func TestRangeString(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		In  Range
+		Out string
+	}{
+		{NewRange("1", "10"), "[1,10)"},
+		{Range{Lower: "1", UpperInclusive: true}, "(1,]"},
+		{Range{}, "(,)"},
+		{Range{Lower: "1", Upper: "2", Empty: true}, "empty"},
+		{NewRange("2018-01-01 00:00:00+00", ""), `["2018-01-01 00:00:00+00",)`},
+		{NewRange(`a"b`, "c,d"), `["a\"b","c,d")`},
+	}
+
+	for i, test := range tests {
+		if got := test.In.String(); got != test.Out {
+			t.Errorf("%d) Expected %s, got %s", i, test.Out, got)
+		}
+
+		// Every range must survive a round trip through its text format
+		parsed, err := ParseRange(test.In.String())
+		if err != nil {
+			t.Errorf("%d) %s", i, err)
+		}
+		want := test.In
+		if want.Empty {
+			want = Range{Empty: true}
+		}
+		if parsed != want {
+			t.Errorf("%d) Expected round trip %#v, got %#v", i, want, parsed)
+		}
+	}
+}
+
+func TestNullRange(t *testing.T) {
+	t.Parallel()
+
+	var n NullRange
+	if err := n.Scan(nil); err != nil {
+		t.Fatal(err)
+	}
+	if n.Valid {
+		t.Error("Expected a NULL range to be invalid")
+	}
+	if v, _ := n.Value(); v != nil {
+		t.Errorf("Expected a nil value, got %#v", v)
+	}
+
+	if err := n.Scan([]byte("[1,5)")); err != nil {
+		t.Fatal(err)
+	}
+	if !n.Valid || n.Range != NewRange("1", "5") {
+		t.Errorf("Expected a valid [1,5) range, got %#v", n)
+	}
+	if v, _ := n.Value(); v != "[1,5)" {
+		t.Errorf("Expected [1,5), got %#v", v)
+	}
+
+	if err := n.Scan(5); err == nil {
+		t.Error("Expected an error scanning an int")
+	}
+}